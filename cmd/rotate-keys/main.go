@@ -0,0 +1,52 @@
+// Command rotate-keys brings the personal identifiers stored by the application under the active
+// encryption key. It encrypts the values stored in plain text, rewraps the values encrypted with
+// older keys and fills the missing blind indexes. Once it has run, retired keys may be removed
+// from ENCRYPTION_KEYS.
+//
+// With -decrypt, it writes every value back in plain text instead, before rolling back the
+// migration that introduced the encryption.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/persistence"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/database"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/logger"
+)
+
+func main() {
+	decrypt := flag.Bool("decrypt", false, "write the identifiers back in plain text")
+	batchSize := flag.Int("batch", 500, "number of users read at a time")
+	flag.Parse()
+
+	cfg := config.Load()
+	log := logger.New(cfg.Application)
+
+	keyring, err := encryption.ParseKeyring(cfg.Encryption.Keys, cfg.Encryption.KeyID, cfg.Encryption.BlindIndexKey)
+	if errors.Is(err, encryption.ErrNoKeys) && !cfg.Application.IsProduction() {
+		log.Warn("no encryption keys configured, deriving them from the application secret")
+		keyring, err = encryption.DeriveKeyring(cfg.Application.Secret)
+	}
+	if err != nil {
+		log.Fatal("failed to load encryption keys", err)
+	}
+
+	db, err := database.NewPostgresConn(cfg.Database)
+	if err != nil {
+		log.Fatal("failed to connect to database", err)
+	}
+	defer db.Close()
+
+	users := persistence.NewUserPersistence(db, keyring)
+	updated, err := users.RotateIdentifiers(context.Background(), *batchSize, *decrypt)
+	if err != nil {
+		log.Fatalw("failed to rotate user identifiers", "updated", updated, "error", err)
+	}
+
+	log.Infow("user identifiers rotated", "updated", updated, "decrypt", *decrypt)
+}
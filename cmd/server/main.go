@@ -2,18 +2,23 @@ package main
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
 	"time"
+	// Business hours are read in the timezones of the locations, which the runtime image does not ship
+	_ "time/tzdata"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/cmd/server/orchestrator"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/cmd/server/router"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/cmd/server/worker"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/database"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/i18n"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/logger"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
@@ -63,9 +68,31 @@ func main() {
 	w := worker.NewWorker(queue, cfg, log)
 	go w.Start()
 
-	tokenManager := auth.NewTokenManager(cfg.Application.Secret)
-	orchestrator := orchestrator.New(cfg, log, db, cache, queue, tokenManager)
+	keys, err := auth.LoadKeySet(cfg.JWT.KeysDir, cfg.JWT.SigningKeyID)
+	if errors.Is(err, auth.ErrNoSigningKey) && !cfg.Application.IsProduction() {
+		log.Warnw("no JWT signing key configured, using a temporary key that is lost on restart", "keysDir", cfg.JWT.KeysDir)
+		keys, err = auth.NewEphemeralKeySet()
+	}
+	failOnError(err, "failed to load JWT keys")
+	log.Infow("JWT keys loaded", "signingKeyID", keys.SigningKeyID())
+
+	keyring, err := encryption.ParseKeyring(cfg.Encryption.Keys, cfg.Encryption.KeyID, cfg.Encryption.BlindIndexKey)
+	if errors.Is(err, encryption.ErrNoKeys) && !cfg.Application.IsProduction() {
+		log.Warn("no encryption keys configured, deriving them from the application secret")
+		keyring, err = encryption.DeriveKeyring(cfg.Application.Secret)
+	}
+	failOnError(err, "failed to load encryption keys")
+
+	geocoder, err := geocoding.New(cfg.Geocoding)
+	failOnError(err, "failed to set up geocoding")
+	if geocoder == nil {
+		log.Info("no geocoding provider configured, business locations must be sent with their coordinates")
+	}
+
+	tokenManager := auth.NewTokenManager(keys)
+	orchestrator := orchestrator.New(cfg, log, db, cache, queue, tokenManager, keyring, geocoder)
 	symphony := orchestrator.Compose()
+	go w.Every(cfg.Application.AccountPurgeInterval, "purge deleted accounts", symphony.AccountService.PurgeDue)
 
 	router := router.NewServerRouter(cfg, symphony)
 
@@ -2,10 +2,16 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"net"
 	"net/http"
+	"path"
 	"slices"
 	"strings"
 
+	adminApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
@@ -15,14 +21,22 @@ import (
 )
 
 type Middleware struct {
-	UserPersistence domain.UserRepository
-	TokenManager    *auth.TokenManager
+	UserPersistence      domain.UserRepository
+	TokenManager         *auth.TokenManager
+	SessionService       *application.SessionService
+	RoleService          *adminApp.RoleService
+	ScopeService         *adminApp.AdminScopeService
+	ImpersonationService *application.ImpersonationService
 }
 
-func NewMiddleware(userRepo domain.UserRepository, tokenManager *auth.TokenManager) *Middleware {
+func NewMiddleware(userRepo domain.UserRepository, tokenManager *auth.TokenManager, sessionService *application.SessionService, roleService *adminApp.RoleService, scopeService *adminApp.AdminScopeService, impersonationService *application.ImpersonationService) *Middleware {
 	return &Middleware{
-		UserPersistence: userRepo,
-		TokenManager:    tokenManager,
+		UserPersistence:      userRepo,
+		TokenManager:         tokenManager,
+		SessionService:       sessionService,
+		RoleService:          roleService,
+		ScopeService:         scopeService,
+		ImpersonationService: impersonationService,
 	}
 }
 
@@ -72,6 +86,35 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			return
 		}
 
+		// Impersonation tokens belong to the session of the admin acting as the user
+		sessionUserID := userID
+		var impersonatorID uuid.NullUUID
+		if claims.ImpersonatorID != "" {
+			adminID, err := uuid.Parse(claims.ImpersonatorID)
+			if err != nil {
+				response.UnauthorizedT(ctx, w, "error.invalid_token")
+				return
+			}
+			impersonatorID = uuid.NullUUID{UUID: adminID, Valid: true}
+			sessionUserID = adminID
+		}
+
+		// Tokens of revoked sessions are rejected before they expire
+		ip := r.RemoteAddr
+		if host, _, err := net.SplitHostPort(ip); err == nil {
+			ip = host
+		}
+		session, err := m.SessionService.Touch(ctx, sessionUserID, claims.ID, &dto.ClientInfo{Device: r.UserAgent(), IPAddress: ip})
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) {
+				response.UnauthorizedT(ctx, w, "error.session_revoked")
+				return
+			}
+
+			response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+			return
+		}
+
 		user, err := m.UserPersistence.GetByID(ctx, userID)
 		if err != nil {
 			response.NotFoundT(ctx, w, "error.user_not_found")
@@ -95,48 +138,116 @@ func (m *Middleware) Authenticate(next http.Handler) http.Handler {
 			ctx = i18n.SetLanguage(ctx, i18n.Language(userLang))
 		}
 
-		ctx = context.WithValue(
-			ctx,
-			auth.UserContextKey,
-			&dto.UserAsContext{
-				ID:             user.ID,
-				Email:          user.Email,
-				RoleID:         user.RoleID,
-				Language:       userLang,
-				IsCatholic:     user.IsCatholic,
-				IsEntrepreneur: user.IsEntrepreneur,
-			},
-		)
+		userCtx := &dto.UserAsContext{
+			ID:                  user.ID,
+			Email:               user.Email,
+			RoleID:              user.RoleID,
+			Language:            userLang,
+			IsCatholic:          user.IsCatholic,
+			IsEntrepreneur:      user.IsEntrepreneur,
+			SessionID:           session.ID,
+			IsTwoFactorVerified: session.TwoFactor,
+			ImpersonatorID:      impersonatorID,
+		}
+		ctx = context.WithValue(ctx, auth.UserContextKey, userCtx)
+
+		if impersonatorID.Valid {
+			m.serveImpersonated(w, r.WithContext(ctx), next, userCtx, ip)
+			return
+		}
 
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-func (m *Middleware) Authorize(allowedRoles ...int16) func(http.Handler) http.Handler {
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			ctx := r.Context()
-			userCtx := ctx.Value(auth.UserContextKey)
-			if userCtx == nil {
-				response.UnauthorizedT(ctx, w, "error.unauthorized")
-				return
-			}
+// AuthenticateIfPresent authenticates the requests carrying a token, as Authenticate does, and lets
+// anonymous requests through, for public routes that show more to signed-in users.
+func (m *Middleware) AuthenticateIfPresent(next http.Handler) http.Handler {
+	authenticated := m.Authenticate(next)
 
-			user, ok := userCtx.(*dto.UserAsContext)
-			if !ok {
-				response.UnauthorizedT(ctx, w, "error.unauthorized")
-				return
-			}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			next.ServeHTTP(w, r)
+			return
+		}
 
-			// Check if user's role is in allowedRoles
-			if slices.Contains(allowedRoles, user.RoleID) {
-				next.ServeHTTP(w, r)
-				return
-			}
+		authenticated.ServeHTTP(w, r)
+	})
+}
 
-			response.ForbiddenT(ctx, w, "error.unauthorized")
-		})
+// impersonationDeniedReads are the reads refused while impersonating, which hand over the personal
+// data of the user in bulk rather than show what the user sees
+var impersonationDeniedReads = []string{
+	"/api/v1/user/*/export",
+	"/api/v1/user/*/sessions",
+}
+
+// serveImpersonated lets an admin acting as a user read what the user sees but not change it.
+// Every request is recorded in the impersonation audit log, refused ones included.
+func (m *Middleware) serveImpersonated(w http.ResponseWriter, r *http.Request, next http.Handler, user *dto.UserAsContext, ip string) {
+	ctx := r.Context()
+	log := &domain.ImpersonationLog{
+		AdminID:   user.ImpersonatorID,
+		UserID:    uuid.NullUUID{UUID: user.ID, Valid: true},
+		SessionID: user.SessionID,
+		Method:    r.Method,
+		Path:      r.URL.Path,
+		IPAddress: ip,
+	}
+
+	switch {
+	case !isReadRequest(r):
+		response.ForbiddenT(ctx, w, "error.impersonation_read_only")
+		log.StatusCode = http.StatusForbidden
+		log.Blocked = true
+	case isDeniedRead(r):
+		response.ForbiddenT(ctx, w, "error.impersonation_personal_data")
+		log.StatusCode = http.StatusForbidden
+		log.Blocked = true
+	default:
+		recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(recorder, r)
+		log.StatusCode = recorder.status
+	}
+
+	// The entry is written once the response is sent, even when the client went away
+	m.ImpersonationService.Record(context.WithoutCancel(ctx), log)
+}
+
+// isReadRequest reports whether the request only reads data. Listings take their filters
+// in the body of a POST to a path ending in /list, they are reads as well.
+func isReadRequest(r *http.Request) bool {
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	case http.MethodPost:
+		return strings.HasSuffix(strings.TrimSuffix(r.URL.Path, "/"), "/list")
+	}
+
+	return false
+}
+
+// isDeniedRead reports whether the request is one of impersonationDeniedReads
+func isDeniedRead(r *http.Request) bool {
+	requestPath := strings.TrimSuffix(r.URL.Path, "/")
+	for _, pattern := range impersonationDeniedReads {
+		if matched, _ := path.Match(pattern, requestPath); matched {
+			return true
+		}
 	}
+
+	return false
+}
+
+// statusRecorder keeps the status code written by the handler
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.status = status
+	s.ResponseWriter.WriteHeader(status)
 }
 
 func (m *Middleware) UserIsEntrepreneur(next http.Handler) http.Handler {
@@ -187,7 +298,8 @@ func (m *Middleware) UserIsCatholic(next http.Handler) http.Handler {
 	})
 }
 
-func (m *Middleware) RequireRoles(allowedRoles ...int16) func(http.Handler) http.Handler {
+// RequirePermission lets through users whose role was granted the permission with the given key
+func (m *Middleware) RequirePermission(key string) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			ctx := r.Context()
@@ -203,13 +315,71 @@ func (m *Middleware) RequireRoles(allowedRoles ...int16) func(http.Handler) http
 				return
 			}
 
-			// Check if user's role is in allowedRoles
-			if slices.Contains(allowedRoles, user.RoleID) {
+			allowed, err := m.RoleService.HasPermission(ctx, user.RoleID, key)
+			if err != nil {
+				response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+				return
+			}
+
+			if !allowed {
+				response.ForbiddenT(ctx, w, "error.unauthorized")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// LoadAdminScope puts the church or diocese the authenticated user is limited to in the context
+func (m *Middleware) LoadAdminScope(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		user, ok := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+		if !ok {
+			response.UnauthorizedT(ctx, w, "error.unauthorized")
+			return
+		}
+
+		scope, err := m.ScopeService.Get(ctx, user.ID)
+		if err != nil {
+			if errors.Is(err, adminDomain.ErrAdminScopeNotFound) {
 				next.ServeHTTP(w, r)
 				return
 			}
 
-			response.ForbiddenT(ctx, w, "error.unauthorized")
+			response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+			return
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(ctx, auth.AdminScopeContextKey, scope)))
+	})
+}
+
+// RequireTwoFactor rejects users holding one of the given roles unless their session
+// was opened with a second factor. Without roles it applies to every user.
+func (m *Middleware) RequireTwoFactor(roles ...int16) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			userCtx := ctx.Value(auth.UserContextKey)
+			if userCtx == nil {
+				response.UnauthorizedT(ctx, w, "error.unauthorized")
+				return
+			}
+
+			user, ok := userCtx.(*dto.UserAsContext)
+			if !ok {
+				response.UnauthorizedT(ctx, w, "error.unauthorized")
+				return
+			}
+
+			if (len(roles) == 0 || slices.Contains(roles, user.RoleID)) && !user.IsTwoFactorVerified {
+				response.ForbiddenT(ctx, w, "error.two_factor_required")
+				return
+			}
+
+			next.ServeHTTP(w, r)
 		})
 	}
 }
@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"go.uber.org/zap"
+)
+
+// userRepositoryStub finds the one user it holds, the other methods are not used by Authenticate
+type userRepositoryStub struct {
+	domain.UserRepository
+	user *domain.User
+}
+
+func (s *userRepositoryStub) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
+	if id != s.user.ID {
+		return nil, domain.ErrUserNotFound
+	}
+	return s.user, nil
+}
+
+// impersonationLogRecorder keeps the entries of the impersonation audit log
+type impersonationLogRecorder struct {
+	domain.ImpersonationLogRepository
+	logs []*domain.ImpersonationLog
+}
+
+func (r *impersonationLogRecorder) Create(ctx context.Context, log *domain.ImpersonationLog) error {
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func TestMiddleware_Impersonation(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		t.Fatalf("Failed to generate key set: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys)
+
+	client := redis.NewClient(&redis.Options{Addr: miniredis.RunT(t).Addr()})
+	t.Cleanup(func() { client.Close() })
+	sessions := application.NewSessionService(logger, storage.NewCacheStorage(client))
+
+	adminID := uuid.New()
+	session, err := sessions.Create(context.Background(), adminID, &dto.ClientInfo{Device: "Go-http-client/1.1", IPAddress: "192.0.2.1"}, true)
+	if err != nil {
+		t.Fatalf("Failed to create session: %v", err)
+	}
+
+	user := &domain.User{ID: uuid.New(), Email: "user@example.com", RoleID: constants.ROLE_USER, IsActive: true, IsVerified: true}
+	token, err := tokens.GenerateImpersonationToken(user.ID.String(), user.RoleID, adminID.String(), session.ID)
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	logs := &impersonationLogRecorder{}
+	m := NewMiddleware(&userRepositoryStub{user: user}, tokens, sessions, nil, nil, application.NewImpersonationService(logger, tokens, nil, logs))
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		status  int
+		blocked bool
+	}{
+		{
+			name:   "reads what the user sees",
+			method: http.MethodGet,
+			path:   "/api/v1/user/" + user.ID.String(),
+			status: http.StatusOK,
+		},
+		{
+			name:   "lists what the user sees",
+			method: http.MethodPost,
+			path:   "/api/v1/entrepreneur/business/own/list",
+			status: http.StatusOK,
+		},
+		{
+			name:    "changes are refused",
+			method:  http.MethodPut,
+			path:    "/api/v1/user/" + user.ID.String(),
+			status:  http.StatusForbidden,
+			blocked: true,
+		},
+		{
+			name:    "the export of personal data is refused",
+			method:  http.MethodGet,
+			path:    "/api/v1/user/" + user.ID.String() + "/export",
+			status:  http.StatusForbidden,
+			blocked: true,
+		},
+		{
+			name:    "the sessions of the user are refused",
+			method:  http.MethodGet,
+			path:    "/api/v1/user/" + user.ID.String() + "/sessions/",
+			status:  http.StatusForbidden,
+			blocked: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			served := false
+			handler := m.Authenticate(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				served = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			w := httptest.NewRecorder()
+
+			handler.ServeHTTP(w, req)
+
+			if w.Code != tt.status {
+				t.Fatalf("Expected status %d, got %d", tt.status, w.Code)
+			}
+			if served == tt.blocked {
+				t.Errorf("Expected the handler to be served: %v, got %v", !tt.blocked, served)
+			}
+
+			if len(logs.logs) == 0 {
+				t.Fatalf("Expected the request to be recorded")
+			}
+			log := logs.logs[len(logs.logs)-1]
+			if log.Path != tt.path || log.Blocked != tt.blocked || log.StatusCode != tt.status {
+				t.Errorf("Expected a log of %s blocked: %v with status %d, got %s blocked: %v with status %d", tt.path, tt.blocked, tt.status, log.Path, log.Blocked, log.StatusCode)
+			}
+			if log.AdminID.UUID != adminID || log.UserID.UUID != user.ID {
+				t.Errorf("Expected a log of admin %s acting as %s, got %s acting as %s", adminID, user.ID, log.AdminID.UUID, log.UserID.UUID)
+			}
+		})
+	}
+}
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/httprate"
+)
+
+type rateLimitErrorKey struct{}
+
+// RateLimit limits the requests of each client to a route group. Requests carrying a valid access
+// token are counted by user, with the limit of their role, other requests by IP address, so the
+// users behind a shared address do not use up each other's requests.
+// The token is only verified here, whether its session is still open is up to Authenticate.
+// Responses carry the RateLimit-Limit, RateLimit-Remaining and RateLimit-Policy headers of the
+// IETF draft, and Retry-After once limited. RateLimit-Reset is left out: the counter weighs in the
+// requests of the previous window as well, so the count has no time at which it starts over.
+func (m *Middleware) RateLimit(group string, limit config.RateLimit, window time.Duration, counter httprate.LimitCounter) func(http.Handler) http.Handler {
+	limiter := httprate.NewRateLimiter(limit.Anonymous, window,
+		httprate.WithLimitCounter(counter),
+		httprate.WithResponseHeaders(httprate.ResponseHeaders{
+			Limit:      "RateLimit-Limit",
+			Remaining:  "RateLimit-Remaining",
+			RetryAfter: "Retry-After",
+		}),
+		httprate.WithErrorHandler(func(w http.ResponseWriter, r *http.Request, err error) {
+			*r.Context().Value(rateLimitErrorKey{}).(*error) = err
+		}),
+	)
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			key, requests := m.rateLimitKey(r, limit)
+
+			var counterErr error
+			limitCtx := context.WithValue(httprate.WithRequestLimit(ctx, requests), rateLimitErrorKey{}, &counterErr)
+
+			w.Header().Set("RateLimit-Policy", fmt.Sprintf("%d;w=%d", requests, int(window.Seconds())))
+
+			if limiter.OnLimit(w, r.WithContext(limitCtx), group+":"+key) {
+				if counterErr != nil {
+					response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+					return
+				}
+
+				response.TooManyRequestsT(ctx, w, "error.too_many_requests")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// rateLimitKey identifies the client of the request and returns the number of requests it may make
+func (m *Middleware) rateLimitKey(r *http.Request, limit config.RateLimit) (string, int) {
+	if token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer "); ok {
+		if claims, err := m.TokenManager.ParseToken(token); err == nil && claims.UserID != "" {
+			if requests, ok := limit.Roles[claims.RoleID]; ok {
+				return "user:" + claims.UserID, requests
+			}
+			return "user:" + claims.UserID, limit.User
+		}
+	}
+
+	ip, _ := httprate.KeyByIP(r)
+	return "ip:" + ip, limit.Anonymous
+}
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/go-chi/httprate"
+)
+
+// rateLimitRequest is a request made from the address, with the token when not empty
+type rateLimitRequest struct {
+	remoteAddr string
+	token      string
+}
+
+func TestMiddleware_RateLimit(t *testing.T) {
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		t.Fatalf("Failed to generate key set: %v", err)
+	}
+	tokens := auth.NewTokenManager(keys)
+
+	userToken, err := tokens.GenerateToken("user-123", constants.ROLE_USER, "session-123")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	adminToken, err := tokens.GenerateToken("admin-123", constants.ROLE_ADMIN, "session-456")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	limit := config.RateLimit{Anonymous: 2, User: 3, Roles: map[int16]int{constants.ROLE_ADMIN: 4}}
+	window := time.Minute
+
+	tests := []struct {
+		name     string
+		requests []rateLimitRequest
+		statuses []int
+		// limit is the RateLimit-Limit expected on every response
+		limit int
+	}{
+		{
+			name:     "anonymous requests are counted by IP",
+			requests: []rateLimitRequest{{"192.0.2.1:1234", ""}, {"192.0.2.1:1234", ""}, {"192.0.2.1:1234", ""}},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			limit:    2,
+		},
+		{
+			name:     "IP addresses are counted apart",
+			requests: []rateLimitRequest{{"192.0.2.1:1234", ""}, {"192.0.2.1:1234", ""}, {"192.0.2.2:1234", ""}},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusOK},
+			limit:    2,
+		},
+		{
+			name: "users are counted across IP addresses",
+			requests: []rateLimitRequest{
+				{"192.0.2.1:1234", userToken}, {"192.0.2.2:1234", userToken}, {"192.0.2.3:1234", userToken}, {"192.0.2.4:1234", userToken},
+			},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			limit:    3,
+		},
+		{
+			name: "users do not use up the requests of their IP",
+			requests: []rateLimitRequest{
+				{"192.0.2.1:1234", userToken}, {"192.0.2.1:1234", userToken}, {"192.0.2.1:1234", ""}, {"192.0.2.1:1234", ""},
+			},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK},
+		},
+		{
+			name: "roles with a limit of their own",
+			requests: []rateLimitRequest{
+				{"192.0.2.1:1234", adminToken}, {"192.0.2.1:1234", adminToken}, {"192.0.2.1:1234", adminToken},
+				{"192.0.2.1:1234", adminToken}, {"192.0.2.1:1234", adminToken},
+			},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			limit:    4,
+		},
+		{
+			name:     "invalid tokens are counted by IP",
+			requests: []rateLimitRequest{{"192.0.2.1:1234", "invalid"}, {"192.0.2.1:1234", "invalid"}, {"192.0.2.1:1234", "invalid"}},
+			statuses: []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests},
+			limit:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Middleware{TokenManager: tokens}
+			handler := m.RateLimit("test", limit, window, httprate.NewLocalLimitCounter(window))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			for i, request := range tt.requests {
+				req := httptest.NewRequest(http.MethodGet, "/", nil)
+				req.RemoteAddr = request.remoteAddr
+				if request.token != "" {
+					req.Header.Set("Authorization", "Bearer "+request.token)
+				}
+				w := httptest.NewRecorder()
+
+				handler.ServeHTTP(w, req)
+
+				if w.Code != tt.statuses[i] {
+					t.Fatalf("Request %d: expected status %d, got %d", i+1, tt.statuses[i], w.Code)
+				}
+
+				// The sliding window has no time at which the count starts over
+				if reset := w.Header().Get("RateLimit-Reset"); reset != "" {
+					t.Errorf("Request %d: expected no RateLimit-Reset header, got '%s'", i+1, reset)
+				}
+
+				if w.Code == http.StatusTooManyRequests {
+					if w.Header().Get("RateLimit-Remaining") != "0" {
+						t.Errorf("Request %d: expected RateLimit-Remaining '0', got '%s'", i+1, w.Header().Get("RateLimit-Remaining"))
+					}
+					if w.Header().Get("Retry-After") == "" {
+						t.Errorf("Request %d: expected a Retry-After header", i+1)
+					}
+				}
+
+				if tt.limit == 0 {
+					continue
+				}
+				if expected := strconv.Itoa(tt.limit); w.Header().Get("RateLimit-Limit") != expected {
+					t.Errorf("Request %d: expected RateLimit-Limit '%s', got '%s'", i+1, expected, w.Header().Get("RateLimit-Limit"))
+				}
+				if expected := strconv.Itoa(tt.limit) + ";w=60"; w.Header().Get("RateLimit-Policy") != expected {
+					t.Errorf("Request %d: expected RateLimit-Policy '%s', got '%s'", i+1, expected, w.Header().Get("RateLimit-Policy"))
+				}
+			}
+		})
+	}
+}
+
+func TestMiddleware_RateLimitKey(t *testing.T) {
+	keys, err := auth.NewEphemeralKeySet()
+	if err != nil {
+		t.Fatalf("Failed to generate key set: %v", err)
+	}
+	m := &Middleware{TokenManager: auth.NewTokenManager(keys)}
+
+	userToken, err := m.TokenManager.GenerateToken("user-123", constants.ROLE_USER, "session-123")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	managerToken, err := m.TokenManager.GenerateToken("manager-123", constants.ROLE_MANAGER, "session-456")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	limit := config.RateLimit{Anonymous: 10, User: 30, Roles: map[int16]int{constants.ROLE_MANAGER: 90}}
+
+	tests := []struct {
+		name          string
+		authorization string
+		key           string
+		requests      int
+	}{
+		{
+			name:     "no token",
+			key:      "ip:192.0.2.1",
+			requests: 10,
+		},
+		{
+			name:          "user token",
+			authorization: "Bearer " + userToken,
+			key:           "user:user-123",
+			requests:      30,
+		},
+		{
+			name:          "token of a role with its own limit",
+			authorization: "Bearer " + managerToken,
+			key:           "user:manager-123",
+			requests:      90,
+		},
+		{
+			name:          "invalid token",
+			authorization: "Bearer invalid",
+			key:           "ip:192.0.2.1",
+			requests:      10,
+		},
+		{
+			name:          "not a bearer token",
+			authorization: userToken,
+			key:           "ip:192.0.2.1",
+			requests:      10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = "192.0.2.1:1234"
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+
+			key, requests := m.rateLimitKey(req, limit)
+
+			if key != tt.key {
+				t.Errorf("Expected key '%s', got '%s'", tt.key, key)
+			}
+			if requests != tt.requests {
+				t.Errorf("Expected %d requests, got %d", tt.requests, requests)
+			}
+		})
+	}
+}
@@ -12,26 +12,42 @@ import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/http"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/persistence"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
 	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
 type Symphony struct {
-	Auth       *http.AuthHandler
-	User       *http.UserHandler
-	Business   *entrepreneurHttp.BusinessHandler
-	Product    *entrepreneurHttp.ProductHandler
-	Service    *entrepreneurHttp.ServiceHandler
-	Job        *entrepreneurHttp.JobHandler
-	Middleware *middleware.Middleware
+	Auth                    *http.AuthHandler
+	OIDC                    *http.OIDCHandler
+	Passkey                 *http.PasskeyHandler
+	Attestation             *http.CatholicAttestationHandler
+	EntrepreneurApplication *http.EntrepreneurApplicationHandler
+	User                    *http.UserHandler
+	Business                *entrepreneurHttp.BusinessHandler
+	BusinessMember          *entrepreneurHttp.BusinessMemberHandler
+	BusinessLocation        *entrepreneurHttp.BusinessLocationHandler
+	BusinessHours           *entrepreneurHttp.BusinessHoursHandler
+	Product                 *entrepreneurHttp.ProductHandler
+	Service                 *entrepreneurHttp.ServiceHandler
+	Job                     *entrepreneurHttp.JobHandler
+	Search                  *entrepreneurHttp.SearchHandler
+	Middleware              *middleware.Middleware
 	// Admin handlers
-	AdminUser        *adminHttp.UserHandler
-	AdminBusiness    *adminHttp.BusinessHandler
-	AdminChurch      *adminHttp.ChurchHandler
-	AdminIndustry    *adminHttp.IndustryHandler
-	AdminFieldOfWork *adminHttp.FieldOfWorkHandler
+	AdminUser                    *adminHttp.UserHandler
+	AdminBusiness                *adminHttp.BusinessHandler
+	AdminChurch                  *adminHttp.ChurchHandler
+	AdminIndustry                *adminHttp.IndustryHandler
+	AdminFieldOfWork             *adminHttp.FieldOfWorkHandler
+	AdminRole                    *adminHttp.RoleHandler
+	AdminPermission              *adminHttp.PermissionHandler
+	AdminAttestation             *adminHttp.CatholicAttestationHandler
+	AdminEntrepreneurApplication *adminHttp.EntrepreneurApplicationHandler
+	// Scheduled tasks
+	AccountService *application.AccountService
 }
 
 type Orchestrator struct {
@@ -41,9 +57,11 @@ type Orchestrator struct {
 	cache        storage.CacheStorage
 	queue        storage.QueueStorage
 	tokenManager *auth.TokenManager
+	keyring      *encryption.Keyring
+	geocoder     geocoding.Geocoder
 }
 
-func New(cfg config.Config, log *zap.SugaredLogger, db *sqlx.DB, redis storage.CacheStorage, queue storage.QueueStorage, tokenManager *auth.TokenManager) *Orchestrator {
+func New(cfg config.Config, log *zap.SugaredLogger, db *sqlx.DB, redis storage.CacheStorage, queue storage.QueueStorage, tokenManager *auth.TokenManager, keyring *encryption.Keyring, geocoder geocoding.Geocoder) *Orchestrator {
 	return &Orchestrator{
 		cfg:          cfg,
 		log:          log,
@@ -51,71 +69,128 @@ func New(cfg config.Config, log *zap.SugaredLogger, db *sqlx.DB, redis storage.C
 		cache:        redis,
 		queue:        queue,
 		tokenManager: tokenManager,
+		keyring:      keyring,
+		geocoder:     geocoder,
 	}
 }
 
 func (o *Orchestrator) Compose() *Symphony {
 	// # Persistence
 	// ## User
-	userPersistence := persistence.NewUserPersistence(o.db)
+	userPersistence := persistence.NewUserPersistence(o.db, o.keyring)
 	notificationPreferencesPersistence := persistence.NewNotificationPreferencesPersistence(o.db)
 	jobProfilePersistence := persistence.NewJobProfilePersistence(o.db)
+	twoFactorPersistence := persistence.NewTwoFactorPersistence(o.db)
+	loginLockoutPersistence := persistence.NewLoginLockoutPersistence(o.db)
+	impersonationLogPersistence := persistence.NewImpersonationLogPersistence(o.db)
+	userIdentityPersistence := persistence.NewUserIdentityPersistence(o.db)
+	passkeyPersistence := persistence.NewPasskeyPersistence(o.db)
+	catholicAttestationPersistence := persistence.NewCatholicAttestationPersistence(o.db, o.keyring)
+	entrepreneurApplicationPersistence := persistence.NewEntrepreneurApplicationPersistence(o.db)
 	// ## Entrepreneur
 	businessPersistence := entrepreneurPersist.NewBusinessPersistence(o.db)
+	businessMemberPersistence := entrepreneurPersist.NewBusinessMemberPersistence(o.db)
+	businessLocationPersistence := entrepreneurPersist.NewBusinessLocationPersistence(o.db)
+	businessHoursPersistence := entrepreneurPersist.NewBusinessHoursPersistence(o.db)
 	productPersistence := entrepreneurPersist.NewProductPersistence(o.db)
 	servicePersistence := entrepreneurPersist.NewServicePersistence(o.db)
 	jobPersistence := entrepreneurPersist.NewJobPersistence(o.db)
+	searchPersistence := entrepreneurPersist.NewSearchPersistence(o.db)
 	// ## Admin
 	addressPersistence := adminPersist.NewAddressPersistence(o.db)
 	churchPersistence := adminPersist.NewChurchPersistence(o.db)
 	industryPersistence := adminPersist.NewIndustryPersistence(o.db)
 	fieldOfWorkPersistence := adminPersist.NewFieldOfWorkPersistence(o.db)
+	rolePersistence := adminPersist.NewRolePersistence(o.db)
+	permissionPersistence := adminPersist.NewPermissionPersistence(o.db)
+	adminScopePersistence := adminPersist.NewAdminScopePersistence(o.db)
 
 	// # Application
 	// ## User
-	authService := application.NewAuthService(o.log, o.cfg, o.cache, o.queue, o.tokenManager, userPersistence)
-	userService := application.NewUserService(o.log, userPersistence, notificationPreferencesPersistence, jobProfilePersistence, addressPersistence)
+	sessionService := application.NewSessionService(o.log, o.cache)
+	twoFactorService := application.NewTwoFactorService(o.log, o.cfg, twoFactorPersistence)
+	lockoutService := application.NewLockoutService(o.log, o.cache, loginLockoutPersistence)
+	authService := application.NewAuthService(o.log, o.cfg, o.cache, o.queue, o.tokenManager, userPersistence, passkeyPersistence, sessionService, twoFactorService, lockoutService)
+	userService := application.NewUserService(o.log, userPersistence, notificationPreferencesPersistence, jobProfilePersistence, addressPersistence, userIdentityPersistence, permissionPersistence)
+	oidcService := application.NewOIDCService(o.log, o.cfg, o.cache, userPersistence, userIdentityPersistence, userService, authService)
+	passkeyService := application.NewPasskeyService(o.log, o.cfg, o.cache, userPersistence, passkeyPersistence, authService)
+	attestationService := application.NewCatholicAttestationService(o.log, o.cfg, o.queue, catholicAttestationPersistence, userPersistence, churchPersistence, authService)
+	entrepreneurApplicationService := application.NewEntrepreneurApplicationService(o.log, o.cfg, o.queue, entrepreneurApplicationPersistence, userPersistence, industryPersistence, authService)
+	impersonationService := application.NewImpersonationService(o.log, o.tokenManager, userPersistence, impersonationLogPersistence)
+	accountService := application.NewAccountService(o.log, o.cfg, o.queue, userPersistence, addressPersistence, businessPersistence, productPersistence, servicePersistence, jobPersistence, userService, sessionService, authService)
 	// ## Entrepreneur
-	businessService := entrepreneurApp.NewBusinessService(o.log, o.cache, businessPersistence)
-	productService := entrepreneurApp.NewProductService(o.log, productPersistence, businessPersistence)
-	serviceService := entrepreneurApp.NewServiceService(o.log, servicePersistence, businessPersistence)
-	jobService := entrepreneurApp.NewJobService(o.log, jobPersistence, businessPersistence)
+	businessService := entrepreneurApp.NewBusinessService(o.log, o.cache, businessPersistence, businessMemberPersistence, businessHoursPersistence)
+	businessMemberService := entrepreneurApp.NewBusinessMemberService(o.log, o.cfg, o.queue, businessMemberPersistence, businessPersistence, userPersistence)
+	businessLocationService := entrepreneurApp.NewBusinessLocationService(o.log, o.cache, o.geocoder, businessLocationPersistence, businessPersistence, businessMemberPersistence)
+	businessHoursService := entrepreneurApp.NewBusinessHoursService(o.log, o.cache, businessHoursPersistence, businessPersistence, businessMemberPersistence)
+	productService := entrepreneurApp.NewProductService(o.log, productPersistence, businessPersistence, businessMemberPersistence)
+	serviceService := entrepreneurApp.NewServiceService(o.log, servicePersistence, businessPersistence, businessMemberPersistence)
+	jobService := entrepreneurApp.NewJobService(o.log, jobPersistence, businessPersistence, businessMemberPersistence)
+	searchService := entrepreneurApp.NewSearchService(o.log, searchPersistence)
 	// ## Admin
 	churchService := adminApp.NewChurchService(o.log, churchPersistence, addressPersistence)
 	industryService := adminApp.NewIndustryService(o.log, industryPersistence)
 	fieldOfWorkService := adminApp.NewFieldOfWorkService(o.log, fieldOfWorkPersistence)
+	roleService := adminApp.NewRoleService(o.log, o.cache, rolePersistence, permissionPersistence)
+	permissionService := adminApp.NewPermissionService(o.log, o.cache, permissionPersistence)
+	adminScopeService := adminApp.NewAdminScopeService(o.log, adminScopePersistence, churchPersistence)
 
 	// # HTTP
 	// ## User
-	authHandler := http.NewAuthHandler(o.log, o.cache, authService, userService)
-	userHandler := http.NewUserHandler(o.log, userService)
+	authHandler := http.NewAuthHandler(o.log, o.cache, authService, userService, twoFactorService)
+	oidcHandler := http.NewOIDCHandler(o.log, oidcService)
+	passkeyHandler := http.NewPasskeyHandler(o.log, passkeyService)
+	userHandler := http.NewUserHandler(o.log, userService, sessionService, accountService)
+	attestationHandler := http.NewCatholicAttestationHandler(o.log, attestationService)
+	entrepreneurApplicationHandler := http.NewEntrepreneurApplicationHandler(o.log, entrepreneurApplicationService)
 	// ## Entrepreneur
 	businessHandler := entrepreneurHttp.NewBusinessHandler(o.log, businessService)
+	businessMemberHandler := entrepreneurHttp.NewBusinessMemberHandler(o.log, businessMemberService)
+	businessLocationHandler := entrepreneurHttp.NewBusinessLocationHandler(o.log, businessLocationService)
+	businessHoursHandler := entrepreneurHttp.NewBusinessHoursHandler(o.log, businessHoursService)
 	productHandler := entrepreneurHttp.NewProductHandler(o.log, productService)
 	serviceHandler := entrepreneurHttp.NewServiceHandler(o.log, serviceService)
 	jobHandler := entrepreneurHttp.NewJobHandler(o.log, jobService)
+	searchHandler := entrepreneurHttp.NewSearchHandler(o.log, searchService)
 	// ## Admin
-	adminUserHandler := adminHttp.NewUserHandler(o.log, userService)
-	adminBusinessHandler := adminHttp.NewBusinessHandler(o.log, businessService)
+	adminUserHandler := adminHttp.NewUserHandler(o.log, userService, lockoutService, adminScopeService, impersonationService, sessionService)
+	adminBusinessHandler := adminHttp.NewBusinessHandler(o.log, businessService, userService, adminScopeService)
 	adminChurchHandler := adminHttp.NewChurchHandler(o.log, churchService)
 	adminIndustryHandler := adminHttp.NewIndustryHandler(o.log, industryService)
 	adminFieldOfWorkHandler := adminHttp.NewFieldOfWorkHandler(o.log, fieldOfWorkService)
+	adminRoleHandler := adminHttp.NewRoleHandler(o.log, roleService)
+	adminPermissionHandler := adminHttp.NewPermissionHandler(o.log, permissionService)
+	adminAttestationHandler := adminHttp.NewCatholicAttestationHandler(o.log, attestationService, adminScopeService)
+	adminEntrepreneurApplicationHandler := adminHttp.NewEntrepreneurApplicationHandler(o.log, entrepreneurApplicationService, userService, adminScopeService)
 
 	// # Middleware
-	middleware := middleware.NewMiddleware(userPersistence, o.tokenManager)
+	middleware := middleware.NewMiddleware(userPersistence, o.tokenManager, sessionService, roleService, adminScopeService, impersonationService)
 
 	return &Symphony{
-		Auth:             authHandler,
-		User:             userHandler,
-		Business:         businessHandler,
-		Product:          productHandler,
-		Service:          serviceHandler,
-		Job:              jobHandler,
-		AdminUser:        adminUserHandler,
-		AdminBusiness:    adminBusinessHandler,
-		AdminChurch:      adminChurchHandler,
-		AdminIndustry:    adminIndustryHandler,
-		AdminFieldOfWork: adminFieldOfWorkHandler,
-		Middleware:       middleware,
+		Auth:                         authHandler,
+		OIDC:                         oidcHandler,
+		Passkey:                      passkeyHandler,
+		Attestation:                  attestationHandler,
+		EntrepreneurApplication:      entrepreneurApplicationHandler,
+		User:                         userHandler,
+		Business:                     businessHandler,
+		BusinessMember:               businessMemberHandler,
+		BusinessLocation:             businessLocationHandler,
+		BusinessHours:                businessHoursHandler,
+		Product:                      productHandler,
+		Service:                      serviceHandler,
+		Job:                          jobHandler,
+		Search:                       searchHandler,
+		AdminUser:                    adminUserHandler,
+		AdminBusiness:                adminBusinessHandler,
+		AdminChurch:                  adminChurchHandler,
+		AdminIndustry:                adminIndustryHandler,
+		AdminFieldOfWork:             adminFieldOfWorkHandler,
+		AdminRole:                    adminRoleHandler,
+		AdminPermission:              adminPermissionHandler,
+		AdminAttestation:             adminAttestationHandler,
+		AdminEntrepreneurApplication: adminEntrepreneurApplicationHandler,
+		Middleware:                   middleware,
+		AccountService:               accountService,
 	}
 }
@@ -10,7 +10,6 @@ import (
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
 	"github.com/go-chi/cors"
-	"github.com/go-chi/httprate"
 	httprateredis "github.com/go-chi/httprate-redis"
 	"github.com/redis/go-redis/v9"
 )
@@ -49,31 +48,73 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 		AllowCredentials: srv.cfg.API.CORS.AllowCredentials,
 		MaxAge:           srv.cfg.API.CORS.MaxAge, // Maximum value not ignored by any of major browsers
 	}))
-	// Rate Limiting: 10 Reqs/Min for each endpoint.
-	// IP stored in Redis with key httprate:[ipaddr]
-	r.Use(httprate.Limit(
-		srv.cfg.API.RateLimiter.RequestLimit,
-		srv.cfg.API.RateLimiter.WindowLength,
-		httprate.WithKeyFuncs(httprate.KeyByIP, httprate.KeyByEndpoint),
-		httprateredis.WithRedisLimitCounter(&httprateredis.Config{
-			Client: client,
-		}),
-	))
+	// Rate limiting, per route group: users are counted by ID and anonymous clients by IP,
+	// in Redis with keys httprate:[group]:user:[id] and httprate:[group]:ip:[ipaddr]
+	rateLimiter := srv.cfg.API.RateLimiter
+	counter := httprateredis.NewCounter(&httprateredis.Config{
+		Client:       client,
+		WindowLength: rateLimiter.WindowLength,
+	})
+	authLimit := srv.symphony.Middleware.RateLimit("auth", rateLimiter.Auth, rateLimiter.WindowLength, counter)
+	publicLimit := srv.symphony.Middleware.RateLimit("public", rateLimiter.Public, rateLimiter.WindowLength, counter)
+	adminLimit := srv.symphony.Middleware.RateLimit("admin", rateLimiter.Admin, rateLimiter.WindowLength, counter)
+
+	r.With(publicLimit).Get("/.well-known/jwks.json", srv.symphony.Auth.JWKS)
 
 	r.Route("/api/v1", func(r chi.Router) {
 		r.Route("/auth", func(r chi.Router) {
+			r.Use(authLimit)
 			r.Put("/register", srv.symphony.Auth.Register)
 			r.Post("/login", srv.symphony.Auth.Login)
+			r.Post("/magic-link", srv.symphony.Auth.RequestMagicLink)
+			r.Post("/magic-link/{token}", srv.symphony.Auth.MagicLinkLogin)
+			r.Get("/oidc/{provider}", srv.symphony.OIDC.Authorize)
+			r.Post("/oidc/{provider}/callback", srv.symphony.OIDC.Callback)
+			r.Post("/oidc/complete", srv.symphony.OIDC.CompleteProfile)
 			r.Post("/password/reset", srv.symphony.Auth.RequestPasswordReset)
 			r.Patch("/password/reset/{id}/{token}", srv.symphony.Auth.ConfirmPasswordReset)
+			r.Post("/email/verify/resend", srv.symphony.Auth.ResendVerificationEmail)
 			r.Patch("/email/verify/{token}", srv.symphony.Auth.VerifyEmail)
+			r.Patch("/unlock/{token}", srv.symphony.Auth.UnlockAccount)
 			r.Get("/refresh", srv.symphony.Auth.Refresh)
+			r.Post("/2fa/verify", srv.symphony.Auth.VerifyTwoFactor)
+			r.Post("/2fa/passkey/begin", srv.symphony.Passkey.BeginTwoFactor)
+			r.Post("/2fa/passkey/finish", srv.symphony.Passkey.FinishTwoFactor)
+			r.Post("/passkey/login/begin", srv.symphony.Passkey.BeginLogin)
+			r.Post("/passkey/login/finish", srv.symphony.Passkey.FinishLogin)
+
+			r.Group(func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.Authenticate)
+				r.Post("/logout", srv.symphony.Auth.Logout)
+				r.Post("/logout-all", srv.symphony.Auth.LogoutAll)
+				r.Post("/2fa/enroll", srv.symphony.Auth.EnrollTwoFactor)
+				r.Post("/2fa/enable", srv.symphony.Auth.EnableTwoFactor)
+				r.Post("/2fa/disable", srv.symphony.Auth.DisableTwoFactor)
+				r.Post("/2fa/recovery-codes", srv.symphony.Auth.RegenerateRecoveryCodes)
+				r.Get("/passkeys", srv.symphony.Passkey.List)
+				r.Post("/passkeys/register/begin", srv.symphony.Passkey.BeginRegistration)
+				r.Post("/passkeys/register/finish", srv.symphony.Passkey.FinishRegistration)
+				r.Patch("/passkeys/{id}", srv.symphony.Passkey.Rename)
+				r.Delete("/passkeys/{id}", srv.symphony.Passkey.Delete)
+			})
 		})
 
 		r.Route("/user", func(r chi.Router) {
+			r.Use(publicLimit)
 			r.Use(srv.symphony.Middleware.Authenticate)
+			r.Get("/attestation", srv.symphony.Attestation.List)
+			r.Post("/attestation", srv.symphony.Attestation.Submit)
+			r.Get("/entrepreneur-application", srv.symphony.EntrepreneurApplication.List)
+			r.Post("/entrepreneur-application", srv.symphony.EntrepreneurApplication.Submit)
+			r.Put("/entrepreneur-application/{id}", srv.symphony.EntrepreneurApplication.Resubmit)
 			r.Get("/{id}", srv.symphony.User.GetByID)
 			r.Put("/{id}", srv.symphony.User.Update)
+			r.Get("/{id}/sessions", srv.symphony.User.ListSessions)
+			r.Get("/{id}/export", srv.symphony.User.Export)
+			r.Delete("/{id}", srv.symphony.User.Delete)
+			r.Patch("/{id}/deletion/cancel", srv.symphony.User.CancelDeletion)
+			r.Patch("/{id}/password", srv.symphony.Auth.ChangePassword)
+			r.Patch("/{id}/email", srv.symphony.Auth.RequestEmailChange)
 			// r.Post("/list", srv.symphony.User.List)
 			// Flags handlers
 			// r.Route("/{id}/flag", func(r chi.Router) {
@@ -84,19 +125,58 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 		})
 
 		r.Route("/entrepreneur", func(r chi.Router) {
+			r.Use(publicLimit)
 			r.Route("/business", func(r chi.Router) {
 				// Public routes
 				r.Post("/list", srv.symphony.Business.List)
 				r.Get("/{id}", srv.symphony.Business.GetByID)
 
-				// Authenticated routes
+				// Registering a business is for Catholic entrepreneurs
 				r.Group(func(r chi.Router) {
 					r.Use(srv.symphony.Middleware.Authenticate)
 					r.Use(srv.symphony.Middleware.UserIsCatholic)
 					r.Use(srv.symphony.Middleware.UserIsEntrepreneur)
 					r.Post("/", srv.symphony.Business.Create)
+				})
+
+				// Authenticated routes, the business members allowed are checked by the service
+				r.Group(func(r chi.Router) {
+					r.Use(srv.symphony.Middleware.Authenticate)
+					r.Post("/own/list", srv.symphony.Business.ListOwn)
+					r.Patch("/{id}/submit", srv.symphony.Business.Submit)
 					r.Put("/{id}", srv.symphony.Business.Update)
 					r.Delete("/{id}", srv.symphony.Business.Delete)
+					r.Patch("/{id}/owner", srv.symphony.Business.TransferOwnership)
+				})
+
+				// Members, invited users do not have to be entrepreneurs themselves
+				r.Route("/{id}/members", func(r chi.Router) {
+					r.Use(srv.symphony.Middleware.Authenticate)
+					r.Get("/", srv.symphony.BusinessMember.List)
+					r.Post("/", srv.symphony.BusinessMember.Invite)
+					r.Post("/accept", srv.symphony.BusinessMember.Accept)
+					r.Patch("/{userID}", srv.symphony.BusinessMember.UpdateRole)
+					r.Delete("/{userID}", srv.symphony.BusinessMember.Remove)
+				})
+
+				// Locations are public once the business is approved, and managed by its members
+				r.Route("/{id}/locations", func(r chi.Router) {
+					r.With(srv.symphony.Middleware.AuthenticateIfPresent).Get("/", srv.symphony.BusinessLocation.List)
+
+					r.Group(func(r chi.Router) {
+						r.Use(srv.symphony.Middleware.Authenticate)
+						r.Post("/", srv.symphony.BusinessLocation.Create)
+						r.Put("/{locationID}", srv.symphony.BusinessLocation.Update)
+						r.Delete("/{locationID}", srv.symphony.BusinessLocation.Delete)
+					})
+				})
+
+				// Hours are read along with the business, and managed by its members
+				r.Route("/{id}/hours", func(r chi.Router) {
+					r.Use(srv.symphony.Middleware.Authenticate)
+					r.Put("/", srv.symphony.BusinessHours.ReplaceHours)
+					r.Put("/exceptions/{date}", srv.symphony.BusinessHours.UpsertException)
+					r.Delete("/exceptions/{date}", srv.symphony.BusinessHours.DeleteException)
 				})
 			})
 
@@ -105,11 +185,10 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 				r.Post("/list", srv.symphony.Product.List)
 				r.Get("/{id}", srv.symphony.Product.GetByID)
 
-				// Authenticated routes
+				// Authenticated routes, the business members allowed are checked by the service
 				r.Group(func(r chi.Router) {
 					r.Use(srv.symphony.Middleware.Authenticate)
 					r.Use(srv.symphony.Middleware.UserIsCatholic)
-					r.Use(srv.symphony.Middleware.UserIsEntrepreneur)
 					r.Post("/", srv.symphony.Product.Create)
 					r.Put("/{id}", srv.symphony.Product.Update)
 					r.Delete("/{id}", srv.symphony.Product.Delete)
@@ -121,11 +200,10 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 				r.Post("/list", srv.symphony.Service.List)
 				r.Get("/{id}", srv.symphony.Service.GetByID)
 
-				// Authenticated routes
+				// Authenticated routes, the business members allowed are checked by the service
 				r.Group(func(r chi.Router) {
 					r.Use(srv.symphony.Middleware.Authenticate)
 					r.Use(srv.symphony.Middleware.UserIsCatholic)
-					r.Use(srv.symphony.Middleware.UserIsEntrepreneur)
 					r.Post("/", srv.symphony.Service.Create)
 					r.Put("/{id}", srv.symphony.Service.Update)
 					r.Delete("/{id}", srv.symphony.Service.Delete)
@@ -138,41 +216,74 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 				r.Post("/list", srv.symphony.Job.List)
 				r.Get("/{id}", srv.symphony.Job.GetByID)
 
-				r.Group(func(r chi.Router) {
-					r.Use(srv.symphony.Middleware.UserIsEntrepreneur)
-					r.Post("/", srv.symphony.Job.Create)
-					r.Put("/{id}", srv.symphony.Job.Update)
-					r.Delete("/{id}", srv.symphony.Job.Delete)
-				})
+				r.Post("/", srv.symphony.Job.Create)
+				r.Put("/{id}", srv.symphony.Job.Update)
+				r.Delete("/{id}", srv.symphony.Job.Delete)
 			})
 		})
 
-		// Admin routes - requires authentication and admin role
+		// Search across businesses, products, services and jobs, the latter for Catholic users only
+		r.With(publicLimit, srv.symphony.Middleware.AuthenticateIfPresent).Get("/search", srv.symphony.Search.Search)
+
+		// Admin routes - require authentication, a two-factor session and the permission of each route
 		r.Route("/admin", func(r chi.Router) {
+			r.Use(adminLimit)
 			r.Use(srv.symphony.Middleware.Authenticate)
-			r.Use(srv.symphony.Middleware.RequireRoles(constants.ROLE_ADMIN))
+			// Any role may be granted admin permissions, so every admin session needs a second factor
+			r.Use(srv.symphony.Middleware.RequireTwoFactor())
+			// Admins assigned to a church or diocese only see and manage its people
+			r.Use(srv.symphony.Middleware.LoadAdminScope)
 
 			// User management
 			r.Route("/user", func(r chi.Router) {
-				r.Get("/{id}", srv.symphony.AdminUser.GetByID)
-				r.Post("/list", srv.symphony.AdminUser.List)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_READ)).Get("/{id}", srv.symphony.AdminUser.GetByID)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_READ)).Post("/list", srv.symphony.AdminUser.List)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_READ)).Get("/{id}/sessions", srv.symphony.AdminUser.ListSessions)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_READ)).Post("/lockout/list", srv.symphony.AdminUser.ListLockouts)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_IMPERSONATE)).Post("/impersonation/list", srv.symphony.AdminUser.ListImpersonations)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_IMPERSONATE)).Post("/{id}/impersonate", srv.symphony.AdminUser.Impersonate)
 				r.Route("/{id}/flag", func(r chi.Router) {
+					r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_MANAGE))
 					r.Patch("/active", srv.symphony.AdminUser.SetIsActive)
 					r.Patch("/catholic", srv.symphony.AdminUser.SetIsCatholic)
 					r.Patch("/entrepreneur", srv.symphony.AdminUser.SetIsEntrepreneur)
 				})
-				r.Patch("/{id}/role", srv.symphony.AdminUser.SetRole)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_ASSIGN_ROLE)).Patch("/{id}/role", srv.symphony.AdminUser.SetRole)
+				r.Route("/{id}/scope", func(r chi.Router) {
+					r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_ASSIGN_SCOPE))
+					r.Get("/", srv.symphony.AdminUser.GetScope)
+					r.Put("/", srv.symphony.AdminUser.SetScope)
+					r.Delete("/", srv.symphony.AdminUser.DeleteScope)
+				})
+			})
+
+			// Catholic attestations, reviewed by the managers of the parish they name
+			r.Route("/attestation", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_REVIEW_ATTESTATION))
+				r.Post("/list", srv.symphony.AdminAttestation.List)
+				r.Get("/{id}", srv.symphony.AdminAttestation.GetByID)
+				r.Patch("/{id}/review", srv.symphony.AdminAttestation.Review)
+			})
+
+			// Entrepreneur applications, approved before users may register a business
+			r.Route("/entrepreneur-application", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_USER_REVIEW_ENTREPRENEUR))
+				r.Post("/list", srv.symphony.AdminEntrepreneurApplication.List)
+				r.Get("/{id}", srv.symphony.AdminEntrepreneurApplication.GetByID)
+				r.Patch("/{id}/review", srv.symphony.AdminEntrepreneurApplication.Review)
 			})
 
 			// Business management
 			r.Route("/business", func(r chi.Router) {
-				r.Get("/{id}", srv.symphony.AdminBusiness.GetByID)
-				r.Post("/list", srv.symphony.AdminBusiness.List)
-				r.Patch("/{id}/flag/active", srv.symphony.AdminBusiness.SetIsActive)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_BUSINESS_READ)).Get("/{id}", srv.symphony.AdminBusiness.GetByID)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_BUSINESS_READ)).Post("/list", srv.symphony.AdminBusiness.List)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_BUSINESS_APPROVE)).Post("/moderation/list", srv.symphony.AdminBusiness.ModerationQueue)
+				r.With(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_BUSINESS_APPROVE)).Patch("/{id}/status", srv.symphony.AdminBusiness.Moderate)
 			})
 
 			// Church management
 			r.Route("/church", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_CHURCH_MANAGE))
 				r.Post("/", srv.symphony.AdminChurch.Create)
 				r.Get("/{id}", srv.symphony.AdminChurch.GetByID)
 				r.Put("/{id}", srv.symphony.AdminChurch.Update)
@@ -182,6 +293,7 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 
 			// Industry management
 			r.Route("/industry", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_INDUSTRY_MANAGE))
 				r.Post("/", srv.symphony.AdminIndustry.Create)
 				r.Get("/", srv.symphony.AdminIndustry.GetAll)
 				r.Get("/{id}", srv.symphony.AdminIndustry.GetByID)
@@ -191,12 +303,35 @@ func (srv *ServerRouter) Mount(client *redis.Client) http.Handler {
 
 			// Field of work management
 			r.Route("/field-of-work", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_FIELD_OF_WORK_MANAGE))
 				r.Post("/", srv.symphony.AdminFieldOfWork.Create)
 				r.Get("/", srv.symphony.AdminFieldOfWork.GetAll)
 				r.Get("/{id}", srv.symphony.AdminFieldOfWork.GetByID)
 				r.Put("/{id}", srv.symphony.AdminFieldOfWork.Update)
 				r.Delete("/{id}", srv.symphony.AdminFieldOfWork.Delete)
 			})
+
+			// Role management
+			r.Route("/role", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_ROLE_MANAGE))
+				r.Post("/", srv.symphony.AdminRole.Create)
+				r.Get("/", srv.symphony.AdminRole.GetAll)
+				r.Get("/{id}", srv.symphony.AdminRole.GetByID)
+				r.Put("/{id}", srv.symphony.AdminRole.Update)
+				r.Delete("/{id}", srv.symphony.AdminRole.Delete)
+				r.Get("/{id}/permissions", srv.symphony.AdminRole.GetPermissions)
+				r.Put("/{id}/permissions", srv.symphony.AdminRole.SetPermissions)
+			})
+
+			// Permission management
+			r.Route("/permission", func(r chi.Router) {
+				r.Use(srv.symphony.Middleware.RequirePermission(constants.PERMISSION_ROLE_MANAGE))
+				r.Post("/", srv.symphony.AdminPermission.Create)
+				r.Get("/", srv.symphony.AdminPermission.GetAll)
+				r.Get("/{id}", srv.symphony.AdminPermission.GetByID)
+				r.Put("/{id}", srv.symphony.AdminPermission.Update)
+				r.Delete("/{id}", srv.symphony.AdminPermission.Delete)
+			})
 		})
 	})
 
@@ -1,6 +1,9 @@
 package worker
 
 import (
+	"context"
+	"time"
+
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/cmd/server/worker/email"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/cmd/server/worker/notification"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
@@ -32,3 +35,15 @@ func (w *Worker) Start() {
 
 	w.Logger.Info("Worker started")
 }
+
+// Every runs the task at each interval until the process exits, logging its failures
+func (w *Worker) Every(interval time.Duration, name string, task func(context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for range ticker.C {
+		if err := task(context.Background()); err != nil {
+			w.Logger.Errorw("scheduled task failed", "task", name, "error", err)
+		}
+	}
+}
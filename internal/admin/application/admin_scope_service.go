@@ -0,0 +1,109 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type AdminScopeService struct {
+	logger     *zap.SugaredLogger
+	scopeRepo  domain.AdminScopeRepository
+	churchRepo domain.ChurchRepository
+}
+
+func NewAdminScopeService(logger *zap.SugaredLogger, scopeRepo domain.AdminScopeRepository, churchRepo domain.ChurchRepository) *AdminScopeService {
+	return &AdminScopeService{
+		logger:     logger,
+		scopeRepo:  scopeRepo,
+		churchRepo: churchRepo,
+	}
+}
+
+// Get returns the scope assigned to the user, or ErrAdminScopeNotFound when they administer every church
+func (s *AdminScopeService) Get(ctx context.Context, userID uuid.UUID) (*domain.AdminScope, error) {
+	scope, err := s.scopeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrAdminScopeNotFound) {
+			return nil, domain.ErrAdminScopeNotFound
+		}
+
+		s.logger.Errorw("failed to get admin scope", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return scope, nil
+}
+
+// Set limits the user to a church or a diocese, replacing their previous scope
+func (s *AdminScopeService) Set(ctx context.Context, req *dto.AdminScopeSetRequest) (*domain.AdminScope, error) {
+	if (req.ChurchID == nil) == (req.Diocese == "") {
+		return nil, domain.ErrInvalidAdminScope
+	}
+
+	scope := &domain.AdminScope{UserID: req.UserID}
+	if req.ChurchID != nil {
+		if _, err := s.churchRepo.GetByID(ctx, *req.ChurchID); err != nil {
+			if errors.Is(err, domain.ErrChurchNotFound) {
+				return nil, domain.ErrChurchNotFound
+			}
+
+			s.logger.Errorw("failed to get church by ID", "id", *req.ChurchID, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+
+		scope.ChurchID = uuid.NullUUID{UUID: *req.ChurchID, Valid: true}
+	} else {
+		scope.Diocese = sql.NullString{String: req.Diocese, Valid: true}
+	}
+
+	if err := s.scopeRepo.Upsert(ctx, scope); err != nil {
+		s.logger.Errorw("failed to set admin scope", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return scope, nil
+}
+
+// Delete lifts the scope of the user, who then administers every church
+func (s *AdminScopeService) Delete(ctx context.Context, userID uuid.UUID) error {
+	if _, err := s.Get(ctx, userID); err != nil {
+		return err
+	}
+
+	if err := s.scopeRepo.Delete(ctx, userID); err != nil {
+		s.logger.Errorw("failed to delete admin scope", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// CoversChurch reports whether people of the church fall within the scope. A nil scope covers every church.
+func (s *AdminScopeService) CoversChurch(ctx context.Context, scope *domain.AdminScope, churchID uuid.UUID) (bool, error) {
+	if scope == nil {
+		return true, nil
+	}
+
+	if scope.ChurchID.Valid {
+		return scope.ChurchID.UUID == churchID, nil
+	}
+
+	church, err := s.churchRepo.GetByID(ctx, churchID)
+	if err != nil {
+		if errors.Is(err, domain.ErrChurchNotFound) {
+			return false, nil
+		}
+
+		s.logger.Errorw("failed to get church by ID", "id", churchID, "error", err)
+		return false, response.ErrInternalServerError
+	}
+
+	return scope.Covers(church), nil
+}
@@ -0,0 +1,158 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockAdminScopeRepository
+type MockAdminScopeRepository struct {
+	mock.Mock
+}
+
+func (m *MockAdminScopeRepository) Upsert(ctx context.Context, scope *domain.AdminScope) error {
+	args := m.Called(ctx, scope)
+	return args.Error(0)
+}
+
+func (m *MockAdminScopeRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.AdminScope, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.AdminScope), args.Error(1)
+}
+
+func (m *MockAdminScopeRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func TestAdminScopeService_Set(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	mockRepo := new(MockAdminScopeRepository)
+	mockChurchRepo := new(MockChurchRepository)
+	service := NewAdminScopeService(logger, mockRepo, mockChurchRepo)
+	ctx := context.Background()
+	userID := uuid.New()
+	churchID := uuid.New()
+
+	t.Run("Church", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockChurchRepo.ExpectedCalls = nil
+		mockChurchRepo.On("GetByID", ctx, churchID).Return(&domain.Church{ID: churchID}, nil)
+		mockRepo.On("Upsert", ctx, mock.MatchedBy(func(s *domain.AdminScope) bool {
+			return s.UserID == userID && s.ChurchID.UUID == churchID && !s.Diocese.Valid
+		})).Return(nil)
+
+		scope, err := service.Set(ctx, &dto.AdminScopeSetRequest{UserID: userID, ChurchID: &churchID})
+
+		assert.NoError(t, err)
+		assert.True(t, scope.ChurchID.Valid)
+		mockRepo.AssertExpectations(t)
+		mockChurchRepo.AssertExpectations(t)
+	})
+
+	t.Run("Diocese", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("Upsert", ctx, mock.MatchedBy(func(s *domain.AdminScope) bool {
+			return !s.ChurchID.Valid && s.Diocese.String == "Diocese of Rome"
+		})).Return(nil)
+
+		_, err := service.Set(ctx, &dto.AdminScopeSetRequest{UserID: userID, Diocese: "Diocese of Rome"})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("ChurchAndDiocese", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+
+		scope, err := service.Set(ctx, &dto.AdminScopeSetRequest{UserID: userID, ChurchID: &churchID, Diocese: "Diocese of Rome"})
+
+		assert.Nil(t, scope)
+		assert.Equal(t, domain.ErrInvalidAdminScope, err)
+	})
+
+	t.Run("Neither", func(t *testing.T) {
+		scope, err := service.Set(ctx, &dto.AdminScopeSetRequest{UserID: userID})
+
+		assert.Nil(t, scope)
+		assert.Equal(t, domain.ErrInvalidAdminScope, err)
+	})
+
+	t.Run("UnknownChurch", func(t *testing.T) {
+		mockChurchRepo.ExpectedCalls = nil
+		mockChurchRepo.On("GetByID", ctx, churchID).Return(nil, domain.ErrChurchNotFound)
+
+		scope, err := service.Set(ctx, &dto.AdminScopeSetRequest{UserID: userID, ChurchID: &churchID})
+
+		assert.Nil(t, scope)
+		assert.Equal(t, domain.ErrChurchNotFound, err)
+	})
+}
+
+func TestAdminScopeService_CoversChurch(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	mockChurchRepo := new(MockChurchRepository)
+	service := NewAdminScopeService(logger, new(MockAdminScopeRepository), mockChurchRepo)
+	ctx := context.Background()
+
+	parish := &domain.Church{ID: uuid.New(), Diocese: "Diocese of Rome"}
+	elsewhere := &domain.Church{ID: uuid.New(), Diocese: "Diocese of Milan"}
+
+	t.Run("Unscoped", func(t *testing.T) {
+		covered, err := service.CoversChurch(ctx, nil, elsewhere.ID)
+
+		assert.NoError(t, err)
+		assert.True(t, covered)
+	})
+
+	t.Run("Church", func(t *testing.T) {
+		scope := &domain.AdminScope{ChurchID: uuid.NullUUID{UUID: parish.ID, Valid: true}}
+
+		covered, err := service.CoversChurch(ctx, scope, parish.ID)
+		assert.NoError(t, err)
+		assert.True(t, covered)
+
+		covered, err = service.CoversChurch(ctx, scope, elsewhere.ID)
+		assert.NoError(t, err)
+		assert.False(t, covered)
+	})
+
+	t.Run("Diocese", func(t *testing.T) {
+		mockChurchRepo.ExpectedCalls = nil
+		mockChurchRepo.On("GetByID", ctx, parish.ID).Return(parish, nil)
+		mockChurchRepo.On("GetByID", ctx, elsewhere.ID).Return(elsewhere, nil)
+		scope := &domain.AdminScope{Diocese: sql.NullString{String: "Diocese of Rome", Valid: true}}
+
+		covered, err := service.CoversChurch(ctx, scope, parish.ID)
+		assert.NoError(t, err)
+		assert.True(t, covered)
+
+		covered, err = service.CoversChurch(ctx, scope, elsewhere.ID)
+		assert.NoError(t, err)
+		assert.False(t, covered)
+	})
+
+	t.Run("ChurchLookupFailure", func(t *testing.T) {
+		mockChurchRepo.ExpectedCalls = nil
+		mockChurchRepo.On("GetByID", ctx, parish.ID).Return(nil, errors.New("db error"))
+		scope := &domain.AdminScope{Diocese: sql.NullString{String: "Diocese of Rome", Valid: true}}
+
+		covered, err := service.CoversChurch(ctx, scope, parish.ID)
+
+		assert.False(t, covered)
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+}
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -152,8 +154,16 @@ func (s *ChurchService) List(ctx context.Context, req *dto.ChurchListRequest) (*
 		return nil, response.ErrInternalServerError
 	}
 
+	var page pagination.Page
+	if req.Cursor != nil {
+		churches, page = pagination.Trim(churches, req.Cursor, pagination.Limit(req.Limit), func(c *domain.Church) (time.Time, uuid.UUID) {
+			return c.CreatedAt, c.ID
+		})
+	}
+
+	// Cursor pages skip the count, which would read the whole list on every page
 	count := 0
-	if len(churches) > 0 {
+	if len(churches) > 0 && req.Cursor == nil {
 		count, err = s.churchRepo.Count(ctx, req)
 		if err != nil {
 			s.logger.Errorw("failed to count churches", "error", err)
@@ -166,5 +176,6 @@ func (s *ChurchService) List(ctx context.Context, req *dto.ChurchListRequest) (*
 		Count:    count,
 		Limit:    req.Limit,
 		Offset:   req.Offset,
+		Page:     page,
 	}, nil
 }
@@ -0,0 +1,130 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"go.uber.org/zap"
+)
+
+type PermissionService struct {
+	logger         *zap.SugaredLogger
+	cache          storage.CacheStorage
+	permissionRepo domain.PermissionRepository
+}
+
+func NewPermissionService(logger *zap.SugaredLogger, cache storage.CacheStorage, permissionRepo domain.PermissionRepository) *PermissionService {
+	return &PermissionService{
+		logger:         logger,
+		cache:          cache,
+		permissionRepo: permissionRepo,
+	}
+}
+
+// Create adds a permission. It takes effect once a route requires its key.
+func (s *PermissionService) Create(ctx context.Context, req *dto.PermissionCreateRequest) (*domain.Permission, error) {
+	// Check if permission with same key already exists
+	if _, err := s.permissionRepo.GetByKey(ctx, req.Key); err == nil {
+		return nil, domain.ErrPermissionAlreadyExists
+	} else if !errors.Is(err, domain.ErrPermissionNotFound) {
+		s.logger.Errorw("failed to check existing permission", "key", req.Key, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	permission := &domain.Permission{
+		Key:         req.Key,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	}
+
+	if err := s.permissionRepo.Create(ctx, permission); err != nil {
+		s.logger.Errorw("failed to create permission", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return permission, nil
+}
+
+func (s *PermissionService) Update(ctx context.Context, req *dto.PermissionUpdateRequest) error {
+	_, err := s.permissionRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionNotFound) {
+			return domain.ErrPermissionNotFound
+		}
+
+		s.logger.Errorw("failed to get permission by ID", "id", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	// Check if updating to a key that already exists (and belongs to a different permission)
+	if existingPermission, err := s.permissionRepo.GetByKey(ctx, req.Key); err == nil && existingPermission.ID != req.ID {
+		return domain.ErrPermissionAlreadyExists
+	}
+
+	permission := &domain.Permission{
+		ID:          req.ID,
+		Key:         req.Key,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	}
+
+	if err := s.permissionRepo.Update(ctx, permission); err != nil {
+		s.logger.Errorw("failed to update permission", "id", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	// A renamed key changes what every role holding it may do
+	clearCachedPermissions(ctx, s.logger, s.cache, "*")
+
+	return nil
+}
+
+func (s *PermissionService) Delete(ctx context.Context, id int16) error {
+	_, err := s.permissionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionNotFound) {
+			return domain.ErrPermissionNotFound
+		}
+
+		s.logger.Errorw("failed to get permission by ID", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if err := s.permissionRepo.Delete(ctx, id); err != nil {
+		s.logger.Errorw("failed to delete permission", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	clearCachedPermissions(ctx, s.logger, s.cache, "*")
+
+	return nil
+}
+
+func (s *PermissionService) GetByID(ctx context.Context, id int16) (*domain.Permission, error) {
+	permission, err := s.permissionRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrPermissionNotFound) {
+			return nil, domain.ErrPermissionNotFound
+		}
+
+		s.logger.Errorw("failed to get permission by ID", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return permission, nil
+}
+
+func (s *PermissionService) GetAll(ctx context.Context) (*dto.PermissionListResponse, error) {
+	permissions, err := s.permissionRepo.GetAll(ctx)
+	if err != nil && !errors.Is(err, domain.ErrPermissionNotFound) {
+		s.logger.Errorw("failed to get all permissions", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.PermissionListResponse{
+		Permissions: permissions,
+	}, nil
+}
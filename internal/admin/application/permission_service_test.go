@@ -0,0 +1,151 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockPermissionRepository
+type MockPermissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionRepository) Create(ctx context.Context, permission *domain.Permission) error {
+	args := m.Called(ctx, permission)
+	if args.Error(0) == nil {
+		permission.ID = 1
+	}
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) Update(ctx context.Context, permission *domain.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) Delete(ctx context.Context, id int16) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) GetAll(ctx context.Context) ([]*domain.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByID(ctx context.Context, id int16) (*domain.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByKey(ctx context.Context, key string) (*domain.Permission, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByRoleID(ctx context.Context, roleID int16) ([]*domain.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Permission), args.Error(1)
+}
+
+func TestPermissionService_Create(t *testing.T) {
+	_, _, mockRepo, cache := setupRoleTest(t)
+	service := NewPermissionService(zap.NewNop().Sugar(), cache, mockRepo)
+	ctx := context.Background()
+
+	req := &dto.PermissionCreateRequest{
+		Key: "business.feature",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByKey", ctx, req.Key).Return(nil, domain.ErrPermissionNotFound)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Permission")).Return(nil)
+
+		result, err := service.Create(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, req.Key, result.Key)
+		assert.False(t, result.Description.Valid)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByKey", ctx, req.Key).Return(&domain.Permission{ID: 1, Key: req.Key}, nil)
+
+		result, err := service.Create(ctx, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrPermissionAlreadyExists, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("CreateFailure", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByKey", ctx, req.Key).Return(nil, domain.ErrPermissionNotFound)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Permission")).Return(errors.New("db error"))
+
+		result, err := service.Create(ctx, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, response.ErrInternalServerError, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestPermissionService_Delete(t *testing.T) {
+	roleService, _, mockRepo, cache := setupRoleTest(t)
+	service := NewPermissionService(zap.NewNop().Sugar(), cache, mockRepo)
+	ctx := context.Background()
+
+	t.Run("ClearsCachedGrants", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).
+			Return([]*domain.Permission{{ID: 5, Key: constants.PERMISSION_BUSINESS_APPROVE}}, nil).Once()
+		allowed, err := roleService.HasPermission(ctx, constants.ROLE_MANAGER, constants.PERMISSION_BUSINESS_APPROVE)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		mockRepo.On("GetByID", ctx, int16(5)).Return(&domain.Permission{ID: 5}, nil)
+		mockRepo.On("Delete", ctx, int16(5)).Return(nil)
+		assert.NoError(t, service.Delete(ctx, 5))
+
+		mockRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).Return([]*domain.Permission{}, nil).Once()
+		allowed, err = roleService.HasPermission(ctx, constants.ROLE_MANAGER, constants.PERMISSION_BUSINESS_APPROVE)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, int16(42)).Return(nil, domain.ErrPermissionNotFound)
+
+		err := service.Delete(ctx, 42)
+
+		assert.Equal(t, domain.ErrPermissionNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"go.uber.org/zap"
+)
+
+// rolePermissionsCacheTTL bounds how long a permission check may lag behind a grant change
+// made by another instance
+const rolePermissionsCacheTTL = 10 * time.Minute
+
+type RoleService struct {
+	logger         *zap.SugaredLogger
+	cache          storage.CacheStorage
+	roleRepo       domain.RoleRepository
+	permissionRepo domain.PermissionRepository
+}
+
+func NewRoleService(logger *zap.SugaredLogger, cache storage.CacheStorage, roleRepo domain.RoleRepository, permissionRepo domain.PermissionRepository) *RoleService {
+	return &RoleService{
+		logger:         logger,
+		cache:          cache,
+		roleRepo:       roleRepo,
+		permissionRepo: permissionRepo,
+	}
+}
+
+func (s *RoleService) Create(ctx context.Context, req *dto.RoleCreateRequest) (*domain.Role, error) {
+	// Check if role with same name already exists
+	if _, err := s.roleRepo.GetByName(ctx, req.Name); err == nil {
+		return nil, domain.ErrRoleAlreadyExists
+	} else if !errors.Is(err, domain.ErrRoleNotFound) {
+		s.logger.Errorw("failed to check existing role", "name", req.Name, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	role := &domain.Role{
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	}
+
+	if err := s.roleRepo.Create(ctx, role); err != nil {
+		s.logger.Errorw("failed to create role", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return role, nil
+}
+
+func (s *RoleService) Update(ctx context.Context, req *dto.RoleUpdateRequest) error {
+	_, err := s.roleRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return domain.ErrRoleNotFound
+		}
+
+		s.logger.Errorw("failed to get role by ID", "id", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	// Check if updating to a name that already exists (and belongs to a different role)
+	if existingRole, err := s.roleRepo.GetByName(ctx, req.Name); err == nil && existingRole.ID != req.ID {
+		return domain.ErrRoleAlreadyExists
+	}
+
+	role := &domain.Role{
+		ID:          req.ID,
+		Name:        req.Name,
+		Description: sql.NullString{String: req.Description, Valid: req.Description != ""},
+	}
+
+	if err := s.roleRepo.Update(ctx, role); err != nil {
+		s.logger.Errorw("failed to update role", "id", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// Delete removes a custom role. The built-in roles are referenced by the code and roles
+// still held by users are kept.
+func (s *RoleService) Delete(ctx context.Context, id int16) error {
+	if id <= constants.ROLE_USER {
+		return domain.ErrRoleProtected
+	}
+
+	_, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return domain.ErrRoleNotFound
+		}
+
+		s.logger.Errorw("failed to get role by ID", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	count, err := s.roleRepo.CountUsers(ctx, id)
+	if err != nil {
+		s.logger.Errorw("failed to count role users", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if count > 0 {
+		return domain.ErrRoleInUse
+	}
+
+	if err := s.roleRepo.Delete(ctx, id); err != nil {
+		s.logger.Errorw("failed to delete role", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	clearCachedPermissions(ctx, s.logger, s.cache, strconv.Itoa(int(id)))
+
+	return nil
+}
+
+func (s *RoleService) GetByID(ctx context.Context, id int16) (*domain.Role, error) {
+	role, err := s.roleRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrRoleNotFound) {
+			return nil, domain.ErrRoleNotFound
+		}
+
+		s.logger.Errorw("failed to get role by ID", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return role, nil
+}
+
+func (s *RoleService) GetAll(ctx context.Context) (*dto.RoleListResponse, error) {
+	roles, err := s.roleRepo.GetAll(ctx)
+	if err != nil && !errors.Is(err, domain.ErrRoleNotFound) {
+		s.logger.Errorw("failed to get all roles", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.RoleListResponse{
+		Roles: roles,
+	}, nil
+}
+
+// GetPermissions lists the permissions granted to the role. Admin holds all of them.
+func (s *RoleService) GetPermissions(ctx context.Context, id int16) (*dto.PermissionListResponse, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+
+	var permissions []*domain.Permission
+	var err error
+	if id == constants.ROLE_ADMIN {
+		permissions, err = s.permissionRepo.GetAll(ctx)
+	} else {
+		permissions, err = s.permissionRepo.GetByRoleID(ctx, id)
+	}
+	if err != nil && !errors.Is(err, domain.ErrPermissionNotFound) {
+		s.logger.Errorw("failed to get role permissions", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.PermissionListResponse{
+		Permissions: permissions,
+	}, nil
+}
+
+// SetPermissions replaces the permissions granted to the role
+func (s *RoleService) SetPermissions(ctx context.Context, req *dto.RoleSetPermissionsRequest) error {
+	// Admin always holds every permission, so its grants are not editable
+	if req.ID == constants.ROLE_ADMIN {
+		return domain.ErrRoleProtected
+	}
+
+	if _, err := s.GetByID(ctx, req.ID); err != nil {
+		return err
+	}
+
+	for _, permissionID := range req.PermissionIDs {
+		if _, err := s.permissionRepo.GetByID(ctx, permissionID); err != nil {
+			if errors.Is(err, domain.ErrPermissionNotFound) {
+				return domain.ErrPermissionNotFound
+			}
+
+			s.logger.Errorw("failed to get permission by ID", "id", permissionID, "error", err)
+			return response.ErrInternalServerError
+		}
+	}
+
+	if err := s.roleRepo.SetPermissions(ctx, req.ID, req.PermissionIDs); err != nil {
+		s.logger.Errorw("failed to set role permissions", "id", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	clearCachedPermissions(ctx, s.logger, s.cache, strconv.Itoa(int(req.ID)))
+
+	return nil
+}
+
+// HasPermission reports whether the role was granted the permission with the given key
+func (s *RoleService) HasPermission(ctx context.Context, roleID int16, key string) (bool, error) {
+	if roleID == constants.ROLE_ADMIN {
+		return true, nil
+	}
+
+	keys, err := s.permissionKeys(ctx, roleID)
+	if err != nil {
+		return false, err
+	}
+
+	return slices.Contains(keys, key), nil
+}
+
+// permissionKeys returns the keys granted to the role, from the cache when possible
+func (s *RoleService) permissionKeys(ctx context.Context, roleID int16) ([]string, error) {
+	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_ROLE_PERMISSIONS, strconv.Itoa(int(roleID)))
+
+	var keys []string
+	if cached, err := s.cache.GetString(ctx, cacheKey); err == nil {
+		if err := json.Unmarshal([]byte(cached), &keys); err == nil {
+			return keys, nil
+		}
+	}
+
+	permissions, err := s.permissionRepo.GetByRoleID(ctx, roleID)
+	if err != nil {
+		s.logger.Errorw("failed to get role permissions", "roleID", roleID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	keys = make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		keys = append(keys, permission.Key)
+	}
+
+	if data, err := json.Marshal(keys); err == nil {
+		if err := s.cache.SetString(ctx, cacheKey, string(data), rolePermissionsCacheTTL); err != nil {
+			s.logger.Warnw("failed to cache role permissions", "roleID", roleID, "error", err)
+		}
+	}
+
+	return keys, nil
+}
+
+// clearCachedPermissions drops the cached permission keys of the matching roles ("*" for all)
+func clearCachedPermissions(ctx context.Context, logger *zap.SugaredLogger, cache storage.CacheStorage, roleID string) {
+	keys, err := cache.Scan(ctx, cache.BuildKey(storage.CACHE_PREFIX_ROLE_PERMISSIONS, roleID))
+	if err != nil {
+		logger.Warnw("failed to scan cached role permissions", "roleID", roleID, "error", err)
+		return
+	}
+
+	for _, key := range keys {
+		if err := cache.Del(ctx, key); err != nil && !errors.Is(err, storage.ErrCacheMiss) {
+			logger.Warnw("failed to clear cached role permissions", "key", key, "error", err)
+		}
+	}
+}
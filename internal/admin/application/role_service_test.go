@@ -0,0 +1,268 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockRoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+func (m *MockRoleRepository) Create(ctx context.Context, role *domain.Role) error {
+	args := m.Called(ctx, role)
+	if args.Error(0) == nil {
+		role.ID = 6
+	}
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Update(ctx context.Context, role *domain.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) Delete(ctx context.Context, id int16) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockRoleRepository) GetAll(ctx context.Context) ([]*domain.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetByID(ctx context.Context, id int16) (*domain.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Role), args.Error(1)
+}
+
+func (m *MockRoleRepository) CountUsers(ctx context.Context, id int16) (int, error) {
+	args := m.Called(ctx, id)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRoleRepository) SetPermissions(ctx context.Context, id int16, permissionIDs []int16) error {
+	args := m.Called(ctx, id, permissionIDs)
+	return args.Error(0)
+}
+
+func setupRoleTest(t *testing.T) (*RoleService, *MockRoleRepository, *MockPermissionRepository, storage.CacheStorage) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	cache := storage.NewCacheStorage(client)
+	mockRoleRepo := new(MockRoleRepository)
+	mockPermissionRepo := new(MockPermissionRepository)
+
+	return NewRoleService(zap.NewNop().Sugar(), cache, mockRoleRepo, mockPermissionRepo), mockRoleRepo, mockPermissionRepo, cache
+}
+
+func TestRoleService_Create(t *testing.T) {
+	service, mockRepo, _, _ := setupRoleTest(t)
+	ctx := context.Background()
+
+	req := &dto.RoleCreateRequest{
+		Name:        "Diocesan Staff",
+		Description: "Approves businesses of the diocese",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByName", ctx, req.Name).Return(nil, domain.ErrRoleNotFound)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*domain.Role")).Return(nil)
+
+		result, err := service.Create(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int16(6), result.ID)
+		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, req.Description, result.Description.String)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyExists", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByName", ctx, req.Name).Return(&domain.Role{ID: 6, Name: req.Name}, nil)
+
+		result, err := service.Create(ctx, req)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrRoleAlreadyExists, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRoleService_Delete(t *testing.T) {
+	service, mockRepo, _, _ := setupRoleTest(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, int16(6)).Return(&domain.Role{ID: 6}, nil)
+		mockRepo.On("CountUsers", ctx, int16(6)).Return(0, nil)
+		mockRepo.On("Delete", ctx, int16(6)).Return(nil)
+
+		err := service.Delete(ctx, 6)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("BuiltInRole", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+
+		err := service.Delete(ctx, constants.ROLE_ASSISTANT)
+
+		assert.Equal(t, domain.ErrRoleProtected, err)
+	})
+
+	t.Run("InUse", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, int16(6)).Return(&domain.Role{ID: 6}, nil)
+		mockRepo.On("CountUsers", ctx, int16(6)).Return(3, nil)
+
+		err := service.Delete(ctx, 6)
+
+		assert.Equal(t, domain.ErrRoleInUse, err)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotFound", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, int16(7)).Return(nil, domain.ErrRoleNotFound)
+
+		err := service.Delete(ctx, 7)
+
+		assert.Equal(t, domain.ErrRoleNotFound, err)
+		mockRepo.AssertExpectations(t)
+	})
+}
+
+func TestRoleService_SetPermissions(t *testing.T) {
+	service, mockRepo, mockPermissionRepo, _ := setupRoleTest(t)
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockPermissionRepo.ExpectedCalls = nil
+		req := &dto.RoleSetPermissionsRequest{ID: constants.ROLE_ASSISTANT, PermissionIDs: []int16{1, 5}}
+		mockRepo.On("GetByID", ctx, req.ID).Return(&domain.Role{ID: req.ID}, nil)
+		mockPermissionRepo.On("GetByID", ctx, int16(1)).Return(&domain.Permission{ID: 1}, nil)
+		mockPermissionRepo.On("GetByID", ctx, int16(5)).Return(&domain.Permission{ID: 5}, nil)
+		mockRepo.On("SetPermissions", ctx, req.ID, req.PermissionIDs).Return(nil)
+
+		err := service.SetPermissions(ctx, req)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockPermissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("UnknownPermission", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockPermissionRepo.ExpectedCalls = nil
+		req := &dto.RoleSetPermissionsRequest{ID: constants.ROLE_ASSISTANT, PermissionIDs: []int16{42}}
+		mockRepo.On("GetByID", ctx, req.ID).Return(&domain.Role{ID: req.ID}, nil)
+		mockPermissionRepo.On("GetByID", ctx, int16(42)).Return(nil, domain.ErrPermissionNotFound)
+
+		err := service.SetPermissions(ctx, req)
+
+		assert.Equal(t, domain.ErrPermissionNotFound, err)
+	})
+
+	t.Run("AdminRole", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+
+		err := service.SetPermissions(ctx, &dto.RoleSetPermissionsRequest{ID: constants.ROLE_ADMIN})
+
+		assert.Equal(t, domain.ErrRoleProtected, err)
+	})
+}
+
+func TestRoleService_HasPermission(t *testing.T) {
+	service, mockRepo, mockPermissionRepo, _ := setupRoleTest(t)
+	ctx := context.Background()
+
+	t.Run("AdminHoldsEveryPermission", func(t *testing.T) {
+		mockPermissionRepo.ExpectedCalls = nil
+
+		allowed, err := service.HasPermission(ctx, constants.ROLE_ADMIN, constants.PERMISSION_ROLE_MANAGE)
+
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockPermissionRepo.AssertNotCalled(t, "GetByRoleID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GrantsAreCached", func(t *testing.T) {
+		mockPermissionRepo.ExpectedCalls = nil
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_ASSISTANT).
+			Return([]*domain.Permission{{ID: 4, Key: constants.PERMISSION_BUSINESS_READ}}, nil).Once()
+
+		allowed, err := service.HasPermission(ctx, constants.ROLE_ASSISTANT, constants.PERMISSION_BUSINESS_READ)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+
+		allowed, err = service.HasPermission(ctx, constants.ROLE_ASSISTANT, constants.PERMISSION_BUSINESS_APPROVE)
+		assert.NoError(t, err)
+		assert.False(t, allowed)
+
+		mockPermissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("SetPermissionsClearsCache", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockPermissionRepo.ExpectedCalls = nil
+		req := &dto.RoleSetPermissionsRequest{ID: constants.ROLE_ASSISTANT, PermissionIDs: []int16{5}}
+		mockRepo.On("GetByID", ctx, req.ID).Return(&domain.Role{ID: req.ID}, nil)
+		mockRepo.On("SetPermissions", ctx, req.ID, req.PermissionIDs).Return(nil)
+		mockPermissionRepo.On("GetByID", ctx, int16(5)).Return(&domain.Permission{ID: 5}, nil)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_ASSISTANT).
+			Return([]*domain.Permission{{ID: 5, Key: constants.PERMISSION_BUSINESS_APPROVE}}, nil).Once()
+
+		assert.NoError(t, service.SetPermissions(ctx, req))
+
+		allowed, err := service.HasPermission(ctx, constants.ROLE_ASSISTANT, constants.PERMISSION_BUSINESS_APPROVE)
+		assert.NoError(t, err)
+		assert.True(t, allowed)
+		mockPermissionRepo.AssertExpectations(t)
+	})
+
+	t.Run("RepositoryFailure", func(t *testing.T) {
+		mockPermissionRepo.ExpectedCalls = nil
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).Return(nil, errors.New("db error"))
+
+		allowed, err := service.HasPermission(ctx, constants.ROLE_MANAGER, constants.PERMISSION_USER_READ)
+
+		assert.False(t, allowed)
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+}
@@ -0,0 +1,26 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AdminScope corresponds to the "admin_scopes" table.
+// It limits what a user administers to the people of one church, or of every church of a diocese.
+type AdminScope struct {
+	UserID    uuid.UUID      `json:"user_id" db:"user_id"`
+	ChurchID  uuid.NullUUID  `json:"church_id" db:"church_id"`
+	Diocese   sql.NullString `json:"diocese" db:"diocese"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+}
+
+// Covers reports whether the church falls within the scope.
+func (s *AdminScope) Covers(church *Church) bool {
+	if s.ChurchID.Valid {
+		return church.ID == s.ChurchID.UUID
+	}
+
+	return church.Diocese == s.Diocese.String
+}
@@ -0,0 +1,13 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type AdminScopeRepository interface {
+	Upsert(ctx context.Context, scope *AdminScope) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*AdminScope, error)
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
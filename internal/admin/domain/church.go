@@ -2,7 +2,9 @@ package domain
 
 import (
 	"database/sql"
+	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -17,6 +19,7 @@ type Church struct {
 	AddressID     uuid.UUID      `json:"address_id" db:"address_id"`
 	IsArchdiocese bool           `json:"is_archdiocese" db:"is_archdiocese"`
 	IsActive      bool           `json:"is_active" db:"is_active"`
+	CreatedAt     time.Time      `json:"created_at" db:"created_at"`
 }
 
 // ChurchFilters defines criteria for filtering churches.
@@ -27,7 +30,8 @@ type ChurchFilters struct {
 	IsActive      *bool      `json:"is_active,omitempty"`
 	NameContains  *string    `json:"name_contains,omitempty"`
 
-	// Pagination
-	Limit  *int `json:"limit,omitempty"`
-	Offset *int `json:"offset,omitempty"`
+	// Pagination, by offset or, once a cursor is given, by cursor from the newest churches
+	Limit  *int               `json:"limit,omitempty"`
+	Offset *int               `json:"offset,omitempty"`
+	Cursor *pagination.Cursor `json:"cursor,omitempty"`
 }
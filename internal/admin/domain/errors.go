@@ -24,3 +24,24 @@ var (
 	ErrIndustryNotFound      = errors.New("industry not found")
 	ErrIndustryAlreadyExists = errors.New("industry already exists")
 )
+
+// Role errors
+var (
+	ErrRoleNotFound      = errors.New("role not found")
+	ErrRoleAlreadyExists = errors.New("role already exists")
+	ErrRoleInUse         = errors.New("role is assigned to users")
+	ErrRoleProtected     = errors.New("built-in role cannot be changed")
+)
+
+// Permission errors
+var (
+	ErrPermissionNotFound      = errors.New("permission not found")
+	ErrPermissionAlreadyExists = errors.New("permission already exists")
+)
+
+// AdminScope errors
+var (
+	ErrAdminScopeNotFound = errors.New("admin scope not found")
+	ErrInvalidAdminScope  = errors.New("admin scope must name either a church or a diocese")
+	ErrOutOfAdminScope    = errors.New("outside of the admin scope")
+)
@@ -0,0 +1,11 @@
+package domain
+
+import "database/sql"
+
+// Permission corresponds to the "permissions" table.
+// The Key field names the guarded action (e.g., "business.approve").
+type Permission struct {
+	ID          int16          `json:"id" db:"id"`
+	Key         string         `json:"key" db:"key"`
+	Description sql.NullString `json:"description" db:"description"`
+}
@@ -0,0 +1,13 @@
+package domain
+
+import "context"
+
+type PermissionRepository interface {
+	Create(ctx context.Context, permission *Permission) error
+	Update(ctx context.Context, permission *Permission) error
+	Delete(ctx context.Context, id int16) error
+	GetAll(ctx context.Context) ([]*Permission, error)
+	GetByID(ctx context.Context, id int16) (*Permission, error)
+	GetByKey(ctx context.Context, key string) (*Permission, error)
+	GetByRoleID(ctx context.Context, roleID int16) ([]*Permission, error)
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "database/sql"
+
+// Role corresponds to the "roles" table.
+// The built-in roles keep the IDs of constants.ROLE_ADMIN through constants.ROLE_USER.
+type Role struct {
+	ID          int16          `json:"id" db:"id"`
+	Name        string         `json:"name" db:"name"`
+	Description sql.NullString `json:"description" db:"description"`
+}
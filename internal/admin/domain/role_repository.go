@@ -0,0 +1,14 @@
+package domain
+
+import "context"
+
+type RoleRepository interface {
+	Create(ctx context.Context, role *Role) error
+	Update(ctx context.Context, role *Role) error
+	Delete(ctx context.Context, id int16) error
+	GetAll(ctx context.Context) ([]*Role, error)
+	GetByID(ctx context.Context, id int16) (*Role, error)
+	GetByName(ctx context.Context, name string) (*Role, error)
+	CountUsers(ctx context.Context, id int16) (int, error)
+	SetPermissions(ctx context.Context, id int16, permissionIDs []int16) error
+}
@@ -0,0 +1,10 @@
+package dto
+
+import "github.com/google/uuid"
+
+// AdminScopeSetRequest names either a church or a diocese
+type AdminScopeSetRequest struct {
+	UserID   uuid.UUID  `json:"-"`
+	ChurchID *uuid.UUID `json:"church_id"`
+	Diocese  string     `json:"diocese"`
+}
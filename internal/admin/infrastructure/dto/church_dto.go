@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -34,4 +35,5 @@ type ChurchListResponse struct {
 	Count    int              `json:"count"`
 	Limit    *int             `json:"limit"`
 	Offset   *int             `json:"offset"`
+	pagination.Page
 }
@@ -0,0 +1,18 @@
+package dto
+
+import "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+
+type PermissionCreateRequest struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+type PermissionUpdateRequest struct {
+	ID          int16  `json:"id"`
+	Key         string `json:"key"`
+	Description string `json:"description"`
+}
+
+type PermissionListResponse struct {
+	Permissions []*domain.Permission `json:"permissions"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+
+type RoleCreateRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleUpdateRequest struct {
+	ID          int16  `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type RoleListResponse struct {
+	Roles []*domain.Role `json:"roles"`
+}
+
+type RoleSetPermissionsRequest struct {
+	ID            int16   `json:"id"`
+	PermissionIDs []int16 `json:"permission_ids"`
+}
@@ -0,0 +1,36 @@
+package http
+
+import (
+	"context"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	entrepreneurDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	userDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+)
+
+// adminScope returns the scope loaded for the authenticated admin, nil when they administer every church
+func adminScope(ctx context.Context) *domain.AdminScope {
+	scope, _ := ctx.Value(auth.AdminScopeContextKey).(*domain.AdminScope)
+	return scope
+}
+
+// userScope returns the scope of the admin as a filter of the user lists, nil when they administer every church
+func userScope(ctx context.Context) *userDomain.ChurchScope {
+	scope := adminScope(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	return &userDomain.ChurchScope{ChurchID: scope.ChurchID, Diocese: scope.Diocese}
+}
+
+// businessScope returns the scope of the admin as a filter of the business lists, nil when they administer every church
+func businessScope(ctx context.Context) *entrepreneurDomain.ChurchScope {
+	scope := adminScope(ctx)
+	if scope == nil {
+		return nil
+	}
+
+	return &entrepreneurDomain.ChurchScope{ChurchID: scope.ChurchID, Diocese: scope.Diocese}
+}
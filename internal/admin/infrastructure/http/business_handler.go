@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 
+	adminApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -16,12 +20,16 @@ import (
 type BusinessHandler struct {
 	logger          *zap.SugaredLogger
 	businessService *application.BusinessService
+	userService     *userApp.UserService
+	scopeService    *adminApp.AdminScopeService
 }
 
-func NewBusinessHandler(logger *zap.SugaredLogger, businessService *application.BusinessService) *BusinessHandler {
+func NewBusinessHandler(logger *zap.SugaredLogger, businessService *application.BusinessService, userService *userApp.UserService, scopeService *adminApp.AdminScopeService) *BusinessHandler {
 	return &BusinessHandler{
 		logger:          logger,
 		businessService: businessService,
+		userService:     userService,
+		scopeService:    scopeService,
 	}
 }
 
@@ -45,6 +53,10 @@ func (h *BusinessHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.checkScope(w, r, business) {
+		return
+	}
+
 	response.OKT(ctx, w, "success.business_retrieved", business)
 }
 
@@ -55,42 +67,149 @@ func (h *BusinessHandler) List(w http.ResponseWriter, r *http.Request) {
 		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
 		return
 	}
+	req.Scope = businessScope(ctx)
+
+	h.list(w, r, &req)
+}
+
+// ModerationQueue lists the businesses awaiting review, those submitted first coming first
+func (h *BusinessHandler) ModerationQueue(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.BusinessListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	pendingReview := domain.BusinessStatusPendingReview
+	req.Status = &pendingReview
+	req.ModerationQueue = true
+	req.Scope = businessScope(ctx)
+
+	h.list(w, r, &req)
+}
 
-	list, err := h.businessService.List(ctx, &req)
+func (h *BusinessHandler) list(w http.ResponseWriter, r *http.Request, req *dto.BusinessListRequest) {
+	ctx := r.Context()
+	list, err := h.businessService.List(ctx, req)
 	if err != nil {
+		if err == domain.ErrInvalidBusinessStatus {
+			response.BadRequestT(ctx, w, "error.invalid_business_status", nil)
+			return
+		}
+		if err == domain.ErrInvalidFacet {
+			response.BadRequestT(ctx, w, "error.invalid_facet", nil)
+			return
+		}
+		if err == domain.ErrInvalidSort {
+			response.BadRequestT(ctx, w, "error.invalid_sort", nil)
+			return
+		}
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidRadius {
+			response.BadRequestT(ctx, w, "error.invalid_radius", nil)
+			return
+		}
+
 		h.logger.Errorw("failed to list businesses", "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_list_businesses")
 		return
 	}
 
-	response.OKT(ctx, w, "success.businesses_listed", list)
+	response.OKWithMetaT(ctx, w, "success.businesses_listed", list, list.Meta())
 }
 
-func (h *BusinessHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
+// Moderate approves, rejects, suspends or reinstates a business
+func (h *BusinessHandler) Moderate(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	id, err := uuid.Parse(chi.URLParam(r, "id"))
 	if err != nil {
 		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
 		return
 	}
 
-	var req dto.BusinessUpdatePropertyRequest
+	var req dto.BusinessModerateRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
 		return
 	}
 	req.ID = id
+	req.ReviewerID = userCtx.ID
+
+	if adminScope(ctx) != nil {
+		business, err := h.businessService.GetByID(ctx, id)
+		if err != nil {
+			if err == domain.ErrBusinessNotFound {
+				response.NotFoundT(ctx, w, "error.business_not_found")
+				return
+			}
+
+			h.logger.Errorw("failed to get business by ID", "businessID", id, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_update_business_status")
+			return
+		}
 
-	if err := h.businessService.UpdateActiveStatus(ctx, &req); err != nil {
+		if !h.checkScope(w, r, business) {
+			return
+		}
+	}
+
+	business, err := h.businessService.Moderate(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidBusinessStatus {
+			response.BadRequestT(ctx, w, "error.invalid_business_status", nil)
+			return
+		}
+		if err == domain.ErrBusinessStatusReasonMissing {
+			response.BadRequestT(ctx, w, "error.business_status_reason_required", nil)
+			return
+		}
 		if err == domain.ErrBusinessNotFound {
 			response.NotFoundT(ctx, w, "error.business_not_found")
 			return
 		}
+		if err == domain.ErrInvalidBusinessTransition {
+			response.ConflictT(ctx, w, "error.invalid_business_transition", nil)
+			return
+		}
 
-		h.logger.Errorw("failed to set is_active flag", "businessID", id, "error", err)
+		h.logger.Errorw("failed to moderate business", "businessID", id, "status", req.Status, "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_update_business_status")
 		return
 	}
 
-	response.OKT(ctx, w, "success.business_status_updated", nil)
+	response.OKT(ctx, w, "success.business_status_updated", business)
+}
+
+// checkScope answers for a business whose owner is outside the scope of the admin and reports whether to go on
+func (h *BusinessHandler) checkScope(w http.ResponseWriter, r *http.Request, business *domain.Business) bool {
+	ctx := r.Context()
+	scope := adminScope(ctx)
+	if scope == nil {
+		return true
+	}
+
+	owner, err := h.userService.GetByID(ctx, business.UserID)
+	if err != nil {
+		h.logger.Errorw("failed to get business owner", "businessID", business.ID, "userID", business.UserID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+		return false
+	}
+
+	covered, err := h.scopeService.CoversChurch(ctx, scope, owner.User.ChurchID)
+	if err != nil {
+		h.logger.Errorw("failed to check admin scope", "businessID", business.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+		return false
+	}
+
+	if !covered {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return false
+	}
+
+	return true
 }
@@ -0,0 +1,140 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	adminApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type CatholicAttestationHandler struct {
+	logger             *zap.SugaredLogger
+	attestationService *application.CatholicAttestationService
+	scopeService       *adminApp.AdminScopeService
+}
+
+func NewCatholicAttestationHandler(logger *zap.SugaredLogger, attestationService *application.CatholicAttestationService, scopeService *adminApp.AdminScopeService) *CatholicAttestationHandler {
+	return &CatholicAttestationHandler{
+		logger:             logger,
+		attestationService: attestationService,
+		scopeService:       scopeService,
+	}
+}
+
+func (h *CatholicAttestationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.CatholicAttestationListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.Scope = userScope(ctx)
+
+	list, err := h.attestationService.List(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidAttestationStatus {
+			response.BadRequestT(ctx, w, "error.invalid_attestation_status", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to list attestations", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_attestations")
+		return
+	}
+
+	response.OKT(ctx, w, "success.attestations_listed", list)
+}
+
+func (h *CatholicAttestationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	attestation, ok := h.getInScope(w, r)
+	if !ok {
+		return
+	}
+
+	response.OKT(ctx, w, "success.attestation_retrieved", attestation)
+}
+
+// Review approves or rejects an attestation of a parish the admin oversees
+func (h *CatholicAttestationHandler) Review(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	var req dto.CatholicAttestationReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	attestation, ok := h.getInScope(w, r)
+	if !ok {
+		return
+	}
+	req.ID = attestation.ID
+	req.ReviewerID = userCtx.ID
+
+	attestation, err := h.attestationService.Review(ctx, &req)
+	if err != nil {
+		if err == domain.ErrAttestationNotFound {
+			response.NotFoundT(ctx, w, "error.attestation_not_found")
+			return
+		}
+		if err == domain.ErrAttestationAlreadyReviewed {
+			response.ConflictT(ctx, w, "error.attestation_already_reviewed", nil)
+			return
+		}
+		if err == domain.ErrAttestationSelfReview {
+			response.ForbiddenT(ctx, w, "error.attestation_self_review")
+			return
+		}
+
+		h.logger.Errorw("failed to review attestation", "attestationID", req.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_review_attestation")
+		return
+	}
+
+	response.OKT(ctx, w, "success.attestation_reviewed", attestation)
+}
+
+// getInScope loads the attestation of the URL, answering for it being missing or of a church outside the scope of the admin
+func (h *CatholicAttestationHandler) getInScope(w http.ResponseWriter, r *http.Request) (*domain.CatholicAttestation, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_attestation_id", nil)
+		return nil, false
+	}
+
+	attestation, err := h.attestationService.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrAttestationNotFound {
+			response.NotFoundT(ctx, w, "error.attestation_not_found")
+			return nil, false
+		}
+
+		h.logger.Errorw("failed to get attestation by ID", "attestationID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_attestation")
+		return nil, false
+	}
+
+	covered, err := h.scopeService.CoversChurch(ctx, adminScope(ctx), attestation.ChurchID)
+	if err != nil {
+		h.logger.Errorw("failed to check admin scope", "churchID", attestation.ChurchID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+		return nil, false
+	}
+
+	if !covered {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return nil, false
+	}
+
+	return attestation, true
+}
@@ -141,5 +141,5 @@ func (h *ChurchHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.OKT(ctx, w, "success.churches_listed", list)
+	response.OKWithMetaT(ctx, w, "success.churches_listed", list, list.Meta())
 }
@@ -0,0 +1,158 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	adminApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type EntrepreneurApplicationHandler struct {
+	logger             *zap.SugaredLogger
+	applicationService *application.EntrepreneurApplicationService
+	userService        *application.UserService
+	scopeService       *adminApp.AdminScopeService
+}
+
+func NewEntrepreneurApplicationHandler(logger *zap.SugaredLogger, applicationService *application.EntrepreneurApplicationService, userService *application.UserService, scopeService *adminApp.AdminScopeService) *EntrepreneurApplicationHandler {
+	return &EntrepreneurApplicationHandler{
+		logger:             logger,
+		applicationService: applicationService,
+		userService:        userService,
+		scopeService:       scopeService,
+	}
+}
+
+// List returns the review queue, limited to applicants of the churches the admin oversees
+func (h *EntrepreneurApplicationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.EntrepreneurApplicationListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.Scope = userScope(ctx)
+
+	list, err := h.applicationService.List(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidApplicationStatus {
+			response.BadRequestT(ctx, w, "error.invalid_application_status", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to list entrepreneur applications", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_applications")
+		return
+	}
+
+	response.OKT(ctx, w, "success.applications_listed", list)
+}
+
+func (h *EntrepreneurApplicationHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	entrepreneurApplication, ok := h.getInScope(w, r)
+	if !ok {
+		return
+	}
+
+	response.OKT(ctx, w, "success.application_retrieved", entrepreneurApplication)
+}
+
+// Review approves, rejects or asks the applicant for more information
+func (h *EntrepreneurApplicationHandler) Review(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	var req dto.EntrepreneurApplicationReviewRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	entrepreneurApplication, ok := h.getInScope(w, r)
+	if !ok {
+		return
+	}
+	req.ID = entrepreneurApplication.ID
+	req.ReviewerID = userCtx.ID
+
+	entrepreneurApplication, err := h.applicationService.Review(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidApplicationDecision {
+			response.BadRequestT(ctx, w, "error.invalid_application_decision", nil)
+			return
+		}
+		if err == domain.ErrRequiredField {
+			response.BadRequestT(ctx, w, "error.application_comment_required", nil)
+			return
+		}
+		if err == domain.ErrApplicationNotFound {
+			response.NotFoundT(ctx, w, "error.application_not_found")
+			return
+		}
+		if err == domain.ErrApplicationClosed {
+			response.ConflictT(ctx, w, "error.application_closed", nil)
+			return
+		}
+		if err == domain.ErrApplicationSelfReview {
+			response.ForbiddenT(ctx, w, "error.application_self_review")
+			return
+		}
+
+		h.logger.Errorw("failed to review entrepreneur application", "applicationID", req.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_review_application")
+		return
+	}
+
+	response.OKT(ctx, w, "success.application_reviewed", entrepreneurApplication)
+}
+
+// getInScope loads the application of the URL, answering for it being missing or of an applicant outside the scope of the admin
+func (h *EntrepreneurApplicationHandler) getInScope(w http.ResponseWriter, r *http.Request) (*domain.EntrepreneurApplication, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_application_id", nil)
+		return nil, false
+	}
+
+	entrepreneurApplication, err := h.applicationService.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrApplicationNotFound {
+			response.NotFoundT(ctx, w, "error.application_not_found")
+			return nil, false
+		}
+
+		h.logger.Errorw("failed to get entrepreneur application by ID", "applicationID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_application")
+		return nil, false
+	}
+
+	applicant, err := h.userService.GetByID(ctx, entrepreneurApplication.UserID)
+	if err != nil {
+		h.logger.Errorw("failed to get applicant", "applicationID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_application")
+		return nil, false
+	}
+
+	covered, err := h.scopeService.CoversChurch(ctx, adminScope(ctx), applicant.User.ChurchID)
+	if err != nil {
+		h.logger.Errorw("failed to check admin scope", "churchID", applicant.User.ChurchID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+		return nil, false
+	}
+
+	if !covered {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return nil, false
+	}
+
+	return entrepreneurApplication, true
+}
@@ -0,0 +1,142 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type PermissionHandler struct {
+	logger            *zap.SugaredLogger
+	permissionService *application.PermissionService
+}
+
+func NewPermissionHandler(logger *zap.SugaredLogger, permissionService *application.PermissionService) *PermissionHandler {
+	return &PermissionHandler{
+		logger:            logger,
+		permissionService: permissionService,
+	}
+}
+
+func (h *PermissionHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.PermissionCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	permission, err := h.permissionService.Create(ctx, &req)
+	if err != nil {
+		if err == domain.ErrPermissionAlreadyExists {
+			response.ConflictT(ctx, w, "error.permission_already_exists", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to create permission", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_create_permission")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.permission_created", permission)
+}
+
+func (h *PermissionHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_permission_id", nil)
+		return
+	}
+
+	var req dto.PermissionUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = int16(id)
+
+	if err := h.permissionService.Update(ctx, &req); err != nil {
+		if err == domain.ErrPermissionNotFound {
+			response.NotFoundT(ctx, w, "error.permission_not_found")
+			return
+		}
+		if err == domain.ErrPermissionAlreadyExists {
+			response.ConflictT(ctx, w, "error.permission_already_exists", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to update permission", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_permission")
+		return
+	}
+
+	response.OKT(ctx, w, "success.permission_updated", nil)
+}
+
+func (h *PermissionHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_permission_id", nil)
+		return
+	}
+
+	if err := h.permissionService.Delete(ctx, int16(id)); err != nil {
+		if err == domain.ErrPermissionNotFound {
+			response.NotFoundT(ctx, w, "error.permission_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to delete permission", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_permission")
+		return
+	}
+
+	response.OKT(ctx, w, "success.permission_deleted", nil)
+}
+
+func (h *PermissionHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_permission_id", nil)
+		return
+	}
+
+	permission, err := h.permissionService.GetByID(ctx, int16(id))
+	if err != nil {
+		if err == domain.ErrPermissionNotFound {
+			response.NotFoundT(ctx, w, "error.permission_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to get permission by ID", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_permission")
+		return
+	}
+
+	response.OKT(ctx, w, "success.permission_retrieved", permission)
+}
+
+func (h *PermissionHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := h.permissionService.GetAll(ctx)
+	if err != nil {
+		h.logger.Errorw("failed to get all permissions", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_permissions")
+		return
+	}
+
+	response.OKT(ctx, w, "success.permissions_retrieved", list)
+}
@@ -0,0 +1,214 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type RoleHandler struct {
+	logger      *zap.SugaredLogger
+	roleService *application.RoleService
+}
+
+func NewRoleHandler(logger *zap.SugaredLogger, roleService *application.RoleService) *RoleHandler {
+	return &RoleHandler{
+		logger:      logger,
+		roleService: roleService,
+	}
+}
+
+func (h *RoleHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.RoleCreateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	role, err := h.roleService.Create(ctx, &req)
+	if err != nil {
+		if err == domain.ErrRoleAlreadyExists {
+			response.ConflictT(ctx, w, "error.role_already_exists", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to create role", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_create_role")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.role_created", role)
+}
+
+func (h *RoleHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_role_id", nil)
+		return
+	}
+
+	var req dto.RoleUpdateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = int16(id)
+
+	if err := h.roleService.Update(ctx, &req); err != nil {
+		if err == domain.ErrRoleNotFound {
+			response.NotFoundT(ctx, w, "error.role_not_found")
+			return
+		}
+		if err == domain.ErrRoleAlreadyExists {
+			response.ConflictT(ctx, w, "error.role_already_exists", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to update role", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_role")
+		return
+	}
+
+	response.OKT(ctx, w, "success.role_updated", nil)
+}
+
+func (h *RoleHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_role_id", nil)
+		return
+	}
+
+	if err := h.roleService.Delete(ctx, int16(id)); err != nil {
+		if err == domain.ErrRoleNotFound {
+			response.NotFoundT(ctx, w, "error.role_not_found")
+			return
+		}
+		if err == domain.ErrRoleProtected {
+			response.ForbiddenT(ctx, w, "error.role_protected")
+			return
+		}
+		if err == domain.ErrRoleInUse {
+			response.ConflictT(ctx, w, "error.role_in_use", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to delete role", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_role")
+		return
+	}
+
+	response.OKT(ctx, w, "success.role_deleted", nil)
+}
+
+func (h *RoleHandler) GetByID(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_role_id", nil)
+		return
+	}
+
+	role, err := h.roleService.GetByID(ctx, int16(id))
+	if err != nil {
+		if err == domain.ErrRoleNotFound {
+			response.NotFoundT(ctx, w, "error.role_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to get role by ID", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_role")
+		return
+	}
+
+	response.OKT(ctx, w, "success.role_retrieved", role)
+}
+
+func (h *RoleHandler) GetAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	list, err := h.roleService.GetAll(ctx)
+	if err != nil {
+		h.logger.Errorw("failed to get all roles", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_roles")
+		return
+	}
+
+	response.OKT(ctx, w, "success.roles_retrieved", list)
+}
+
+// GetPermissions lists the permissions granted to a role
+func (h *RoleHandler) GetPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_role_id", nil)
+		return
+	}
+
+	list, err := h.roleService.GetPermissions(ctx, int16(id))
+	if err != nil {
+		if err == domain.ErrRoleNotFound {
+			response.NotFoundT(ctx, w, "error.role_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to get role permissions", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_permissions")
+		return
+	}
+
+	response.OKT(ctx, w, "success.permissions_retrieved", list)
+}
+
+// SetPermissions replaces the permissions granted to a role
+func (h *RoleHandler) SetPermissions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	idParam := chi.URLParam(r, "id")
+	id, err := strconv.ParseInt(idParam, 10, 16)
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_role_id", nil)
+		return
+	}
+
+	var req dto.RoleSetPermissionsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = int16(id)
+
+	if err := h.roleService.SetPermissions(ctx, &req); err != nil {
+		if err == domain.ErrRoleNotFound {
+			response.NotFoundT(ctx, w, "error.role_not_found")
+			return
+		}
+		if err == domain.ErrPermissionNotFound {
+			response.NotFoundT(ctx, w, "error.permission_not_found")
+			return
+		}
+		if err == domain.ErrRoleProtected {
+			response.ForbiddenT(ctx, w, "error.role_protected")
+			return
+		}
+
+		h.logger.Errorw("failed to set role permissions", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_set_role_permissions")
+		return
+	}
+
+	response.OKT(ctx, w, "success.role_permissions_updated", nil)
+}
@@ -4,9 +4,13 @@ import (
 	"encoding/json"
 	"net/http"
 
+	adminApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/application"
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	adminDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -14,14 +18,22 @@ import (
 )
 
 type UserHandler struct {
-	logger      *zap.SugaredLogger
-	userService *application.UserService
+	logger               *zap.SugaredLogger
+	userService          *application.UserService
+	lockoutService       *application.LockoutService
+	scopeService         *adminApp.AdminScopeService
+	impersonationService *application.ImpersonationService
+	sessionService       *application.SessionService
 }
 
-func NewUserHandler(logger *zap.SugaredLogger, userService *application.UserService) *UserHandler {
+func NewUserHandler(logger *zap.SugaredLogger, userService *application.UserService, lockoutService *application.LockoutService, scopeService *adminApp.AdminScopeService, impersonationService *application.ImpersonationService, sessionService *application.SessionService) *UserHandler {
 	return &UserHandler{
-		logger:      logger,
-		userService: userService,
+		logger:               logger,
+		userService:          userService,
+		lockoutService:       lockoutService,
+		scopeService:         scopeService,
+		impersonationService: impersonationService,
+		sessionService:       sessionService,
 	}
 }
 
@@ -45,6 +57,10 @@ func (h *UserHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !h.checkScope(w, r, user.User.ChurchID) {
+		return
+	}
+
 	response.OKT(ctx, w, "success.user_retrieved", user)
 }
 
@@ -55,6 +71,7 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
 		return
 	}
+	req.Scope = userScope(ctx)
 
 	list, err := h.userService.List(ctx, &req)
 	if err != nil {
@@ -63,7 +80,107 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.OKT(ctx, w, "success.users_listed", list)
+	response.OKWithMetaT(ctx, w, "success.users_listed", list, list.Meta())
+}
+
+// ListLockouts lists the lockouts triggered by repeated failed logins
+func (h *UserHandler) ListLockouts(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.LoginLockoutListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ChurchScope = userScope(ctx)
+
+	list, err := h.lockoutService.List(ctx, &req)
+	if err != nil {
+		h.logger.Errorw("failed to list login lockouts", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_lockouts")
+		return
+	}
+
+	response.OKT(ctx, w, "success.lockouts_listed", list)
+}
+
+// ListSessions returns the devices a user within the scope of the admin is signed in on
+func (h *UserHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
+	sessions, err := h.sessionService.List(ctx, id)
+	if err != nil {
+		h.logger.Errorw("failed to list sessions", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_sessions")
+		return
+	}
+
+	response.OKT(ctx, w, "success.sessions_listed", sessions)
+}
+
+// Impersonate issues a short-lived token for the admin to act as the user, read-only
+func (h *UserHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
+	impersonation, err := h.impersonationService.Start(ctx, &dto.ImpersonationStartRequest{
+		AdminID:   userCtx.ID,
+		UserID:    id,
+		SessionID: userCtx.SessionID,
+	})
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+		if err == domain.ErrCannotImpersonate {
+			response.ForbiddenT(ctx, w, "error.cannot_impersonate")
+			return
+		}
+
+		h.logger.Errorw("failed to impersonate user", "userID", id, "adminID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_impersonate_user")
+		return
+	}
+
+	response.OKT(ctx, w, "success.user_impersonated", impersonation)
+}
+
+// ListImpersonations lists the requests admins made while impersonating users
+func (h *UserHandler) ListImpersonations(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.ImpersonationLogListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	list, err := h.impersonationService.List(ctx, &req)
+	if err != nil {
+		h.logger.Errorw("failed to list impersonation logs", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_impersonations")
+		return
+	}
+
+	response.OKT(ctx, w, "success.impersonations_listed", list)
 }
 
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -81,6 +198,10 @@ func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
 	if err := h.userService.UpdateActiveStatus(ctx, &req); err != nil {
 		if err == domain.ErrUserNotFound {
 			response.NotFoundT(ctx, w, "error.user_not_found")
@@ -110,6 +231,16 @@ func (h *UserHandler) SetIsCatholic(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 
+	// Only an approved attestation recognises a user as Catholic, admins may still revoke it
+	if req.Value {
+		response.BadRequestT(ctx, w, "error.catholic_flag_requires_attestation", nil)
+		return
+	}
+
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
 	if err := h.userService.UpdateCatholicStatus(ctx, &req); err != nil {
 		if err == domain.ErrUserNotFound {
 			response.NotFoundT(ctx, w, "error.user_not_found")
@@ -139,6 +270,10 @@ func (h *UserHandler) SetIsEntrepreneur(w http.ResponseWriter, r *http.Request)
 	}
 	req.ID = id
 
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
 	if err := h.userService.UpdateEntrepreneurStatus(ctx, &req); err != nil {
 		if err == domain.ErrUserNotFound {
 			response.NotFoundT(ctx, w, "error.user_not_found")
@@ -168,12 +303,21 @@ func (h *UserHandler) SetRole(w http.ResponseWriter, r *http.Request) {
 	}
 	req.ID = id
 
+	if !h.checkUserScope(w, r, id) {
+		return
+	}
+
 	if err := h.userService.SetRole(ctx, &req); err != nil {
 		if err == domain.ErrUserNotFound {
 			response.NotFoundT(ctx, w, "error.user_not_found")
 			return
 		}
 
+		if err == domain.ErrRoleNotAssignable {
+			response.ForbiddenT(ctx, w, "error.role_not_assignable")
+			return
+		}
+
 		h.logger.Errorw("failed to set user role", "userID", id, "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_set_role")
 		return
@@ -181,3 +325,147 @@ func (h *UserHandler) SetRole(w http.ResponseWriter, r *http.Request) {
 
 	response.OKT(ctx, w, "success.user_role_updated", nil)
 }
+
+// GetScope returns the church or diocese the user is limited to when administering
+func (h *UserHandler) GetScope(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	scope, err := h.scopeService.Get(ctx, id)
+	if err != nil {
+		if err == adminDomain.ErrAdminScopeNotFound {
+			response.NotFoundT(ctx, w, "error.admin_scope_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to get admin scope", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_admin_scope")
+		return
+	}
+
+	response.OKT(ctx, w, "success.admin_scope_retrieved", scope)
+}
+
+// SetScope limits what the user administers to a church or a diocese
+func (h *UserHandler) SetScope(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	var req adminDto.AdminScopeSetRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.UserID = id
+
+	// Scoped admins cannot hand out scopes, which could reach beyond their own
+	if adminScope(ctx) != nil {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return
+	}
+
+	if _, err := h.userService.GetByID(ctx, id); err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to get user by ID", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_set_admin_scope")
+		return
+	}
+
+	scope, err := h.scopeService.Set(ctx, &req)
+	if err != nil {
+		if err == adminDomain.ErrInvalidAdminScope {
+			response.BadRequestT(ctx, w, "error.invalid_admin_scope", nil)
+			return
+		}
+		if err == adminDomain.ErrChurchNotFound {
+			response.NotFoundT(ctx, w, "error.church_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to set admin scope", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_set_admin_scope")
+		return
+	}
+
+	response.OKT(ctx, w, "success.admin_scope_updated", scope)
+}
+
+// DeleteScope lets the user administer every church again
+func (h *UserHandler) DeleteScope(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	if adminScope(ctx) != nil {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return
+	}
+
+	if err := h.scopeService.Delete(ctx, id); err != nil {
+		if err == adminDomain.ErrAdminScopeNotFound {
+			response.NotFoundT(ctx, w, "error.admin_scope_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to delete admin scope", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_admin_scope")
+		return
+	}
+
+	response.OKT(ctx, w, "success.admin_scope_deleted", nil)
+}
+
+// checkUserScope answers for the user being outside the scope of the admin, or missing, and reports whether to go on
+func (h *UserHandler) checkUserScope(w http.ResponseWriter, r *http.Request, id uuid.UUID) bool {
+	ctx := r.Context()
+	if adminScope(ctx) == nil {
+		return true
+	}
+
+	user, err := h.userService.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return false
+		}
+
+		h.logger.Errorw("failed to get user by ID", "userID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_get_user")
+		return false
+	}
+
+	return h.checkScope(w, r, user.User.ChurchID)
+}
+
+// checkScope answers for a church outside the scope of the admin and reports whether to go on
+func (h *UserHandler) checkScope(w http.ResponseWriter, r *http.Request, churchID uuid.UUID) bool {
+	ctx := r.Context()
+	covered, err := h.scopeService.CoversChurch(ctx, adminScope(ctx), churchID)
+	if err != nil {
+		h.logger.Errorw("failed to check admin scope", "churchID", churchID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.internal_server_error")
+		return false
+	}
+
+	if !covered {
+		response.ForbiddenT(ctx, w, "error.outside_admin_scope")
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type AdminScopePersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+func NewAdminScopePersistence(db *sqlx.DB) *AdminScopePersistence {
+	return &AdminScopePersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Upsert assigns the scope to the user, replacing the previous one.
+func (r *AdminScopePersistence) Upsert(ctx context.Context, scope *domain.AdminScope) error {
+	query, args, err := r.psql.Insert("admin_scopes").
+		Columns("user_id", "church_id", "diocese").
+		Values(scope.UserID, scope.ChurchID, scope.Diocese).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET church_id = EXCLUDED.church_id, diocese = EXCLUDED.diocese, created_at = CURRENT_TIMESTAMP").
+		Suffix("RETURNING created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build upsert admin scope query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&scope.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute upsert admin scope query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *AdminScopePersistence) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.AdminScope, error) {
+	var scope domain.AdminScope
+	query, args, err := r.psql.Select("*").From("admin_scopes").Where(sq.Eq{"user_id": userID}).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get admin scope query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &scope, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAdminScopeNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get admin scope query: %w", err)
+	}
+
+	return &scope, nil
+}
+
+func (r *AdminScopePersistence) Delete(ctx context.Context, userID uuid.UUID) error {
+	query, args, err := r.psql.Delete("admin_scopes").
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete admin scope query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete admin scope query: %w", err)
+	}
+
+	return nil
+}
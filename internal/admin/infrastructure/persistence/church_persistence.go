@@ -6,6 +6,7 @@ import (
 	"fmt"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -59,14 +60,14 @@ func (r *ChurchPersistence) Create(tx *sqlx.Tx, church *domain.Church) error {
 	query, args, err := r.psql.Insert("church").
 		Columns("name", "diocese", "parish_number", "website_url", "phone_number", "address_id", "is_archdiocese", "is_active").
 		Values(church.Name, church.Diocese, church.ParishNumber, church.WebsiteURL, church.PhoneNumber, church.AddressID, church.IsArchdiocese, church.IsActive).
-		Suffix("RETURNING id").
+		Suffix("RETURNING id, created_at").
 		ToSql()
 
 	if err != nil {
 		return fmt.Errorf("failed to build create church query: %w", err)
 	}
 
-	if err := tx.GetContext(context.Background(), &church.ID, query, args...); err != nil {
+	if err := tx.QueryRowxContext(context.Background(), query, args...).Scan(&church.ID, &church.CreatedAt); err != nil {
 		return fmt.Errorf("failed to execute create church query: %w", err)
 	}
 
@@ -182,16 +183,21 @@ func (r *ChurchPersistence) List(ctx context.Context, filter *domain.ChurchFilte
 		if filter.NameContains != nil {
 			builder = builder.Where(sq.Like{"name": fmt.Sprintf("%%%s%%", *filter.NameContains)})
 		}
-		if filter.Limit != nil {
+	}
+
+	// Churches are listed by name, paging by cursor lists them newest first instead
+	if filter != nil && filter.Cursor != nil {
+		builder = pagination.Keyset(builder, filter.Cursor, true, pagination.Limit(filter.Limit))
+	} else {
+		builder = builder.OrderBy("name ASC")
+		if filter != nil && filter.Limit != nil {
 			builder = builder.Limit(uint64(*filter.Limit))
 		}
-		if filter.Offset != nil {
+		if filter != nil && filter.Offset != nil {
 			builder = builder.Offset(uint64(*filter.Offset))
 		}
 	}
 
-	builder = builder.OrderBy("name ASC")
-
 	query, args, err := builder.ToSql()
 	if err != nil {
 		return nil, fmt.Errorf("failed to build list churches query: %w", err)
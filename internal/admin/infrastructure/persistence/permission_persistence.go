@@ -0,0 +1,147 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type PermissionPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+func NewPermissionPersistence(db *sqlx.DB) *PermissionPersistence {
+	return &PermissionPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *PermissionPersistence) Create(ctx context.Context, permission *domain.Permission) error {
+	query, args, err := r.psql.Insert("permissions").
+		Columns("key", "description").
+		Values(permission.Key, permission.Description).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create permission query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&permission.ID); err != nil {
+		return fmt.Errorf("failed to execute create permission query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PermissionPersistence) Update(ctx context.Context, permission *domain.Permission) error {
+	query, args, err := r.psql.Update("permissions").
+		Set("key", permission.Key).
+		Set("description", permission.Description).
+		Where(sq.Eq{"id": permission.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update permission query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update permission query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PermissionPersistence) Delete(ctx context.Context, id int16) error {
+	query, args, err := r.psql.Delete("permissions").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete permission query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete permission query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PermissionPersistence) GetAll(ctx context.Context) ([]*domain.Permission, error) {
+	var permissions []*domain.Permission
+	query, args, err := r.psql.Select("*").From("permissions").OrderBy("key ASC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get all permissions query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &permissions, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPermissionNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get all permissions query: %w", err)
+	}
+
+	return permissions, nil
+}
+
+func (r *PermissionPersistence) GetByID(ctx context.Context, id int16) (*domain.Permission, error) {
+	var permission domain.Permission
+	query, args, err := r.psql.Select("*").From("permissions").Where(sq.Eq{"id": id}).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get permission by id query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &permission, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPermissionNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get permission by id query: %w", err)
+	}
+
+	return &permission, nil
+}
+
+func (r *PermissionPersistence) GetByKey(ctx context.Context, key string) (*domain.Permission, error) {
+	var permission domain.Permission
+	query, args, err := r.psql.Select("*").From("permissions").Where(sq.Eq{"key": key}).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get permission by key query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &permission, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPermissionNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get permission by key query: %w", err)
+	}
+
+	return &permission, nil
+}
+
+// GetByRoleID retrieves the permissions granted to a role.
+func (r *PermissionPersistence) GetByRoleID(ctx context.Context, roleID int16) ([]*domain.Permission, error) {
+	var permissions []*domain.Permission
+	query, args, err := r.psql.Select("p.*").
+		From("permissions p").
+		Join("role_permissions rp ON rp.permission_id = p.id").
+		Where(sq.Eq{"rp.role_id": roleID}).
+		OrderBy("p.key ASC").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get permissions by role query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &permissions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute get permissions by role query: %w", err)
+	}
+
+	return permissions, nil
+}
@@ -0,0 +1,186 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+type RolePersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+func NewRolePersistence(db *sqlx.DB) *RolePersistence {
+	return &RolePersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+func (r *RolePersistence) Create(ctx context.Context, role *domain.Role) error {
+	query, args, err := r.psql.Insert("roles").
+		Columns("name", "description").
+		Values(role.Name, role.Description).
+		Suffix("RETURNING id").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create role query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&role.ID); err != nil {
+		return fmt.Errorf("failed to execute create role query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RolePersistence) Update(ctx context.Context, role *domain.Role) error {
+	query, args, err := r.psql.Update("roles").
+		Set("name", role.Name).
+		Set("description", role.Description).
+		Where(sq.Eq{"id": role.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update role query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update role query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RolePersistence) Delete(ctx context.Context, id int16) error {
+	query, args, err := r.psql.Delete("roles").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete role query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete role query: %w", err)
+	}
+
+	return nil
+}
+
+func (r *RolePersistence) GetAll(ctx context.Context) ([]*domain.Role, error) {
+	var roles []*domain.Role
+	query, args, err := r.psql.Select("*").From("roles").OrderBy("id ASC").ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get all roles query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &roles, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get all roles query: %w", err)
+	}
+
+	return roles, nil
+}
+
+func (r *RolePersistence) GetByID(ctx context.Context, id int16) (*domain.Role, error) {
+	var role domain.Role
+	query, args, err := r.psql.Select("*").From("roles").Where(sq.Eq{"id": id}).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get role by id query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &role, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get role by id query: %w", err)
+	}
+
+	return &role, nil
+}
+
+func (r *RolePersistence) GetByName(ctx context.Context, name string) (*domain.Role, error) {
+	var role domain.Role
+	query, args, err := r.psql.Select("*").From("roles").Where(sq.Eq{"name": name}).Limit(1).ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get role by name query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &role, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrRoleNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get role by name query: %w", err)
+	}
+
+	return &role, nil
+}
+
+// CountUsers counts the users holding the role, which keep it from being deleted.
+func (r *RolePersistence) CountUsers(ctx context.Context, id int16) (int, error) {
+	var count int
+	query, args, err := r.psql.Select("COUNT(*)").From("users").Where(sq.Eq{"role_id": id}).ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count role users query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count role users query: %w", err)
+	}
+
+	return count, nil
+}
+
+// SetPermissions replaces the permissions granted to the role.
+func (r *RolePersistence) SetPermissions(ctx context.Context, id int16, permissionIDs []int16) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args, err := r.psql.Delete("role_permissions").Where(sq.Eq{"role_id": id}).ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build clear role permissions query: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute clear role permissions query: %w", err)
+	}
+
+	if len(permissionIDs) > 0 {
+		insert := r.psql.Insert("role_permissions").Columns("role_id", "permission_id")
+		for _, permissionID := range permissionIDs {
+			insert = insert.Values(id, permissionID)
+		}
+
+		query, args, err = insert.Suffix("ON CONFLICT DO NOTHING").ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build grant role permissions query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute grant role permissions query: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
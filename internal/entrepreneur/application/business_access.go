@@ -0,0 +1,33 @@
+package application
+
+import (
+	"context"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// authorizeBusinessMember checks that the authenticated user is an active member of the business
+// whose role grants the permission, and returns their membership. Anyone else gets ErrUnauthorized.
+func authorizeBusinessMember(ctx context.Context, logger *zap.SugaredLogger, memberRepo domain.BusinessMemberRepository, businessID uuid.UUID, permission domain.BusinessPermission) (*domain.BusinessMember, error) {
+	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
+	member, err := memberRepo.GetByBusinessAndUser(ctx, businessID, userCtx.ID)
+	if err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return nil, domain.ErrUnauthorized
+		}
+
+		logger.Errorw("failed to get business member", "businessID", businessID, "userID", userCtx.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !member.IsActive() || !member.Role.Has(permission) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	return member, nil
+}
@@ -0,0 +1,113 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// BusinessHoursService manages the weekly hours of a business and the dates they do not apply.
+// The schedule is read along with the business, so every change invalidates the cached business.
+type BusinessHoursService struct {
+	logger       *zap.SugaredLogger
+	cache        storage.CacheStorage
+	hoursRepo    domain.BusinessHoursRepository
+	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
+}
+
+func NewBusinessHoursService(logger *zap.SugaredLogger, cache storage.CacheStorage, hoursRepo domain.BusinessHoursRepository, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository) *BusinessHoursService {
+	return &BusinessHoursService{
+		logger:       logger,
+		cache:        cache,
+		hoursRepo:    hoursRepo,
+		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
+	}
+}
+
+func (s *BusinessHoursService) ReplaceHours(ctx context.Context, req *dto.BusinessHoursRequest) error {
+	if err := domain.ValidateOpeningHours(req.Hours); err != nil {
+		return err
+	}
+
+	if err := s.authorize(ctx, req.BusinessID); err != nil {
+		return err
+	}
+
+	if err := s.hoursRepo.ReplaceHours(ctx, req.BusinessID, req.Hours); err != nil {
+		s.logger.Errorw("failed to replace business hours", "businessID", req.BusinessID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	s.invalidateCaches(ctx, req.BusinessID)
+
+	return nil
+}
+
+func (s *BusinessHoursService) UpsertException(ctx context.Context, req *dto.HoursExceptionRequest) (*domain.HoursException, error) {
+	exception := &domain.HoursException{
+		BusinessID: req.BusinessID,
+		Date:       req.Date,
+		Closed:     req.Closed,
+		OpensAt:    req.OpensAt,
+		ClosesAt:   req.ClosesAt,
+		Note:       sql.NullString{String: req.Note, Valid: req.Note != ""},
+	}
+	if err := exception.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.authorize(ctx, req.BusinessID); err != nil {
+		return nil, err
+	}
+
+	if err := s.hoursRepo.UpsertException(ctx, exception); err != nil {
+		s.logger.Errorw("failed to save business hours exception", "businessID", req.BusinessID, "date", req.Date, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	s.invalidateCaches(ctx, req.BusinessID)
+
+	return exception, nil
+}
+
+func (s *BusinessHoursService) DeleteException(ctx context.Context, businessID uuid.UUID, date string) error {
+	if err := s.authorize(ctx, businessID); err != nil {
+		return err
+	}
+
+	if err := s.hoursRepo.DeleteException(ctx, businessID, date); err != nil {
+		if err == domain.ErrHoursExceptionNotFound {
+			return err
+		}
+		s.logger.Errorw("failed to delete business hours exception", "businessID", businessID, "date", date, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	s.invalidateCaches(ctx, businessID)
+
+	return nil
+}
+
+// authorize checks the business exists and the user may edit it
+func (s *BusinessHoursService) authorize(ctx context.Context, businessID uuid.UUID) error {
+	if _, err := s.businessRepo.GetByID(ctx, businessID); err != nil {
+		return err
+	}
+
+	_, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, businessID, domain.BusinessPermissionEdit)
+	return err
+}
+
+// invalidateCaches drops the cached business and the cached lists, which may be filtered by the hours
+func (s *BusinessHoursService) invalidateCaches(ctx context.Context, businessID uuid.UUID) {
+	invalidateBusinessCache(ctx, s.logger, s.cache, businessID)
+	invalidateBusinessListCache(ctx, s.logger, s.cache)
+}
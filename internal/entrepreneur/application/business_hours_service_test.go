@@ -0,0 +1,274 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockBusinessHoursRepository
+type MockBusinessHoursRepository struct {
+	mock.Mock
+}
+
+func (m *MockBusinessHoursRepository) GetSchedule(ctx context.Context, businessID uuid.UUID) (*domain.BusinessSchedule, error) {
+	args := m.Called(ctx, businessID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusinessSchedule), args.Error(1)
+}
+
+func (m *MockBusinessHoursRepository) ReplaceHours(ctx context.Context, businessID uuid.UUID, hours []domain.OpeningHours) error {
+	args := m.Called(ctx, businessID, hours)
+	return args.Error(0)
+}
+
+func (m *MockBusinessHoursRepository) UpsertException(ctx context.Context, exception *domain.HoursException) error {
+	args := m.Called(ctx, exception)
+	return args.Error(0)
+}
+
+func (m *MockBusinessHoursRepository) DeleteException(ctx context.Context, businessID uuid.UUID, date string) error {
+	args := m.Called(ctx, businessID, date)
+	return args.Error(0)
+}
+
+type hoursTestSetup struct {
+	service      *BusinessHoursService
+	hoursRepo    *MockBusinessHoursRepository
+	businessRepo *MockBusinessRepository
+	memberRepo   *MockBusinessMemberRepository
+	cache        *MockCacheStorage
+}
+
+func setupHoursTest() *hoursTestSetup {
+	s := &hoursTestSetup{
+		hoursRepo:    new(MockBusinessHoursRepository),
+		businessRepo: new(MockBusinessRepository),
+		memberRepo:   new(MockBusinessMemberRepository),
+		cache:        new(MockCacheStorage),
+	}
+	s.cache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business")
+	s.cache.On("Del", mock.Anything, "business").Return(nil)
+	s.cache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
+	s.cache.On("Scan", mock.Anything, "business_list:*").Return([]string{}, nil)
+	s.service = NewBusinessHoursService(zap.NewNop().Sugar(), s.cache, s.hoursRepo, s.businessRepo, s.memberRepo)
+	return s
+}
+
+// at returns a pointer to the time of day, for the optional hours of exceptions
+func at(hours, minutes int) *domain.TimeOfDay {
+	t := domain.TimeOfDay(hours*60 + minutes)
+	return &t
+}
+
+func TestBusinessHoursService_ReplaceHours(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+	hours := []domain.OpeningHours{
+		{Weekday: time.Monday, OpensAt: *at(8, 0), ClosesAt: *at(12, 0)},
+		{Weekday: time.Monday, OpensAt: *at(14, 0), ClosesAt: *at(18, 0)},
+		{Weekday: time.Saturday, OpensAt: *at(9, 0), ClosesAt: *at(24, 0)},
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+		s.hoursRepo.On("ReplaceHours", ctx, businessID, hours).Return(nil)
+
+		err := s.service.ReplaceHours(ctx, &dto.BusinessHoursRequest{BusinessID: businessID, Hours: hours})
+
+		assert.NoError(t, err)
+		s.hoursRepo.AssertExpectations(t)
+		s.cache.AssertCalled(t, "Del", mock.Anything, "business")
+		s.cache.AssertCalled(t, "Scan", mock.Anything, "business_list:*")
+	})
+
+	t.Run("InvalidHours", func(t *testing.T) {
+		tests := map[string][]domain.OpeningHours{
+			"ClosesBeforeOpening": {{Weekday: time.Monday, OpensAt: *at(18, 0), ClosesAt: *at(8, 0)}},
+			"InvalidWeekday":      {{Weekday: time.Weekday(7), OpensAt: *at(8, 0), ClosesAt: *at(18, 0)}},
+			"Overlapping": {
+				{Weekday: time.Friday, OpensAt: *at(14, 0), ClosesAt: *at(18, 0)},
+				{Weekday: time.Friday, OpensAt: *at(8, 0), ClosesAt: *at(15, 0)},
+			},
+		}
+		for name, hours := range tests {
+			t.Run(name, func(t *testing.T) {
+				s := setupHoursTest()
+
+				err := s.service.ReplaceHours(ctx, &dto.BusinessHoursRequest{BusinessID: businessID, Hours: hours})
+
+				assert.Equal(t, domain.ErrInvalidOpeningHours, err)
+				s.hoursRepo.AssertNotCalled(t, "ReplaceHours", mock.Anything, mock.Anything, mock.Anything)
+			})
+		}
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
+
+		err := s.service.ReplaceHours(ctx, &dto.BusinessHoursRequest{BusinessID: businessID, Hours: hours})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		s.hoursRepo.AssertNotCalled(t, "ReplaceHours", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestBusinessHoursService_UpsertException(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+
+	t.Run("Closed", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.hoursRepo.On("UpsertException", ctx, mock.AnythingOfType("*domain.HoursException")).Return(nil)
+
+		exception, err := s.service.UpsertException(ctx, &dto.HoursExceptionRequest{BusinessID: businessID, Date: "2026-12-25", Closed: true, Note: "Christmas"})
+
+		assert.NoError(t, err)
+		assert.True(t, exception.Closed)
+		assert.Equal(t, "Christmas", exception.Note.String)
+		s.cache.AssertCalled(t, "Del", mock.Anything, "business")
+	})
+
+	t.Run("SpecialHours", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.hoursRepo.On("UpsertException", ctx, mock.AnythingOfType("*domain.HoursException")).Return(nil)
+
+		exception, err := s.service.UpsertException(ctx, &dto.HoursExceptionRequest{BusinessID: businessID, Date: "2026-12-24", OpensAt: at(8, 0), ClosesAt: at(12, 0)})
+
+		assert.NoError(t, err)
+		assert.False(t, exception.Closed)
+		assert.Equal(t, "12:00", exception.ClosesAt.String())
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		tests := map[string]*dto.HoursExceptionRequest{
+			"Date":             {BusinessID: businessID, Date: "25/12/2026", Closed: true},
+			"ClosedWithHours":  {BusinessID: businessID, Date: "2026-12-25", Closed: true, OpensAt: at(8, 0), ClosesAt: at(12, 0)},
+			"OpenWithoutHours": {BusinessID: businessID, Date: "2026-12-24"},
+			"ClosesBefore":     {BusinessID: businessID, Date: "2026-12-24", OpensAt: at(12, 0), ClosesAt: at(8, 0)},
+		}
+		for name, req := range tests {
+			t.Run(name, func(t *testing.T) {
+				s := setupHoursTest()
+
+				_, err := s.service.UpsertException(ctx, req)
+
+				assert.Equal(t, domain.ErrInvalidHoursException, err)
+				s.hoursRepo.AssertNotCalled(t, "UpsertException", mock.Anything, mock.Anything)
+			})
+		}
+	})
+}
+
+func TestBusinessHoursService_DeleteException(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+
+	t.Run("NotFound", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.hoursRepo.On("DeleteException", ctx, businessID, "2026-12-25").Return(domain.ErrHoursExceptionNotFound)
+
+		err := s.service.DeleteException(ctx, businessID, "2026-12-25")
+
+		assert.Equal(t, domain.ErrHoursExceptionNotFound, err)
+		s.cache.AssertNotCalled(t, "Del", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Failure", func(t *testing.T) {
+		s := setupHoursTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.hoursRepo.On("DeleteException", ctx, businessID, "2026-12-25").Return(errors.New("db error"))
+
+		err := s.service.DeleteException(ctx, businessID, "2026-12-25")
+
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+}
+
+func TestBusinessService_GetByID_IsOpenNow(t *testing.T) {
+	ctx := context.Background()
+	id := uuid.New()
+	saoPaulo, _ := time.LoadLocation("America/Sao_Paulo")
+	lisbon, _ := time.LoadLocation("Europe/Lisbon")
+	schedule := func() *domain.BusinessSchedule {
+		return &domain.BusinessSchedule{
+			Hours: []domain.OpeningHours{
+				{Weekday: time.Wednesday, OpensAt: *at(8, 0), ClosesAt: *at(18, 0)},
+			},
+			Exceptions: []domain.HoursException{
+				{BusinessID: id, Date: "2026-12-09", Closed: true},
+				{BusinessID: id, Date: "2026-12-16", OpensAt: at(8, 0), ClosesAt: at(12, 0)},
+			},
+		}
+	}
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		timezones []string
+		open      bool
+	}{
+		{name: "DuringHours", now: time.Date(2026, 12, 2, 10, 0, 0, 0, saoPaulo), open: true},
+		{name: "ClosingTime", now: time.Date(2026, 12, 2, 18, 0, 0, 0, saoPaulo), open: false},
+		{name: "OtherWeekday", now: time.Date(2026, 12, 3, 10, 0, 0, 0, saoPaulo), open: false},
+		{name: "ClosedException", now: time.Date(2026, 12, 9, 10, 0, 0, 0, saoPaulo), open: false},
+		{name: "SpecialHoursException", now: time.Date(2026, 12, 16, 14, 0, 0, 0, saoPaulo), open: false},
+		{name: "LocationTimezone", now: time.Date(2026, 12, 2, 10, 0, 0, 0, lisbon), timezones: []string{"Europe/Lisbon"}, open: true},
+		{name: "AnyLocation", now: time.Date(2026, 12, 2, 7, 0, 0, 0, saoPaulo), timezones: []string{"America/Sao_Paulo", "Europe/Lisbon"}, open: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockBusinessRepository)
+			mockHoursRepo := new(MockBusinessHoursRepository)
+			mockCache := new(MockCacheStorage)
+			service := NewBusinessService(zap.NewNop().Sugar(), mockCache, mockRepo, new(MockBusinessMemberRepository), mockHoursRepo)
+			service.now = func() time.Time { return tt.now }
+
+			businessSchedule := schedule()
+			businessSchedule.Timezones = tt.timezones
+			mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business")
+			mockCache.On("Get", ctx, "business", mock.AnythingOfType("*domain.Business")).Return(errors.New("cache miss"))
+			mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id}, nil)
+			mockHoursRepo.On("GetSchedule", ctx, id).Return(businessSchedule, nil)
+			mockCache.On("Set", ctx, "business", mock.MatchedBy(func(b *domain.Business) bool { return b.IsOpenNow == nil }), mock.Anything).Return(nil)
+
+			business, err := service.GetByID(ctx, id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, businessSchedule, business.Schedule)
+			if assert.NotNil(t, business.IsOpenNow) {
+				assert.Equal(t, tt.open, *business.IsOpenNow)
+			}
+			mockCache.AssertExpectations(t)
+		})
+	}
+}
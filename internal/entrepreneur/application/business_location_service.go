@@ -0,0 +1,232 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// BusinessLocationService manages the places a business works from, which the lists filtered
+// by distance search. Locations sent without coordinates are geocoded when a geocoder is set up.
+type BusinessLocationService struct {
+	logger       *zap.SugaredLogger
+	cache        storage.CacheStorage
+	geocoder     geocoding.Geocoder
+	locationRepo domain.BusinessLocationRepository
+	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
+}
+
+// NewBusinessLocationService creates the service, geocoder being nil when geocoding is disabled
+func NewBusinessLocationService(logger *zap.SugaredLogger, cache storage.CacheStorage, geocoder geocoding.Geocoder, locationRepo domain.BusinessLocationRepository, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository) *BusinessLocationService {
+	return &BusinessLocationService{
+		logger:       logger,
+		cache:        cache,
+		geocoder:     geocoder,
+		locationRepo: locationRepo,
+		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
+	}
+}
+
+// List returns the locations of a business. Like the business itself, they are public once it is
+// approved, and only shown to its members before that.
+func (s *BusinessLocationService) List(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessLocation, error) {
+	business, err := s.businessRepo.GetByID(ctx, businessID)
+	if err != nil {
+		return nil, err
+	}
+
+	if business.Status != domain.BusinessStatusApproved {
+		if _, ok := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext); !ok {
+			return nil, domain.ErrBusinessNotFound
+		}
+		if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, businessID, domain.BusinessPermissionCatalog); err != nil {
+			if err == domain.ErrUnauthorized {
+				return nil, domain.ErrBusinessNotFound
+			}
+			return nil, err
+		}
+	}
+
+	locations, err := s.locationRepo.ListByBusiness(ctx, businessID)
+	if err != nil {
+		s.logger.Errorw("failed to list business locations", "businessID", businessID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return locations, nil
+}
+
+func (s *BusinessLocationService) Create(ctx context.Context, req *dto.BusinessLocationRequest) (*domain.BusinessLocation, error) {
+	if err := validateLocationRequest(req); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.businessRepo.GetByID(ctx, req.BusinessID); err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionEdit); err != nil {
+		return nil, err
+	}
+
+	location := &domain.BusinessLocation{BusinessID: req.BusinessID}
+	if err := s.fill(ctx, location, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.Create(ctx, location); err != nil {
+		s.logger.Errorw("failed to create business location", "businessID", req.BusinessID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	invalidateBusinessCache(ctx, s.logger, s.cache, req.BusinessID)
+	invalidateBusinessListCache(ctx, s.logger, s.cache)
+
+	return location, nil
+}
+
+func (s *BusinessLocationService) Update(ctx context.Context, req *dto.BusinessLocationRequest) (*domain.BusinessLocation, error) {
+	if err := validateLocationRequest(req); err != nil {
+		return nil, err
+	}
+
+	location, err := s.getOfBusiness(ctx, req.BusinessID, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionEdit); err != nil {
+		return nil, err
+	}
+
+	if err := s.fill(ctx, location, req); err != nil {
+		return nil, err
+	}
+
+	if err := s.locationRepo.Update(ctx, location); err != nil {
+		if err == domain.ErrBusinessLocationNotFound {
+			return nil, err
+		}
+		s.logger.Errorw("failed to update business location", "id", req.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	invalidateBusinessCache(ctx, s.logger, s.cache, req.BusinessID)
+	invalidateBusinessListCache(ctx, s.logger, s.cache)
+
+	return location, nil
+}
+
+func (s *BusinessLocationService) Delete(ctx context.Context, businessID, id uuid.UUID) error {
+	if _, err := s.getOfBusiness(ctx, businessID, id); err != nil {
+		return err
+	}
+
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, businessID, domain.BusinessPermissionEdit); err != nil {
+		return err
+	}
+
+	if err := s.locationRepo.Delete(ctx, id); err != nil {
+		if err == domain.ErrBusinessLocationNotFound {
+			return err
+		}
+		s.logger.Errorw("failed to delete business location", "id", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	invalidateBusinessCache(ctx, s.logger, s.cache, businessID)
+	invalidateBusinessListCache(ctx, s.logger, s.cache)
+
+	return nil
+}
+
+// getOfBusiness returns the location, or ErrBusinessLocationNotFound when it belongs to another business
+func (s *BusinessLocationService) getOfBusiness(ctx context.Context, businessID, id uuid.UUID) (*domain.BusinessLocation, error) {
+	location, err := s.locationRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrBusinessLocationNotFound {
+			return nil, err
+		}
+		s.logger.Errorw("failed to get business location", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if location.BusinessID != businessID {
+		return nil, domain.ErrBusinessLocationNotFound
+	}
+
+	return location, nil
+}
+
+// fill copies the request into the location, geocoding the address when no coordinates were sent
+func (s *BusinessLocationService) fill(ctx context.Context, location *domain.BusinessLocation, req *dto.BusinessLocationRequest) error {
+	location.Label = sql.NullString{String: req.Label, Valid: req.Label != ""}
+	location.StreetLine1 = req.StreetLine1
+	location.StreetLine2 = sql.NullString{String: req.StreetLine2, Valid: req.StreetLine2 != ""}
+	location.City = req.City
+	location.StateProvince = req.StateProvince
+	location.PostalCode = req.PostalCode
+	location.Country = req.Country
+	location.ServiceRadiusKm = req.ServiceRadiusKm
+	location.Timezone = req.Timezone
+	if location.Timezone == "" {
+		location.Timezone = domain.DefaultTimezone
+	}
+
+	if req.Latitude != nil && req.Longitude != nil {
+		location.Latitude, location.Longitude = *req.Latitude, *req.Longitude
+		return nil
+	}
+
+	if s.geocoder == nil {
+		return domain.ErrMissingCoordinates
+	}
+
+	coordinates, err := s.geocoder.Geocode(ctx, geocoding.Address{
+		StreetLine1:   req.StreetLine1,
+		StreetLine2:   req.StreetLine2,
+		City:          req.City,
+		StateProvince: req.StateProvince,
+		PostalCode:    req.PostalCode,
+		Country:       req.Country,
+	})
+	if err != nil {
+		if errors.Is(err, geocoding.ErrNotFound) {
+			return domain.ErrAddressNotGeocoded
+		}
+		s.logger.Errorw("failed to geocode business location", "businessID", req.BusinessID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	location.Latitude, location.Longitude = coordinates.Latitude, coordinates.Longitude
+	return nil
+}
+
+// validateLocationRequest checks the coordinates, when sent, the service radius and the timezone
+func validateLocationRequest(req *dto.BusinessLocationRequest) error {
+	if (req.Latitude == nil) != (req.Longitude == nil) {
+		return domain.ErrInvalidCoordinates
+	}
+	if req.Latitude != nil && !domain.ValidCoordinates(*req.Latitude, *req.Longitude) {
+		return domain.ErrInvalidCoordinates
+	}
+	if req.ServiceRadiusKm < 0 || req.ServiceRadiusKm > domain.MaxServiceRadiusKm {
+		return domain.ErrInvalidServiceRadius
+	}
+	if req.Timezone != "" && !domain.ValidTimezone(req.Timezone) {
+		return domain.ErrInvalidTimezone
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding/geocodingtest"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockBusinessLocationRepository
+type MockBusinessLocationRepository struct {
+	mock.Mock
+}
+
+func (m *MockBusinessLocationRepository) Create(ctx context.Context, location *domain.BusinessLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+
+func (m *MockBusinessLocationRepository) Update(ctx context.Context, location *domain.BusinessLocation) error {
+	args := m.Called(ctx, location)
+	return args.Error(0)
+}
+
+func (m *MockBusinessLocationRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockBusinessLocationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.BusinessLocation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusinessLocation), args.Error(1)
+}
+
+func (m *MockBusinessLocationRepository) ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessLocation, error) {
+	args := m.Called(ctx, businessID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BusinessLocation), args.Error(1)
+}
+
+type locationTestSetup struct {
+	service      *BusinessLocationService
+	locationRepo *MockBusinessLocationRepository
+	businessRepo *MockBusinessRepository
+	memberRepo   *MockBusinessMemberRepository
+	cache        *MockCacheStorage
+	geocoder     *geocodingtest.Stub
+}
+
+func setupLocationTest() *locationTestSetup {
+	s := &locationTestSetup{
+		locationRepo: new(MockBusinessLocationRepository),
+		businessRepo: new(MockBusinessRepository),
+		memberRepo:   new(MockBusinessMemberRepository),
+		cache:        new(MockCacheStorage),
+		geocoder:     geocodingtest.NewStub(),
+	}
+	s.cache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business")
+	s.cache.On("Del", mock.Anything, "business").Return(nil)
+	s.cache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
+	s.cache.On("Scan", mock.Anything, "business_list:*").Return([]string{}, nil)
+	s.service = NewBusinessLocationService(zap.NewNop().Sugar(), s.cache, s.geocoder, s.locationRepo, s.businessRepo, s.memberRepo)
+	return s
+}
+
+func TestBusinessLocationService_Create(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+	latitude, longitude := -23.5503, -46.6339
+	address := geocoding.Address{StreetLine1: "Praça da Sé", City: "São Paulo", StateProvince: "SP", PostalCode: "01001-000", Country: "Brazil"}
+	newRequest := func() *dto.BusinessLocationRequest {
+		return &dto.BusinessLocationRequest{
+			BusinessID:      businessID,
+			StreetLine1:     address.StreetLine1,
+			City:            address.City,
+			StateProvince:   address.StateProvince,
+			PostalCode:      address.PostalCode,
+			Country:         address.Country,
+			ServiceRadiusKm: 15,
+		}
+	}
+
+	t.Run("WithCoordinates", func(t *testing.T) {
+		s := setupLocationTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+		s.locationRepo.On("Create", ctx, mock.AnythingOfType("*domain.BusinessLocation")).Return(nil)
+		req := newRequest()
+		req.Latitude, req.Longitude = &latitude, &longitude
+
+		location, err := s.service.Create(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, latitude, location.Latitude)
+		assert.Equal(t, 15.0, location.ServiceRadiusKm)
+		assert.Equal(t, domain.DefaultTimezone, location.Timezone)
+		assert.Equal(t, 0, s.geocoder.Calls)
+		s.locationRepo.AssertExpectations(t)
+		s.cache.AssertCalled(t, "Del", mock.Anything, "business")
+		s.cache.AssertCalled(t, "Scan", mock.Anything, "business_list:*")
+	})
+
+	t.Run("InvalidTimezone", func(t *testing.T) {
+		s := setupLocationTest()
+		req := newRequest()
+		req.Latitude, req.Longitude = &latitude, &longitude
+		req.Timezone = "America/Atlantis"
+
+		_, err := s.service.Create(ctx, req)
+
+		assert.Equal(t, domain.ErrInvalidTimezone, err)
+		s.businessRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Geocoded", func(t *testing.T) {
+		s := setupLocationTest()
+		s.geocoder.Add(address, geocoding.Coordinates{Latitude: latitude, Longitude: longitude})
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.locationRepo.On("Create", ctx, mock.AnythingOfType("*domain.BusinessLocation")).Return(nil)
+
+		location, err := s.service.Create(ctx, newRequest())
+
+		assert.NoError(t, err)
+		assert.Equal(t, latitude, location.Latitude)
+		assert.Equal(t, longitude, location.Longitude)
+	})
+
+	t.Run("AddressNotFound", func(t *testing.T) {
+		s := setupLocationTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+
+		_, err := s.service.Create(ctx, newRequest())
+
+		assert.Equal(t, domain.ErrAddressNotGeocoded, err)
+		s.locationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("GeocoderUnavailable", func(t *testing.T) {
+		s := setupLocationTest()
+		s.geocoder.Err = geocoding.ErrUnavailable
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+
+		_, err := s.service.Create(ctx, newRequest())
+
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+
+	t.Run("GeocodingDisabled", func(t *testing.T) {
+		s := setupLocationTest()
+		s.service = NewBusinessLocationService(zap.NewNop().Sugar(), s.cache, nil, s.locationRepo, s.businessRepo, s.memberRepo)
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+
+		_, err := s.service.Create(ctx, newRequest())
+
+		assert.Equal(t, domain.ErrMissingCoordinates, err)
+	})
+
+	t.Run("InvalidCoordinates", func(t *testing.T) {
+		s := setupLocationTest()
+		req := newRequest()
+		outOfBounds := 91.0
+		req.Latitude, req.Longitude = &outOfBounds, &longitude
+
+		_, err := s.service.Create(ctx, req)
+
+		assert.Equal(t, domain.ErrInvalidCoordinates, err)
+		s.businessRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidServiceRadius", func(t *testing.T) {
+		s := setupLocationTest()
+		req := newRequest()
+		req.ServiceRadiusKm = domain.MaxServiceRadiusKm + 1
+
+		_, err := s.service.Create(ctx, req)
+
+		assert.Equal(t, domain.ErrInvalidServiceRadius, err)
+	})
+
+	t.Run("EditorUnauthorized", func(t *testing.T) {
+		s := setupLocationTest()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
+
+		_, err := s.service.Create(ctx, newRequest())
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		assert.Equal(t, 0, s.geocoder.Calls)
+	})
+}
+
+func TestBusinessLocationService_List(t *testing.T) {
+	businessID := uuid.New()
+	locations := []*domain.BusinessLocation{{ID: uuid.New(), BusinessID: businessID}}
+
+	t.Run("Approved", func(t *testing.T) {
+		s := setupLocationTest()
+		ctx := context.Background()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, Status: domain.BusinessStatusApproved}, nil)
+		s.locationRepo.On("ListByBusiness", ctx, businessID).Return(locations, nil)
+
+		result, err := s.service.List(ctx, businessID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, locations, result)
+	})
+
+	t.Run("DraftAnonymous", func(t *testing.T) {
+		s := setupLocationTest()
+		ctx := context.Background()
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, Status: domain.BusinessStatusDraft}, nil)
+
+		_, err := s.service.List(ctx, businessID)
+
+		assert.Equal(t, domain.ErrBusinessNotFound, err)
+		s.locationRepo.AssertNotCalled(t, "ListByBusiness", mock.Anything, mock.Anything)
+	})
+
+	t.Run("DraftMember", func(t *testing.T) {
+		s := setupLocationTest()
+		userID := uuid.New()
+		ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, Status: domain.BusinessStatusDraft}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
+		s.locationRepo.On("ListByBusiness", ctx, businessID).Return(locations, nil)
+
+		result, err := s.service.List(ctx, businessID)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+	})
+}
+
+func TestBusinessLocationService_Delete(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+	locationID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		s := setupLocationTest()
+		s.locationRepo.On("GetByID", ctx, locationID).Return(&domain.BusinessLocation{ID: locationID, BusinessID: businessID}, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.locationRepo.On("Delete", ctx, locationID).Return(nil)
+
+		err := s.service.Delete(ctx, businessID, locationID)
+
+		assert.NoError(t, err)
+		s.locationRepo.AssertExpectations(t)
+	})
+
+	t.Run("OtherBusiness", func(t *testing.T) {
+		s := setupLocationTest()
+		s.locationRepo.On("GetByID", ctx, locationID).Return(&domain.BusinessLocation{ID: locationID, BusinessID: uuid.New()}, nil)
+
+		err := s.service.Delete(ctx, businessID, locationID)
+
+		assert.Equal(t, domain.ErrBusinessLocationNotFound, err)
+		s.locationRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
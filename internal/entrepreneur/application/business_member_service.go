@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userApp "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	userDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// BusinessMemberService manages the people who run a business along with its owner.
+// Owners and managers invite registered users by email, the invitation takes effect once the
+// user accepts it, and members are only managed by members of a higher role.
+type BusinessMemberService struct {
+	logger       *zap.SugaredLogger
+	config       config.Config
+	queue        storage.QueueStorage
+	memberRepo   domain.BusinessMemberRepository
+	businessRepo domain.BusinessRepository
+	userRepo     userDomain.UserRepository
+}
+
+func NewBusinessMemberService(logger *zap.SugaredLogger, cfg config.Config, queue storage.QueueStorage, memberRepo domain.BusinessMemberRepository, businessRepo domain.BusinessRepository, userRepo userDomain.UserRepository) *BusinessMemberService {
+	return &BusinessMemberService{
+		logger:       logger,
+		config:       cfg,
+		queue:        queue,
+		memberRepo:   memberRepo,
+		businessRepo: businessRepo,
+		userRepo:     userRepo,
+	}
+}
+
+// List returns the members and pending invitations of a business to any of its members
+func (s *BusinessMemberService) List(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessMember, error) {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, businessID, domain.BusinessPermissionCatalog); err != nil {
+		return nil, err
+	}
+
+	members, err := s.memberRepo.ListByBusiness(ctx, businessID)
+	if err != nil {
+		s.logger.Errorw("failed to list business members", "businessID", businessID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return members, nil
+}
+
+// Invite adds a pending member to the business and emails them the invitation
+func (s *BusinessMemberService) Invite(ctx context.Context, req *dto.BusinessMemberInviteRequest) (*domain.BusinessMember, error) {
+	// The business changes owner through a transfer only
+	if !req.Role.IsValid() || req.Role == domain.BusinessRoleOwner {
+		return nil, domain.ErrInvalidBusinessRole
+	}
+
+	inviter, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionMembers)
+	if err != nil {
+		return nil, err
+	}
+
+	if !inviter.Role.Outranks(req.Role) {
+		return nil, domain.ErrUnauthorized
+	}
+
+	business, err := s.businessRepo.GetByID(ctx, req.BusinessID)
+	if err != nil {
+		if err == domain.ErrBusinessNotFound {
+			return nil, domain.ErrBusinessNotFound
+		}
+
+		s.logger.Errorw("failed to get business by ID", "id", req.BusinessID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, strings.TrimSpace(req.Email))
+	if err != nil {
+		if err == userDomain.ErrUserNotFound {
+			return nil, userDomain.ErrUserNotFound
+		}
+
+		s.logger.Errorw("failed to get user by email", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if _, err := s.memberRepo.GetByBusinessAndUser(ctx, req.BusinessID, user.ID); err == nil {
+		return nil, domain.ErrBusinessMemberExists
+	} else if err != domain.ErrBusinessMemberNotFound {
+		s.logger.Errorw("failed to get business member", "businessID", req.BusinessID, "userID", user.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	member := &domain.BusinessMember{
+		BusinessID: req.BusinessID,
+		UserID:     user.ID,
+		Role:       req.Role,
+		InvitedBy:  uuid.NullUUID{UUID: inviter.UserID, Valid: true},
+		FirstName:  user.FirstName,
+		LastName:   user.LastName,
+		Email:      user.Email,
+	}
+
+	if err := s.memberRepo.Create(nil, member); err != nil {
+		s.logger.Errorw("failed to create business member", "businessID", req.BusinessID, "userID", user.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.sendInvitationEmail(ctx, user, business); err != nil {
+		s.logger.Warnw("failed to send business invitation email", "businessID", req.BusinessID, "userID", user.ID, "error", err)
+	}
+
+	return member, nil
+}
+
+// Accept makes the authenticated user an active member of the business they were invited to
+func (s *BusinessMemberService) Accept(ctx context.Context, businessID uuid.UUID) error {
+	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
+	if err := s.memberRepo.Accept(ctx, businessID, userCtx.ID); err != nil {
+		if err == domain.ErrBusinessInvitationNotFound {
+			return domain.ErrBusinessInvitationNotFound
+		}
+
+		s.logger.Errorw("failed to accept business invitation", "businessID", businessID, "userID", userCtx.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// UpdateRole lets the owner switch a member between manager and editor
+func (s *BusinessMemberService) UpdateRole(ctx context.Context, req *dto.BusinessMemberRoleRequest) error {
+	if !req.Role.IsValid() || req.Role == domain.BusinessRoleOwner {
+		return domain.ErrInvalidBusinessRole
+	}
+
+	owner, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionOwn)
+	if err != nil {
+		return err
+	}
+
+	if req.UserID == owner.UserID {
+		return domain.ErrInvalidBusinessRole
+	}
+
+	if err := s.memberRepo.UpdateRole(ctx, req.BusinessID, req.UserID, req.Role); err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return domain.ErrBusinessMemberNotFound
+		}
+
+		s.logger.Errorw("failed to update business member role", "businessID", req.BusinessID, "userID", req.UserID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// Remove takes a member or a pending invitation out of the business. Members may leave, or
+// decline an invitation, on their own; others are removed by a member of a higher role.
+func (s *BusinessMemberService) Remove(ctx context.Context, businessID, userID uuid.UUID) error {
+	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
+	member, err := s.memberRepo.GetByBusinessAndUser(ctx, businessID, userID)
+	if err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return domain.ErrBusinessMemberNotFound
+		}
+
+		s.logger.Errorw("failed to get business member", "businessID", businessID, "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if member.Role == domain.BusinessRoleOwner {
+		return domain.ErrBusinessOwnerRemoval
+	}
+
+	if userID != userCtx.ID {
+		remover, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, businessID, domain.BusinessPermissionMembers)
+		if err != nil {
+			return err
+		}
+
+		if !remover.Role.Outranks(member.Role) {
+			return domain.ErrUnauthorized
+		}
+	}
+
+	if err := s.memberRepo.Delete(ctx, businessID, userID); err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return domain.ErrBusinessMemberNotFound
+		}
+
+		s.logger.Errorw("failed to delete business member", "businessID", businessID, "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// sendInvitationEmail tells the user who invited them to which business, in their language
+func (s *BusinessMemberService) sendInvitationEmail(ctx context.Context, user *userDomain.User, business *domain.Business) error {
+	link := userApp.FrontendLink(s.config, "entrepreneur", "business", business.ID.String(), "members", "accept")
+	return userApp.SendReviewEmail(ctx, s.queue, s.config.SMTP.From, user, constants.EMAIL_TEMPLATE_BUSINESS_INVITATION, "email.business_invitation", business.Name, link)
+}
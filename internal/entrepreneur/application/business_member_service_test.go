@@ -0,0 +1,219 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockUserRepository only implements the lookups the member service makes
+type MockUserRepository struct {
+	userDomain.UserRepository
+	mock.Mock
+}
+
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*userDomain.User, error) {
+	args := m.Called(ctx, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*userDomain.User), args.Error(1)
+}
+
+// MockQueueStorage
+type MockQueueStorage struct {
+	mock.Mock
+}
+
+func (m *MockQueueStorage) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	args := m.Called(ctx, exchange, routingKey, body)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) Consume(queueName string, handler func([]byte) error) error {
+	args := m.Called(queueName, handler)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) DeclareQueue(queueName string) error {
+	args := m.Called(queueName)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+type memberTestSetup struct {
+	service      *BusinessMemberService
+	memberRepo   *MockBusinessMemberRepository
+	businessRepo *MockBusinessRepository
+	userRepo     *MockUserRepository
+	queue        *MockQueueStorage
+}
+
+func setupMemberTest() *memberTestSetup {
+	s := &memberTestSetup{
+		memberRepo:   new(MockBusinessMemberRepository),
+		businessRepo: new(MockBusinessRepository),
+		userRepo:     new(MockUserRepository),
+		queue:        new(MockQueueStorage),
+	}
+	s.service = NewBusinessMemberService(zap.NewNop().Sugar(), config.Config{}, s.queue, s.memberRepo, s.businessRepo, s.userRepo)
+	return s
+}
+
+func TestBusinessMemberService_Invite(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+	invitee := &userDomain.User{ID: uuid.New(), FirstName: "Maria", Email: "maria@example.com"}
+
+	t.Run("Success", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, Name: "Bakery"}, nil)
+		s.userRepo.On("GetByEmail", ctx, invitee.Email).Return(invitee, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, invitee.ID).Return(nil, domain.ErrBusinessMemberNotFound)
+		s.memberRepo.On("Create", (*sqlx.Tx)(nil), mock.MatchedBy(func(m *domain.BusinessMember) bool {
+			return m.UserID == invitee.ID && m.Role == domain.BusinessRoleEditor && m.InvitedBy.UUID == userID && !m.IsActive()
+		})).Return(nil)
+		s.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.Anything).Return(nil)
+
+		member, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: " maria@example.com ", Role: domain.BusinessRoleEditor})
+
+		assert.NoError(t, err)
+		assert.Equal(t, invitee.ID, member.UserID)
+		s.memberRepo.AssertExpectations(t)
+		s.queue.AssertExpectations(t)
+	})
+
+	t.Run("OwnerRole", func(t *testing.T) {
+		s := setupMemberTest()
+
+		_, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: invitee.Email, Role: domain.BusinessRoleOwner})
+
+		assert.Equal(t, domain.ErrInvalidBusinessRole, err)
+		s.memberRepo.AssertNotCalled(t, "GetByBusinessAndUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("SameRank", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+
+		_, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: invitee.Email, Role: domain.BusinessRoleManager})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+		s.memberRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("EditorCannotInvite", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
+
+		_, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: invitee.Email, Role: domain.BusinessRoleEditor})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	t.Run("AlreadyMember", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.userRepo.On("GetByEmail", ctx, invitee.Email).Return(invitee, nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, invitee.ID).Return(&domain.BusinessMember{BusinessID: businessID, UserID: invitee.ID}, nil)
+
+		_, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: invitee.Email, Role: domain.BusinessRoleManager})
+
+		assert.Equal(t, domain.ErrBusinessMemberExists, err)
+		s.memberRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownUser", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+		s.businessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID}, nil)
+		s.userRepo.On("GetByEmail", ctx, invitee.Email).Return(nil, userDomain.ErrUserNotFound)
+
+		_, err := s.service.Invite(ctx, &dto.BusinessMemberInviteRequest{BusinessID: businessID, Email: invitee.Email, Role: domain.BusinessRoleEditor})
+
+		assert.Equal(t, userDomain.ErrUserNotFound, err)
+	})
+}
+
+func TestBusinessMemberService_Accept(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("Accept", ctx, businessID, userID).Return(nil)
+
+		assert.NoError(t, s.service.Accept(ctx, businessID))
+		s.memberRepo.AssertExpectations(t)
+	})
+
+	t.Run("NoInvitation", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("Accept", ctx, businessID, userID).Return(domain.ErrBusinessInvitationNotFound)
+
+		assert.Equal(t, domain.ErrBusinessInvitationNotFound, s.service.Accept(ctx, businessID))
+	})
+}
+
+func TestBusinessMemberService_Remove(t *testing.T) {
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	businessID := uuid.New()
+	memberID := uuid.New()
+
+	t.Run("ByManager", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, memberID).Return(activeMember(businessID, memberID, domain.BusinessRoleEditor), nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+		s.memberRepo.On("Delete", ctx, businessID, memberID).Return(nil)
+
+		assert.NoError(t, s.service.Remove(ctx, businessID, memberID))
+		s.memberRepo.AssertExpectations(t)
+	})
+
+	t.Run("Leave", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+		s.memberRepo.On("Delete", ctx, businessID, userID).Return(nil)
+
+		assert.NoError(t, s.service.Remove(ctx, businessID, userID))
+		s.memberRepo.AssertExpectations(t)
+	})
+
+	t.Run("Owner", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleOwner), nil)
+
+		assert.Equal(t, domain.ErrBusinessOwnerRemoval, s.service.Remove(ctx, businessID, userID))
+		s.memberRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("SameRank", func(t *testing.T) {
+		s := setupMemberTest()
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, memberID).Return(activeMember(businessID, memberID, domain.BusinessRoleManager), nil)
+		s.memberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleManager), nil)
+
+		assert.Equal(t, domain.ErrUnauthorized, s.service.Remove(ctx, businessID, memberID))
+		s.memberRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
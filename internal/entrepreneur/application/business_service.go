@@ -7,15 +7,18 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
 	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
 	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
 	"go.uber.org/zap"
 )
 
@@ -28,13 +31,19 @@ type BusinessService struct {
 	logger       *zap.SugaredLogger
 	cache        storage.CacheStorage
 	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
+	hoursRepo    domain.BusinessHoursRepository
+	now          func() time.Time
 }
 
-func NewBusinessService(logger *zap.SugaredLogger, cache storage.CacheStorage, businessRepo domain.BusinessRepository) *BusinessService {
+func NewBusinessService(logger *zap.SugaredLogger, cache storage.CacheStorage, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository, hoursRepo domain.BusinessHoursRepository) *BusinessService {
 	return &BusinessService{
 		logger:       logger,
 		cache:        cache,
 		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
+		hoursRepo:    hoursRepo,
+		now:          time.Now,
 	}
 }
 
@@ -50,10 +59,23 @@ func (s *BusinessService) Create(ctx context.Context, req *dto.BusinessCreateReq
 		PhoneNumber:      sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""},
 		WebsiteURL:       sql.NullString{String: req.WebsiteURL, Valid: req.WebsiteURL != ""},
 		LogoURL:          sql.NullString{String: req.LogoURL, Valid: req.LogoURL != ""},
-		IsActive:         true,
+		Status:           domain.BusinessStatusDraft,
 	}
 
-	if err := s.businessRepo.Create(nil, business); err != nil {
+	// The creator becomes the owner member of the business
+	err := s.businessRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		if err := s.businessRepo.Create(tx, business); err != nil {
+			return err
+		}
+
+		return s.memberRepo.Create(tx, &domain.BusinessMember{
+			BusinessID: business.ID,
+			UserID:     userCtx.ID,
+			Role:       domain.BusinessRoleOwner,
+			AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+		})
+	})
+	if err != nil {
 		s.logger.Errorw("failed to create business", "error", err)
 		return nil, response.ErrInternalServerError
 	}
@@ -65,14 +87,13 @@ func (s *BusinessService) Create(ctx context.Context, req *dto.BusinessCreateReq
 }
 
 func (s *BusinessService) Update(ctx context.Context, req *dto.BusinessUpdateRequest) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	business, err := s.businessRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, business.ID, domain.BusinessPermissionEdit); err != nil {
+		return err
 	}
 
 	business.IndustryID = req.IndustryID
@@ -83,7 +104,6 @@ func (s *BusinessService) Update(ctx context.Context, req *dto.BusinessUpdateReq
 	business.PhoneNumber = sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""}
 	business.WebsiteURL = sql.NullString{String: req.WebsiteURL, Valid: req.WebsiteURL != ""}
 	business.LogoURL = sql.NullString{String: req.LogoURL, Valid: req.LogoURL != ""}
-	business.IsActive = req.IsActive
 
 	if err := s.businessRepo.Update(nil, business); err != nil {
 		s.logger.Errorw("failed to update business", "id", req.ID, "error", err)
@@ -98,14 +118,13 @@ func (s *BusinessService) Update(ctx context.Context, req *dto.BusinessUpdateReq
 }
 
 func (s *BusinessService) Delete(ctx context.Context, id uuid.UUID) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
-	business, err := s.businessRepo.GetByID(ctx, id)
-	if err != nil {
+	if _, err := s.businessRepo.GetByID(ctx, id); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
+	// Only the owner may delete the business
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, id, domain.BusinessPermissionOwn); err != nil {
+		return err
 	}
 
 	if err := s.businessRepo.Delete(nil, id); err != nil {
@@ -125,6 +144,7 @@ func (s *BusinessService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bu
 	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_BUSINESS, id.String())
 	var business domain.Business
 	if err := s.cache.Get(ctx, cacheKey, &business); err == nil {
+		s.setOpenNow(&business)
 		return &business, nil
 	}
 
@@ -139,22 +159,48 @@ func (s *BusinessService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bu
 		return nil, response.ErrInternalServerError
 	}
 
+	businessFromDB.Schedule, err = s.hoursRepo.GetSchedule(ctx, id)
+	if err != nil {
+		s.logger.Errorw("failed to get business schedule", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
 	// Store in cache
 	if err := s.cache.Set(ctx, cacheKey, businessFromDB, businessCacheTTL); err != nil {
 		s.logger.Warnw("failed to cache business", "id", id, "error", err)
 	}
 
+	s.setOpenNow(businessFromDB)
 	return businessFromDB, nil
 }
 
+// setOpenNow computes whether the business is open, after the business is cached as it changes with the time
+func (s *BusinessService) setOpenNow(business *domain.Business) {
+	if business.Schedule == nil {
+		return
+	}
+
+	open := business.Schedule.IsOpenAt(s.now())
+	business.IsOpenNow = &open
+}
+
 func (s *BusinessService) List(ctx context.Context, req *dto.BusinessListRequest) (*dto.BusinessListResponse, error) {
+	if req.Status != nil && !req.Status.IsValid() {
+		return nil, domain.ErrInvalidBusinessStatus
+	}
+	if err := req.ValidateListOptions(); err != nil {
+		return nil, err
+	}
+
 	// Generate cache key based on filter parameters
 	cacheKey := s.buildListCacheKey(req)
 
-	// Try to get from cache
+	// Try to get from cache, unless the list depends on the time of the request
 	var cachedResponse dto.BusinessListResponse
-	if err := s.cache.Get(ctx, cacheKey, &cachedResponse); err == nil {
-		return &cachedResponse, nil
+	if !req.OpenNow {
+		if err := s.cache.Get(ctx, cacheKey, &cachedResponse); err == nil {
+			return &cachedResponse, nil
+		}
 	}
 
 	// Cache miss - get from database
@@ -164,8 +210,16 @@ func (s *BusinessService) List(ctx context.Context, req *dto.BusinessListRequest
 		return nil, response.ErrInternalServerError
 	}
 
+	var page pagination.Page
+	if req.Cursor != nil {
+		businesses, page = pagination.Trim(businesses, req.Cursor, pagination.Limit(req.Limit), func(b *domain.Business) (time.Time, uuid.UUID) {
+			return b.CreatedAt, b.ID
+		})
+	}
+
+	// Cursor pages skip the count, which would read the whole list on every page
 	count := 0
-	if len(businesses) > 0 {
+	if len(businesses) > 0 && req.Cursor == nil {
 		count, err = s.businessRepo.Count(ctx, req)
 		if err != nil {
 			s.logger.Errorw("failed to count businesses", "error", err)
@@ -173,41 +227,169 @@ func (s *BusinessService) List(ctx context.Context, req *dto.BusinessListRequest
 		}
 	}
 
+	// Facets are counted even without results, values left out by the other filters may still match
+	var facets domain.Facets
+	if len(req.Facets) > 0 {
+		facets, err = s.businessRepo.Facets(ctx, req)
+		if err != nil {
+			s.logger.Errorw("failed to count business facets", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
 	resp := &dto.BusinessListResponse{
 		Businesses: businesses,
 		Count:      count,
 		Limit:      req.Limit,
 		Offset:     req.Offset,
+		Facets:     facets,
+		Page:       page,
 	}
 
 	// Store in cache
-	if err := s.cache.Set(ctx, cacheKey, resp, businessListCacheTTL); err != nil {
-		s.logger.Warnw("failed to cache business list", "error", err)
+	if !req.OpenNow {
+		if err := s.cache.Set(ctx, cacheKey, resp, businessListCacheTTL); err != nil {
+			s.logger.Warnw("failed to cache business list", "error", err)
+		}
 	}
 
 	return resp, nil
 }
 
-func (s *BusinessService) UpdateActiveStatus(ctx context.Context, req *dto.BusinessUpdatePropertyRequest) error {
+// Submit sends a draft or rejected business to the moderation queue on behalf of its owner or a manager
+func (s *BusinessService) Submit(ctx context.Context, id uuid.UUID) (*domain.Business, error) {
+	business, err := s.businessRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == domain.ErrBusinessNotFound {
+			return nil, domain.ErrBusinessNotFound
+		}
+
+		s.logger.Errorw("failed to get business by ID", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, business.ID, domain.BusinessPermissionEdit); err != nil {
+		return nil, err
+	}
+
+	from := business.Status
+	if !from.CanTransitionTo(domain.BusinessStatusPendingReview) {
+		return nil, domain.ErrInvalidBusinessTransition
+	}
+
+	business.Status = domain.BusinessStatusPendingReview
+	business.StatusReason = sql.NullString{}
+	business.SubmittedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.updateStatus(ctx, business, from); err != nil {
+		return nil, err
+	}
+
+	return business, nil
+}
+
+// Moderate approves, rejects, suspends or reinstates a business on behalf of an admin
+func (s *BusinessService) Moderate(ctx context.Context, req *dto.BusinessModerateRequest) (*domain.Business, error) {
+	reason := strings.TrimSpace(req.Reason)
+	switch req.Status {
+	case domain.BusinessStatusApproved:
+	case domain.BusinessStatusRejected, domain.BusinessStatusSuspended:
+		// The owner has to know what to change
+		if reason == "" {
+			return nil, domain.ErrBusinessStatusReasonMissing
+		}
+	default:
+		return nil, domain.ErrInvalidBusinessStatus
+	}
+
 	business, err := s.businessRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		if err == domain.ErrBusinessNotFound {
-			return domain.ErrBusinessNotFound
+			return nil, domain.ErrBusinessNotFound
 		}
 
 		s.logger.Errorw("failed to get business by ID", "id", req.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	from := business.Status
+	if !from.CanTransitionTo(req.Status) {
+		return nil, domain.ErrInvalidBusinessTransition
+	}
+
+	business.Status = req.Status
+	business.StatusReason = sql.NullString{String: reason, Valid: reason != ""}
+	business.ReviewerID = uuid.NullUUID{UUID: req.ReviewerID, Valid: true}
+	business.ReviewedAt = sql.NullTime{Time: time.Now(), Valid: true}
+
+	if err := s.updateStatus(ctx, business, from); err != nil {
+		return nil, err
+	}
+
+	return business, nil
+}
+
+// TransferOwnership hands the business over to another active member who is an entrepreneur. The previous owner
+// stays on as a manager.
+func (s *BusinessService) TransferOwnership(ctx context.Context, req *dto.BusinessTransferOwnershipRequest) error {
+	owner, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionOwn)
+	if err != nil {
+		return err
+	}
+
+	if req.UserID == owner.UserID {
+		return domain.ErrInvalidInput
+	}
+
+	member, err := s.memberRepo.GetByBusinessAndUser(ctx, req.BusinessID, req.UserID)
+	if err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return domain.ErrBusinessMemberNotFound
+		}
+
+		s.logger.Errorw("failed to get business member", "businessID", req.BusinessID, "userID", req.UserID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	// Invitations have to be accepted before the business can be handed over
+	if !member.IsActive() {
+		return domain.ErrBusinessMemberNotFound
+	}
+
+	// Only entrepreneurs may own a business, as only they may register one
+	if !member.IsEntrepreneur {
+		return domain.ErrOwnerNotEntrepreneur
+	}
+
+	if err := s.memberRepo.TransferOwnership(ctx, req.BusinessID, owner.UserID, req.UserID); err != nil {
+		if err == domain.ErrBusinessMemberNotFound {
+			return domain.ErrBusinessMemberNotFound
+		}
+
+		s.logger.Errorw("failed to transfer business ownership", "businessID", req.BusinessID, "userID", req.UserID, "error", err)
 		return response.ErrInternalServerError
 	}
 
-	business.IsActive = req.Value
+	// The owner is part of the cached business
+	s.invalidateBusinessCache(ctx, req.BusinessID)
+	s.invalidateListCache(ctx)
+
+	return nil
+}
+
+// updateStatus stores the new status of the business and drops it from the caches, as it may
+// have entered or left the public listings
+func (s *BusinessService) updateStatus(ctx context.Context, business *domain.Business, from domain.BusinessStatus) error {
+	if err := s.businessRepo.UpdateStatus(ctx, business, from); err != nil {
+		if err == domain.ErrInvalidBusinessTransition {
+			return domain.ErrInvalidBusinessTransition
+		}
 
-	if err := s.businessRepo.UpdateProperty(ctx, req.ID, domain.BusinessIsActive, req.Value); err != nil {
-		s.logger.Errorw("failed to update business active status", "id", req.ID, "error", err)
+		s.logger.Errorw("failed to update business status", "id", business.ID, "status", business.Status, "error", err)
 		return response.ErrInternalServerError
 	}
 
-	// Invalidate caches
-	s.invalidateBusinessCache(ctx, req.ID)
+	s.invalidateBusinessCache(ctx, business.ID)
 	s.invalidateListCache(ctx)
 
 	return nil
@@ -217,32 +399,53 @@ func (s *BusinessService) UpdateActiveStatus(ctx context.Context, req *dto.Busin
 
 // buildListCacheKey generates a unique cache key based on filter parameters
 func (s *BusinessService) buildListCacheKey(req *dto.BusinessListRequest) string {
-	// Serialize the filter to JSON and hash it for a consistent key
+	// Serialize the filter to JSON and hash it for a consistent key. The scope, the member and the
+	// ordering are not part of the filter's JSON, so such lists are told apart explicitly.
 	filterBytes, _ := json.Marshal(req)
+	if req.Scope != nil {
+		scopeBytes, _ := json.Marshal(req.Scope)
+		filterBytes = append(filterBytes, scopeBytes...)
+	}
+	if req.MemberID != nil {
+		filterBytes = append(filterBytes, req.MemberID.String()...)
+	}
+	if req.ModerationQueue {
+		filterBytes = append(filterBytes, "queue"...)
+	}
 	hash := sha256.Sum256(filterBytes)
 	return s.cache.BuildKey(storage.CACHE_PREFIX_BUSINESS_LIST, hex.EncodeToString(hash[:8]))
 }
 
 // invalidateBusinessCache removes a specific business from cache
 func (s *BusinessService) invalidateBusinessCache(ctx context.Context, id uuid.UUID) {
-	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_BUSINESS, id.String())
-	if err := s.cache.Del(ctx, cacheKey); err != nil && !errors.Is(err, storage.ErrCacheMiss) {
-		s.logger.Warnw("failed to invalidate business cache", "id", id, "error", err)
+	invalidateBusinessCache(ctx, s.logger, s.cache, id)
+}
+
+// invalidateBusinessCache removes the cached business, for the services changing what is read along with it
+func invalidateBusinessCache(ctx context.Context, logger *zap.SugaredLogger, cache storage.CacheStorage, id uuid.UUID) {
+	cacheKey := cache.BuildKey(storage.CACHE_PREFIX_BUSINESS, id.String())
+	if err := cache.Del(ctx, cacheKey); err != nil && !errors.Is(err, storage.ErrCacheMiss) {
+		logger.Warnw("failed to invalidate business cache", "id", id, "error", err)
 	}
 }
 
 // invalidateListCache removes all business list caches using scan and delete
 func (s *BusinessService) invalidateListCache(ctx context.Context) {
-	pattern := s.cache.BuildKey(storage.CACHE_PREFIX_BUSINESS_LIST, "*")
-	keys, err := s.cache.Scan(ctx, pattern)
+	invalidateBusinessListCache(ctx, s.logger, s.cache)
+}
+
+// invalidateBusinessListCache removes the cached business lists, for the services changing what they list
+func invalidateBusinessListCache(ctx context.Context, logger *zap.SugaredLogger, cache storage.CacheStorage) {
+	pattern := cache.BuildKey(storage.CACHE_PREFIX_BUSINESS_LIST, "*")
+	keys, err := cache.Scan(ctx, pattern)
 	if err != nil {
-		s.logger.Warnw("failed to scan business list cache keys", "error", err)
+		logger.Warnw("failed to scan business list cache keys", "error", err)
 		return
 	}
 
 	for _, key := range keys {
-		if err := s.cache.Del(ctx, key); err != nil && !errors.Is(err, storage.ErrCacheMiss) {
-			s.logger.Warnw("failed to invalidate business list cache", "key", key, "error", err)
+		if err := cache.Del(ctx, key); err != nil && !errors.Is(err, storage.ErrCacheMiss) {
+			logger.Warnw("failed to invalidate business list cache", "key", key, "error", err)
 		}
 	}
 }
@@ -2,6 +2,7 @@ package application
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
 	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
 	"github.com/google/uuid"
@@ -65,11 +67,79 @@ func (m *MockBusinessRepository) Count(ctx context.Context, filter *domain.Busin
 	return args.Int(0), args.Error(1)
 }
 
-func (m *MockBusinessRepository) UpdateProperty(ctx context.Context, id uuid.UUID, property domain.BusinessProperty, value any) error {
-	args := m.Called(ctx, id, property, value)
+func (m *MockBusinessRepository) Facets(ctx context.Context, filter *domain.BusinessFilters) (domain.Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
+func (m *MockBusinessRepository) UpdateStatus(ctx context.Context, business *domain.Business, from domain.BusinessStatus) error {
+	args := m.Called(ctx, business, from)
+	return args.Error(0)
+}
+
+func (m *MockBusinessRepository) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	return fn(nil)
+}
+
+// MockBusinessMemberRepository
+type MockBusinessMemberRepository struct {
+	mock.Mock
+}
+
+func (m *MockBusinessMemberRepository) Create(tx *sqlx.Tx, member *domain.BusinessMember) error {
+	args := m.Called(tx, member)
 	return args.Error(0)
 }
 
+func (m *MockBusinessMemberRepository) GetByBusinessAndUser(ctx context.Context, businessID, userID uuid.UUID) (*domain.BusinessMember, error) {
+	args := m.Called(ctx, businessID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.BusinessMember), args.Error(1)
+}
+
+func (m *MockBusinessMemberRepository) ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessMember, error) {
+	args := m.Called(ctx, businessID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.BusinessMember), args.Error(1)
+}
+
+func (m *MockBusinessMemberRepository) Accept(ctx context.Context, businessID, userID uuid.UUID) error {
+	args := m.Called(ctx, businessID, userID)
+	return args.Error(0)
+}
+
+func (m *MockBusinessMemberRepository) UpdateRole(ctx context.Context, businessID, userID uuid.UUID, role domain.BusinessRole) error {
+	args := m.Called(ctx, businessID, userID, role)
+	return args.Error(0)
+}
+
+func (m *MockBusinessMemberRepository) Delete(ctx context.Context, businessID, userID uuid.UUID) error {
+	args := m.Called(ctx, businessID, userID)
+	return args.Error(0)
+}
+
+func (m *MockBusinessMemberRepository) TransferOwnership(ctx context.Context, businessID, fromUserID, toUserID uuid.UUID) error {
+	args := m.Called(ctx, businessID, fromUserID, toUserID)
+	return args.Error(0)
+}
+
+// activeMember returns an accepted membership with the role
+func activeMember(businessID, userID uuid.UUID, role domain.BusinessRole) *domain.BusinessMember {
+	return &domain.BusinessMember{
+		BusinessID: businessID,
+		UserID:     userID,
+		Role:       role,
+		AcceptedAt: sql.NullTime{Time: time.Now(), Valid: true},
+	}
+}
+
 // MockCacheStorage
 type MockCacheStorage struct {
 	mock.Mock
@@ -128,11 +198,27 @@ func (m *MockCacheStorage) Exists(ctx context.Context, key string) (bool, error)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCacheStorage) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	args := m.Called(ctx, key, expiration)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCacheStorage) HIncr(ctx context.Context, key string, field string) (int64, error) {
+	args := m.Called(ctx, key, field)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockCacheStorage) TTL(ctx context.Context, key string) (time.Duration, error) {
+	args := m.Called(ctx, key)
+	return args.Get(0).(time.Duration), args.Error(1)
+}
+
 func TestBusinessService_Create(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockBusinessRepository)
+	mockMemberRepo := new(MockBusinessMemberRepository)
 	mockCache := new(MockCacheStorage)
-	service := NewBusinessService(logger, mockCache, mockRepo)
+	service := NewBusinessService(logger, mockCache, mockRepo, mockMemberRepo, new(MockBusinessHoursRepository))
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -148,6 +234,9 @@ func TestBusinessService_Create(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Business")).Return(nil)
+		mockMemberRepo.On("Create", (*sqlx.Tx)(nil), mock.MatchedBy(func(m *domain.BusinessMember) bool {
+			return m.UserID == userID && m.Role == domain.BusinessRoleOwner && m.IsActive()
+		})).Return(nil)
 		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
 		mockCache.On("Scan", ctx, "business_list:*").Return([]string{}, nil)
 
@@ -156,7 +245,9 @@ func TestBusinessService_Create(t *testing.T) {
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
 		assert.Equal(t, req.Name, result.Name)
+		assert.Equal(t, domain.BusinessStatusDraft, result.Status)
 		mockRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure", func(t *testing.T) {
@@ -176,8 +267,9 @@ func TestBusinessService_Create(t *testing.T) {
 func TestBusinessService_Update(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockBusinessRepository)
+	mockMemberRepo := new(MockBusinessMemberRepository)
 	mockCache := new(MockCacheStorage)
-	service := NewBusinessService(logger, mockCache, mockRepo)
+	service := NewBusinessService(logger, mockCache, mockRepo, mockMemberRepo, new(MockBusinessHoursRepository))
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -190,7 +282,6 @@ func TestBusinessService_Update(t *testing.T) {
 		Name:        "Updated Name",
 		Description: "Updated Desc",
 		Email:       "updated@email.com",
-		IsActive:    true,
 	}
 
 	existingBusiness := &domain.Business{
@@ -202,6 +293,7 @@ func TestBusinessService_Update(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleManager), nil)
 		mockRepo.On("Update", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Business")).Return(nil)
 		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business:" + id.String())
 		mockCache.On("Del", ctx, "business:"+id.String()).Return(nil)
@@ -228,9 +320,10 @@ func TestBusinessService_Update(t *testing.T) {
 
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockCache.ExpectedCalls = nil
-		existingBusiness.UserID = uuid.New()
 		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		err := service.Update(ctx, req)
 
@@ -238,13 +331,38 @@ func TestBusinessService_Update(t *testing.T) {
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("EditorCannotEdit", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
+		mockCache.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleEditor), nil)
+
+		err := service.Update(ctx, req)
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+
+	t.Run("PendingInvitation", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
+		mockCache.ExpectedCalls = nil
+		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(&domain.BusinessMember{BusinessID: id, UserID: userID, Role: domain.BusinessRoleManager}, nil)
+
+		err := service.Update(ctx, req)
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
 }
 
 func TestBusinessService_Delete(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockBusinessRepository)
+	mockMemberRepo := new(MockBusinessMemberRepository)
 	mockCache := new(MockCacheStorage)
-	service := NewBusinessService(logger, mockCache, mockRepo)
+	service := NewBusinessService(logger, mockCache, mockRepo, mockMemberRepo, new(MockBusinessHoursRepository))
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -258,6 +376,7 @@ func TestBusinessService_Delete(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleOwner), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(nil)
 		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business:" + id.String())
 		mockCache.On("Del", ctx, "business:"+id.String()).Return(nil)
@@ -274,6 +393,7 @@ func TestBusinessService_Delete(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
 		mockCache.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleOwner), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(errors.New("db error"))
 
 		err := service.Delete(ctx, id)
@@ -284,9 +404,10 @@ func TestBusinessService_Delete(t *testing.T) {
 
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockCache.ExpectedCalls = nil
-		existingBusiness.UserID = uuid.New()
 		mockRepo.On("GetByID", ctx, id).Return(existingBusiness, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleManager), nil)
 
 		err := service.Delete(ctx, id)
 
@@ -299,8 +420,9 @@ func TestBusinessService_Delete(t *testing.T) {
 func TestBusinessService_GetByID(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockBusinessRepository)
+	mockHoursRepo := new(MockBusinessHoursRepository)
 	mockCache := new(MockCacheStorage)
-	service := NewBusinessService(logger, mockCache, mockRepo)
+	service := NewBusinessService(logger, mockCache, mockRepo, new(MockBusinessMemberRepository), mockHoursRepo)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -314,6 +436,7 @@ func TestBusinessService_GetByID(t *testing.T) {
 		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return(cacheKey)
 		mockCache.On("Get", ctx, cacheKey, mock.AnythingOfType("*domain.Business")).Return(errors.New("cache miss"))
 		mockRepo.On("GetByID", ctx, id).Return(expectedBusiness, nil)
+		mockHoursRepo.On("GetSchedule", ctx, id).Return(&domain.BusinessSchedule{}, nil)
 		mockCache.On("Set", ctx, cacheKey, expectedBusiness, mock.Anything).Return(nil)
 
 		result, err := service.GetByID(ctx, id)
@@ -344,7 +467,7 @@ func TestBusinessService_List(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockBusinessRepository)
 	mockCache := new(MockCacheStorage)
-	service := NewBusinessService(logger, mockCache, mockRepo)
+	service := NewBusinessService(logger, mockCache, mockRepo, new(MockBusinessMemberRepository), new(MockBusinessHoursRepository))
 	ctx := context.Background()
 
 	req := &dto.BusinessListRequest{
@@ -389,4 +512,255 @@ func TestBusinessService_List(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("ScopedListsAreCachedApart", func(t *testing.T) {
+		mockCache.ExpectedCalls = nil
+		var hashes []string
+		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).
+			Run(func(args mock.Arguments) { hashes = append(hashes, args.Get(1).([]string)[0]) }).
+			Return("business_list")
+		mockCache.On("Get", ctx, "business_list", mock.AnythingOfType("*dto.BusinessListResponse")).Return(nil)
+
+		scoped := *req
+		scoped.Scope = &domain.ChurchScope{Diocese: sql.NullString{String: "Diocese of Rome", Valid: true}}
+
+		_, err := service.List(ctx, req)
+		assert.NoError(t, err)
+		_, err = service.List(ctx, &scoped)
+		assert.NoError(t, err)
+
+		assert.Len(t, hashes, 2)
+		assert.NotEqual(t, hashes[0], hashes[1])
+	})
+
+	t.Run("ModerationQueueCursor", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		mockCache.ExpectedCalls = nil
+
+		queueReq := &dto.BusinessListRequest{ModerationQueue: true, Cursor: &pagination.Cursor{}}
+
+		result, err := service.List(ctx, queueReq)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidSort, err)
+		mockRepo.AssertNotCalled(t, "List", ctx, queueReq)
+	})
+}
+
+func TestBusinessService_Submit(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	userID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: userID})
+	id := uuid.New()
+
+	setup := func() (*BusinessService, *MockBusinessRepository, *MockCacheStorage) {
+		mockRepo := new(MockBusinessRepository)
+		mockMemberRepo := new(MockBusinessMemberRepository)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleManager), nil)
+		mockCache := new(MockCacheStorage)
+		return NewBusinessService(logger, mockCache, mockRepo, mockMemberRepo, new(MockBusinessHoursRepository)), mockRepo, mockCache
+	}
+
+	for _, status := range []domain.BusinessStatus{domain.BusinessStatusDraft, domain.BusinessStatusRejected} {
+		t.Run("From "+string(status), func(t *testing.T) {
+			service, mockRepo, mockCache := setup()
+			business := &domain.Business{ID: id, UserID: userID, Status: status, StatusReason: sql.NullString{String: "Missing logo", Valid: true}}
+			mockRepo.On("GetByID", ctx, id).Return(business, nil)
+			mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(b *domain.Business) bool {
+				return b.Status == domain.BusinessStatusPendingReview && b.SubmittedAt.Valid && !b.StatusReason.Valid
+			}), status).Return(nil)
+			mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business:" + id.String())
+			mockCache.On("Del", ctx, "business:"+id.String()).Return(nil)
+			mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
+			mockCache.On("Scan", ctx, "business_list:*").Return([]string{}, nil)
+
+			result, err := service.Submit(ctx, id)
+
+			assert.NoError(t, err)
+			assert.Equal(t, domain.BusinessStatusPendingReview, result.Status)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("AlreadyApproved", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id, UserID: userID, Status: domain.BusinessStatusApproved}, nil)
+
+		result, err := service.Submit(ctx, id)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidBusinessTransition, err)
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("Unauthorized", func(t *testing.T) {
+		mockRepo := new(MockBusinessRepository)
+		mockMemberRepo := new(MockBusinessMemberRepository)
+		service := NewBusinessService(logger, new(MockCacheStorage), mockRepo, mockMemberRepo, new(MockBusinessHoursRepository))
+		mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id, UserID: uuid.New(), Status: domain.BusinessStatusDraft}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, userID).Return(activeMember(id, userID, domain.BusinessRoleEditor), nil)
+
+		result, err := service.Submit(ctx, id)
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
+}
+
+func TestBusinessService_Moderate(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	ctx := context.Background()
+	id := uuid.New()
+	reviewerID := uuid.New()
+
+	setup := func() (*BusinessService, *MockBusinessRepository, *MockCacheStorage) {
+		mockRepo := new(MockBusinessRepository)
+		mockCache := new(MockCacheStorage)
+		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business:" + id.String())
+		mockCache.On("Del", ctx, "business:"+id.String()).Return(nil)
+		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
+		mockCache.On("Scan", ctx, "business_list:*").Return([]string{}, nil)
+		return NewBusinessService(logger, mockCache, mockRepo, new(MockBusinessMemberRepository), new(MockBusinessHoursRepository)), mockRepo, mockCache
+	}
+
+	tests := []struct {
+		name   string
+		from   domain.BusinessStatus
+		to     domain.BusinessStatus
+		reason string
+	}{
+		{name: "Approve", from: domain.BusinessStatusPendingReview, to: domain.BusinessStatusApproved},
+		{name: "Reject", from: domain.BusinessStatusPendingReview, to: domain.BusinessStatusRejected, reason: "Not a real business"},
+		{name: "Suspend", from: domain.BusinessStatusApproved, to: domain.BusinessStatusSuspended, reason: "Reported by parishioners"},
+		{name: "Reinstate", from: domain.BusinessStatusSuspended, to: domain.BusinessStatusApproved},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, mockRepo, _ := setup()
+			mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id, Status: tt.from}, nil)
+			mockRepo.On("UpdateStatus", ctx, mock.MatchedBy(func(b *domain.Business) bool {
+				return b.Status == tt.to && b.StatusReason.String == tt.reason && b.ReviewerID.UUID == reviewerID && b.ReviewedAt.Valid
+			}), tt.from).Return(nil)
+
+			result, err := service.Moderate(ctx, &dto.BusinessModerateRequest{ID: id, ReviewerID: reviewerID, Status: tt.to, Reason: tt.reason})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.to, result.Status)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+
+	t.Run("ReasonRequired", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+
+		result, err := service.Moderate(ctx, &dto.BusinessModerateRequest{ID: id, ReviewerID: reviewerID, Status: domain.BusinessStatusSuspended, Reason: " "})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrBusinessStatusReasonMissing, err)
+		mockRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("OwnerStatus", func(t *testing.T) {
+		service, _, _ := setup()
+
+		result, err := service.Moderate(ctx, &dto.BusinessModerateRequest{ID: id, ReviewerID: reviewerID, Status: domain.BusinessStatusPendingReview})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidBusinessStatus, err)
+	})
+
+	t.Run("InvalidTransition", func(t *testing.T) {
+		service, mockRepo, _ := setup()
+		mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id, Status: domain.BusinessStatusDraft}, nil)
+
+		result, err := service.Moderate(ctx, &dto.BusinessModerateRequest{ID: id, ReviewerID: reviewerID, Status: domain.BusinessStatusApproved})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidBusinessTransition, err)
+		mockRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ModeratedConcurrently", func(t *testing.T) {
+		service, mockRepo, mockCache := setup()
+		mockRepo.On("GetByID", ctx, id).Return(&domain.Business{ID: id, Status: domain.BusinessStatusPendingReview}, nil)
+		mockRepo.On("UpdateStatus", ctx, mock.Anything, domain.BusinessStatusPendingReview).Return(domain.ErrInvalidBusinessTransition)
+
+		result, err := service.Moderate(ctx, &dto.BusinessModerateRequest{ID: id, ReviewerID: reviewerID, Status: domain.BusinessStatusApproved})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidBusinessTransition, err)
+		mockCache.AssertNotCalled(t, "Del", mock.Anything, mock.Anything)
+	})
+}
+
+func TestBusinessService_TransferOwnership(t *testing.T) {
+	logger := zap.NewNop().Sugar()
+	ownerID := uuid.New()
+	ctx := context.WithValue(context.Background(), auth.UserContextKey, &userDto.UserAsContext{ID: ownerID})
+	id := uuid.New()
+	newOwnerID := uuid.New()
+
+	setup := func() (*BusinessService, *MockBusinessMemberRepository, *MockCacheStorage) {
+		mockMemberRepo := new(MockBusinessMemberRepository)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, ownerID).Return(activeMember(id, ownerID, domain.BusinessRoleOwner), nil)
+		mockCache := new(MockCacheStorage)
+		return NewBusinessService(logger, mockCache, new(MockBusinessRepository), mockMemberRepo, new(MockBusinessHoursRepository)), mockMemberRepo, mockCache
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, mockMemberRepo, mockCache := setup()
+		newOwner := activeMember(id, newOwnerID, domain.BusinessRoleManager)
+		newOwner.IsEntrepreneur = true
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, newOwnerID).Return(newOwner, nil)
+		mockMemberRepo.On("TransferOwnership", ctx, id, ownerID, newOwnerID).Return(nil)
+		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS, mock.Anything).Return("business:" + id.String())
+		mockCache.On("Del", ctx, "business:"+id.String()).Return(nil)
+		mockCache.On("BuildKey", storage.CACHE_PREFIX_BUSINESS_LIST, mock.Anything).Return("business_list:*")
+		mockCache.On("Scan", ctx, "business_list:*").Return([]string{}, nil)
+
+		err := service.TransferOwnership(ctx, &dto.BusinessTransferOwnershipRequest{BusinessID: id, UserID: newOwnerID})
+
+		assert.NoError(t, err)
+		mockMemberRepo.AssertExpectations(t)
+	})
+
+	t.Run("ToSelf", func(t *testing.T) {
+		service, mockMemberRepo, _ := setup()
+
+		err := service.TransferOwnership(ctx, &dto.BusinessTransferOwnershipRequest{BusinessID: id, UserID: ownerID})
+
+		assert.Equal(t, domain.ErrInvalidInput, err)
+		mockMemberRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("PendingInvitation", func(t *testing.T) {
+		service, mockMemberRepo, _ := setup()
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, newOwnerID).Return(&domain.BusinessMember{BusinessID: id, UserID: newOwnerID, Role: domain.BusinessRoleEditor}, nil)
+
+		err := service.TransferOwnership(ctx, &dto.BusinessTransferOwnershipRequest{BusinessID: id, UserID: newOwnerID})
+
+		assert.Equal(t, domain.ErrBusinessMemberNotFound, err)
+		mockMemberRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotEntrepreneur", func(t *testing.T) {
+		service, mockMemberRepo, _ := setup()
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, newOwnerID).Return(activeMember(id, newOwnerID, domain.BusinessRoleManager), nil)
+
+		err := service.TransferOwnership(ctx, &dto.BusinessTransferOwnershipRequest{BusinessID: id, UserID: newOwnerID})
+
+		assert.Equal(t, domain.ErrOwnerNotEntrepreneur, err)
+		mockMemberRepo.AssertNotCalled(t, "TransferOwnership", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NotOwner", func(t *testing.T) {
+		mockMemberRepo := new(MockBusinessMemberRepository)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, id, ownerID).Return(activeMember(id, ownerID, domain.BusinessRoleManager), nil)
+		service := NewBusinessService(logger, new(MockCacheStorage), new(MockBusinessRepository), mockMemberRepo, new(MockBusinessHoursRepository))
+
+		err := service.TransferOwnership(ctx, &dto.BusinessTransferOwnershipRequest{BusinessID: id, UserID: newOwnerID})
+
+		assert.Equal(t, domain.ErrUnauthorized, err)
+	})
 }
@@ -3,11 +3,11 @@ package application
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
-	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
-	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -17,29 +17,29 @@ type JobService struct {
 	logger       *zap.SugaredLogger
 	jobRepo      domain.JobRepository
 	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
 }
 
-func NewJobService(logger *zap.SugaredLogger, jobRepo domain.JobRepository, businessRepo domain.BusinessRepository) *JobService {
+func NewJobService(logger *zap.SugaredLogger, jobRepo domain.JobRepository, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository) *JobService {
 	return &JobService{
 		logger:       logger,
 		jobRepo:      jobRepo,
 		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
 	}
 }
 
 func (s *JobService) Create(ctx context.Context, req *dto.JobCreateRequest) (*domain.Job, error) {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, req.BusinessID)
-	if err != nil {
+	if _, err := s.businessRepo.GetByID(ctx, req.BusinessID); err != nil {
 		if err == domain.ErrBusinessNotFound {
 			return nil, domain.ErrBusinessNotFound
 		}
 		return nil, err
 	}
 
-	if business.UserID != userCtx.ID {
-		return nil, domain.ErrUnauthorized
+	// Any member may manage the catalog of the business
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionCatalog); err != nil {
+		return nil, err
 	}
 
 	job := &domain.Job{
@@ -61,22 +61,15 @@ func (s *JobService) Create(ctx context.Context, req *dto.JobCreateRequest) (*do
 }
 
 func (s *JobService) Update(ctx context.Context, req *dto.JobUpdateRequest) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	job, err := s.jobRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, job.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, job.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	job.Title = req.Title
 	job.Description = req.Description
 	job.Type = req.Type
@@ -93,22 +86,15 @@ func (s *JobService) Update(ctx context.Context, req *dto.JobUpdateRequest) erro
 }
 
 func (s *JobService) Delete(ctx context.Context, id uuid.UUID) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	job, err := s.jobRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, job.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, job.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	if err := s.jobRepo.Delete(nil, id); err != nil {
 		s.logger.Errorw("failed to delete job", "id", id, "error", err)
 		return response.ErrInternalServerError
@@ -117,8 +103,9 @@ func (s *JobService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetByID retrieves the job for public view, which only jobs of approved businesses are.
 func (s *JobService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
-	job, err := s.jobRepo.GetByID(ctx, id)
+	job, err := s.jobRepo.GetApprovedByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrJobNotFound {
 			return nil, err
@@ -132,14 +119,26 @@ func (s *JobService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, er
 }
 
 func (s *JobService) List(ctx context.Context, req *dto.JobListRequest) (*dto.JobListResponse, error) {
+	if err := req.ValidateListOptions(); err != nil {
+		return nil, err
+	}
+
 	jobs, err := s.jobRepo.List(ctx, req)
 	if err != nil && err != domain.ErrJobNotFound {
 		s.logger.Errorw("failed to list jobs", "error", err)
 		return nil, response.ErrInternalServerError
 	}
 
+	var page pagination.Page
+	if req.Cursor != nil {
+		jobs, page = pagination.Trim(jobs, req.Cursor, pagination.Limit(req.Limit), func(j *domain.Job) (time.Time, uuid.UUID) {
+			return j.CreatedAt, j.ID
+		})
+	}
+
+	// Cursor pages skip the count, which would read the whole list on every page
 	count := 0
-	if len(jobs) > 0 {
+	if len(jobs) > 0 && req.Cursor == nil {
 		count, err = s.jobRepo.Count(ctx, req)
 		if err != nil {
 			s.logger.Errorw("failed to count jobs", "error", err)
@@ -147,10 +146,21 @@ func (s *JobService) List(ctx context.Context, req *dto.JobListRequest) (*dto.Jo
 		}
 	}
 
+	var facets domain.Facets
+	if len(req.Facets) > 0 {
+		facets, err = s.jobRepo.Facets(ctx, req)
+		if err != nil {
+			s.logger.Errorw("failed to count job facets", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
 	return &dto.JobListResponse{
 		Jobs:   jobs,
 		Count:  count,
 		Limit:  req.Limit,
 		Offset: req.Offset,
+		Facets: facets,
+		Page:   page,
 	}, nil
 }
@@ -50,6 +50,14 @@ func (m *MockJobRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.
 	return args.Get(0).(*domain.Job), args.Error(1)
 }
 
+func (m *MockJobRepository) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Job), args.Error(1)
+}
+
 func (m *MockJobRepository) List(ctx context.Context, filter *domain.JobFilters) ([]*domain.Job, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
@@ -63,11 +71,20 @@ func (m *MockJobRepository) Count(ctx context.Context, filter *domain.JobFilters
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockJobRepository) Facets(ctx context.Context, filter *domain.JobFilters) (domain.Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
 func TestJobService_Create(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockJobRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewJobService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -82,7 +99,8 @@ func TestJobService_Create(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Job")).Return(nil)
 
 		result, err := service.Create(ctx, req)
@@ -92,10 +110,12 @@ func TestJobService_Create(t *testing.T) {
 		assert.Equal(t, req.Title, result.Title)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_BusinessNotFound", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
 		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(nil, domain.ErrBusinessNotFound)
 
@@ -105,12 +125,15 @@ func TestJobService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrBusinessNotFound, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_Unauthorized", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: uuid.New()}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		result, err := service.Create(ctx, req)
 
@@ -118,12 +141,15 @@ func TestJobService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_RepoError", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Job")).Return(errors.New("db error"))
 
 		result, err := service.Create(ctx, req)
@@ -133,6 +159,7 @@ func TestJobService_Create(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -140,7 +167,8 @@ func TestJobService_Update(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockJobRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewJobService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -163,19 +191,19 @@ func TestJobService_Update(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingJob, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Update", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Job")).Return(nil)
 
 		err := service.Update(ctx, req)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrJobNotFound)
 
 		err := service.Update(ctx, req)
@@ -187,16 +215,16 @@ func TestJobService_Update(t *testing.T) {
 
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingJob, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: uuid.New()}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		err := service.Update(ctx, req)
 
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -204,7 +232,8 @@ func TestJobService_Delete(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockJobRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewJobService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -219,28 +248,28 @@ func TestJobService_Delete(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingJob, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(nil)
 
 		err := service.Delete(ctx, id)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingJob, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(errors.New("db error"))
 
 		err := service.Delete(ctx, id)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -248,7 +277,8 @@ func TestJobService_GetByID(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockJobRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewJobService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -258,7 +288,7 @@ func TestJobService_GetByID(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.On("GetByID", ctx, id).Return(expectedJob, nil)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(expectedJob, nil)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -269,7 +299,7 @@ func TestJobService_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrJobNotFound)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(nil, domain.ErrJobNotFound)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -284,7 +314,8 @@ func TestJobService_List(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockJobRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewJobService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 
 	req := &dto.JobListRequest{
@@ -321,4 +352,45 @@ func TestJobService_List(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Facets", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		remote := domain.JobLocationRemote
+		facetReq := &dto.JobListRequest{
+			Locations: []domain.JobLocation{remote},
+			Facets:    []string{"type", "location"},
+		}
+		facets := domain.Facets{
+			"type":     {{Value: string(domain.JobTypeFullTime), Count: 3}},
+			"location": {{Value: string(domain.JobLocationOnSite), Count: 2}},
+		}
+		mockRepo.On("List", ctx, facetReq).Return([]*domain.Job{}, nil)
+		mockRepo.On("Facets", ctx, facetReq).Return(facets, nil)
+
+		result, err := service.List(ctx, facetReq)
+
+		// The other locations are counted even though no remote job matches
+		assert.NoError(t, err)
+		assert.Equal(t, 0, result.Count)
+		assert.Equal(t, facets, result.Facets)
+		mockRepo.AssertNotCalled(t, "Count", ctx, facetReq)
+	})
+
+	t.Run("InvalidFacet", func(t *testing.T) {
+		mockRepo := new(MockJobRepository)
+		service := NewJobService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
+
+		result, err := service.List(ctx, &dto.JobListRequest{Facets: []string{"price"}})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidFacet, err)
+		mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidSort", func(t *testing.T) {
+		result, err := service.List(ctx, &dto.JobListRequest{SortBy: "title", SortOrder: "sideways"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidSort, err)
+	})
 }
@@ -3,11 +3,11 @@ package application
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
-	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
-	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -17,29 +17,29 @@ type ProductService struct {
 	logger       *zap.SugaredLogger
 	productRepo  domain.ProductRepository
 	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
 }
 
-func NewProductService(logger *zap.SugaredLogger, productRepo domain.ProductRepository, businessRepo domain.BusinessRepository) *ProductService {
+func NewProductService(logger *zap.SugaredLogger, productRepo domain.ProductRepository, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository) *ProductService {
 	return &ProductService{
 		logger:       logger,
 		productRepo:  productRepo,
 		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
 	}
 }
 
 func (s *ProductService) Create(ctx context.Context, req *dto.ProductCreateRequest) (*domain.Product, error) {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, req.BusinessID)
-	if err != nil {
+	if _, err := s.businessRepo.GetByID(ctx, req.BusinessID); err != nil {
 		if err == domain.ErrBusinessNotFound {
 			return nil, domain.ErrBusinessNotFound
 		}
 		return nil, err
 	}
 
-	if business.UserID != userCtx.ID {
-		return nil, domain.ErrUnauthorized
+	// Any member may manage the catalog of the business
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionCatalog); err != nil {
+		return nil, err
 	}
 
 	product := &domain.Product{
@@ -60,22 +60,15 @@ func (s *ProductService) Create(ctx context.Context, req *dto.ProductCreateReque
 }
 
 func (s *ProductService) Update(ctx context.Context, req *dto.ProductUpdateRequest) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	product, err := s.productRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, product.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, product.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	product.Name = req.Name
 	product.Description = req.Description
 	product.Price = req.Price
@@ -91,22 +84,15 @@ func (s *ProductService) Update(ctx context.Context, req *dto.ProductUpdateReque
 }
 
 func (s *ProductService) Delete(ctx context.Context, id uuid.UUID) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	product, err := s.productRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, product.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, product.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	if err := s.productRepo.Delete(nil, id); err != nil {
 		s.logger.Errorw("failed to delete product", "id", id, "error", err)
 		return response.ErrInternalServerError
@@ -115,8 +101,9 @@ func (s *ProductService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetByID retrieves the product for public view, which only products of approved businesses are.
 func (s *ProductService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
-	product, err := s.productRepo.GetByID(ctx, id)
+	product, err := s.productRepo.GetApprovedByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrProductNotFound {
 			return nil, err
@@ -130,14 +117,26 @@ func (s *ProductService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Pro
 }
 
 func (s *ProductService) List(ctx context.Context, req *dto.ProductListRequest) (*dto.ProductListResponse, error) {
+	if err := req.ValidateListOptions(); err != nil {
+		return nil, err
+	}
+
 	products, err := s.productRepo.List(ctx, req)
 	if err != nil && err != domain.ErrProductNotFound {
 		s.logger.Errorw("failed to list products", "error", err)
 		return nil, response.ErrInternalServerError
 	}
 
+	var page pagination.Page
+	if req.Cursor != nil {
+		products, page = pagination.Trim(products, req.Cursor, pagination.Limit(req.Limit), func(p *domain.Product) (time.Time, uuid.UUID) {
+			return p.CreatedAt, p.ID
+		})
+	}
+
+	// Cursor pages skip the count, which would read the whole list on every page
 	count := 0
-	if len(products) > 0 {
+	if len(products) > 0 && req.Cursor == nil {
 		count, err = s.productRepo.Count(ctx, req)
 		if err != nil {
 			s.logger.Errorw("failed to count products", "error", err)
@@ -145,10 +144,21 @@ func (s *ProductService) List(ctx context.Context, req *dto.ProductListRequest)
 		}
 	}
 
+	var facets domain.Facets
+	if len(req.Facets) > 0 {
+		facets, err = s.productRepo.Facets(ctx, req)
+		if err != nil {
+			s.logger.Errorw("failed to count product facets", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
 	return &dto.ProductListResponse{
 		Products: products,
 		Count:    count,
 		Limit:    req.Limit,
 		Offset:   req.Offset,
+		Facets:   facets,
+		Page:     page,
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
 	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -50,6 +51,14 @@ func (m *MockProductRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return args.Get(0).(*domain.Product), args.Error(1)
 }
 
+func (m *MockProductRepository) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Product), args.Error(1)
+}
+
 func (m *MockProductRepository) List(ctx context.Context, filter *domain.ProductFilters) ([]*domain.Product, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
@@ -63,11 +72,20 @@ func (m *MockProductRepository) Count(ctx context.Context, filter *domain.Produc
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockProductRepository) Facets(ctx context.Context, filter *domain.ProductFilters) (domain.Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
 func TestProductService_Create(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockProductRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewProductService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewProductService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -82,7 +100,8 @@ func TestProductService_Create(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Product")).Return(nil)
 
 		result, err := service.Create(ctx, req)
@@ -92,10 +111,12 @@ func TestProductService_Create(t *testing.T) {
 		assert.Equal(t, req.Name, result.Name)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_BusinessNotFound", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
 		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(nil, domain.ErrBusinessNotFound)
 
@@ -105,12 +126,15 @@ func TestProductService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrBusinessNotFound, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_Unauthorized", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: uuid.New()}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		result, err := service.Create(ctx, req)
 
@@ -118,12 +142,15 @@ func TestProductService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_RepoError", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Product")).Return(errors.New("db error"))
 
 		result, err := service.Create(ctx, req)
@@ -133,6 +160,7 @@ func TestProductService_Create(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -140,7 +168,8 @@ func TestProductService_Update(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockProductRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewProductService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewProductService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -165,19 +194,19 @@ func TestProductService_Update(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingProduct, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Update", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Product")).Return(nil)
 
 		err := service.Update(ctx, req)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrProductNotFound)
 
 		err := service.Update(ctx, req)
@@ -189,16 +218,16 @@ func TestProductService_Update(t *testing.T) {
 
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingProduct, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: uuid.New()}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		err := service.Update(ctx, req)
 
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -206,7 +235,8 @@ func TestProductService_Delete(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockProductRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewProductService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewProductService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -221,28 +251,28 @@ func TestProductService_Delete(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingProduct, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(nil)
 
 		err := service.Delete(ctx, id)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingProduct, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(errors.New("db error"))
 
 		err := service.Delete(ctx, id)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -250,7 +280,8 @@ func TestProductService_GetByID(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockProductRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewProductService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewProductService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -260,7 +291,7 @@ func TestProductService_GetByID(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.On("GetByID", ctx, id).Return(expectedProduct, nil)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(expectedProduct, nil)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -271,7 +302,7 @@ func TestProductService_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrProductNotFound)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(nil, domain.ErrProductNotFound)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -286,7 +317,8 @@ func TestProductService_List(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockProductRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewProductService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewProductService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 
 	req := &dto.ProductListRequest{
@@ -323,4 +355,58 @@ func TestProductService_List(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("SortAndFacets", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		from, to := 25.0, 50.0
+		facetReq := &dto.ProductListRequest{
+			SortBy:    "price",
+			SortOrder: domain.SortOrderDesc,
+			Facets:    []string{"price"},
+		}
+		facets := domain.Facets{"price": {{Value: "25-50", From: &from, To: &to, Count: 2}}}
+		mockRepo.On("List", ctx, facetReq).Return(expectedProducts, nil)
+		mockRepo.On("Count", ctx, facetReq).Return(2, nil)
+		mockRepo.On("Facets", ctx, facetReq).Return(facets, nil)
+
+		result, err := service.List(ctx, facetReq)
+
+		assert.NoError(t, err)
+		assert.Equal(t, facets, result.Facets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("FacetsFailure", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		facetReq := &dto.ProductListRequest{Facets: []string{"business_id"}}
+		mockRepo.On("List", ctx, facetReq).Return(expectedProducts, nil)
+		mockRepo.On("Count", ctx, facetReq).Return(2, nil)
+		mockRepo.On("Facets", ctx, facetReq).Return(nil, errors.New("db error"))
+
+		result, err := service.List(ctx, facetReq)
+
+		assert.Nil(t, result)
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+
+	t.Run("Cursor", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		cursorReq := &dto.ProductListRequest{
+			Limit:  func() *int { i := 1; return &i }(),
+			Cursor: &pagination.Cursor{},
+		}
+		mockRepo.On("List", ctx, cursorReq).Return(expectedProducts, nil)
+
+		result, err := service.List(ctx, cursorReq)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Products, 1)
+		assert.Zero(t, result.Count)
+		mockRepo.AssertNotCalled(t, "Count", ctx, cursorReq)
+		assert.Nil(t, result.PrevCursor)
+		if assert.NotNil(t, result.NextCursor) {
+			assert.Equal(t, expectedProducts[0].ID, result.NextCursor.ID)
+		}
+		mockRepo.AssertExpectations(t)
+	})
 }
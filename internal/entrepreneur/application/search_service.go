@@ -0,0 +1,77 @@
+package application
+
+import (
+	"context"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSearchLimit = 20
+	maxSearchLimit     = 50
+	// maxSearchQueryLength keeps queries to a few sentences, longer ones are not searches
+	maxSearchQueryLength = 200
+)
+
+type SearchService struct {
+	logger     *zap.SugaredLogger
+	searchRepo domain.SearchRepository
+}
+
+func NewSearchService(logger *zap.SugaredLogger, searchRepo domain.SearchRepository) *SearchService {
+	return &SearchService{
+		logger:     logger,
+		searchRepo: searchRepo,
+	}
+}
+
+// Search finds the businesses, products, services and jobs matching the query, ranked by relevance.
+func (s *SearchService) Search(ctx context.Context, req *dto.SearchRequest) (*dto.SearchResponse, error) {
+	req.Query = strings.TrimSpace(req.Query)
+	if req.Query == "" || len(req.Query) > maxSearchQueryLength {
+		return nil, domain.ErrInvalidSearchQuery
+	}
+
+	for _, entity := range req.Types {
+		if !entity.IsValid() {
+			return nil, domain.ErrInvalidSearchType
+		}
+	}
+
+	if req.Limit == nil || *req.Limit <= 0 {
+		limit := defaultSearchLimit
+		req.Limit = &limit
+	} else if *req.Limit > maxSearchLimit {
+		limit := maxSearchLimit
+		req.Limit = &limit
+	}
+	if req.Offset != nil && *req.Offset < 0 {
+		req.Offset = nil
+	}
+
+	results, err := s.searchRepo.Search(ctx, req)
+	if err != nil {
+		s.logger.Errorw("failed to search", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	count := 0
+	if len(results) > 0 {
+		count, err = s.searchRepo.Count(ctx, req)
+		if err != nil {
+			s.logger.Errorw("failed to count search results", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
+	return &dto.SearchResponse{
+		Results: results,
+		Count:   count,
+		Limit:   req.Limit,
+		Offset:  req.Offset,
+	}, nil
+}
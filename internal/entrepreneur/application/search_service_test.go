@@ -0,0 +1,106 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+// MockSearchRepository
+type MockSearchRepository struct {
+	mock.Mock
+}
+
+func (m *MockSearchRepository) Search(ctx context.Context, filter *domain.SearchFilters) ([]*domain.SearchResult, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.SearchResult), args.Error(1)
+}
+
+func (m *MockSearchRepository) Count(ctx context.Context, filter *domain.SearchFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func TestSearchService_Search(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Success", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(zap.NewNop().Sugar(), mockRepo)
+		req := &dto.SearchRequest{Query: "  padaria  ", Types: []domain.SearchEntity{domain.SearchEntityBusiness}}
+		results := []*domain.SearchResult{
+			{Type: domain.SearchEntityBusiness, ID: uuid.New(), Title: "Padaria São José", Snippet: "<mark>Padaria</mark> artesanal"},
+		}
+		mockRepo.On("Search", ctx, req).Return(results, nil)
+		mockRepo.On("Count", ctx, req).Return(1, nil)
+
+		resp, err := service.Search(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, results, resp.Results)
+		assert.Equal(t, 1, resp.Count)
+		assert.Equal(t, "padaria", req.Query)
+		assert.Equal(t, defaultSearchLimit, *resp.Limit)
+	})
+
+	t.Run("LimitCapped", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(zap.NewNop().Sugar(), mockRepo)
+		limit := 1000
+		req := &dto.SearchRequest{Query: "bolo", Limit: &limit}
+		mockRepo.On("Search", ctx, req).Return([]*domain.SearchResult{}, nil)
+
+		resp, err := service.Search(ctx, req)
+
+		assert.NoError(t, err)
+		assert.Equal(t, maxSearchLimit, *resp.Limit)
+		assert.Equal(t, 0, resp.Count)
+		mockRepo.AssertNotCalled(t, "Count", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidQuery", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(zap.NewNop().Sugar(), mockRepo)
+
+		for _, query := range []string{"", "   ", strings.Repeat("a", maxSearchQueryLength+1)} {
+			resp, err := service.Search(ctx, &dto.SearchRequest{Query: query})
+
+			assert.Nil(t, resp)
+			assert.Equal(t, domain.ErrInvalidSearchQuery, err)
+		}
+		mockRepo.AssertNotCalled(t, "Search", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidType", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(zap.NewNop().Sugar(), mockRepo)
+
+		resp, err := service.Search(ctx, &dto.SearchRequest{Query: "bolo", Types: []domain.SearchEntity{"church"}})
+
+		assert.Nil(t, resp)
+		assert.Equal(t, domain.ErrInvalidSearchType, err)
+	})
+
+	t.Run("RepositoryError", func(t *testing.T) {
+		mockRepo := new(MockSearchRepository)
+		service := NewSearchService(zap.NewNop().Sugar(), mockRepo)
+		req := &dto.SearchRequest{Query: "bolo"}
+		mockRepo.On("Search", ctx, req).Return(nil, assert.AnError)
+
+		resp, err := service.Search(ctx, req)
+
+		assert.Nil(t, resp)
+		assert.Equal(t, response.ErrInternalServerError, err)
+	})
+}
@@ -2,11 +2,11 @@ package application
 
 import (
 	"context"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
-	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
-	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
@@ -16,29 +16,29 @@ type ServiceService struct {
 	logger       *zap.SugaredLogger
 	serviceRepo  domain.ServiceRepository
 	businessRepo domain.BusinessRepository
+	memberRepo   domain.BusinessMemberRepository
 }
 
-func NewServiceService(logger *zap.SugaredLogger, serviceRepo domain.ServiceRepository, businessRepo domain.BusinessRepository) *ServiceService {
+func NewServiceService(logger *zap.SugaredLogger, serviceRepo domain.ServiceRepository, businessRepo domain.BusinessRepository, memberRepo domain.BusinessMemberRepository) *ServiceService {
 	return &ServiceService{
 		logger:       logger,
 		serviceRepo:  serviceRepo,
 		businessRepo: businessRepo,
+		memberRepo:   memberRepo,
 	}
 }
 
 func (s *ServiceService) Create(ctx context.Context, req *dto.ServiceCreateRequest) (*domain.Service, error) {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, req.BusinessID)
-	if err != nil {
+	if _, err := s.businessRepo.GetByID(ctx, req.BusinessID); err != nil {
 		if err == domain.ErrBusinessNotFound {
 			return nil, domain.ErrBusinessNotFound
 		}
 		return nil, err
 	}
 
-	if business.UserID != userCtx.ID {
-		return nil, domain.ErrUnauthorized
+	// Any member may manage the catalog of the business
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, req.BusinessID, domain.BusinessPermissionCatalog); err != nil {
+		return nil, err
 	}
 
 	service := &domain.Service{
@@ -57,22 +57,15 @@ func (s *ServiceService) Create(ctx context.Context, req *dto.ServiceCreateReque
 }
 
 func (s *ServiceService) Update(ctx context.Context, req *dto.ServiceUpdateRequest) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	service, err := s.serviceRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, service.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, service.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	service.Name = req.Name
 	service.Description = req.Description
 	service.Price = req.Price
@@ -86,22 +79,15 @@ func (s *ServiceService) Update(ctx context.Context, req *dto.ServiceUpdateReque
 }
 
 func (s *ServiceService) Delete(ctx context.Context, id uuid.UUID) error {
-	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
 	service, err := s.serviceRepo.GetByID(ctx, id)
 	if err != nil {
 		return err
 	}
 
-	// Check if business belongs to user
-	business, err := s.businessRepo.GetByID(ctx, service.BusinessID)
-	if err != nil {
+	if _, err := authorizeBusinessMember(ctx, s.logger, s.memberRepo, service.BusinessID, domain.BusinessPermissionCatalog); err != nil {
 		return err
 	}
 
-	if business.UserID != userCtx.ID {
-		return domain.ErrUnauthorized
-	}
-
 	if err := s.serviceRepo.Delete(nil, id); err != nil {
 		s.logger.Errorw("failed to delete service", "id", id, "error", err)
 		return response.ErrInternalServerError
@@ -110,8 +96,9 @@ func (s *ServiceService) Delete(ctx context.Context, id uuid.UUID) error {
 	return nil
 }
 
+// GetByID retrieves the service for public view, which only services of approved businesses are.
 func (s *ServiceService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
-	service, err := s.serviceRepo.GetByID(ctx, id)
+	service, err := s.serviceRepo.GetApprovedByID(ctx, id)
 	if err != nil {
 		if err == domain.ErrServiceNotFound {
 			return nil, err
@@ -125,14 +112,26 @@ func (s *ServiceService) GetByID(ctx context.Context, id uuid.UUID) (*domain.Ser
 }
 
 func (s *ServiceService) List(ctx context.Context, req *dto.ServiceListRequest) (*dto.ServiceListResponse, error) {
+	if err := req.ValidateListOptions(); err != nil {
+		return nil, err
+	}
+
 	services, err := s.serviceRepo.List(ctx, req)
 	if err != nil && err != domain.ErrServiceNotFound {
 		s.logger.Errorw("failed to list services", "error", err)
 		return nil, response.ErrInternalServerError
 	}
 
+	var page pagination.Page
+	if req.Cursor != nil {
+		services, page = pagination.Trim(services, req.Cursor, pagination.Limit(req.Limit), func(s *domain.Service) (time.Time, uuid.UUID) {
+			return s.CreatedAt, s.ID
+		})
+	}
+
+	// Cursor pages skip the count, which would read the whole list on every page
 	count := 0
-	if len(services) > 0 {
+	if len(services) > 0 && req.Cursor == nil {
 		count, err = s.serviceRepo.Count(ctx, req)
 		if err != nil {
 			s.logger.Errorw("failed to count services", "error", err)
@@ -140,10 +139,21 @@ func (s *ServiceService) List(ctx context.Context, req *dto.ServiceListRequest)
 		}
 	}
 
+	var facets domain.Facets
+	if len(req.Facets) > 0 {
+		facets, err = s.serviceRepo.Facets(ctx, req)
+		if err != nil {
+			s.logger.Errorw("failed to count service facets", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
 	return &dto.ServiceListResponse{
 		Services: services,
 		Count:    count,
 		Limit:    req.Limit,
 		Offset:   req.Offset,
+		Facets:   facets,
+		Page:     page,
 	}, nil
 }
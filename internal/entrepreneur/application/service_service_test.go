@@ -3,6 +3,7 @@ package application
 import (
 	"context"
 	"errors"
+	"math"
 	"testing"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
@@ -50,6 +51,14 @@ func (m *MockServiceRepository) GetByID(ctx context.Context, id uuid.UUID) (*dom
 	return args.Get(0).(*domain.Service), args.Error(1)
 }
 
+func (m *MockServiceRepository) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Service), args.Error(1)
+}
+
 func (m *MockServiceRepository) List(ctx context.Context, filter *domain.ServiceFilters) ([]*domain.Service, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
@@ -63,11 +72,20 @@ func (m *MockServiceRepository) Count(ctx context.Context, filter *domain.Servic
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockServiceRepository) Facets(ctx context.Context, filter *domain.ServiceFilters) (domain.Facets, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(domain.Facets), args.Error(1)
+}
+
 func TestServiceService_Create(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockServiceRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewServiceService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewServiceService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -81,7 +99,8 @@ func TestServiceService_Create(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Service")).Return(nil)
 
 		result, err := service.Create(ctx, req)
@@ -91,10 +110,12 @@ func TestServiceService_Create(t *testing.T) {
 		assert.Equal(t, req.Name, result.Name)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_BusinessNotFound", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
 		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(nil, domain.ErrBusinessNotFound)
 
@@ -104,12 +125,15 @@ func TestServiceService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrBusinessNotFound, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_Unauthorized", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: uuid.New()}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		result, err := service.Create(ctx, req)
 
@@ -117,12 +141,15 @@ func TestServiceService_Create(t *testing.T) {
 		assert.Nil(t, result)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure_RepoError", func(t *testing.T) {
 		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID, UserID: userID}, nil)
+		mockBusinessRepo.On("GetByID", ctx, req.BusinessID).Return(&domain.Business{ID: req.BusinessID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, req.BusinessID, userID).Return(activeMember(req.BusinessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Create", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Service")).Return(errors.New("db error"))
 
 		result, err := service.Create(ctx, req)
@@ -132,6 +159,7 @@ func TestServiceService_Create(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -139,7 +167,8 @@ func TestServiceService_Update(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockServiceRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewServiceService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewServiceService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -163,19 +192,19 @@ func TestServiceService_Update(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingService, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Update", (*sqlx.Tx)(nil), mock.AnythingOfType("*domain.Service")).Return(nil)
 
 		err := service.Update(ctx, req)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrServiceNotFound)
 
 		err := service.Update(ctx, req)
@@ -187,16 +216,16 @@ func TestServiceService_Update(t *testing.T) {
 
 	t.Run("Unauthorized", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingService, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: uuid.New()}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(nil, domain.ErrBusinessMemberNotFound)
 
 		err := service.Update(ctx, req)
 
 		assert.Error(t, err)
 		assert.Equal(t, domain.ErrUnauthorized, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -204,7 +233,8 @@ func TestServiceService_Delete(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockServiceRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewServiceService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewServiceService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 
 	userID := uuid.New()
 	userCtx := &userDto.UserAsContext{ID: userID}
@@ -219,28 +249,28 @@ func TestServiceService_Delete(t *testing.T) {
 
 	t.Run("Success", func(t *testing.T) {
 		mockRepo.On("GetByID", ctx, id).Return(existingService, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(nil)
 
 		err := service.Delete(ctx, id)
 
 		assert.NoError(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 
 	t.Run("Failure", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockBusinessRepo.ExpectedCalls = nil
+		mockMemberRepo.ExpectedCalls = nil
 		mockRepo.On("GetByID", ctx, id).Return(existingService, nil)
-		mockBusinessRepo.On("GetByID", ctx, businessID).Return(&domain.Business{ID: businessID, UserID: userID}, nil)
+		mockMemberRepo.On("GetByBusinessAndUser", ctx, businessID, userID).Return(activeMember(businessID, userID, domain.BusinessRoleEditor), nil)
 		mockRepo.On("Delete", (*sqlx.Tx)(nil), id).Return(errors.New("db error"))
 
 		err := service.Delete(ctx, id)
 
 		assert.Error(t, err)
 		mockRepo.AssertExpectations(t)
-		mockBusinessRepo.AssertExpectations(t)
+		mockMemberRepo.AssertExpectations(t)
 	})
 }
 
@@ -248,7 +278,8 @@ func TestServiceService_GetByID(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockServiceRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewServiceService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewServiceService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 	id := uuid.New()
 
@@ -258,7 +289,7 @@ func TestServiceService_GetByID(t *testing.T) {
 	}
 
 	t.Run("Success", func(t *testing.T) {
-		mockRepo.On("GetByID", ctx, id).Return(expectedService, nil)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(expectedService, nil)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -269,7 +300,7 @@ func TestServiceService_GetByID(t *testing.T) {
 
 	t.Run("NotFound", func(t *testing.T) {
 		mockRepo.ExpectedCalls = nil
-		mockRepo.On("GetByID", ctx, id).Return(nil, domain.ErrServiceNotFound)
+		mockRepo.On("GetApprovedByID", ctx, id).Return(nil, domain.ErrServiceNotFound)
 
 		result, err := service.GetByID(ctx, id)
 
@@ -284,7 +315,8 @@ func TestServiceService_List(t *testing.T) {
 	logger := zap.NewNop().Sugar()
 	mockRepo := new(MockServiceRepository)
 	mockBusinessRepo := new(MockBusinessRepository)
-	service := NewServiceService(logger, mockRepo, mockBusinessRepo)
+	mockMemberRepo := new(MockBusinessMemberRepository)
+	service := NewServiceService(logger, mockRepo, mockBusinessRepo, mockMemberRepo)
 	ctx := context.Background()
 
 	req := &dto.ServiceListRequest{
@@ -321,4 +353,32 @@ func TestServiceService_List(t *testing.T) {
 		assert.Equal(t, response.ErrInternalServerError, err)
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("Near", func(t *testing.T) {
+		mockRepo.ExpectedCalls = nil
+		nearReq := &dto.ServiceListRequest{Near: &domain.Near{Latitude: -23.55, Longitude: -46.63, RadiusKm: 5}}
+		mockRepo.On("List", ctx, nearReq).Return(expectedServices, nil)
+		mockRepo.On("Count", ctx, nearReq).Return(2, nil)
+
+		result, err := service.List(ctx, nearReq)
+
+		assert.NoError(t, err)
+		assert.Len(t, result.Services, 2)
+	})
+
+	t.Run("InvalidNear", func(t *testing.T) {
+		for near, expected := range map[domain.Near]error{
+			{Latitude: 91, Longitude: 0}:                                      domain.ErrInvalidCoordinates,
+			{Latitude: 0, Longitude: 0, RadiusKm: domain.MaxNearRadiusKm + 1}: domain.ErrInvalidRadius,
+			{Latitude: math.NaN(), Longitude: 0}:                              domain.ErrInvalidCoordinates,
+			{Latitude: 0, Longitude: math.Inf(-1)}:                            domain.ErrInvalidCoordinates,
+			{Latitude: 0, Longitude: 0, RadiusKm: math.NaN()}:                 domain.ErrInvalidRadius,
+			{Latitude: 0, Longitude: 0, RadiusKm: math.Inf(1)}:                domain.ErrInvalidRadius,
+		} {
+			result, err := service.List(ctx, &dto.ServiceListRequest{Near: &near})
+
+			assert.Nil(t, result)
+			assert.Equal(t, expected, err)
+		}
+	})
 }
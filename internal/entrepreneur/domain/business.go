@@ -4,16 +4,49 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
-// BusinessProperty used for dynamic update
-type BusinessProperty string
+// BusinessStatus tells where a business stands in its moderation.
+type BusinessStatus string
 
 const (
-	BusinessIsActive BusinessProperty = "is_active"
+	BusinessStatusDraft BusinessStatus = "draft"
+	// BusinessStatusPendingReview waits on an admin to approve or reject the business
+	BusinessStatusPendingReview BusinessStatus = "pending_review"
+	// BusinessStatusApproved is the only status listed publicly
+	BusinessStatusApproved  BusinessStatus = "approved"
+	BusinessStatusRejected  BusinessStatus = "rejected"
+	BusinessStatusSuspended BusinessStatus = "suspended"
 )
 
+// businessTransitions lists the statuses each status may move to. Owners send drafts and
+// rejected businesses to review, admins take every other step.
+var businessTransitions = map[BusinessStatus][]BusinessStatus{
+	BusinessStatusDraft:         {BusinessStatusPendingReview},
+	BusinessStatusPendingReview: {BusinessStatusApproved, BusinessStatusRejected},
+	BusinessStatusApproved:      {BusinessStatusSuspended},
+	BusinessStatusRejected:      {BusinessStatusPendingReview},
+	BusinessStatusSuspended:     {BusinessStatusApproved},
+}
+
+// IsValid reports whether the status is one of the known statuses.
+func (s BusinessStatus) IsValid() bool {
+	_, ok := businessTransitions[s]
+	return ok
+}
+
+// CanTransitionTo reports whether a business may move from the status to next.
+func (s BusinessStatus) CanTransitionTo(next BusinessStatus) bool {
+	for _, status := range businessTransitions[s] {
+		if status == next {
+			return true
+		}
+	}
+	return false
+}
+
 // Business corresponds to the "business" table.
 type Business struct {
 	ID               uuid.UUID      `json:"id" db:"id"`
@@ -26,18 +59,73 @@ type Business struct {
 	PhoneNumber      sql.NullString `json:"phone_number" db:"phone_number"`
 	WebsiteURL       sql.NullString `json:"website_url" db:"website_url"`
 	LogoURL          sql.NullString `json:"logo_url" db:"logo_url"`
-	IsActive         bool           `json:"is_active" db:"is_active"`
+	Status           BusinessStatus `json:"status" db:"status"`
+	StatusReason     sql.NullString `json:"status_reason" db:"status_reason"`
+	ReviewerID       uuid.NullUUID  `json:"reviewer_id" db:"reviewer_id"`
+	SubmittedAt      sql.NullTime   `json:"submitted_at" db:"submitted_at"`
+	ReviewedAt       sql.NullTime   `json:"reviewed_at" db:"reviewed_at"`
 	CreatedAt        time.Time      `json:"created_at" db:"created_at"`
+
+	// Schedule is read along with the business by BusinessService.GetByID only
+	Schedule *BusinessSchedule `json:"schedule,omitempty" db:"-"`
+	// IsOpenNow is computed from the schedule when the business is read, and never cached
+	IsOpenNow *bool `json:"is_open_now,omitempty" db:"-"`
+}
+
+// ChurchScope narrows a list to the businesses owned by people of a church, or of every church of
+// a diocese when no church is set
+type ChurchScope struct {
+	ChurchID uuid.NullUUID
+	Diocese  sql.NullString
 }
 
 // BusinessFilters defines criteria for filtering businesses.
 type BusinessFilters struct {
-	UserID       *uuid.UUID `json:"user_id,omitempty"`
-	IndustryID   *int16     `json:"industry_id"`
-	IsActive     *bool      `json:"is_active"`
-	NameContains *string    `json:"name_contains"`
-
-	// Pagination
-	Limit  *int `json:"limit"`
-	Offset *int `json:"offset"`
+	UserID       *uuid.UUID      `json:"user_id,omitempty"`
+	IndustryID   *int16          `json:"industry_id"`
+	IndustryIDs  []int16         `json:"industry_ids"`
+	Status       *BusinessStatus `json:"status"`
+	NameContains *string         `json:"name_contains"`
+
+	// Scope limits the list to businesses owned by people an admin with a scope may manage, it is never read from requests
+	Scope *ChurchScope `json:"-"`
+	// MemberID limits the list to businesses the user is an active member of, it is never read from requests
+	MemberID *uuid.UUID `json:"-"`
+	// ModerationQueue orders the list by submission, oldest first, instead of newest businesses first
+	ModerationQueue bool `json:"-"`
+
+	// Near limits the list to businesses located around a point
+	Near *Near `json:"near"`
+
+	// OpenNow limits the list to businesses open at the time of the request
+	OpenNow bool `json:"open_now"`
+
+	// Facets lists the facets to count the matching businesses by, out of BusinessFacets
+	Facets []string `json:"facets"`
+
+	// Sorting, by one of BusinessSortFields, newest businesses first by default
+	SortBy    string    `json:"sort_by"`
+	SortOrder SortOrder `json:"sort_order"`
+
+	// Pagination, by offset or, once a cursor is given, by cursor
+	Limit  *int               `json:"limit"`
+	Offset *int               `json:"offset"`
+	Cursor *pagination.Cursor `json:"cursor"`
+}
+
+var (
+	BusinessFacets     = []string{"industry_id"}
+	BusinessSortFields = []string{"created_at", "name"}
+)
+
+// ValidateListOptions checks the requested near filter, facets and sort.
+func (f *BusinessFilters) ValidateListOptions() error {
+	if err := f.Near.Validate(); err != nil {
+		return err
+	}
+	// The moderation queue is sorted by submission, which cursors cannot page through
+	if f.ModerationQueue && f.Cursor != nil {
+		return ErrInvalidSort
+	}
+	return validateListOptions(f.Facets, BusinessFacets, f.SortBy, f.SortOrder, BusinessSortFields, f.Cursor != nil)
 }
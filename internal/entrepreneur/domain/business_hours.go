@@ -0,0 +1,196 @@
+package domain
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTimezone is the timezone of the locations added without one, and the one the hours of a
+// business without locations are read in
+const DefaultTimezone = "America/Sao_Paulo"
+
+// DateLayout is the layout of the dates of hours exceptions
+const DateLayout = "2006-01-02"
+
+// TimeOfDay is a wall clock time in minutes since midnight, written as "15:04". Periods may close
+// at 24:00, the end of the day.
+type TimeOfDay int
+
+const endOfDay TimeOfDay = 24 * 60
+
+// ParseTimeOfDay reads a time written as "15:04", or "15:04:05" as Postgres writes it.
+func ParseTimeOfDay(text string) (TimeOfDay, error) {
+	var hours, minutes, seconds int
+	n, _ := fmt.Sscanf(text, "%d:%d:%d", &hours, &minutes, &seconds)
+	if (len(text) != 5 || n != 2) && (len(text) != 8 || n != 3) {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	if hours < 0 || minutes < 0 || minutes > 59 || seconds != 0 {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+
+	t := TimeOfDay(hours*60 + minutes)
+	if t > endOfDay {
+		return 0, fmt.Errorf("invalid time of day %q", text)
+	}
+	return t, nil
+}
+
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60)
+}
+
+func (t TimeOfDay) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *TimeOfDay) UnmarshalText(text []byte) error {
+	parsed, err := ParseTimeOfDay(string(text))
+	if err != nil {
+		return err
+	}
+
+	*t = parsed
+	return nil
+}
+
+// Scan reads a TIME column.
+func (t *TimeOfDay) Scan(src any) error {
+	switch value := src.(type) {
+	case []byte:
+		return t.UnmarshalText(value)
+	case string:
+		return t.UnmarshalText([]byte(value))
+	case time.Time:
+		*t = TimeOfDay(value.Hour()*60 + value.Minute())
+		return nil
+	default:
+		return fmt.Errorf("cannot scan %T into a time of day", src)
+	}
+}
+
+func (t TimeOfDay) Value() (driver.Value, error) {
+	return t.String(), nil
+}
+
+// OpeningHours is a period a business is open on a day of the week, corresponds to the "business_hours" table.
+type OpeningHours struct {
+	Weekday  time.Weekday `json:"weekday" db:"weekday"`
+	OpensAt  TimeOfDay    `json:"opens_at" db:"opens_at"`
+	ClosesAt TimeOfDay    `json:"closes_at" db:"closes_at"`
+}
+
+// HoursException corresponds to the "business_hour_exceptions" table. It replaces the weekly hours
+// on its date, the business being closed the whole day or open during the special hours.
+type HoursException struct {
+	BusinessID uuid.UUID      `json:"business_id" db:"business_id"`
+	Date       string         `json:"date" db:"date"`
+	Closed     bool           `json:"closed" db:"closed"`
+	OpensAt    *TimeOfDay     `json:"opens_at" db:"opens_at"`
+	ClosesAt   *TimeOfDay     `json:"closes_at" db:"closes_at"`
+	Note       sql.NullString `json:"note" db:"note"`
+}
+
+// ValidateOpeningHours checks the weekly schedule of a business: valid weekdays, periods that
+// close after they open and periods of a day that do not overlap.
+func ValidateOpeningHours(hours []OpeningHours) error {
+	sorted := slices.Clone(hours)
+	slices.SortFunc(sorted, func(a, b OpeningHours) int {
+		if a.Weekday != b.Weekday {
+			return int(a.Weekday) - int(b.Weekday)
+		}
+		return int(a.OpensAt) - int(b.OpensAt)
+	})
+
+	for i, period := range sorted {
+		if period.Weekday < time.Sunday || period.Weekday > time.Saturday || period.OpensAt >= period.ClosesAt {
+			return ErrInvalidOpeningHours
+		}
+		if i > 0 && sorted[i-1].Weekday == period.Weekday && sorted[i-1].ClosesAt > period.OpensAt {
+			return ErrInvalidOpeningHours
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the date of the exception and that it has hours unless the business is closed.
+func (e *HoursException) Validate() error {
+	if _, err := time.Parse(DateLayout, e.Date); err != nil {
+		return ErrInvalidHoursException
+	}
+	if e.Closed {
+		if e.OpensAt != nil || e.ClosesAt != nil {
+			return ErrInvalidHoursException
+		}
+		return nil
+	}
+	if e.OpensAt == nil || e.ClosesAt == nil || *e.OpensAt >= *e.ClosesAt {
+		return ErrInvalidHoursException
+	}
+	return nil
+}
+
+// BusinessSchedule holds the weekly hours of a business, its upcoming exceptions and the
+// timezones of its locations, the hours being kept in local time at each location.
+type BusinessSchedule struct {
+	Hours      []OpeningHours   `json:"hours"`
+	Exceptions []HoursException `json:"exceptions"`
+	Timezones  []string         `json:"timezones"`
+}
+
+// IsOpenAt reports whether the business is open at the time at any of its locations. An exception
+// on the local date of a location replaces the weekly hours of that day.
+func (s *BusinessSchedule) IsOpenAt(at time.Time) bool {
+	timezones := s.Timezones
+	if len(timezones) == 0 {
+		timezones = []string{DefaultTimezone}
+	}
+
+	for _, timezone := range timezones {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			continue
+		}
+
+		local := at.In(location)
+		now := TimeOfDay(local.Hour()*60 + local.Minute())
+		if exception := s.exceptionOn(local.Format(DateLayout)); exception != nil {
+			if !exception.Closed && *exception.OpensAt <= now && now < *exception.ClosesAt {
+				return true
+			}
+			continue
+		}
+
+		for _, period := range s.Hours {
+			if period.Weekday == local.Weekday() && period.OpensAt <= now && now < period.ClosesAt {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func (s *BusinessSchedule) exceptionOn(date string) *HoursException {
+	for i := range s.Exceptions {
+		if s.Exceptions[i].Date == date {
+			return &s.Exceptions[i]
+		}
+	}
+	return nil
+}
+
+// ValidTimezone reports whether the timezone is a known IANA timezone, such as "America/Sao_Paulo".
+func ValidTimezone(timezone string) bool {
+	if timezone == "" || timezone == "Local" {
+		return false
+	}
+	_, err := time.LoadLocation(timezone)
+	return err == nil
+}
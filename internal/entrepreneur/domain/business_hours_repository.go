@@ -0,0 +1,17 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BusinessHoursRepository interface {
+	// GetSchedule reads the weekly hours of the business, its exceptions from yesterday on and the timezones of its locations
+	GetSchedule(ctx context.Context, businessID uuid.UUID) (*BusinessSchedule, error)
+	// ReplaceHours replaces the weekly hours of the business, in a single transaction
+	ReplaceHours(ctx context.Context, businessID uuid.UUID, hours []OpeningHours) error
+	// UpsertException adds the exception or replaces the one on the same date
+	UpsertException(ctx context.Context, exception *HoursException) error
+	DeleteException(ctx context.Context, businessID uuid.UUID, date string) error
+}
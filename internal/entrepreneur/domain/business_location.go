@@ -0,0 +1,83 @@
+package domain
+
+import (
+	"database/sql"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// MaxServiceRadiusKm bounds the service radius of a location, which keeps distance searches
+	// within a box the index can look up
+	MaxServiceRadiusKm = 100
+	// DefaultNearRadiusKm is the search radius of a Near filter given without one
+	DefaultNearRadiusKm = 10
+	MaxNearRadiusKm     = 500
+)
+
+// BusinessLocation corresponds to the "business_locations" table.
+type BusinessLocation struct {
+	ID            uuid.UUID      `json:"id" db:"id"`
+	BusinessID    uuid.UUID      `json:"business_id" db:"business_id"`
+	Label         sql.NullString `json:"label" db:"label"`
+	StreetLine1   string         `json:"street_line_1" db:"street_line_1"`
+	StreetLine2   sql.NullString `json:"street_line_2" db:"street_line_2"`
+	City          string         `json:"city" db:"city"`
+	StateProvince string         `json:"state_province" db:"state_province"`
+	PostalCode    string         `json:"postal_code" db:"postal_code"`
+	Country       string         `json:"country" db:"country"`
+	Latitude      float64        `json:"latitude" db:"latitude"`
+	Longitude     float64        `json:"longitude" db:"longitude"`
+	// ServiceRadiusKm is how far from the location the business serves, 0 when only at the location
+	ServiceRadiusKm float64 `json:"service_radius_km" db:"service_radius_km"`
+	// Timezone is the IANA timezone the opening hours are kept in at the location
+	Timezone  string    `json:"timezone" db:"timezone"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// ValidCoordinates reports whether the latitude and longitude are within the bounds of the globe.
+func ValidCoordinates(latitude, longitude float64) bool {
+	if !finite(latitude) || !finite(longitude) {
+		return false
+	}
+	return latitude >= -90 && latitude <= 90 && longitude >= -180 && longitude <= 180
+}
+
+// finite reports whether the value is neither NaN nor infinite, which the query parameters parse
+func finite(value float64) bool {
+	return !math.IsNaN(value) && !math.IsInf(value, 0)
+}
+
+// Near filters a list down to the businesses with a location within the radius of a point, or
+// whose service radius reaches the point.
+type Near struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	// RadiusKm defaults to DefaultNearRadiusKm
+	RadiusKm float64 `json:"radius_km"`
+}
+
+// Validate checks the point and the radius, a nil filter being valid.
+func (n *Near) Validate() error {
+	if n == nil {
+		return nil
+	}
+	if !ValidCoordinates(n.Latitude, n.Longitude) {
+		return ErrInvalidCoordinates
+	}
+	if !finite(n.RadiusKm) || n.RadiusKm < 0 || n.RadiusKm > MaxNearRadiusKm {
+		return ErrInvalidRadius
+	}
+	return nil
+}
+
+// Radius returns the search radius in kilometers.
+func (n *Near) Radius() float64 {
+	if n.RadiusKm == 0 {
+		return DefaultNearRadiusKm
+	}
+	return n.RadiusKm
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type BusinessLocationRepository interface {
+	Create(ctx context.Context, location *BusinessLocation) error
+	Update(ctx context.Context, location *BusinessLocation) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	GetByID(ctx context.Context, id uuid.UUID) (*BusinessLocation, error)
+	ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*BusinessLocation, error)
+}
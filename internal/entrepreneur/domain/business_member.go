@@ -0,0 +1,90 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BusinessRole is the part a member plays in running a business.
+type BusinessRole string
+
+const (
+	// BusinessRoleOwner is held by a single member, the one recorded as the user of the business
+	BusinessRoleOwner   BusinessRole = "owner"
+	BusinessRoleManager BusinessRole = "manager"
+	BusinessRoleEditor  BusinessRole = "editor"
+)
+
+// BusinessPermission is an action on a business that depends on the role of the member.
+type BusinessPermission string
+
+const (
+	// BusinessPermissionCatalog allows managing the products, services and jobs of the business
+	BusinessPermissionCatalog BusinessPermission = "catalog"
+	// BusinessPermissionEdit allows editing the business and sending it to review
+	BusinessPermissionEdit BusinessPermission = "edit"
+	// BusinessPermissionMembers allows inviting and removing members of lower roles
+	BusinessPermissionMembers BusinessPermission = "members"
+	// BusinessPermissionOwn allows deleting the business, transferring it and changing roles
+	BusinessPermissionOwn BusinessPermission = "own"
+)
+
+var businessRolePermissions = map[BusinessRole][]BusinessPermission{
+	BusinessRoleOwner:   {BusinessPermissionCatalog, BusinessPermissionEdit, BusinessPermissionMembers, BusinessPermissionOwn},
+	BusinessRoleManager: {BusinessPermissionCatalog, BusinessPermissionEdit, BusinessPermissionMembers},
+	BusinessRoleEditor:  {BusinessPermissionCatalog},
+}
+
+// businessRoleRanks orders the roles, members only manage members of a lower rank
+var businessRoleRanks = map[BusinessRole]int{
+	BusinessRoleOwner:   3,
+	BusinessRoleManager: 2,
+	BusinessRoleEditor:  1,
+}
+
+// IsValid reports whether the role is one of the known roles.
+func (r BusinessRole) IsValid() bool {
+	_, ok := businessRolePermissions[r]
+	return ok
+}
+
+// Has reports whether the role grants the permission.
+func (r BusinessRole) Has(permission BusinessPermission) bool {
+	for _, p := range businessRolePermissions[r] {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// Outranks reports whether the role is above other, e.g. to invite or remove a member of that role.
+func (r BusinessRole) Outranks(other BusinessRole) bool {
+	return businessRoleRanks[r] > businessRoleRanks[other]
+}
+
+// BusinessMember corresponds to the "business_members" table, along with the name, email and entrepreneur flag of the user.
+type BusinessMember struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	BusinessID uuid.UUID     `json:"business_id" db:"business_id"`
+	UserID     uuid.UUID     `json:"user_id" db:"user_id"`
+	Role       BusinessRole  `json:"role" db:"role"`
+	InvitedBy  uuid.NullUUID `json:"invited_by" db:"invited_by"`
+	// AcceptedAt is null while the invitation is pending
+	AcceptedAt sql.NullTime `json:"accepted_at" db:"accepted_at"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time    `json:"updated_at" db:"updated_at"`
+
+	// User details, read from the users table
+	FirstName      string `json:"first_name" db:"first_name"`
+	LastName       string `json:"last_name" db:"last_name"`
+	Email          string `json:"email" db:"email"`
+	IsEntrepreneur bool   `json:"is_entrepreneur" db:"is_entrepreneur"`
+}
+
+// IsActive reports whether the member accepted the invitation and may act on the business.
+func (m *BusinessMember) IsActive() bool {
+	return m.AcceptedAt.Valid
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type BusinessMemberRepository interface {
+	Create(tx *sqlx.Tx, member *BusinessMember) error
+	GetByBusinessAndUser(ctx context.Context, businessID, userID uuid.UUID) (*BusinessMember, error)
+	ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*BusinessMember, error)
+	// Accept marks the pending invitation of the user as accepted, ErrBusinessInvitationNotFound is returned when there is none
+	Accept(ctx context.Context, businessID, userID uuid.UUID) error
+	UpdateRole(ctx context.Context, businessID, userID uuid.UUID, role BusinessRole) error
+	Delete(ctx context.Context, businessID, userID uuid.UUID) error
+	// TransferOwnership makes the member the owner of the business and the current owner a manager, in a single transaction
+	TransferOwnership(ctx context.Context, businessID, fromUserID, toUserID uuid.UUID) error
+}
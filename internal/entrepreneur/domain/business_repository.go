@@ -8,11 +8,15 @@ import (
 )
 
 type BusinessRepository interface {
+	UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error
 	Create(tx *sqlx.Tx, business *Business) error
 	Update(tx *sqlx.Tx, business *Business) error
-	UpdateProperty(ctx context.Context, id uuid.UUID, property BusinessProperty, value any) error
+	// UpdateStatus stores the status of the business and its review details, provided the
+	// business is still in the from status. ErrInvalidBusinessTransition is returned otherwise.
+	UpdateStatus(ctx context.Context, business *Business, from BusinessStatus) error
 	Delete(tx *sqlx.Tx, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Business, error)
 	List(ctx context.Context, filter *BusinessFilters) ([]*Business, error)
 	Count(ctx context.Context, filter *BusinessFilters) (int, error)
+	Facets(ctx context.Context, filter *BusinessFilters) (Facets, error)
 }
@@ -11,10 +11,54 @@ var (
 	ErrForbidden      = errors.New("forbidden action")
 )
 
+// List errors
+var (
+	ErrInvalidFacet = errors.New("invalid facet")
+	ErrInvalidSort  = errors.New("invalid sort")
+	// ErrInvalidRadius is returned for a near filter with a negative radius or one above MaxNearRadiusKm
+	ErrInvalidRadius = errors.New("invalid radius")
+)
+
 // Business errors
 var (
 	ErrBusinessNotFound      = errors.New("business not found")
 	ErrBusinessAlreadyExists = errors.New("business already exists")
+	ErrInvalidBusinessStatus = errors.New("invalid business status")
+	// ErrInvalidBusinessTransition is returned when the business may not move to the requested status
+	ErrInvalidBusinessTransition   = errors.New("invalid business status transition")
+	ErrBusinessStatusReasonMissing = errors.New("business status reason is required")
+)
+
+// Business member errors
+var (
+	ErrBusinessMemberNotFound     = errors.New("business member not found")
+	ErrBusinessMemberExists       = errors.New("user is already a member of the business")
+	ErrBusinessInvitationNotFound = errors.New("business invitation not found")
+	ErrInvalidBusinessRole        = errors.New("invalid business role")
+	// ErrBusinessOwnerRemoval is returned when removing the owner, who has to transfer the business first
+	ErrBusinessOwnerRemoval = errors.New("the owner cannot leave the business")
+	// ErrOwnerNotEntrepreneur is returned when handing the business over to a member who is not an entrepreneur
+	ErrOwnerNotEntrepreneur = errors.New("the owner of a business has to be an entrepreneur")
+)
+
+// Business location errors
+var (
+	ErrBusinessLocationNotFound = errors.New("business location not found")
+	ErrInvalidCoordinates       = errors.New("invalid coordinates")
+	ErrInvalidServiceRadius     = errors.New("invalid service radius")
+	// ErrMissingCoordinates is returned for a location sent without coordinates while geocoding is disabled
+	ErrMissingCoordinates = errors.New("location coordinates are required")
+	// ErrAddressNotGeocoded is returned when the geocoding provider does not know the address of a location
+	ErrAddressNotGeocoded = errors.New("address could not be geocoded")
+)
+
+// Business hours errors
+var (
+	// ErrInvalidOpeningHours is returned for a weekly schedule with an invalid weekday or period, or overlapping periods
+	ErrInvalidOpeningHours    = errors.New("invalid opening hours")
+	ErrInvalidHoursException  = errors.New("invalid hours exception")
+	ErrHoursExceptionNotFound = errors.New("hours exception not found")
+	ErrInvalidTimezone        = errors.New("invalid timezone")
 )
 
 // Product errors
@@ -31,3 +75,9 @@ var (
 var (
 	ErrJobNotFound = errors.New("job not found")
 )
+
+// Search errors
+var (
+	ErrInvalidSearchQuery = errors.New("invalid search query")
+	ErrInvalidSearchType  = errors.New("invalid search type")
+)
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -38,13 +39,43 @@ type Job struct {
 
 // JobFilters defines criteria for filtering jobs.
 type JobFilters struct {
-	BusinessID    *uuid.UUID   `json:"business_id"`
-	Type          *JobType     `json:"type"`
-	Location      *JobLocation `json:"location"`
-	IsOpen        *bool        `json:"is_open"`
-	TitleContains *string      `json:"title_contains"`
-
-	// Pagination
-	Limit  *int `json:"limit"`
-	Offset *int `json:"offset"`
+	BusinessID    *uuid.UUID    `json:"business_id"`
+	BusinessIDs   []uuid.UUID   `json:"business_ids"`
+	Type          *JobType      `json:"type"`
+	Types         []JobType     `json:"types"`
+	Location      *JobLocation  `json:"location"`
+	Locations     []JobLocation `json:"locations"`
+	IsOpen        *bool         `json:"is_open"`
+	TitleContains *string       `json:"title_contains"`
+
+	// Near limits the list to the jobs of businesses located around a point
+	Near *Near `json:"near"`
+
+	// ApprovedOnly limits the list to the jobs of approved businesses, it is never read from requests
+	ApprovedOnly bool `json:"-"`
+
+	// Facets lists the facets to count the matching jobs by, out of JobFacets
+	Facets []string `json:"facets"`
+
+	// Sorting, by one of JobSortFields, newest jobs first by default
+	SortBy    string    `json:"sort_by"`
+	SortOrder SortOrder `json:"sort_order"`
+
+	// Pagination, by offset or, once a cursor is given, by cursor
+	Limit  *int               `json:"limit"`
+	Offset *int               `json:"offset"`
+	Cursor *pagination.Cursor `json:"cursor"`
+}
+
+var (
+	JobFacets     = []string{"business_id", "type", "location", "is_open"}
+	JobSortFields = []string{"created_at", "title"}
+)
+
+// ValidateListOptions checks the requested near filter, facets and sort.
+func (f *JobFilters) ValidateListOptions() error {
+	if err := f.Near.Validate(); err != nil {
+		return err
+	}
+	return validateListOptions(f.Facets, JobFacets, f.SortBy, f.SortOrder, JobSortFields, f.Cursor != nil)
 }
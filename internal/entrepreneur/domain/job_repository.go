@@ -12,6 +12,8 @@ type JobRepository interface {
 	Update(tx *sqlx.Tx, job *Job) error
 	Delete(tx *sqlx.Tx, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Job, error)
+	GetApprovedByID(ctx context.Context, id uuid.UUID) (*Job, error)
 	Count(ctx context.Context, filter *JobFilters) (int, error)
+	Facets(ctx context.Context, filter *JobFilters) (Facets, error)
 	List(ctx context.Context, filter *JobFilters) ([]*Job, error)
 }
@@ -0,0 +1,50 @@
+package domain
+
+import "slices"
+
+// SortOrder is the direction a list is sorted in.
+type SortOrder string
+
+const (
+	SortOrderAsc  SortOrder = "asc"
+	SortOrderDesc SortOrder = "desc"
+)
+
+// FacetCount is the number of records sharing a value of a facet, under the filters of the list.
+// Price ranges carry their bounds as well, From included and To excluded, To being nil for the last range.
+type FacetCount struct {
+	Value string   `json:"value" db:"value"`
+	From  *float64 `json:"from,omitempty" db:"-"`
+	To    *float64 `json:"to,omitempty" db:"-"`
+	Count int      `json:"count" db:"count"`
+}
+
+// Facets holds the counts of each requested facet, by facet name.
+type Facets map[string][]FacetCount
+
+// PriceRanges are the bounds products and services are counted by in the price facet,
+// from 0 up to the first bound, between each bound and the next, and from the last bound up.
+var PriceRanges = []float64{25, 50, 100, 250, 500}
+
+// validateListOptions checks the facets and the sort requested for a list against the ones
+// the listed entity supports, and that the sort can be paged by cursor when one is given.
+func validateListOptions(facets, supportedFacets []string, sortBy string, sortOrder SortOrder, sortFields []string, cursor bool) error {
+	for _, facet := range facets {
+		if !slices.Contains(supportedFacets, facet) {
+			return ErrInvalidFacet
+		}
+	}
+
+	if sortBy != "" && !slices.Contains(sortFields, sortBy) {
+		return ErrInvalidSort
+	}
+	if sortOrder != "" && sortOrder != SortOrderAsc && sortOrder != SortOrderDesc {
+		return ErrInvalidSort
+	}
+	// Cursors point into lists sorted by creation, they cannot page through any other sort
+	if cursor && sortBy != "" && sortBy != "created_at" {
+		return ErrInvalidSort
+	}
+
+	return nil
+}
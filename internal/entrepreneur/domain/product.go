@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -21,13 +22,35 @@ type Product struct {
 
 // ProductFilters defines criteria for filtering products.
 type ProductFilters struct {
-	BusinessID   *uuid.UUID `json:"business_id"`
-	IsAvailable  *bool      `json:"is_available"`
-	NameContains *string    `json:"name_contains"`
-	MinPrice     *float64   `json:"min_price"`
-	MaxPrice     *float64   `json:"max_price"`
-
-	// Pagination
-	Limit  *int `json:"limit"`
-	Offset *int `json:"offset"`
+	BusinessID   *uuid.UUID  `json:"business_id"`
+	BusinessIDs  []uuid.UUID `json:"business_ids"`
+	IsAvailable  *bool       `json:"is_available"`
+	NameContains *string     `json:"name_contains"`
+	MinPrice     *float64    `json:"min_price"`
+	MaxPrice     *float64    `json:"max_price"`
+
+	// ApprovedOnly limits the list to the products of approved businesses, it is never read from requests
+	ApprovedOnly bool `json:"-"`
+
+	// Facets lists the facets to count the matching products by, out of ProductFacets
+	Facets []string `json:"facets"`
+
+	// Sorting, by one of ProductSortFields, newest products first by default
+	SortBy    string    `json:"sort_by"`
+	SortOrder SortOrder `json:"sort_order"`
+
+	// Pagination, by offset or, once a cursor is given, by cursor
+	Limit  *int               `json:"limit"`
+	Offset *int               `json:"offset"`
+	Cursor *pagination.Cursor `json:"cursor"`
+}
+
+var (
+	ProductFacets     = []string{"business_id", "is_available", "price"}
+	ProductSortFields = []string{"created_at", "name", "price"}
+)
+
+// ValidateListOptions checks the requested facets and sort.
+func (f *ProductFilters) ValidateListOptions() error {
+	return validateListOptions(f.Facets, ProductFacets, f.SortBy, f.SortOrder, ProductSortFields, f.Cursor != nil)
 }
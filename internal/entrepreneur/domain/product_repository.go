@@ -12,6 +12,8 @@ type ProductRepository interface {
 	Update(tx *sqlx.Tx, product *Product) error
 	Delete(tx *sqlx.Tx, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Product, error)
+	GetApprovedByID(ctx context.Context, id uuid.UUID) (*Product, error)
 	List(ctx context.Context, filter *ProductFilters) ([]*Product, error)
 	Count(ctx context.Context, filter *ProductFilters) (int, error)
+	Facets(ctx context.Context, filter *ProductFilters) (Facets, error)
 }
@@ -0,0 +1,64 @@
+package domain
+
+import "github.com/google/uuid"
+
+// SearchEntity is the kind of record a search result points to.
+type SearchEntity string
+
+const (
+	SearchEntityBusiness SearchEntity = "business"
+	SearchEntityProduct  SearchEntity = "product"
+	SearchEntityService  SearchEntity = "service"
+	SearchEntityJob      SearchEntity = "job"
+)
+
+// IsValid reports whether the entity is one of the searchable entities.
+func (e SearchEntity) IsValid() bool {
+	switch e {
+	case SearchEntityBusiness, SearchEntityProduct, SearchEntityService, SearchEntityJob:
+		return true
+	}
+	return false
+}
+
+// SearchResult is a record matching a search, whatever its entity.
+type SearchResult struct {
+	Type SearchEntity `json:"type" db:"type"`
+	ID   uuid.UUID    `json:"id" db:"id"`
+	// BusinessID is the business the product, service or job belongs to, the business itself otherwise
+	BusinessID uuid.UUID `json:"business_id" db:"business_id"`
+	Title      string    `json:"title" db:"title"`
+	// Snippet is the part of the description that matched, the matched words wrapped in <mark> tags.
+	// Only the tags are markup, the rest of the text must be escaped before it is rendered.
+	Snippet string  `json:"snippet" db:"snippet"`
+	Rank    float64 `json:"rank" db:"rank"`
+}
+
+// SearchFilters defines criteria for a full-text search.
+type SearchFilters struct {
+	Query string `json:"q"`
+	// Types restricts the results to the given entities, all of them when empty
+	Types []SearchEntity `json:"types"`
+	// IncludeJobs lets jobs into the results, they are only shown to Catholic users
+	IncludeJobs bool `json:"-"`
+
+	// Pagination
+	Limit  *int `json:"limit"`
+	Offset *int `json:"offset"`
+}
+
+// Searches reports whether the results may include the entity.
+func (f *SearchFilters) Searches(entity SearchEntity) bool {
+	if entity == SearchEntityJob && !f.IncludeJobs {
+		return false
+	}
+	if len(f.Types) == 0 {
+		return true
+	}
+	for _, t := range f.Types {
+		if t == entity {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,8 @@
+package domain
+
+import "context"
+
+type SearchRepository interface {
+	Search(ctx context.Context, filter *SearchFilters) ([]*SearchResult, error)
+	Count(ctx context.Context, filter *SearchFilters) (int, error)
+}
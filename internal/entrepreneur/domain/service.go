@@ -3,6 +3,7 @@ package domain
 import (
 	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -18,12 +19,40 @@ type Service struct {
 
 // ServiceFilters defines criteria for filtering services.
 type ServiceFilters struct {
-	BusinessID   *uuid.UUID `json:"business_id"`
-	NameContains *string    `json:"name_contains"`
-	MinPrice     *float64   `json:"min_price"`
-	MaxPrice     *float64   `json:"max_price"`
-
-	// Pagination
-	Limit  *int `json:"limit"`
-	Offset *int `json:"offset"`
+	BusinessID   *uuid.UUID  `json:"business_id"`
+	BusinessIDs  []uuid.UUID `json:"business_ids"`
+	NameContains *string     `json:"name_contains"`
+	MinPrice     *float64    `json:"min_price"`
+	MaxPrice     *float64    `json:"max_price"`
+
+	// Near limits the list to the services of businesses located around a point
+	Near *Near `json:"near"`
+
+	// ApprovedOnly limits the list to the services of approved businesses, it is never read from requests
+	ApprovedOnly bool `json:"-"`
+
+	// Facets lists the facets to count the matching services by, out of ServiceFacets
+	Facets []string `json:"facets"`
+
+	// Sorting, by one of ServiceSortFields, newest services first by default
+	SortBy    string    `json:"sort_by"`
+	SortOrder SortOrder `json:"sort_order"`
+
+	// Pagination, by offset or, once a cursor is given, by cursor
+	Limit  *int               `json:"limit"`
+	Offset *int               `json:"offset"`
+	Cursor *pagination.Cursor `json:"cursor"`
+}
+
+var (
+	ServiceFacets     = []string{"business_id", "price"}
+	ServiceSortFields = []string{"created_at", "name", "price"}
+)
+
+// ValidateListOptions checks the requested near filter, facets and sort.
+func (f *ServiceFilters) ValidateListOptions() error {
+	if err := f.Near.Validate(); err != nil {
+		return err
+	}
+	return validateListOptions(f.Facets, ServiceFacets, f.SortBy, f.SortOrder, ServiceSortFields, f.Cursor != nil)
 }
@@ -12,6 +12,8 @@ type ServiceRepository interface {
 	Update(tx *sqlx.Tx, service *Service) error
 	Delete(tx *sqlx.Tx, id uuid.UUID) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Service, error)
+	GetApprovedByID(ctx context.Context, id uuid.UUID) (*Service, error)
 	Count(ctx context.Context, filter *ServiceFilters) (int, error)
+	Facets(ctx context.Context, filter *ServiceFilters) (Facets, error)
 	List(ctx context.Context, filter *ServiceFilters) ([]*Service, error)
 }
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -27,7 +28,6 @@ type BusinessUpdateRequest struct {
 	PhoneNumber      string    `json:"phone_number"`
 	WebsiteURL       string    `json:"website_url"`
 	LogoURL          string    `json:"logo_url"`
-	IsActive         bool      `json:"is_active"`
 }
 
 type BusinessListRequest = domain.BusinessFilters
@@ -37,9 +37,22 @@ type BusinessListResponse struct {
 	Count      int                `json:"count"`
 	Limit      *int               `json:"limit"`
 	Offset     *int               `json:"offset"`
+	// Facets holds the counts of the requested facets, under the same filters
+	Facets domain.Facets `json:"facets,omitempty"`
+	pagination.Page
 }
 
-type BusinessUpdatePropertyRequest struct {
-	ID    uuid.UUID `json:"-"`
-	Value bool      `json:"value"`
+// BusinessModerateRequest moves a business to a status decided by an admin
+type BusinessModerateRequest struct {
+	ID         uuid.UUID             `json:"-"`
+	ReviewerID uuid.UUID             `json:"-"`
+	Status     domain.BusinessStatus `json:"status"`
+	// Reason is shown to the owner, it is required to reject or suspend a business
+	Reason string `json:"reason"`
+}
+
+// BusinessTransferOwnershipRequest hands a business over to another of its members
+type BusinessTransferOwnershipRequest struct {
+	BusinessID uuid.UUID `json:"-"`
+	UserID     uuid.UUID `json:"user_id"`
 }
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/google/uuid"
+)
+
+// BusinessHoursRequest replaces the weekly schedule of a business, an empty list leaving it without hours.
+type BusinessHoursRequest struct {
+	BusinessID uuid.UUID             `json:"-"`
+	Hours      []domain.OpeningHours `json:"hours"`
+}
+
+// HoursExceptionRequest sets the hours of a business on a date, read from the URL. Hours are left
+// out when the business is closed.
+type HoursExceptionRequest struct {
+	BusinessID uuid.UUID         `json:"-"`
+	Date       string            `json:"-"`
+	Closed     bool              `json:"closed"`
+	OpensAt    *domain.TimeOfDay `json:"opens_at"`
+	ClosesAt   *domain.TimeOfDay `json:"closes_at"`
+	Note       string            `json:"note"`
+}
@@ -0,0 +1,23 @@
+package dto
+
+import (
+	"github.com/google/uuid"
+)
+
+// BusinessLocationRequest adds or updates a location of a business. The coordinates are found by
+// geocoding the address when they are left out, and the timezone defaults to domain.DefaultTimezone.
+type BusinessLocationRequest struct {
+	ID              uuid.UUID `json:"-"`
+	BusinessID      uuid.UUID `json:"-"`
+	Label           string    `json:"label"`
+	StreetLine1     string    `json:"street_line_1"`
+	StreetLine2     string    `json:"street_line_2"`
+	City            string    `json:"city"`
+	StateProvince   string    `json:"state_province"`
+	PostalCode      string    `json:"postal_code"`
+	Country         string    `json:"country"`
+	Latitude        *float64  `json:"latitude"`
+	Longitude       *float64  `json:"longitude"`
+	ServiceRadiusKm float64   `json:"service_radius_km"`
+	Timezone        string    `json:"timezone"`
+}
@@ -0,0 +1,19 @@
+package dto
+
+import (
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/google/uuid"
+)
+
+// BusinessMemberInviteRequest invites a registered user to help run a business
+type BusinessMemberInviteRequest struct {
+	BusinessID uuid.UUID           `json:"-"`
+	Email      string              `json:"email"`
+	Role       domain.BusinessRole `json:"role"`
+}
+
+type BusinessMemberRoleRequest struct {
+	BusinessID uuid.UUID           `json:"-"`
+	UserID     uuid.UUID           `json:"-"`
+	Role       domain.BusinessRole `json:"role"`
+}
@@ -2,6 +2,7 @@ package dto
 
 import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -32,4 +33,7 @@ type JobListResponse struct {
 	Count  int           `json:"count"`
 	Limit  *int          `json:"limit"`
 	Offset *int          `json:"offset"`
+	// Facets holds the counts of the requested facets, under the same filters
+	Facets domain.Facets `json:"facets,omitempty"`
+	pagination.Page
 }
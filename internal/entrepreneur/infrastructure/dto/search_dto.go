@@ -0,0 +1,12 @@
+package dto
+
+import "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+
+type SearchRequest = domain.SearchFilters
+
+type SearchResponse struct {
+	Results []*domain.SearchResult `json:"results"`
+	Count   int                    `json:"count"`
+	Limit   *int                   `json:"limit"`
+	Offset  *int                   `json:"offset"`
+}
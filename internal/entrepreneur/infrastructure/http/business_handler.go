@@ -7,6 +7,8 @@ import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -115,6 +117,12 @@ func (h *BusinessHandler) GetByID(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Businesses are public only once approved
+	if business.Status != domain.BusinessStatusApproved {
+		response.NotFoundT(ctx, w, "error.business_not_found")
+		return
+	}
+
 	response.OKT(ctx, w, "success.business_retrieved", business)
 }
 
@@ -125,13 +133,147 @@ func (h *BusinessHandler) List(w http.ResponseWriter, r *http.Request) {
 		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
 		return
 	}
+	if !readNear(w, r, &req.Near) {
+		return
+	}
+	approved := domain.BusinessStatusApproved
+	req.Status = &approved
 
 	result, err := h.businessService.List(ctx, &req)
 	if err != nil {
+		if err == domain.ErrInvalidFacet {
+			response.BadRequestT(ctx, w, "error.invalid_facet", nil)
+			return
+		}
+		if err == domain.ErrInvalidSort {
+			response.BadRequestT(ctx, w, "error.invalid_sort", nil)
+			return
+		}
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidRadius {
+			response.BadRequestT(ctx, w, "error.invalid_radius", nil)
+			return
+		}
 		h.logger.Errorw("failed to list businesses", "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_list_businesses")
 		return
 	}
 
-	response.OKT(ctx, w, "success.businesses_listed", result)
+	response.OKWithMetaT(ctx, w, "success.businesses_listed", result, result.Meta())
+}
+
+// ListOwn lists the businesses the authenticated user runs, as owner or member, whatever their status
+func (h *BusinessHandler) ListOwn(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext)
+	var req dto.BusinessListRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.MemberID = &userCtx.ID
+
+	result, err := h.businessService.List(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidBusinessStatus {
+			response.BadRequestT(ctx, w, "error.invalid_business_status", nil)
+			return
+		}
+		if err == domain.ErrInvalidFacet {
+			response.BadRequestT(ctx, w, "error.invalid_facet", nil)
+			return
+		}
+		if err == domain.ErrInvalidSort {
+			response.BadRequestT(ctx, w, "error.invalid_sort", nil)
+			return
+		}
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidRadius {
+			response.BadRequestT(ctx, w, "error.invalid_radius", nil)
+			return
+		}
+		h.logger.Errorw("failed to list own businesses", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_businesses")
+		return
+	}
+
+	response.OKWithMetaT(ctx, w, "success.businesses_listed", result, result.Meta())
+}
+
+// Submit sends a business of the owner to review
+func (h *BusinessHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	business, err := h.businessService.Submit(ctx, id)
+	if err != nil {
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_update_business")
+			return
+		}
+		if err == domain.ErrInvalidBusinessTransition {
+			response.ConflictT(ctx, w, "error.invalid_business_transition", nil)
+			return
+		}
+		h.logger.Errorw("failed to submit business", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_submit_business")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_submitted", business)
+}
+
+// TransferOwnership hands the business over to another of its members
+func (h *BusinessHandler) TransferOwnership(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	var req dto.BusinessTransferOwnershipRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+
+	if err := h.businessService.TransferOwnership(ctx, &req); err != nil {
+		if err == domain.ErrInvalidInput {
+			response.BadRequestT(ctx, w, "error.invalid_ownership_transfer", nil)
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business")
+			return
+		}
+		if err == domain.ErrBusinessMemberNotFound {
+			response.NotFoundT(ctx, w, "error.business_member_not_found")
+			return
+		}
+		if err == domain.ErrOwnerNotEntrepreneur {
+			response.UnprocessableEntityT(ctx, w, "error.owner_not_entrepreneur", nil)
+			return
+		}
+		h.logger.Errorw("failed to transfer business ownership", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_transfer_business")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_transferred", nil)
 }
@@ -0,0 +1,146 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BusinessHoursHandler struct {
+	logger       *zap.SugaredLogger
+	hoursService *application.BusinessHoursService
+}
+
+func NewBusinessHoursHandler(logger *zap.SugaredLogger, hoursService *application.BusinessHoursService) *BusinessHoursHandler {
+	return &BusinessHoursHandler{
+		logger:       logger,
+		hoursService: hoursService,
+	}
+}
+
+func (h *BusinessHoursHandler) ReplaceHours(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	var req dto.BusinessHoursRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+
+	if err := h.hoursService.ReplaceHours(ctx, &req); err != nil {
+		if err == domain.ErrInvalidOpeningHours {
+			response.BadRequestT(ctx, w, "error.invalid_opening_hours", nil)
+			return
+		}
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_hours")
+			return
+		}
+		h.logger.Errorw("failed to update business hours", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_business_hours")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_hours_updated", req.Hours)
+}
+
+func (h *BusinessHoursHandler) UpsertException(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, date, ok := h.parseExceptionPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.HoursExceptionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+	req.Date = date
+
+	exception, err := h.hoursService.UpsertException(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidHoursException {
+			response.BadRequestT(ctx, w, "error.invalid_hours_exception", nil)
+			return
+		}
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_hours")
+			return
+		}
+		h.logger.Errorw("failed to save business hours exception", "id", id, "date", date, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_save_hours_exception")
+		return
+	}
+
+	response.OKT(ctx, w, "success.hours_exception_saved", exception)
+}
+
+func (h *BusinessHoursHandler) DeleteException(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, date, ok := h.parseExceptionPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.hoursService.DeleteException(ctx, id, date); err != nil {
+		if err == domain.ErrHoursExceptionNotFound {
+			response.NotFoundT(ctx, w, "error.hours_exception_not_found")
+			return
+		}
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_hours")
+			return
+		}
+		h.logger.Errorw("failed to delete business hours exception", "id", id, "date", date, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_hours_exception")
+		return
+	}
+
+	response.OKT(ctx, w, "success.hours_exception_deleted", nil)
+}
+
+// parseExceptionPath reads the business ID and the date of the URL, answering for invalid ones
+func (h *BusinessHoursHandler) parseExceptionPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return uuid.Nil, "", false
+	}
+
+	date := chi.URLParam(r, "date")
+	if _, err := time.Parse(domain.DateLayout, date); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_date", nil)
+		return uuid.Nil, "", false
+	}
+
+	return id, date, true
+}
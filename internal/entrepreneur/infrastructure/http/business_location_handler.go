@@ -0,0 +1,196 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BusinessLocationHandler struct {
+	logger          *zap.SugaredLogger
+	locationService *application.BusinessLocationService
+}
+
+func NewBusinessLocationHandler(logger *zap.SugaredLogger, locationService *application.BusinessLocationService) *BusinessLocationHandler {
+	return &BusinessLocationHandler{
+		logger:          logger,
+		locationService: locationService,
+	}
+}
+
+func (h *BusinessLocationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	locations, err := h.locationService.List(ctx, id)
+	if err != nil {
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		h.logger.Errorw("failed to list business locations", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_business_locations")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_locations_listed", locations)
+}
+
+func (h *BusinessLocationHandler) Create(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	var req dto.BusinessLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+
+	location, err := h.locationService.Create(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidServiceRadius {
+			response.BadRequestT(ctx, w, "error.invalid_service_radius", nil)
+			return
+		}
+		if err == domain.ErrInvalidTimezone {
+			response.BadRequestT(ctx, w, "error.invalid_timezone", nil)
+			return
+		}
+		if err == domain.ErrMissingCoordinates {
+			response.BadRequestT(ctx, w, "error.missing_coordinates", nil)
+			return
+		}
+		if err == domain.ErrAddressNotGeocoded {
+			response.UnprocessableEntityT(ctx, w, "error.address_not_geocoded", nil)
+			return
+		}
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_locations")
+			return
+		}
+		h.logger.Errorw("failed to create business location", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_create_business_location")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.business_location_created", location)
+}
+
+func (h *BusinessLocationHandler) Update(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, locationID, ok := h.parseLocationPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.BusinessLocationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+	req.ID = locationID
+
+	location, err := h.locationService.Update(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidServiceRadius {
+			response.BadRequestT(ctx, w, "error.invalid_service_radius", nil)
+			return
+		}
+		if err == domain.ErrInvalidTimezone {
+			response.BadRequestT(ctx, w, "error.invalid_timezone", nil)
+			return
+		}
+		if err == domain.ErrMissingCoordinates {
+			response.BadRequestT(ctx, w, "error.missing_coordinates", nil)
+			return
+		}
+		if err == domain.ErrAddressNotGeocoded {
+			response.UnprocessableEntityT(ctx, w, "error.address_not_geocoded", nil)
+			return
+		}
+		if err == domain.ErrBusinessLocationNotFound {
+			response.NotFoundT(ctx, w, "error.business_location_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_locations")
+			return
+		}
+		h.logger.Errorw("failed to update business location", "id", id, "locationID", locationID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_business_location")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_location_updated", location)
+}
+
+func (h *BusinessLocationHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, locationID, ok := h.parseLocationPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.locationService.Delete(ctx, id, locationID); err != nil {
+		if err == domain.ErrBusinessLocationNotFound {
+			response.NotFoundT(ctx, w, "error.business_location_not_found")
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_locations")
+			return
+		}
+		h.logger.Errorw("failed to delete business location", "id", id, "locationID", locationID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_business_location")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_location_deleted", nil)
+}
+
+// parseLocationPath reads the business and location IDs of the URL, answering for invalid ones
+func (h *BusinessLocationHandler) parseLocationPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	locationID, err := uuid.Parse(chi.URLParam(r, "locationID"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_location_id", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, locationID, true
+}
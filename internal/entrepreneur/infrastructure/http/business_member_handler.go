@@ -0,0 +1,199 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type BusinessMemberHandler struct {
+	logger        *zap.SugaredLogger
+	memberService *application.BusinessMemberService
+}
+
+func NewBusinessMemberHandler(logger *zap.SugaredLogger, memberService *application.BusinessMemberService) *BusinessMemberHandler {
+	return &BusinessMemberHandler{
+		logger:        logger,
+		memberService: memberService,
+	}
+}
+
+func (h *BusinessMemberHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	members, err := h.memberService.List(ctx, id)
+	if err != nil {
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business")
+			return
+		}
+		h.logger.Errorw("failed to list business members", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_business_members")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_members_listed", members)
+}
+
+func (h *BusinessMemberHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	var req dto.BusinessMemberInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+
+	member, err := h.memberService.Invite(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidBusinessRole {
+			response.BadRequestT(ctx, w, "error.invalid_business_role", nil)
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_members")
+			return
+		}
+		if err == domain.ErrBusinessNotFound {
+			response.NotFoundT(ctx, w, "error.business_not_found")
+			return
+		}
+		if err == userDomain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+		if err == domain.ErrBusinessMemberExists {
+			response.ConflictT(ctx, w, "error.business_member_exists", nil)
+			return
+		}
+		h.logger.Errorw("failed to invite business member", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_invite_business_member")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.business_member_invited", member)
+}
+
+// Accept accepts the invitation of the authenticated user to the business
+func (h *BusinessMemberHandler) Accept(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return
+	}
+
+	if err := h.memberService.Accept(ctx, id); err != nil {
+		if err == domain.ErrBusinessInvitationNotFound {
+			response.NotFoundT(ctx, w, "error.business_invitation_not_found")
+			return
+		}
+		h.logger.Errorw("failed to accept business invitation", "id", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_accept_business_invitation")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_invitation_accepted", nil)
+}
+
+func (h *BusinessMemberHandler) UpdateRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, userID, ok := h.parseMemberPath(w, r)
+	if !ok {
+		return
+	}
+
+	var req dto.BusinessMemberRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.BusinessID = id
+	req.UserID = userID
+
+	if err := h.memberService.UpdateRole(ctx, &req); err != nil {
+		if err == domain.ErrInvalidBusinessRole {
+			response.BadRequestT(ctx, w, "error.invalid_business_role", nil)
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_members")
+			return
+		}
+		if err == domain.ErrBusinessMemberNotFound {
+			response.NotFoundT(ctx, w, "error.business_member_not_found")
+			return
+		}
+		h.logger.Errorw("failed to update business member role", "id", id, "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_business_member")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_member_updated", nil)
+}
+
+// Remove removes a member or an invitation, or lets the authenticated user leave the business
+func (h *BusinessMemberHandler) Remove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	id, userID, ok := h.parseMemberPath(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.memberService.Remove(ctx, id, userID); err != nil {
+		if err == domain.ErrBusinessOwnerRemoval {
+			response.ConflictT(ctx, w, "error.business_owner_removal", nil)
+			return
+		}
+		if err == domain.ErrUnauthorized {
+			response.UnauthorizedT(ctx, w, "error.unauthorized_business_members")
+			return
+		}
+		if err == domain.ErrBusinessMemberNotFound {
+			response.NotFoundT(ctx, w, "error.business_member_not_found")
+			return
+		}
+		h.logger.Errorw("failed to remove business member", "id", id, "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_remove_business_member")
+		return
+	}
+
+	response.OKT(ctx, w, "success.business_member_removed", nil)
+}
+
+// parseMemberPath reads the business and user IDs of the URL, answering for invalid ones
+func (h *BusinessMemberHandler) parseMemberPath(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	ctx := r.Context()
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_business_id", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	userID, err := uuid.Parse(chi.URLParam(r, "userID"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return id, userID, true
+}
@@ -0,0 +1,42 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+)
+
+// readNear sets the near filter of a list from the "near=lat,lng" and "radius_km" query
+// parameters, answering for malformed ones. A filter sent in the body is kept when the URL has none.
+func readNear(w http.ResponseWriter, r *http.Request, near **domain.Near) bool {
+	params := r.URL.Query()
+	point := params.Get("near")
+	if point == "" {
+		return true
+	}
+
+	filter := &domain.Near{}
+	lat, lng, ok := strings.Cut(point, ",")
+	latitude, latErr := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	longitude, lngErr := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if !ok || latErr != nil || lngErr != nil {
+		response.BadRequestT(r.Context(), w, "error.invalid_near", nil)
+		return false
+	}
+	filter.Latitude, filter.Longitude = latitude, longitude
+
+	if radius := params.Get("radius_km"); radius != "" {
+		radiusKm, err := strconv.ParseFloat(radius, 64)
+		if err != nil {
+			response.BadRequestT(r.Context(), w, "error.invalid_near", nil)
+			return false
+		}
+		filter.RadiusKm = radiusKm
+	}
+
+	*near = filter
+	return true
+}
@@ -134,12 +134,23 @@ func (h *ProductHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Products are public only once their business is approved
+	req.ApprovedOnly = true
+
 	result, err := h.productService.List(ctx, &req)
 	if err != nil {
+		if err == domain.ErrInvalidFacet {
+			response.BadRequestT(ctx, w, "error.invalid_facet", nil)
+			return
+		}
+		if err == domain.ErrInvalidSort {
+			response.BadRequestT(ctx, w, "error.invalid_sort", nil)
+			return
+		}
 		h.logger.Errorw("failed to list products", "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_list_products")
 		return
 	}
 
-	response.OKT(ctx, w, "success.products_listed", result)
+	response.OKWithMetaT(ctx, w, "success.products_listed", result, result.Meta())
 }
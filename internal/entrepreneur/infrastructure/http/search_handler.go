@@ -0,0 +1,76 @@
+package http
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/infrastructure/dto"
+	userDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"go.uber.org/zap"
+)
+
+type SearchHandler struct {
+	logger        *zap.SugaredLogger
+	searchService *application.SearchService
+}
+
+func NewSearchHandler(logger *zap.SugaredLogger, searchService *application.SearchService) *SearchHandler {
+	return &SearchHandler{
+		logger:        logger,
+		searchService: searchService,
+	}
+}
+
+// Search takes the text in "q", the entities to search in "type" separated by commas, and
+// "limit" and "offset". Jobs are only searched for Catholic users, as they are the only ones
+// allowed to see them.
+func (h *SearchHandler) Search(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	params := r.URL.Query()
+	req := dto.SearchRequest{Query: params.Get("q")}
+
+	if types := params.Get("type"); types != "" {
+		for _, entity := range strings.Split(types, ",") {
+			req.Types = append(req.Types, domain.SearchEntity(strings.TrimSpace(entity)))
+		}
+	}
+
+	for name, target := range map[string]**int{"limit": &req.Limit, "offset": &req.Offset} {
+		value := params.Get(name)
+		if value == "" {
+			continue
+		}
+		number, err := strconv.Atoi(value)
+		if err != nil {
+			response.BadRequestT(ctx, w, "error.invalid_pagination", nil)
+			return
+		}
+		*target = &number
+	}
+
+	if userCtx, ok := ctx.Value(auth.UserContextKey).(*userDto.UserAsContext); ok {
+		req.IncludeJobs = userCtx.IsCatholic
+	}
+
+	result, err := h.searchService.Search(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidSearchQuery {
+			response.BadRequestT(ctx, w, "error.invalid_search_query", nil)
+			return
+		}
+		if err == domain.ErrInvalidSearchType {
+			response.BadRequestT(ctx, w, "error.invalid_search_type", nil)
+			return
+		}
+		h.logger.Errorw("failed to search", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_search")
+		return
+	}
+
+	response.OKT(ctx, w, "success.search_completed", result)
+}
@@ -134,12 +134,35 @@ func (h *ServiceHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if !readNear(w, r, &req.Near) {
+		return
+	}
+
+	// Services are public only once their business is approved
+	req.ApprovedOnly = true
+
 	result, err := h.serviceService.List(ctx, &req)
 	if err != nil {
+		if err == domain.ErrInvalidFacet {
+			response.BadRequestT(ctx, w, "error.invalid_facet", nil)
+			return
+		}
+		if err == domain.ErrInvalidSort {
+			response.BadRequestT(ctx, w, "error.invalid_sort", nil)
+			return
+		}
+		if err == domain.ErrInvalidCoordinates {
+			response.BadRequestT(ctx, w, "error.invalid_coordinates", nil)
+			return
+		}
+		if err == domain.ErrInvalidRadius {
+			response.BadRequestT(ctx, w, "error.invalid_radius", nil)
+			return
+		}
 		h.logger.Errorw("failed to list services", "error", err)
 		response.InternalServerErrorT(ctx, w, "error.failed_list_services")
 		return
 	}
 
-	response.OKT(ctx, w, "success.services_listed", result)
+	response.OKWithMetaT(ctx, w, "success.services_listed", result, result.Meta())
 }
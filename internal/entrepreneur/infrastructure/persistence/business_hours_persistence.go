@@ -0,0 +1,191 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// BusinessHoursPersistence manages data access for the business_hours and business_hour_exceptions tables.
+type BusinessHoursPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewBusinessHoursPersistence creates a new BusinessHoursPersistence.
+func NewBusinessHoursPersistence(db *sqlx.DB) *BusinessHoursPersistence {
+	return &BusinessHoursPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// GetSchedule retrieves the weekly hours of the business, its exceptions from yesterday on, which
+// may still be today somewhere, and the timezones of its locations.
+func (r *BusinessHoursPersistence) GetSchedule(ctx context.Context, businessID uuid.UUID) (*domain.BusinessSchedule, error) {
+	schedule := &domain.BusinessSchedule{
+		Hours:      []domain.OpeningHours{},
+		Exceptions: []domain.HoursException{},
+		Timezones:  []string{},
+	}
+
+	query, args, err := r.psql.Select("weekday", "opens_at", "closes_at").From("business_hours").
+		Where(sq.Eq{"business_id": businessID}).
+		OrderBy("weekday", "opens_at").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list business hours query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &schedule.Hours, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list business hours query: %w", err)
+	}
+
+	query, args, err = r.psql.Select("business_id", "to_char(date, 'YYYY-MM-DD') AS date", "closed", "opens_at", "closes_at", "note").
+		From("business_hour_exceptions").
+		Where(sq.Eq{"business_id": businessID}).
+		Where("date >= CURRENT_DATE - 1").
+		OrderBy("business_hour_exceptions.date").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list business hour exceptions query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &schedule.Exceptions, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list business hour exceptions query: %w", err)
+	}
+
+	query, args, err = r.psql.Select("DISTINCT timezone").From("business_locations").
+		Where(sq.Eq{"business_id": businessID}).
+		OrderBy("timezone").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list business timezones query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &schedule.Timezones, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list business timezones query: %w", err)
+	}
+
+	return schedule, nil
+}
+
+// ReplaceHours removes the weekly hours of the business and inserts the given ones.
+func (r *BusinessHoursPersistence) ReplaceHours(ctx context.Context, businessID uuid.UUID, hours []domain.OpeningHours) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args, err := r.psql.Delete("business_hours").
+		Where(sq.Eq{"business_id": businessID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete business hours query: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete business hours query: %w", err)
+	}
+
+	if len(hours) > 0 {
+		insert := r.psql.Insert("business_hours").Columns("business_id", "weekday", "opens_at", "closes_at")
+		for _, period := range hours {
+			insert = insert.Values(businessID, int(period.Weekday), period.OpensAt, period.ClosesAt)
+		}
+
+		query, args, err = insert.ToSql()
+		if err != nil {
+			return fmt.Errorf("failed to build insert business hours query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute insert business hours query: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UpsertException inserts the exception, replacing the one the business has on the same date.
+func (r *BusinessHoursPersistence) UpsertException(ctx context.Context, exception *domain.HoursException) error {
+	query, args, err := r.psql.Insert("business_hour_exceptions").
+		Columns("business_id", "date", "closed", "opens_at", "closes_at", "note").
+		Values(exception.BusinessID, exception.Date, exception.Closed, exception.OpensAt, exception.ClosesAt, exception.Note).
+		Suffix("ON CONFLICT (business_id, date) DO UPDATE SET closed = EXCLUDED.closed, opens_at = EXCLUDED.opens_at, closes_at = EXCLUDED.closes_at, note = EXCLUDED.note").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build upsert business hour exception query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute upsert business hour exception query: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteException removes the exception the business has on the date.
+func (r *BusinessHoursPersistence) DeleteException(ctx context.Context, businessID uuid.UUID, date string) error {
+	query, args, err := r.psql.Delete("business_hour_exceptions").
+		Where(sq.Eq{"business_id": businessID, "date": date}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete business hour exception query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete business hour exception query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrHoursExceptionNotFound
+	}
+
+	return nil
+}
+
+// openNowCondition filters the businesses open at the time of the query in the local time of any
+// of their locations, or of the default timezone for those without one. An exception on the local
+// date replaces the weekly hours of that day.
+func openNowCondition(businessIDColumn string) sq.Sqlizer {
+	return sq.Expr(
+		"EXISTS (SELECT 1 FROM ("+
+			"SELECT l.timezone FROM business_locations l WHERE l.business_id = "+businessIDColumn+
+			" UNION SELECT ?::varchar WHERE NOT EXISTS (SELECT 1 FROM business_locations l WHERE l.business_id = "+businessIDColumn+")"+
+			") tz, LATERAL (SELECT (CURRENT_TIMESTAMP AT TIME ZONE tz.timezone) AS local_now) n"+
+			" WHERE CASE WHEN EXISTS (SELECT 1 FROM business_hour_exceptions e WHERE e.business_id = "+businessIDColumn+" AND e.date = n.local_now::date)"+
+			" THEN EXISTS (SELECT 1 FROM business_hour_exceptions e WHERE e.business_id = "+businessIDColumn+" AND e.date = n.local_now::date"+
+			" AND NOT e.closed AND e.opens_at <= n.local_now::time AND n.local_now::time < e.closes_at)"+
+			" ELSE EXISTS (SELECT 1 FROM business_hours h WHERE h.business_id = "+businessIDColumn+
+			" AND h.weekday = EXTRACT(DOW FROM n.local_now) AND h.opens_at <= n.local_now::time AND n.local_now::time < h.closes_at)"+
+			" END)",
+		domain.DefaultTimezone,
+	)
+}
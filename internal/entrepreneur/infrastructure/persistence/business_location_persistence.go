@@ -0,0 +1,172 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+var businessLocationColumns = []string{
+	"id", "business_id", "label", "street_line_1", "street_line_2", "city", "state_province", "postal_code", "country",
+	"latitude", "longitude", "service_radius_km", "timezone", "created_at", "updated_at",
+}
+
+// BusinessLocationPersistence manages data access for the business_locations table.
+type BusinessLocationPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewBusinessLocationPersistence creates a new BusinessLocationPersistence.
+func NewBusinessLocationPersistence(db *sqlx.DB) *BusinessLocationPersistence {
+	return &BusinessLocationPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create inserts a new location. The ID and timestamps are generated and returned.
+func (r *BusinessLocationPersistence) Create(ctx context.Context, location *domain.BusinessLocation) error {
+	query, args, err := r.psql.Insert("business_locations").
+		Columns("business_id", "label", "street_line_1", "street_line_2", "city", "state_province", "postal_code", "country", "latitude", "longitude", "service_radius_km", "timezone").
+		Values(location.BusinessID, location.Label, location.StreetLine1, location.StreetLine2, location.City, location.StateProvince, location.PostalCode, location.Country, location.Latitude, location.Longitude, location.ServiceRadiusKm, location.Timezone).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create business location query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&location.ID, &location.CreatedAt, &location.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to execute create business location query: %w", err)
+	}
+
+	return nil
+}
+
+// Update modifies an existing location.
+func (r *BusinessLocationPersistence) Update(ctx context.Context, location *domain.BusinessLocation) error {
+	query, args, err := r.psql.Update("business_locations").
+		Set("label", location.Label).
+		Set("street_line_1", location.StreetLine1).
+		Set("street_line_2", location.StreetLine2).
+		Set("city", location.City).
+		Set("state_province", location.StateProvince).
+		Set("postal_code", location.PostalCode).
+		Set("country", location.Country).
+		Set("latitude", location.Latitude).
+		Set("longitude", location.Longitude).
+		Set("service_radius_km", location.ServiceRadiusKm).
+		Set("timezone", location.Timezone).
+		Where(sq.Eq{"id": location.ID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update business location query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update business location query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrBusinessLocationNotFound
+	}
+
+	return nil
+}
+
+// Delete removes a location.
+func (r *BusinessLocationPersistence) Delete(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.psql.Delete("business_locations").
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete business location query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute delete business location query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrBusinessLocationNotFound
+	}
+
+	return nil
+}
+
+// GetByID retrieves a location by its ID.
+func (r *BusinessLocationPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.BusinessLocation, error) {
+	query, args, err := r.psql.Select(businessLocationColumns...).From("business_locations").
+		Where(sq.Eq{"id": id}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get business location query: %w", err)
+	}
+
+	var location domain.BusinessLocation
+	if err := r.db.GetContext(ctx, &location, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBusinessLocationNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get business location query: %w", err)
+	}
+
+	return &location, nil
+}
+
+// ListByBusiness retrieves the locations of the business, the first one added first.
+func (r *BusinessLocationPersistence) ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessLocation, error) {
+	query, args, err := r.psql.Select(businessLocationColumns...).From("business_locations").
+		Where(sq.Eq{"business_id": businessID}).
+		OrderBy("created_at", "id").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list business locations query: %w", err)
+	}
+
+	locations := []*domain.BusinessLocation{}
+	if err := r.db.SelectContext(ctx, &locations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list business locations query: %w", err)
+	}
+
+	return locations, nil
+}
+
+// nearCondition filters the rows whose business, read from businessIDColumn, has a location
+// within the radius of the point or serving it. Distances are in meters to earthdistance. The
+// box around the point, widened by the largest service radius, lets the index narrow the
+// locations down before their exact distance is measured.
+func nearCondition(near *domain.Near, businessIDColumn string) sq.Sqlizer {
+	radius := near.Radius() * 1000
+	return sq.Expr(
+		"EXISTS (SELECT 1 FROM business_locations l WHERE l.business_id = "+businessIDColumn+
+			" AND earth_box(ll_to_earth(?, ?), ?) @> ll_to_earth(l.latitude, l.longitude)"+
+			" AND earth_distance(ll_to_earth(?, ?), ll_to_earth(l.latitude, l.longitude)) <= ? + l.service_radius_km * 1000)",
+		near.Latitude, near.Longitude, radius+domain.MaxServiceRadiusKm*1000,
+		near.Latitude, near.Longitude, radius,
+	)
+}
@@ -0,0 +1,229 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// businessMemberColumns are read along with the name, email and entrepreneur flag of the member
+var businessMemberColumns = []string{
+	"m.id", "m.business_id", "m.user_id", "m.role", "m.invited_by", "m.accepted_at", "m.created_at", "m.updated_at",
+	"u.first_name", "u.last_name", "u.email", "u.is_entrepreneur",
+}
+
+// BusinessMemberPersistence manages data access for the business_members table.
+type BusinessMemberPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewBusinessMemberPersistence creates a new BusinessMemberPersistence.
+func NewBusinessMemberPersistence(db *sqlx.DB) *BusinessMemberPersistence {
+	return &BusinessMemberPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create adds a member to a business, within the transaction when one is given.
+func (r *BusinessMemberPersistence) Create(tx *sqlx.Tx, member *domain.BusinessMember) error {
+	query, args, err := r.psql.Insert("business_members").
+		Columns("business_id", "user_id", "role", "invited_by", "accepted_at").
+		Values(member.BusinessID, member.UserID, member.Role, member.InvitedBy, member.AcceptedAt).
+		Suffix("RETURNING id, created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create business member query: %w", err)
+	}
+
+	var row *sqlx.Row
+	if tx != nil {
+		row = tx.QueryRowx(query, args...)
+	} else {
+		row = r.db.QueryRowx(query, args...)
+	}
+
+	if err := row.Scan(&member.ID, &member.CreatedAt, &member.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to execute create business member query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByBusinessAndUser retrieves the membership of the user in the business, pending or not.
+func (r *BusinessMemberPersistence) GetByBusinessAndUser(ctx context.Context, businessID, userID uuid.UUID) (*domain.BusinessMember, error) {
+	query, args, err := r.selectMembers().
+		Where(sq.Eq{"m.business_id": businessID, "m.user_id": userID}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get business member query: %w", err)
+	}
+
+	var member domain.BusinessMember
+	if err := r.db.GetContext(ctx, &member, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrBusinessMemberNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get business member query: %w", err)
+	}
+
+	return &member, nil
+}
+
+// ListByBusiness retrieves the members of the business and the pending invitations, the owner first.
+func (r *BusinessMemberPersistence) ListByBusiness(ctx context.Context, businessID uuid.UUID) ([]*domain.BusinessMember, error) {
+	query, args, err := r.selectMembers().
+		Where(sq.Eq{"m.business_id": businessID}).
+		OrderBy("CASE m.role WHEN 'owner' THEN 0 WHEN 'manager' THEN 1 ELSE 2 END", "m.created_at").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list business members query: %w", err)
+	}
+
+	var members []*domain.BusinessMember
+	if err := r.db.SelectContext(ctx, &members, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list business members query: %w", err)
+	}
+
+	return members, nil
+}
+
+// Accept marks the pending invitation of the user as accepted.
+func (r *BusinessMemberPersistence) Accept(ctx context.Context, businessID, userID uuid.UUID) error {
+	query, args, err := r.psql.Update("business_members").
+		Set("accepted_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"business_id": businessID, "user_id": userID, "accepted_at": nil}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build accept business invitation query: %w", err)
+	}
+
+	return execAffectingOne(ctx, r.db, query, args, domain.ErrBusinessInvitationNotFound)
+}
+
+// UpdateRole changes the role of a member, the owner is changed through TransferOwnership only.
+func (r *BusinessMemberPersistence) UpdateRole(ctx context.Context, businessID, userID uuid.UUID, role domain.BusinessRole) error {
+	query, args, err := r.psql.Update("business_members").
+		Set("role", role).
+		Where(sq.Eq{"business_id": businessID, "user_id": userID}).
+		Where(sq.NotEq{"role": domain.BusinessRoleOwner}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update business member role query: %w", err)
+	}
+
+	return execAffectingOne(ctx, r.db, query, args, domain.ErrBusinessMemberNotFound)
+}
+
+// Delete removes a member or a pending invitation from the business.
+func (r *BusinessMemberPersistence) Delete(ctx context.Context, businessID, userID uuid.UUID) error {
+	query, args, err := r.psql.Delete("business_members").
+		Where(sq.Eq{"business_id": businessID, "user_id": userID}).
+		Where(sq.NotEq{"role": domain.BusinessRoleOwner}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete business member query: %w", err)
+	}
+
+	return execAffectingOne(ctx, r.db, query, args, domain.ErrBusinessMemberNotFound)
+}
+
+// TransferOwnership hands the business over to an active member and keeps the previous owner as a manager.
+func (r *BusinessMemberPersistence) TransferOwnership(ctx context.Context, businessID, fromUserID, toUserID uuid.UUID) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	// The owner steps down first, as a business has a single owner at any time
+	query, args, err := r.psql.Update("business_members").
+		Set("role", domain.BusinessRoleManager).
+		Where(sq.Eq{"business_id": businessID, "user_id": fromUserID, "role": domain.BusinessRoleOwner}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build demote business owner query: %w", err)
+	}
+
+	if err = execAffectingOne(ctx, tx, query, args, domain.ErrBusinessMemberNotFound); err != nil {
+		return err
+	}
+
+	query, args, err = r.psql.Update("business_members").
+		Set("role", domain.BusinessRoleOwner).
+		Where(sq.Eq{"business_id": businessID, "user_id": toUserID}).
+		Where(sq.NotEq{"accepted_at": nil}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build promote business owner query: %w", err)
+	}
+
+	if err = execAffectingOne(ctx, tx, query, args, domain.ErrBusinessMemberNotFound); err != nil {
+		return err
+	}
+
+	query, args, err = r.psql.Update("business").
+		Set("user_id", toUserID).
+		Where(sq.Eq{"id": businessID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update business owner query: %w", err)
+	}
+
+	if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update business owner query: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *BusinessMemberPersistence) selectMembers() sq.SelectBuilder {
+	return r.psql.Select(businessMemberColumns...).
+		From("business_members m").
+		Join("users u ON u.id = m.user_id")
+}
+
+// execAffectingOne runs the statement and returns notFound when it matched no row
+func execAffectingOne(ctx context.Context, execer sqlx.ExecerContext, query string, args []any, notFound error) error {
+	result, err := execer.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute business member query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return notFound
+	}
+
+	return nil
+}
@@ -6,11 +6,18 @@ import (
 	"fmt"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+// businessColumns are the columns read into a domain.Business, leaving out the search vector
+var businessColumns = []string{
+	"id", "user_id", "industry_id", "name", "description", "email", "phone_country_code", "phone_number",
+	"website_url", "logo_url", "status", "status_reason", "reviewer_id", "submitted_at", "reviewed_at", "created_at",
+}
+
 type BusinessPersistence struct {
 	db   *sqlx.DB
 	psql sq.StatementBuilderType
@@ -23,15 +30,44 @@ func NewBusinessPersistence(db *sqlx.DB) *BusinessPersistence {
 	}
 }
 
+// UnitOfWork is a helper function that executes a given function within a database transaction.
+func (r *BusinessPersistence) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	var err error
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
 func (r *BusinessPersistence) Create(tx *sqlx.Tx, business *domain.Business) error {
 	query, args, err := r.psql.Insert("business").
 		Columns(
 			"user_id", "industry_id", "name", "description", "email",
-			"phone_country_code", "phone_number", "website_url", "logo_url", "is_active",
+			"phone_country_code", "phone_number", "website_url", "logo_url", "status",
 		).
 		Values(
 			business.UserID, business.IndustryID, business.Name, business.Description, business.Email,
-			business.PhoneCountryCode, business.PhoneNumber, business.WebsiteURL, business.LogoURL, business.IsActive,
+			business.PhoneCountryCode, business.PhoneNumber, business.WebsiteURL, business.LogoURL, business.Status,
 		).
 		Suffix("RETURNING id, created_at").
 		ToSql()
@@ -57,7 +93,6 @@ func (r *BusinessPersistence) Update(tx *sqlx.Tx, business *domain.Business) err
 		Set("phone_number", business.PhoneNumber).
 		Set("website_url", business.WebsiteURL).
 		Set("logo_url", business.LogoURL).
-		Set("is_active", business.IsActive).
 		Where(sq.Eq{"id": business.ID}).
 		ToSql()
 
@@ -90,7 +125,7 @@ func (r *BusinessPersistence) Delete(tx *sqlx.Tx, id uuid.UUID) error {
 
 func (r *BusinessPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Business, error) {
 	var business domain.Business
-	query, args, err := r.psql.Select("*").From("business").
+	query, args, err := r.psql.Select(businessColumns...).From("business").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
 		ToSql()
@@ -110,14 +145,21 @@ func (r *BusinessPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domai
 }
 
 func (r *BusinessPersistence) List(ctx context.Context, filter *domain.BusinessFilters) ([]*domain.Business, error) {
-	queryBuilder := r.psql.Select("*").From("business")
+	queryBuilder := r.psql.Select(businessColumns...).From("business")
 	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
-	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+	switch {
+	case filter.Cursor != nil:
+		queryBuilder = pagination.Keyset(queryBuilder, filter.Cursor, filter.SortOrder != domain.SortOrderAsc, pagination.Limit(filter.Limit))
+	case filter.ModerationQueue:
+		queryBuilder = queryBuilder.OrderBy("submitted_at", "created_at")
+	default:
+		queryBuilder = queryBuilder.OrderBy(orderBy(filter.SortBy, filter.SortOrder, domain.BusinessSortFields)...)
+	}
 
-	if filter.Limit != nil {
+	if filter.Cursor == nil && filter.Limit != nil {
 		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
 	}
-	if filter.Offset != nil {
+	if filter.Cursor == nil && filter.Offset != nil {
 		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
 	}
 
@@ -156,23 +198,63 @@ func (r *BusinessPersistence) Count(ctx context.Context, filter *domain.Business
 	return count, nil
 }
 
-func (r *BusinessPersistence) UpdateProperty(ctx context.Context, id uuid.UUID, property domain.BusinessProperty, value any) error {
+// UpdateStatus moves the business to its status, provided no one moved it from the expected one in the meantime.
+func (r *BusinessPersistence) UpdateStatus(ctx context.Context, business *domain.Business, from domain.BusinessStatus) error {
 	query, args, err := r.psql.Update("business").
-		Set(string(property), value).
-		Where(sq.Eq{"id": id}).
+		Set("status", business.Status).
+		Set("status_reason", business.StatusReason).
+		Set("reviewer_id", business.ReviewerID).
+		Set("submitted_at", business.SubmittedAt).
+		Set("reviewed_at", business.ReviewedAt).
+		Where(sq.Eq{"id": business.ID, "status": from}).
 		ToSql()
 
 	if err != nil {
-		return fmt.Errorf("failed to build update business property query: %w", err)
+		return fmt.Errorf("failed to build update business status query: %w", err)
 	}
 
-	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
-		return fmt.Errorf("failed to execute update business property query: %w", err)
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute update business status query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get affected rows: %w", err)
+	}
+
+	if rows == 0 {
+		return domain.ErrInvalidBusinessTransition
 	}
 
 	return nil
 }
 
+// Facets counts the businesses matching the filter by each requested facet. A facet is counted
+// without its own filter, so the counts of the values not picked yet are still shown.
+func (r *BusinessPersistence) Facets(ctx context.Context, filter *domain.BusinessFilters) (domain.Facets, error) {
+	facets := domain.Facets{}
+	for _, name := range filter.Facets {
+		facetFilter := *filter
+		column := name
+		switch name {
+		case "industry_id":
+			facetFilter.IndustryID, facetFilter.IndustryIDs = nil, nil
+		default:
+			continue
+		}
+
+		queryBuilder := r.buildFilterQuery(r.psql.Select().From("business"), &facetFilter)
+		counts, err := countFacet(ctx, r.db, queryBuilder, column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count businesses by %s: %w", name, err)
+		}
+		facets[name] = counts
+	}
+
+	return facets, nil
+}
+
 func (r *BusinessPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.BusinessFilters) sq.SelectBuilder {
 	if filter.UserID != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"user_id": *filter.UserID})
@@ -180,11 +262,38 @@ func (r *BusinessPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filte
 	if filter.IndustryID != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"industry_id": *filter.IndustryID})
 	}
-	if filter.IsActive != nil {
-		baseQuery = baseQuery.Where(sq.Eq{"is_active": *filter.IsActive})
+	if len(filter.IndustryIDs) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"industry_id": filter.IndustryIDs})
+	}
+	if filter.Status != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"status": *filter.Status})
+	}
+	if filter.MemberID != nil {
+		baseQuery = baseQuery.Where(sq.Expr("id IN (SELECT business_id FROM business_members WHERE user_id = ? AND accepted_at IS NOT NULL)", *filter.MemberID))
+	}
+	if filter.Near != nil {
+		baseQuery = baseQuery.Where(nearCondition(filter.Near, "business.id"))
+	}
+	if filter.OpenNow {
+		baseQuery = baseQuery.Where(openNowCondition("business.id"))
 	}
 	if filter.NameContains != nil {
-		baseQuery = baseQuery.Where(sq.Like{"name": fmt.Sprintf("%%%s%%", *filter.NameContains)})
+		if condition, ok := searchCondition(*filter.NameContains); ok {
+			baseQuery = baseQuery.Where(condition)
+		}
+	}
+	if filter.Scope != nil {
+		if filter.Scope.ChurchID.Valid {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT id FROM users WHERE church_id = ?)", filter.Scope.ChurchID.UUID))
+		} else {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT u.id FROM users u JOIN church c ON c.id = u.church_id WHERE c.diocese = ?)", filter.Scope.Diocese.String))
+		}
 	}
 	return baseQuery
 }
+
+// approvedBusinessCondition filters the rows whose business, read from businessIDColumn, is
+// approved, the only status shown publicly.
+func approvedBusinessCondition(businessIDColumn string) sq.Sqlizer {
+	return sq.Expr("EXISTS (SELECT 1 FROM business b WHERE b.id = "+businessIDColumn+" AND b.status = ?)", domain.BusinessStatusApproved)
+}
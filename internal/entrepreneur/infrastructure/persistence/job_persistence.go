@@ -6,11 +6,16 @@ import (
 	"fmt"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+var jobColumns = []string{
+	"id", "business_id", "title", "description", "type", "location", "application_link", "is_open", "created_at",
+}
+
 type JobPersistence struct {
 	db   *sqlx.DB
 	psql sq.StatementBuilderType
@@ -87,7 +92,7 @@ func (r *JobPersistence) Delete(tx *sqlx.Tx, id uuid.UUID) error {
 
 func (r *JobPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
 	var job domain.Job
-	query, args, err := r.psql.Select("*").From("jobs").
+	query, args, err := r.psql.Select(jobColumns...).From("jobs").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
 		ToSql()
@@ -106,16 +111,42 @@ func (r *JobPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Job
 	return &job, nil
 }
 
-func (r *JobPersistence) List(ctx context.Context, filter *domain.JobFilters) ([]*domain.Job, error) {
-	queryBuilder := r.psql.Select("*").From("jobs")
-	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
-	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+// GetApprovedByID retrieves the job provided its business is approved.
+func (r *JobPersistence) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Job, error) {
+	var job domain.Job
+	query, args, err := r.psql.Select(jobColumns...).From("jobs").
+		Where(sq.Eq{"id": id}).
+		Where(approvedBusinessCondition("jobs.business_id")).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get approved job by id query: %w", err)
+	}
 
-	if filter.Limit != nil {
-		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	if err := r.db.GetContext(ctx, &job, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrJobNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get approved job by id query: %w", err)
 	}
-	if filter.Offset != nil {
-		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+
+	return &job, nil
+}
+
+func (r *JobPersistence) List(ctx context.Context, filter *domain.JobFilters) ([]*domain.Job, error) {
+	queryBuilder := r.psql.Select(jobColumns...).From("jobs")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	if filter.Cursor != nil {
+		queryBuilder = pagination.Keyset(queryBuilder, filter.Cursor, filter.SortOrder != domain.SortOrderAsc, pagination.Limit(filter.Limit))
+	} else {
+		queryBuilder = queryBuilder.OrderBy(orderBy(filter.SortBy, filter.SortOrder, domain.JobSortFields)...)
+		if filter.Limit != nil {
+			queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+		}
+		if filter.Offset != nil {
+			queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+		}
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -153,21 +184,69 @@ func (r *JobPersistence) Count(ctx context.Context, filter *domain.JobFilters) (
 	return count, nil
 }
 
+// Facets counts the jobs matching the filter by each requested facet. A facet is counted
+// without its own filter, so the counts of the values not picked yet are still shown.
+func (r *JobPersistence) Facets(ctx context.Context, filter *domain.JobFilters) (domain.Facets, error) {
+	facets := domain.Facets{}
+	for _, name := range filter.Facets {
+		facetFilter := *filter
+		column := name
+		switch name {
+		case "business_id":
+			facetFilter.BusinessID, facetFilter.BusinessIDs = nil, nil
+		case "type":
+			facetFilter.Type, facetFilter.Types = nil, nil
+		case "location":
+			facetFilter.Location, facetFilter.Locations = nil, nil
+		case "is_open":
+			facetFilter.IsOpen = nil
+		default:
+			continue
+		}
+
+		queryBuilder := r.buildFilterQuery(r.psql.Select().From("jobs"), &facetFilter)
+		counts, err := countFacet(ctx, r.db, queryBuilder, column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count jobs by %s: %w", name, err)
+		}
+		facets[name] = counts
+	}
+
+	return facets, nil
+}
+
 func (r *JobPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.JobFilters) sq.SelectBuilder {
+	if filter.ApprovedOnly {
+		baseQuery = baseQuery.Where(approvedBusinessCondition("jobs.business_id"))
+	}
 	if filter.BusinessID != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"business_id": *filter.BusinessID})
 	}
+	if len(filter.BusinessIDs) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"business_id": filter.BusinessIDs})
+	}
 	if filter.Type != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"type": *filter.Type})
 	}
+	if len(filter.Types) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"type": filter.Types})
+	}
 	if filter.Location != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"location": *filter.Location})
 	}
+	if len(filter.Locations) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"location": filter.Locations})
+	}
 	if filter.IsOpen != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"is_open": *filter.IsOpen})
 	}
+	if filter.Near != nil {
+		baseQuery = baseQuery.Where(nearCondition(filter.Near, "jobs.business_id"))
+	}
 	if filter.TitleContains != nil {
-		baseQuery = baseQuery.Where(sq.Like{"title": fmt.Sprintf("%%%s%%", *filter.TitleContains)})
+		if condition, ok := searchCondition(*filter.TitleContains); ok {
+			baseQuery = baseQuery.Where(condition)
+		}
 	}
 	return baseQuery
 }
@@ -0,0 +1,90 @@
+package persistence
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// priceRangeColumn numbers the price range of each row, 0 being below the first bound of domain.PriceRanges
+var priceRangeColumn = func() string {
+	bounds := make([]string, len(domain.PriceRanges))
+	for i, bound := range domain.PriceRanges {
+		bounds[i] = strconv.FormatFloat(bound, 'f', -1, 64)
+	}
+	return fmt.Sprintf("width_bucket(price, ARRAY[%s]::numeric[])", strings.Join(bounds, ", "))
+}()
+
+// orderBy sorts a list by one of its sort fields, newest rows first when no field is given.
+// Other fields sort ascending unless asked otherwise, and ties are broken by the newest rows.
+func orderBy(sortBy string, sortOrder domain.SortOrder, sortFields []string) []string {
+	if sortBy == "" || sortBy == "created_at" || !slices.Contains(sortFields, sortBy) {
+		if sortOrder == domain.SortOrderAsc {
+			return []string{"created_at ASC"}
+		}
+		return []string{"created_at DESC"}
+	}
+
+	if sortOrder == domain.SortOrderDesc {
+		return []string{sortBy + " DESC", "created_at DESC"}
+	}
+	return []string{sortBy + " ASC", "created_at DESC"}
+}
+
+// countFacet counts the rows of the filtered query by the value of the column, most common values first
+func countFacet(ctx context.Context, db *sqlx.DB, baseQuery sq.SelectBuilder, column string) ([]domain.FacetCount, error) {
+	query, args, err := baseQuery.
+		Columns(column+"::text AS value", "COUNT(*) AS count").
+		GroupBy("1").
+		OrderBy("count DESC", "value").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build count facet query: %w", err)
+	}
+
+	counts := []domain.FacetCount{}
+	if err := db.SelectContext(ctx, &counts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute count facet query: %w", err)
+	}
+
+	return counts, nil
+}
+
+// priceRanges labels the counts of priceRangeColumn with the bounds of their range, in price order
+func priceRanges(counts []domain.FacetCount) []domain.FacetCount {
+	ranges := make([]domain.FacetCount, 0, len(counts))
+	for _, count := range counts {
+		i, err := strconv.Atoi(count.Value)
+		if err != nil || i < 0 || i > len(domain.PriceRanges) {
+			continue
+		}
+
+		from := 0.0
+		if i > 0 {
+			from = domain.PriceRanges[i-1]
+		}
+		count.From = &from
+		count.Value = strconv.FormatFloat(from, 'f', -1, 64) + "+"
+
+		if i < len(domain.PriceRanges) {
+			to := domain.PriceRanges[i]
+			count.To = &to
+			count.Value = strconv.FormatFloat(from, 'f', -1, 64) + "-" + strconv.FormatFloat(to, 'f', -1, 64)
+		}
+		ranges = append(ranges, count)
+	}
+
+	slices.SortFunc(ranges, func(a, b domain.FacetCount) int {
+		return cmp.Compare(*a.From, *b.From)
+	})
+
+	return ranges
+}
@@ -6,11 +6,16 @@ import (
 	"fmt"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+var productColumns = []string{
+	"id", "business_id", "name", "description", "price", "image_url", "is_available", "created_at",
+}
+
 type ProductPersistence struct {
 	db   *sqlx.DB
 	psql sq.StatementBuilderType
@@ -84,7 +89,7 @@ func (r *ProductPersistence) Delete(tx *sqlx.Tx, id uuid.UUID) error {
 
 func (r *ProductPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
 	var product domain.Product
-	query, args, err := r.psql.Select("*").From("products").
+	query, args, err := r.psql.Select(productColumns...).From("products").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
 		ToSql()
@@ -103,16 +108,42 @@ func (r *ProductPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return &product, nil
 }
 
-func (r *ProductPersistence) List(ctx context.Context, filter *domain.ProductFilters) ([]*domain.Product, error) {
-	queryBuilder := r.psql.Select("*").From("products")
-	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
-	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+// GetApprovedByID retrieves the product provided its business is approved.
+func (r *ProductPersistence) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Product, error) {
+	var product domain.Product
+	query, args, err := r.psql.Select(productColumns...).From("products").
+		Where(sq.Eq{"id": id}).
+		Where(approvedBusinessCondition("products.business_id")).
+		Limit(1).
+		ToSql()
 
-	if filter.Limit != nil {
-		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get approved product by id query: %w", err)
 	}
-	if filter.Offset != nil {
-		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+
+	if err := r.db.GetContext(ctx, &product, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrProductNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get approved product by id query: %w", err)
+	}
+
+	return &product, nil
+}
+
+func (r *ProductPersistence) List(ctx context.Context, filter *domain.ProductFilters) ([]*domain.Product, error) {
+	queryBuilder := r.psql.Select(productColumns...).From("products")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	if filter.Cursor != nil {
+		queryBuilder = pagination.Keyset(queryBuilder, filter.Cursor, filter.SortOrder != domain.SortOrderAsc, pagination.Limit(filter.Limit))
+	} else {
+		queryBuilder = queryBuilder.OrderBy(orderBy(filter.SortBy, filter.SortOrder, domain.ProductSortFields)...)
+		if filter.Limit != nil {
+			queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+		}
+		if filter.Offset != nil {
+			queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+		}
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -150,15 +181,56 @@ func (r *ProductPersistence) Count(ctx context.Context, filter *domain.ProductFi
 	return count, nil
 }
 
+// Facets counts the products matching the filter by each requested facet. A facet is counted
+// without its own filter, so the counts of the values not picked yet are still shown.
+func (r *ProductPersistence) Facets(ctx context.Context, filter *domain.ProductFilters) (domain.Facets, error) {
+	facets := domain.Facets{}
+	for _, name := range filter.Facets {
+		facetFilter := *filter
+		column := name
+		switch name {
+		case "business_id":
+			facetFilter.BusinessID, facetFilter.BusinessIDs = nil, nil
+		case "is_available":
+			facetFilter.IsAvailable = nil
+		case "price":
+			facetFilter.MinPrice, facetFilter.MaxPrice = nil, nil
+			column = priceRangeColumn
+		default:
+			continue
+		}
+
+		queryBuilder := r.buildFilterQuery(r.psql.Select().From("products"), &facetFilter)
+		counts, err := countFacet(ctx, r.db, queryBuilder, column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count products by %s: %w", name, err)
+		}
+		if name == "price" {
+			counts = priceRanges(counts)
+		}
+		facets[name] = counts
+	}
+
+	return facets, nil
+}
+
 func (r *ProductPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.ProductFilters) sq.SelectBuilder {
+	if filter.ApprovedOnly {
+		baseQuery = baseQuery.Where(approvedBusinessCondition("products.business_id"))
+	}
 	if filter.BusinessID != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"business_id": *filter.BusinessID})
 	}
+	if len(filter.BusinessIDs) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"business_id": filter.BusinessIDs})
+	}
 	if filter.IsAvailable != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"is_available": *filter.IsAvailable})
 	}
 	if filter.NameContains != nil {
-		baseQuery = baseQuery.Where(sq.Like{"name": fmt.Sprintf("%%%s%%", *filter.NameContains)})
+		if condition, ok := searchCondition(*filter.NameContains); ok {
+			baseQuery = baseQuery.Where(condition)
+		}
 	}
 	if filter.MinPrice != nil {
 		baseQuery = baseQuery.Where(sq.GtOrEq{"price": *filter.MinPrice})
@@ -0,0 +1,38 @@
+package persistence
+
+import (
+	"strings"
+	"unicode"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// searchQuery matches the search vectors against the words in both dictionaries they were
+// indexed with, so a word stemmed differently by each language is still found.
+const searchQuery = "(to_tsquery('portuguese_unaccent', ?) || to_tsquery('english_unaccent', ?))"
+
+// prefixQuery turns free text into a tsquery matching every word, the last ones as prefixes so
+// results show up while the user is still typing. Operators typed by the user are dropped, which
+// keeps to_tsquery from failing on its syntax. It returns an empty string when there are no words.
+func prefixQuery(text string) string {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	for i, word := range words {
+		words[i] = word + ":*"
+	}
+
+	return strings.Join(words, " & ")
+}
+
+// searchCondition filters the rows whose search vector matches the text. It reports false when the
+// text has no words to search for, in which case no filter should be applied.
+func searchCondition(text string) (sq.Sqlizer, bool) {
+	query := prefixQuery(text)
+	if query == "" {
+		return nil, false
+	}
+
+	return sq.Expr("search_vector @@ "+searchQuery, query, query), true
+}
@@ -0,0 +1,161 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// headlineOptions marks the matched words of a snippet and keeps it to a couple of short fragments
+const headlineOptions = "StartSel=<mark>, StopSel=</mark>, MaxWords=30, MinWords=10, MaxFragments=2"
+
+type SearchPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+func NewSearchPersistence(db *sqlx.DB) *SearchPersistence {
+	return &SearchPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Search lists the businesses, products, services and jobs matching the query, best matches first.
+// Only approved businesses are searched, along with the available products, the services and the
+// open jobs of those businesses.
+func (r *SearchPersistence) Search(ctx context.Context, filter *domain.SearchFilters) ([]*domain.SearchResult, error) {
+	results := []*domain.SearchResult{}
+	union, ok, err := r.buildUnionQuery(filter)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return results, nil
+	}
+
+	// Snippets are highlighted in the outer query, Postgres only computes them for the rows returned
+	queryBuilder := r.withSearchQuery(r.psql.Select(
+		"results.type", "results.id", "results.business_id", "results.title",
+		fmt.Sprintf("ts_headline('portuguese_unaccent', results.description, search.query, '%s') AS snippet", headlineOptions),
+		"results.rank",
+	), filter).
+		FromSelect(union, "results").
+		CrossJoin("search").
+		OrderBy("results.rank DESC", "results.created_at DESC")
+
+	if filter.Limit != nil {
+		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	}
+	if filter.Offset != nil {
+		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build search query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &results, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute search query: %w", err)
+	}
+
+	return results, nil
+}
+
+func (r *SearchPersistence) Count(ctx context.Context, filter *domain.SearchFilters) (int, error) {
+	union, ok, err := r.buildUnionQuery(filter)
+	if err != nil {
+		return 0, err
+	}
+	if !ok {
+		return 0, nil
+	}
+
+	query, args, err := r.withSearchQuery(r.psql.Select("COUNT(*)"), filter).
+		FromSelect(union, "results").
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count search query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count search query: %w", err)
+	}
+
+	return count, nil
+}
+
+// withSearchQuery parses the query once, as the "search" table every part of the union joins
+func (r *SearchPersistence) withSearchQuery(baseQuery sq.SelectBuilder, filter *domain.SearchFilters) sq.SelectBuilder {
+	query := prefixQuery(filter.Query)
+	return baseQuery.Prefix("WITH search AS (SELECT "+searchQuery+" AS query)", query, query)
+}
+
+// buildUnionQuery selects the matches of every searched entity, with the same columns. It reports
+// false when there is nothing to search, either no words in the query or no entity left to search.
+func (r *SearchPersistence) buildUnionQuery(filter *domain.SearchFilters) (sq.SelectBuilder, bool, error) {
+	var selects []sq.SelectBuilder
+	if prefixQuery(filter.Query) == "" {
+		return sq.SelectBuilder{}, false, nil
+	}
+
+	if filter.Searches(domain.SearchEntityBusiness) {
+		selects = append(selects, searchSelect(domain.SearchEntityBusiness, "b", "b.name").
+			From("business b"))
+	}
+	if filter.Searches(domain.SearchEntityProduct) {
+		selects = append(selects, searchSelect(domain.SearchEntityProduct, "p", "p.name").
+			From("products p").
+			Join("business b ON b.id = p.business_id").
+			Where(sq.Eq{"p.is_available": true}))
+	}
+	if filter.Searches(domain.SearchEntityService) {
+		selects = append(selects, searchSelect(domain.SearchEntityService, "s", "s.name").
+			From("services s").
+			Join("business b ON b.id = s.business_id"))
+	}
+	if filter.Searches(domain.SearchEntityJob) {
+		selects = append(selects, searchSelect(domain.SearchEntityJob, "j", "j.title").
+			From("jobs j").
+			Join("business b ON b.id = j.business_id").
+			Where(sq.Eq{"j.is_open": true}))
+	}
+
+	if len(selects) == 0 {
+		return sq.SelectBuilder{}, false, nil
+	}
+
+	union := selects[0]
+	for _, next := range selects[1:] {
+		sql, args, err := next.ToSql()
+		if err != nil {
+			return sq.SelectBuilder{}, false, fmt.Errorf("failed to build search union query: %w", err)
+		}
+		union = union.Suffix("UNION ALL "+sql, args...)
+	}
+
+	return union, true, nil
+}
+
+// searchSelect selects the columns of a search result from the table aliased as alias, which is
+// either a business or joined with its business as "b".
+func searchSelect(entity domain.SearchEntity, alias, title string) sq.SelectBuilder {
+	return sq.Select(
+		fmt.Sprintf("'%s' AS type", entity),
+		alias+".id",
+		"b.id AS business_id",
+		title+" AS title",
+		alias+".description",
+		fmt.Sprintf("ts_rank(%s.search_vector, search.query) AS rank", alias),
+		alias+".created_at",
+	).
+		CrossJoin("search").
+		Where(alias + ".search_vector @@ search.query").
+		Where(sq.Eq{"b.status": domain.BusinessStatusApproved})
+}
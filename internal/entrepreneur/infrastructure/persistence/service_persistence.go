@@ -6,11 +6,14 @@ import (
 	"fmt"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
 )
 
+var serviceColumns = []string{"id", "business_id", "name", "description", "price", "created_at"}
+
 type ServicePersistence struct {
 	db   *sqlx.DB
 	psql sq.StatementBuilderType
@@ -81,7 +84,7 @@ func (r *ServicePersistence) Delete(tx *sqlx.Tx, id uuid.UUID) error {
 
 func (r *ServicePersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
 	var service domain.Service
-	query, args, err := r.psql.Select("*").From("services").
+	query, args, err := r.psql.Select(serviceColumns...).From("services").
 		Where(sq.Eq{"id": id}).
 		Limit(1).
 		ToSql()
@@ -100,16 +103,42 @@ func (r *ServicePersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain
 	return &service, nil
 }
 
-func (r *ServicePersistence) List(ctx context.Context, filter *domain.ServiceFilters) ([]*domain.Service, error) {
-	queryBuilder := r.psql.Select("*").From("services")
-	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
-	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+// GetApprovedByID retrieves the service provided its business is approved.
+func (r *ServicePersistence) GetApprovedByID(ctx context.Context, id uuid.UUID) (*domain.Service, error) {
+	var service domain.Service
+	query, args, err := r.psql.Select(serviceColumns...).From("services").
+		Where(sq.Eq{"id": id}).
+		Where(approvedBusinessCondition("services.business_id")).
+		Limit(1).
+		ToSql()
 
-	if filter.Limit != nil {
-		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get approved service by id query: %w", err)
 	}
-	if filter.Offset != nil {
-		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+
+	if err := r.db.GetContext(ctx, &service, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrServiceNotFound
+		}
+		return nil, fmt.Errorf("failed to execute get approved service by id query: %w", err)
+	}
+
+	return &service, nil
+}
+
+func (r *ServicePersistence) List(ctx context.Context, filter *domain.ServiceFilters) ([]*domain.Service, error) {
+	queryBuilder := r.psql.Select(serviceColumns...).From("services")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	if filter.Cursor != nil {
+		queryBuilder = pagination.Keyset(queryBuilder, filter.Cursor, filter.SortOrder != domain.SortOrderAsc, pagination.Limit(filter.Limit))
+	} else {
+		queryBuilder = queryBuilder.OrderBy(orderBy(filter.SortBy, filter.SortOrder, domain.ServiceSortFields)...)
+		if filter.Limit != nil {
+			queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+		}
+		if filter.Offset != nil {
+			queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+		}
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -147,12 +176,54 @@ func (r *ServicePersistence) Count(ctx context.Context, filter *domain.ServiceFi
 	return count, nil
 }
 
+// Facets counts the services matching the filter by each requested facet. A facet is counted
+// without its own filter, so the counts of the values not picked yet are still shown.
+func (r *ServicePersistence) Facets(ctx context.Context, filter *domain.ServiceFilters) (domain.Facets, error) {
+	facets := domain.Facets{}
+	for _, name := range filter.Facets {
+		facetFilter := *filter
+		column := name
+		switch name {
+		case "business_id":
+			facetFilter.BusinessID, facetFilter.BusinessIDs = nil, nil
+		case "price":
+			facetFilter.MinPrice, facetFilter.MaxPrice = nil, nil
+			column = priceRangeColumn
+		default:
+			continue
+		}
+
+		queryBuilder := r.buildFilterQuery(r.psql.Select().From("services"), &facetFilter)
+		counts, err := countFacet(ctx, r.db, queryBuilder, column)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count services by %s: %w", name, err)
+		}
+		if name == "price" {
+			counts = priceRanges(counts)
+		}
+		facets[name] = counts
+	}
+
+	return facets, nil
+}
+
 func (r *ServicePersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.ServiceFilters) sq.SelectBuilder {
+	if filter.ApprovedOnly {
+		baseQuery = baseQuery.Where(approvedBusinessCondition("services.business_id"))
+	}
 	if filter.BusinessID != nil {
 		baseQuery = baseQuery.Where(sq.Eq{"business_id": *filter.BusinessID})
 	}
+	if len(filter.BusinessIDs) > 0 {
+		baseQuery = baseQuery.Where(sq.Eq{"business_id": filter.BusinessIDs})
+	}
+	if filter.Near != nil {
+		baseQuery = baseQuery.Where(nearCondition(filter.Near, "services.business_id"))
+	}
 	if filter.NameContains != nil {
-		baseQuery = baseQuery.Where(sq.Like{"name": fmt.Sprintf("%%%s%%", *filter.NameContains)})
+		if condition, ok := searchCondition(*filter.NameContains); ok {
+			baseQuery = baseQuery.Where(condition)
+		}
 	}
 	if filter.MinPrice != nil {
 		baseQuery = baseQuery.Where(sq.GtOrEq{"price": *filter.MinPrice})
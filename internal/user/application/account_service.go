@@ -0,0 +1,236 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	entrepreneurDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// AccountService covers the rights of users over their personal data: exporting a copy of it
+// and deleting their account. Deletion is scheduled after a grace period during which the user
+// may change their mind, then the account is purged by PurgeDue.
+type AccountService struct {
+	logger       *zap.SugaredLogger
+	config       config.Config
+	queue        storage.QueueStorage
+	userRepo     domain.UserRepository
+	addressRepo  adminDomain.AddressRepository
+	businessRepo entrepreneurDomain.BusinessRepository
+	productRepo  entrepreneurDomain.ProductRepository
+	serviceRepo  entrepreneurDomain.ServiceRepository
+	jobRepo      entrepreneurDomain.JobRepository
+	userService  *UserService
+	sessions     *SessionService
+	auth         *AuthService
+}
+
+func NewAccountService(
+	logger *zap.SugaredLogger,
+	cfg config.Config,
+	queue storage.QueueStorage,
+	userRepo domain.UserRepository,
+	addressRepo adminDomain.AddressRepository,
+	businessRepo entrepreneurDomain.BusinessRepository,
+	productRepo entrepreneurDomain.ProductRepository,
+	serviceRepo entrepreneurDomain.ServiceRepository,
+	jobRepo entrepreneurDomain.JobRepository,
+	userService *UserService,
+	sessionService *SessionService,
+	authService *AuthService,
+) *AccountService {
+	return &AccountService{
+		logger:       logger,
+		config:       cfg,
+		queue:        queue,
+		userRepo:     userRepo,
+		addressRepo:  addressRepo,
+		businessRepo: businessRepo,
+		productRepo:  productRepo,
+		serviceRepo:  serviceRepo,
+		jobRepo:      jobRepo,
+		userService:  userService,
+		sessions:     sessionService,
+		auth:         authService,
+	}
+}
+
+// Export gathers the personal data of the user along with the businesses they own and their catalog
+func (s *AccountService) Export(ctx context.Context, userID uuid.UUID) (*dto.UserDataExport, error) {
+	profile, err := s.userService.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	export := &dto.UserDataExport{
+		ExportedAt:              time.Now().UTC(),
+		User:                    profile.User,
+		NotificationPreferences: profile.NotificationPreferences,
+		JobProfile:              profile.JobProfile,
+		Products:                []*entrepreneurDomain.Product{},
+		Services:                []*entrepreneurDomain.Service{},
+		Jobs:                    []*entrepreneurDomain.Job{},
+	}
+
+	address, err := s.addressRepo.GetByID(ctx, profile.User.AddressID)
+	if err != nil && !errors.Is(err, adminDomain.ErrAddressNotFound) {
+		s.logger.Errorw("failed to get address by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+	export.Address = address
+
+	export.Businesses, err = s.businessRepo.List(ctx, &entrepreneurDomain.BusinessFilters{UserID: &userID})
+	if err != nil {
+		s.logger.Errorw("failed to list businesses", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	for _, business := range export.Businesses {
+		products, err := s.productRepo.List(ctx, &entrepreneurDomain.ProductFilters{BusinessID: &business.ID})
+		if err != nil {
+			s.logger.Errorw("failed to list products", "businessID", business.ID, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+		export.Products = append(export.Products, products...)
+
+		services, err := s.serviceRepo.List(ctx, &entrepreneurDomain.ServiceFilters{BusinessID: &business.ID})
+		if err != nil {
+			s.logger.Errorw("failed to list services", "businessID", business.ID, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+		export.Services = append(export.Services, services...)
+
+		jobs, err := s.jobRepo.List(ctx, &entrepreneurDomain.JobFilters{BusinessID: &business.ID})
+		if err != nil {
+			s.logger.Errorw("failed to list jobs", "businessID", business.ID, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+		export.Jobs = append(export.Jobs, jobs...)
+	}
+
+	return export, nil
+}
+
+// RequestDeletion schedules the deletion of the account at the end of the grace period and emails
+// the user the date. Owners of businesses shared with other members have to hand them over first.
+func (s *AccountService) RequestDeletion(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.DeletionScheduledAt.Valid {
+		return nil, domain.ErrDeletionScheduled
+	}
+
+	shared, err := s.userRepo.OwnsSharedBusiness(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("failed to check shared businesses", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+	if shared {
+		return nil, domain.ErrSharedBusinessOwner
+	}
+
+	scheduledAt := sql.NullTime{Time: time.Now().Add(s.config.Application.AccountDeletionGracePeriod).UTC(), Valid: true}
+	if err := s.userRepo.UpdateProperty(ctx, userID, domain.DeletionScheduledAt, scheduledAt); err != nil {
+		s.logger.Errorw("failed to schedule account deletion", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+	user.DeletionScheduledAt = scheduledAt
+
+	link := s.auth.frontendLink("user", "account")
+	if err := SendReviewEmail(ctx, s.queue, s.config.SMTP.From, user, constants.EMAIL_TEMPLATE_ACCOUNT_DELETION, "email.account_deletion_scheduled", scheduledAt.Time.Format(time.DateOnly), link); err != nil {
+		s.logger.Warnw("failed to send account deletion scheduled email", "userID", userID, "error", err)
+	}
+
+	return user, nil
+}
+
+// CancelDeletion keeps the account of a user who changed their mind during the grace period
+func (s *AccountService) CancelDeletion(ctx context.Context, userID uuid.UUID) error {
+	user, err := s.getUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.DeletionScheduledAt.Valid {
+		return domain.ErrDeletionNotScheduled
+	}
+
+	if err := s.userRepo.UpdateProperty(ctx, userID, domain.DeletionScheduledAt, sql.NullTime{}); err != nil {
+		s.logger.Errorw("failed to cancel account deletion", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// PurgeDue deletes the accounts whose grace period is over, signs them out everywhere and
+// confirms the deletion by email. Accounts that came to own a shared business meanwhile are
+// left for a later run, once the business has been handed over.
+func (s *AccountService) PurgeDue(ctx context.Context) error {
+	users, err := s.userRepo.GetAllDueForDeletion(ctx, time.Now())
+	if err != nil {
+		s.logger.Errorw("failed to list accounts due for deletion", "error", err)
+		return response.ErrInternalServerError
+	}
+
+	purged := 0
+	for _, user := range users {
+		shared, err := s.userRepo.OwnsSharedBusiness(ctx, user.ID)
+		if err != nil {
+			s.logger.Errorw("failed to check shared businesses", "userID", user.ID, "error", err)
+			continue
+		}
+		if shared {
+			s.logger.Warnw("postponing deletion of shared business owner", "userID", user.ID)
+			continue
+		}
+
+		if err := s.userRepo.Delete(ctx, user.ID); err != nil {
+			s.logger.Errorw("failed to delete account", "userID", user.ID, "error", err)
+			continue
+		}
+		purged++
+
+		if err := s.sessions.RevokeAll(ctx, user.ID); err != nil {
+			s.logger.Warnw("failed to revoke sessions of deleted account", "userID", user.ID, "error", err)
+		}
+
+		if err := SendReviewEmail(ctx, s.queue, s.config.SMTP.From, user, constants.EMAIL_TEMPLATE_ACCOUNT_DELETION, "email.account_deleted", "", ""); err != nil {
+			s.logger.Warnw("failed to send account deleted email", "userID", user.ID, "error", err)
+		}
+	}
+
+	if purged > 0 {
+		s.logger.Infow("purged deleted accounts", "count", purged)
+	}
+
+	return nil
+}
+
+func (s *AccountService) getUser(ctx context.Context, userID uuid.UUID) (*domain.User, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return user, nil
+}
@@ -0,0 +1,168 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+const accountTestGracePeriod = 30 * 24 * time.Hour
+
+func setupAccountTest(t *testing.T) (*AccountService, *authTestDeps) {
+	authService, deps := setupAuthTestDeps(t)
+	logger := zap.NewNop().Sugar()
+	cfg := config.Config{}
+	cfg.Application.AccountDeletionGracePeriod = accountTestGracePeriod
+
+	client := redis.NewClient(&redis.Options{Addr: deps.redis.Addr()})
+	t.Cleanup(func() { client.Close() })
+	sessions := NewSessionService(logger, storage.NewCacheStorage(client))
+
+	service := NewAccountService(logger, cfg, deps.queue, deps.userRepo, nil, nil, nil, nil, nil, nil, sessions, authService)
+
+	return service, deps
+}
+
+// expectAccountEmail expects the account email of the section to be sent to the address
+func expectAccountEmail(ctx context.Context, deps *authTestDeps, to, section string) {
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.MatchedBy(func(body []byte) bool {
+		var payload NotificationPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return false
+		}
+		return len(payload.To) == 1 && payload.To[0] == to &&
+			payload.TemplateName == constants.EMAIL_TEMPLATE_ACCOUNT_DELETION &&
+			payload.Subject == "email.account_"+section+".subject"
+	})).Return(nil).Once()
+}
+
+func TestAccountService_RequestDeletion(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), FirstName: "Maria", Email: "maria@example.com", Language: sql.NullString{String: "en-US", Valid: true}}
+
+	t.Run("Success", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(&domain.User{ID: user.ID, FirstName: user.FirstName, Email: user.Email}, nil)
+		deps.userRepo.On("OwnsSharedBusiness", ctx, user.ID).Return(false, nil)
+		deps.userRepo.On("UpdateProperty", ctx, user.ID, domain.DeletionScheduledAt, mock.MatchedBy(func(v sql.NullTime) bool {
+			return v.Valid && time.Until(v.Time) > accountTestGracePeriod-time.Minute
+		})).Return(nil)
+		expectAccountEmail(ctx, deps, user.Email, "deletion_scheduled")
+
+		scheduled, err := service.RequestDeletion(ctx, user.ID)
+
+		require.NoError(t, err)
+		assert.True(t, scheduled.DeletionScheduledAt.Valid)
+		deps.userRepo.AssertExpectations(t)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyScheduled", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(&domain.User{ID: user.ID, DeletionScheduledAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+
+		scheduled, err := service.RequestDeletion(ctx, user.ID)
+
+		assert.Nil(t, scheduled)
+		assert.Equal(t, domain.ErrDeletionScheduled, err)
+		deps.userRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("SharedBusinessOwner", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(&domain.User{ID: user.ID}, nil)
+		deps.userRepo.On("OwnsSharedBusiness", ctx, user.ID).Return(true, nil)
+
+		scheduled, err := service.RequestDeletion(ctx, user.ID)
+
+		assert.Nil(t, scheduled)
+		assert.Equal(t, domain.ErrSharedBusinessOwner, err)
+		deps.userRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(nil, domain.ErrUserNotFound)
+
+		scheduled, err := service.RequestDeletion(ctx, user.ID)
+
+		assert.Nil(t, scheduled)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+}
+
+func TestAccountService_CancelDeletion(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("Success", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, DeletionScheduledAt: sql.NullTime{Time: time.Now(), Valid: true}}, nil)
+		deps.userRepo.On("UpdateProperty", ctx, userID, domain.DeletionScheduledAt, sql.NullTime{}).Return(nil)
+
+		err := service.CancelDeletion(ctx, userID)
+
+		require.NoError(t, err)
+		deps.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("NotScheduled", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID}, nil)
+
+		err := service.CancelDeletion(ctx, userID)
+
+		assert.Equal(t, domain.ErrDeletionNotScheduled, err)
+		deps.userRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccountService_PurgeDue(t *testing.T) {
+	ctx := context.Background()
+	due := &domain.User{ID: uuid.New(), FirstName: "Maria", Email: "maria@example.com"}
+	owner := &domain.User{ID: uuid.New(), FirstName: "José", Email: "jose@example.com"}
+
+	t.Run("DeletesDueAccounts", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetAllDueForDeletion", ctx, mock.AnythingOfType("time.Time")).Return([]*domain.User{due, owner}, nil)
+		deps.userRepo.On("OwnsSharedBusiness", ctx, due.ID).Return(false, nil)
+		deps.userRepo.On("OwnsSharedBusiness", ctx, owner.ID).Return(true, nil)
+		deps.userRepo.On("Delete", ctx, due.ID).Return(nil)
+		expectAccountEmail(ctx, deps, due.Email, "deleted")
+
+		err := service.PurgeDue(ctx)
+
+		require.NoError(t, err)
+		deps.userRepo.AssertExpectations(t)
+		deps.userRepo.AssertNotCalled(t, "Delete", ctx, owner.ID)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("DeleteFailureMovesOn", func(t *testing.T) {
+		service, deps := setupAccountTest(t)
+		deps.userRepo.On("GetAllDueForDeletion", ctx, mock.AnythingOfType("time.Time")).Return([]*domain.User{owner, due}, nil)
+		deps.userRepo.On("OwnsSharedBusiness", ctx, mock.Anything).Return(false, nil)
+		deps.userRepo.On("Delete", ctx, owner.ID).Return(assert.AnError)
+		deps.userRepo.On("Delete", ctx, due.ID).Return(nil)
+		expectAccountEmail(ctx, deps, due.Email, "deleted")
+
+		err := service.PurgeDue(ctx)
+
+		require.NoError(t, err)
+		deps.userRepo.AssertExpectations(t)
+		deps.queue.AssertExpectations(t)
+	})
+}
@@ -4,7 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
-	"fmt"
+	"strings"
 	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
@@ -15,14 +15,44 @@ import (
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/i18n"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
 const (
-	emailVerificationExpiry = 24 * time.Hour
-	passwordResetExpiry     = 1 * time.Hour
+	emailVerificationExpiry  = 24 * time.Hour
+	passwordResetExpiry      = 1 * time.Hour
+	accountUnlockExpiry      = 24 * time.Hour
+	magicLinkExpiry          = 15 * time.Minute
+	twoFactorChallengeExpiry = 5 * time.Minute
+	// twoFactorChallengeAttempts bounds the codes that can be tried against a single challenge
+	twoFactorChallengeAttempts = 5
+	// verificationResendLimit bounds the verification emails sent to an address within verificationResendWindow
+	verificationResendLimit  = 3
+	verificationResendWindow = 1 * time.Hour
+	// magicLinkLimit bounds the sign-in links sent to an address within magicLinkWindow
+	magicLinkLimit  = 5
+	magicLinkWindow = 1 * time.Hour
+	// passwordHistoryLimit is how many previous passwords a user cannot go back to
+	passwordHistoryLimit = 5
 )
 
+// Second factors a login challenge can be answered with
+const (
+	twoFactorMethodTOTP    = "totp"
+	twoFactorMethodPasskey = "passkey"
+)
+
+// twoFactorChallenge is stored between the password and the second factor steps of a login
+type twoFactorChallenge struct {
+	UserID string `redis:"user_id"`
+	Email  string `redis:"email"`
+	// PasskeyID is the passkey that proved the first factor, which cannot prove the second as well
+	PasskeyID string `redis:"passkey_id"`
+	// Attempts is counted in place by takeChallengeAttempt
+	Attempts int `redis:"attempts"`
+}
+
 // NotificationPayload represents the payload sent to the notification queue
 type NotificationPayload struct {
 	From         string   `json:"from"`
@@ -39,9 +69,13 @@ type AuthService struct {
 	queue        storage.QueueStorage
 	tokenManager *auth.TokenManager
 	userRepo     domain.UserRepository
+	passkeyRepo  domain.PasskeyRepository
+	sessions     *SessionService
+	twoFactor    *TwoFactorService
+	lockouts     *LockoutService
 }
 
-func NewAuthService(logger *zap.SugaredLogger, cfg config.Config, cache storage.CacheStorage, queue storage.QueueStorage, tokenManager *auth.TokenManager, userRepo domain.UserRepository) *AuthService {
+func NewAuthService(logger *zap.SugaredLogger, cfg config.Config, cache storage.CacheStorage, queue storage.QueueStorage, tokenManager *auth.TokenManager, userRepo domain.UserRepository, passkeyRepo domain.PasskeyRepository, sessions *SessionService, twoFactor *TwoFactorService, lockouts *LockoutService) *AuthService {
 	return &AuthService{
 		logger:       logger,
 		config:       cfg,
@@ -49,34 +83,491 @@ func NewAuthService(logger *zap.SugaredLogger, cfg config.Config, cache storage.
 		queue:        queue,
 		tokenManager: tokenManager,
 		userRepo:     userRepo,
+		passkeyRepo:  passkeyRepo,
+		sessions:     sessions,
+		twoFactor:    twoFactor,
+		lockouts:     lockouts,
 	}
 }
 
-func (s *AuthService) Login(ctx context.Context, req *dto.UserLoginRequest) (string, error) {
+// Login validates the credentials, opens a session for the client and issues its tokens.
+// Users with two-factor authentication get a challenge token to complete with VerifyTwoFactor instead.
+// Repeated failures throttle further attempts, see LockoutService.
+func (s *AuthService) Login(ctx context.Context, req *dto.UserLoginRequest, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	if err := s.lockouts.Check(ctx, req.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
 	user, err := s.userRepo.GetByEmail(ctx, req.Email)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
-			return "", domain.ErrUserNotFound
+			s.recordLoginFailure(ctx, req.Email, nil, client)
+			return nil, domain.ErrUserNotFound
 		}
 
 		s.logger.Errorw("failed to get user by email", "email", req.Email, "error", err)
-		return "", response.ErrInternalServerError
+		return nil, response.ErrInternalServerError
 	}
 
 	if !user.IsActive {
-		return "", domain.ErrUserInactive
+		return nil, domain.ErrUserInactive
 	}
 
 	if !user.IsVerified {
-		return "", domain.ErrEmailNotVerified
+		return nil, domain.ErrEmailNotVerified
 	}
 
 	if err := auth.VerifyPassword(user.Password, req.Password); err != nil {
 		s.logger.Errorw("failed to verify password", "userID", user.ID, "error", err)
-		return "", domain.ErrInvalidPassword
+		s.recordLoginFailure(ctx, req.Email, user, client)
+		return nil, domain.ErrInvalidPassword
+	}
+
+	resp, err := s.completeLogin(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	// With a second factor pending, the failures are only forgotten once it checks out
+	if !resp.TwoFactorRequired {
+		s.lockouts.Reset(ctx, req.Email)
+	}
+
+	return resp, nil
+}
+
+// completeLogin opens a session for a user who passed the first factor, or starts the
+// two-factor challenge when the user has TOTP enabled or a passkey registered
+func (s *AuthService) completeLogin(ctx context.Context, user *domain.User, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	return s.completeLoginWith(ctx, user, uuid.Nil, client)
+}
+
+// completeLoginWith is completeLogin for a first factor proved with a passkey, which is left out
+// of the second factors. A user without another one gets a session like a user without two-factor.
+func (s *AuthService) completeLoginWith(ctx context.Context, user *domain.User, passkeyID uuid.UUID, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	passkeys, err := s.passkeyRepo.CountByUserID(ctx, user.ID)
+	if err != nil {
+		s.logger.Errorw("failed to count passkeys", "userID", user.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+	if passkeyID != uuid.Nil {
+		passkeys--
+	}
+
+	var methods []string
+	if user.IsTwoFactorEnabled {
+		methods = append(methods, twoFactorMethodTOTP)
+	}
+	if passkeys > 0 {
+		methods = append(methods, twoFactorMethodPasskey)
+	}
+
+	if len(methods) > 0 {
+		return s.createTwoFactorChallenge(ctx, user, passkeyID, methods)
+	}
+
+	session, err := s.sessions.Create(ctx, user.ID, client, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session.ID)
+}
+
+// RequestMagicLink emails a single-use sign-in link, when the deployment allows it. As with
+// password resets, unknown and unusable accounts are skipped without telling the caller.
+func (s *AuthService) RequestMagicLink(ctx context.Context, email string) error {
+	if !s.config.Application.MagicLinkLogin {
+		return domain.ErrMagicLinkDisabled
+	}
+
+	email = normalizeEmail(email)
+	sent, err := s.cache.Incr(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_MAGIC_LINK, "sent", email), magicLinkWindow)
+	if err != nil {
+		s.logger.Errorw("failed to count magic links", "email", email, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if sent > magicLinkLimit {
+		return domain.ErrTooManyMagicLinks
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+
+		s.logger.Errorw("failed to get user by email", "email", email, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if !user.IsActive || !user.IsVerified {
+		return nil
+	}
+
+	if err := s.SendMagicLinkEmail(ctx, user); err != nil {
+		s.logger.Errorw("failed to send magic link email", "userID", user.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// LoginWithMagicLink exchanges a magic link token for the same response as Login
+func (s *AuthService) LoginWithMagicLink(ctx context.Context, token string, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	if !s.config.Application.MagicLinkLogin {
+		return nil, domain.ErrMagicLinkDisabled
+	}
+
+	id, err := s.cache.GetStringAndDel(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_MAGIC_LINK, token))
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return nil, domain.ErrInvalidMagicLink
+		}
+
+		s.logger.Errorw("failed to get magic link token", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, domain.ErrInvalidMagicLink
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidMagicLink
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	return s.completeLogin(ctx, user, client)
+}
+
+// recordLoginFailure counts a failed login and emails the owner of an account that just got locked
+func (s *AuthService) recordLoginFailure(ctx context.Context, email string, user *domain.User, client *dto.ClientInfo) {
+	lockout, err := s.lockouts.RecordFailure(ctx, email, user, client.IPAddress)
+	if err != nil || lockout == nil || user == nil {
+		// The attempt is rejected either way, errors were already logged
+		return
+	}
+
+	if err := s.SendAccountUnlockEmail(ctx, user); err != nil {
+		s.logger.Errorw("failed to send account unlock email", "userID", user.ID, "error", err)
+	}
+}
+
+// UnlockAccount lifts a lockout with the token sent by SendAccountUnlockEmail
+func (s *AuthService) UnlockAccount(ctx context.Context, token string) error {
+	id, err := s.cache.GetStringAndDel(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_ACCOUNT_UNLOCK, token))
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return domain.ErrInvalidUnlockToken
+		}
+
+		s.logger.Errorw("failed to get account unlock token", "error", err)
+		return response.ErrInternalServerError
+	}
+
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return domain.ErrInvalidUnlockToken
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return domain.ErrInvalidUnlockToken
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return s.lockouts.Unlock(ctx, user.Email)
+}
+
+// VerifyTwoFactor completes a login challenge with a TOTP or recovery code
+func (s *AuthService) VerifyTwoFactor(ctx context.Context, req *dto.TwoFactorVerifyRequest, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	challengeKey, challenge, userID, err := s.loadTwoFactorChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// Wrong codes count against the account like wrong passwords, a locked account cannot keep guessing
+	if err := s.lockouts.Check(ctx, challenge.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if err := s.takeChallengeAttempt(ctx, challengeKey); err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactor.Verify(ctx, userID, req.Code, req.RecoveryCode); err != nil {
+		if errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+			s.recordTwoFactorFailure(ctx, userID, client)
+		}
+
+		return nil, err
+	}
+
+	return s.finishTwoFactorChallenge(ctx, challengeKey, userID, client)
+}
+
+// loadTwoFactorChallenge looks up a pending login challenge along with the user it was issued to
+func (s *AuthService) loadTwoFactorChallenge(ctx context.Context, token string) (string, *twoFactorChallenge, uuid.UUID, error) {
+	if token == "" {
+		return "", nil, uuid.Nil, domain.ErrInvalidTwoFactorChallenge
+	}
+
+	challengeKey := s.cache.BuildKey(storage.CACHE_PREFIX_TWO_FACTOR_CHALLENGE, token)
+	var challenge twoFactorChallenge
+	if err := s.cache.Get(ctx, challengeKey, &challenge); err != nil {
+		s.logger.Errorw("failed to get two-factor challenge", "error", err)
+		return "", nil, uuid.Nil, response.ErrInternalServerError
+	}
+
+	userID, err := uuid.Parse(challenge.UserID)
+	if err != nil {
+		return "", nil, uuid.Nil, domain.ErrInvalidTwoFactorChallenge
+	}
+
+	return challengeKey, &challenge, userID, nil
+}
+
+// finishTwoFactorChallenge consumes a challenge whose second factor checked out and opens a
+// session that satisfies RequireTwoFactor
+func (s *AuthService) finishTwoFactorChallenge(ctx context.Context, challengeKey string, userID uuid.UUID, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	if err := s.cache.Del(ctx, challengeKey); err != nil {
+		s.logger.Errorw("failed to delete two-factor challenge", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidTwoFactorChallenge
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	resp, err := s.openTwoFactorSession(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	s.lockouts.Reset(ctx, user.Email)
+
+	return resp, nil
+}
+
+// openTwoFactorSession opens a session for a user who proved both factors
+func (s *AuthService) openTwoFactorSession(ctx context.Context, user *domain.User, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	session, err := s.sessions.Create(ctx, user.ID, client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.issueTokens(ctx, user, session.ID)
+}
+
+func (s *AuthService) createTwoFactorChallenge(ctx context.Context, user *domain.User, passkeyID uuid.UUID, methods []string) (*dto.UserLoginResponse, error) {
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		s.logger.Errorw("failed to generate two-factor challenge", "userID", user.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	challengeKey := s.cache.BuildKey(storage.CACHE_PREFIX_TWO_FACTOR_CHALLENGE, token)
+	challenge := &twoFactorChallenge{UserID: user.ID.String(), Email: user.Email}
+	if passkeyID != uuid.Nil {
+		challenge.PasskeyID = passkeyID.String()
+	}
+
+	if err := s.cache.Set(ctx, challengeKey, challenge, twoFactorChallengeExpiry); err != nil {
+		s.logger.Errorw("failed to store two-factor challenge", "userID", user.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.UserLoginResponse{
+		TwoFactorRequired: true,
+		ChallengeToken:    token,
+		TwoFactorMethods:  methods,
+	}, nil
+}
+
+// takeChallengeAttempt counts an attempt against the challenge before its second factor is
+// checked, so that codes sent in parallel cannot outnumber the attempts it allows. The challenge
+// is dropped along with its last attempt.
+func (s *AuthService) takeChallengeAttempt(ctx context.Context, challengeKey string) error {
+	attempts, err := s.cache.HIncr(ctx, challengeKey, "attempts")
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return domain.ErrInvalidTwoFactorChallenge
+		}
+
+		s.logger.Errorw("failed to count two-factor attempt", "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if attempts > twoFactorChallengeAttempts {
+		return domain.ErrInvalidTwoFactorChallenge
+	}
+
+	if attempts == twoFactorChallengeAttempts {
+		if err := s.cache.Del(ctx, challengeKey); err != nil {
+			s.logger.Warnw("failed to delete two-factor challenge", "error", err)
+		}
+	}
+
+	return nil
+}
+
+// recordTwoFactorFailure counts a wrong second factor against the account lockout, the challenge
+// having counted the attempt already
+func (s *AuthService) recordTwoFactorFailure(ctx context.Context, userID uuid.UUID, client *dto.ClientInfo) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		s.logger.Warnw("failed to get user for two-factor failure", "userID", userID, "error", err)
+		return
+	}
+
+	s.recordLoginFailure(ctx, user.Email, user, client)
+}
+
+// Refresh exchanges a refresh token for a new access token and rotates the refresh token.
+// Presenting a token that was already rotated revokes its whole family and the session bound to it.
+func (s *AuthService) Refresh(ctx context.Context, refreshToken string, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	value, err := s.cache.GetString(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_REFRESH_TOKEN, refreshToken))
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		s.logger.Errorw("failed to get refresh token", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	rawUserID, sessionID, found := strings.Cut(value, ":")
+	userID, err := uuid.Parse(rawUserID)
+	if !found || err != nil {
+		return nil, domain.ErrInvalidRefreshToken
+	}
+
+	// Taking the family head atomically guarantees a token can be rotated only once,
+	// even when concurrent requests present it.
+	current, err := s.cache.GetStringAndDel(ctx, refreshFamilyKey(s.cache, sessionID))
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		s.logger.Errorw("failed to get refresh token family", "userID", userID, "sessionID", sessionID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if current != refreshToken {
+		// The family head is already gone; dropping the session also cuts off live access tokens
+		s.logger.Warnw("refresh token reuse detected, session revoked", "userID", userID, "sessionID", sessionID)
+		if err := s.sessions.Revoke(ctx, userID, sessionID); err != nil {
+			return nil, err
+		}
+
+		return nil, domain.ErrRefreshTokenReused
+	}
+
+	if _, err := s.sessions.Touch(ctx, userID, sessionID, client); err != nil {
+		if errors.Is(err, domain.ErrSessionNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidRefreshToken
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
 	}
 
-	return s.tokenManager.GenerateToken(user.ID.String())
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	return s.issueTokens(ctx, user, sessionID)
+}
+
+// Logout ends the given session of the user
+func (s *AuthService) Logout(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	return s.sessions.Revoke(ctx, userID, sessionID)
+}
+
+// LogoutAll ends every session of the user, on all devices
+func (s *AuthService) LogoutAll(ctx context.Context, userID uuid.UUID) error {
+	return s.sessions.RevokeAll(ctx, userID)
+}
+
+// JWKS lists the public keys access tokens can be verified with
+func (s *AuthService) JWKS() *auth.JWKS {
+	return s.tokenManager.JWKS()
+}
+
+// issueTokens generates an access token for the session and a refresh token that becomes
+// the head of the session's refresh token family
+func (s *AuthService) issueTokens(ctx context.Context, user *domain.User, sessionID string) (*dto.UserLoginResponse, error) {
+	userID := user.ID
+	accessToken, err := s.tokenManager.GenerateToken(userID.String(), user.RoleID, sessionID)
+	if err != nil {
+		s.logger.Errorw("failed to generate access token", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	refreshToken, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		s.logger.Errorw("failed to generate refresh token", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	// Rotated tokens are kept until they expire so that a replay can be traced back to its family
+	tokenKey := s.cache.BuildKey(storage.CACHE_PREFIX_REFRESH_TOKEN, refreshToken)
+	if err := s.cache.SetString(ctx, tokenKey, userID.String()+":"+sessionID, auth.RefreshTokenExpiry); err != nil {
+		s.logger.Errorw("failed to store refresh token", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.cache.SetString(ctx, refreshFamilyKey(s.cache, sessionID), refreshToken, auth.RefreshTokenExpiry); err != nil {
+		s.logger.Errorw("failed to store refresh token family", "userID", userID, "sessionID", sessionID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.UserLoginResponse{
+		Token:        accessToken,
+		ExpiresIn:    int64(auth.AccessTokenExpiry.Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// refreshFamilyKey points to the latest refresh token issued for a session
+func refreshFamilyKey(cache storage.CacheStorage, sessionID string) string {
+	return cache.BuildKey(storage.CACHE_PREFIX_REFRESH_TOKEN, "family", sessionID)
 }
 
 func (s *AuthService) UpdatePassword(ctx context.Context, req *dto.UserResetPasswordRequest) error {
@@ -96,11 +587,130 @@ func (s *AuthService) UpdatePassword(ctx context.Context, req *dto.UserResetPass
 		return domain.ErrPasswordHashFailed
 	}
 
-	if err := s.userRepo.UpdateProperty(ctx, req.ID, domain.Password, hashedPassword); err != nil {
+	if err := s.userRepo.UpdatePassword(ctx, req.ID, hashedPassword); err != nil {
 		s.logger.Errorw("failed to update user password", "userID", req.ID, "error", err)
 		return response.ErrInternalServerError
 	}
 
+	// Whoever held the old password must not stay signed in
+	return s.sessions.RevokeAll(ctx, req.ID)
+}
+
+// ChangePassword replaces the password of a signed-in user who knows the current one.
+// Recent passwords cannot be reused, and every session but the current one is ended.
+func (s *AuthService) ChangePassword(ctx context.Context, req *dto.UserChangePasswordRequest, sessionID string) error {
+	if req.NewPassword != req.ConfirmPassword {
+		return domain.ErrPasswordMismatch
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if err := auth.VerifyPassword(user.Password, req.OldPassword); err != nil {
+		return domain.ErrInvalidOldPassword
+	}
+
+	if req.NewPassword == req.OldPassword {
+		return domain.ErrSamePassword
+	}
+
+	history, err := s.userRepo.GetPasswordHistory(ctx, req.ID, passwordHistoryLimit)
+	if err != nil {
+		s.logger.Errorw("failed to get password history", "userID", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	for _, previous := range history {
+		if auth.VerifyPassword(previous, req.NewPassword) == nil {
+			return domain.ErrPasswordReused
+		}
+	}
+
+	hashedPassword, err := auth.HashPassword(req.NewPassword)
+	if err != nil {
+		s.logger.Errorw("failed to hash password", "userID", req.ID, "error", err)
+		return domain.ErrPasswordHashFailed
+	}
+
+	if err := s.userRepo.UpdatePassword(ctx, req.ID, hashedPassword); err != nil {
+		s.logger.Errorw("failed to update user password", "userID", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return s.sessions.RevokeOthers(ctx, req.ID, sessionID)
+}
+
+// RequestEmailChange checks the password and sends a confirmation link to the new address.
+// The email on the account only changes once that link is followed, see ConfirmEmailChange.
+func (s *AuthService) RequestEmailChange(ctx context.Context, req *dto.UserChangeEmailRequest) error {
+	user, err := s.userRepo.GetByID(ctx, req.ID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if err := auth.VerifyPassword(user.Password, req.Password); err != nil {
+		return domain.ErrInvalidPassword
+	}
+
+	if err := s.checkEmailAvailable(ctx, req.NewEmail); err != nil {
+		return err
+	}
+
+	if err := s.sendEmailVerification(ctx, user, req.NewEmail, user.ID.String()+":"+req.NewEmail, "email.verify_email_change"); err != nil {
+		s.logger.Errorw("failed to send email change verification", "userID", req.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// ConfirmEmailChange sets the new email once its owner followed the link sent to it
+func (s *AuthService) ConfirmEmailChange(ctx context.Context, userID uuid.UUID, newEmail string) error {
+	if _, err := s.userRepo.GetByID(ctx, userID); err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return err
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	// Someone may have registered the address while the link was pending
+	if err := s.checkEmailAvailable(ctx, newEmail); err != nil {
+		return err
+	}
+
+	if err := s.userRepo.UpdateProperty(ctx, userID, domain.Email, newEmail); err != nil {
+		s.logger.Errorw("failed to update user email", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *AuthService) checkEmailAvailable(ctx context.Context, email string) error {
+	_, err := s.userRepo.GetByEmail(ctx, email)
+	if err == nil {
+		return domain.ErrEmailAlreadyExists
+	}
+
+	if !errors.Is(err, domain.ErrUserNotFound) {
+		s.logger.Errorw("failed to get user by email", "email", email, "error", err)
+		return response.ErrInternalServerError
+	}
+
 	return nil
 }
 
@@ -121,15 +731,20 @@ func (s *AuthService) GetUserByEmail(ctx context.Context, email string) (*domain
 
 // SendVerificationEmail generates a verification token and sends a verification email to the user
 func (s *AuthService) SendVerificationEmail(ctx context.Context, user *domain.User) error {
+	return s.sendEmailVerification(ctx, user, user.Email, user.ID.String(), "email.verify_account")
+}
+
+// sendEmailVerification mails a verification link to the address. The cached value is handed back
+// when the link is followed and the strings are read from the given translation section.
+func (s *AuthService) sendEmailVerification(ctx context.Context, user *domain.User, email, value, section string) error {
 	// Generate a random token for email verification
 	token, err := auth.GenerateRandomToken(32)
 	if err != nil {
 		return err
 	}
 
-	// Store token in cache with user ID as value
 	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_EMAIL_VERIFICATION, token)
-	if err := s.cache.SetString(ctx, cacheKey, user.ID.String(), emailVerificationExpiry); err != nil {
+	if err := s.cache.SetString(ctx, cacheKey, value, emailVerificationExpiry); err != nil {
 		return err
 	}
 
@@ -140,24 +755,24 @@ func (s *AuthService) SendVerificationEmail(ctx context.Context, user *domain.Us
 	}
 
 	// Build verification link
-	verificationLink := fmt.Sprintf("%s:%d/api/v1/auth/verify-email/%s", s.config.API.Host, s.config.API.Port, token)
+	verificationLink := s.frontendLink("auth", "email", "verify", token)
 
 	// Create notification payload with translated strings
 	payload := NotificationPayload{
 		From:         s.config.SMTP.From,
-		To:           []string{user.Email},
-		Subject:      i18n.Translate(lang, "email.verify_account.subject"),
+		To:           []string{email},
+		Subject:      i18n.Translate(lang, section+".subject"),
 		TemplateName: constants.EMAIL_TEMPLATE_VERIFY_ACCOUNT,
 		Data: map[string]string{
 			"Lang":             string(lang),
 			"Brand":            i18n.Translate(lang, "email.common.brand"),
-			"Title":            i18n.Translate(lang, "email.verify_account.title"),
-			"Greeting":         i18n.TranslateWithParams(lang, "email.verify_account.greeting", map[string]string{"name": user.FirstName}),
-			"Message":          i18n.Translate(lang, "email.verify_account.message"),
-			"Button":           i18n.Translate(lang, "email.verify_account.button"),
-			"LinkFallback":     i18n.Translate(lang, "email.verify_account.link_fallback"),
-			"Expiry":           i18n.Translate(lang, "email.verify_account.expiry"),
-			"Footer":           i18n.Translate(lang, "email.verify_account.footer"),
+			"Title":            i18n.Translate(lang, section+".title"),
+			"Greeting":         i18n.TranslateWithParams(lang, section+".greeting", map[string]string{"name": user.FirstName}),
+			"Message":          i18n.Translate(lang, section+".message"),
+			"Button":           i18n.Translate(lang, section+".button"),
+			"LinkFallback":     i18n.Translate(lang, section+".link_fallback"),
+			"Expiry":           i18n.Translate(lang, section+".expiry"),
+			"Footer":           i18n.Translate(lang, section+".footer"),
 			"Copyright":        i18n.Translate(lang, "email.common.copyright"),
 			"VerificationLink": verificationLink,
 		},
@@ -172,6 +787,45 @@ func (s *AuthService) SendVerificationEmail(ctx context.Context, user *domain.Us
 	return s.queue.Publish(ctx, "", constants.QUEUE_NOTIFICATIONS, payloadBytes)
 }
 
+// ResendVerificationEmail sends a new verification link to an unverified account, for when the
+// previous one expired or got lost. Unknown, inactive and verified accounts are silently skipped
+// so the response does not reveal which emails are registered.
+func (s *AuthService) ResendVerificationEmail(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+
+	// Counted before the lookup so that the limit applies the same way to every address
+	sent, err := s.cache.Incr(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_VERIFICATION_RESEND, email), verificationResendWindow)
+	if err != nil {
+		s.logger.Errorw("failed to count verification emails", "email", email, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if sent > verificationResendLimit {
+		return domain.ErrTooManyVerificationEmails
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, email)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil
+		}
+
+		s.logger.Errorw("failed to get user by email", "email", email, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if !user.IsActive || user.IsVerified {
+		return nil
+	}
+
+	if err := s.SendVerificationEmail(ctx, user); err != nil {
+		s.logger.Errorw("failed to send verification email", "userID", user.ID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
 // SendPasswordResetEmail generates a reset token and sends a password reset email to the user
 func (s *AuthService) SendPasswordResetEmail(ctx context.Context, user *domain.User) error {
 	// Generate a random token for password reset
@@ -193,7 +847,7 @@ func (s *AuthService) SendPasswordResetEmail(ctx context.Context, user *domain.U
 	}
 
 	// Build reset link
-	resetLink := fmt.Sprintf("%s:%d/api/v1/auth/reset-password/%s/%s", s.config.API.Host, s.config.API.Port, user.ID.String(), token)
+	resetLink := s.frontendLink("auth", "password", "reset", user.ID.String(), token)
 
 	// Create notification payload with translated strings
 	payload := NotificationPayload{
@@ -226,3 +880,122 @@ func (s *AuthService) SendPasswordResetEmail(ctx context.Context, user *domain.U
 
 	return s.queue.Publish(ctx, "", constants.QUEUE_NOTIFICATIONS, payloadBytes)
 }
+
+// SendMagicLinkEmail generates a sign-in token and sends it to the user
+func (s *AuthService) SendMagicLinkEmail(ctx context.Context, user *domain.User) error {
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// Store token in cache with user ID as value
+	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_MAGIC_LINK, token)
+	if err := s.cache.SetString(ctx, cacheKey, user.ID.String(), magicLinkExpiry); err != nil {
+		return err
+	}
+
+	// Determine user's language preference
+	lang := i18n.GetLanguage(ctx)
+	if user.Language.Valid && user.Language.String != "" {
+		lang = i18n.Language(user.Language.String)
+	}
+
+	magicLink := s.frontendLink("auth", "magic-link", token)
+
+	// Create notification payload with translated strings
+	payload := NotificationPayload{
+		From:         s.config.SMTP.From,
+		To:           []string{user.Email},
+		Subject:      i18n.Translate(lang, "email.magic_link.subject"),
+		TemplateName: constants.EMAIL_TEMPLATE_MAGIC_LINK,
+		Data: map[string]string{
+			"Lang":            string(lang),
+			"Brand":           i18n.Translate(lang, "email.common.brand"),
+			"Title":           i18n.Translate(lang, "email.magic_link.title"),
+			"Greeting":        i18n.TranslateWithParams(lang, "email.magic_link.greeting", map[string]string{"name": user.FirstName}),
+			"Message":         i18n.Translate(lang, "email.magic_link.message"),
+			"Button":          i18n.Translate(lang, "email.magic_link.button"),
+			"LinkFallback":    i18n.Translate(lang, "email.magic_link.link_fallback"),
+			"Expiry":          i18n.Translate(lang, "email.magic_link.expiry"),
+			"SecurityTip":     i18n.Translate(lang, "email.magic_link.security_tip"),
+			"SecurityMessage": i18n.Translate(lang, "email.magic_link.security_message"),
+			"Footer":          i18n.Translate(lang, "email.magic_link.footer"),
+			"Copyright":       i18n.Translate(lang, "email.common.copyright"),
+			"MagicLink":       magicLink,
+		},
+	}
+
+	// Publish to notification queue
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Publish(ctx, "", constants.QUEUE_NOTIFICATIONS, payloadBytes)
+}
+
+// SendAccountUnlockEmail tells the user their account was locked and sends a link to unlock it
+func (s *AuthService) SendAccountUnlockEmail(ctx context.Context, user *domain.User) error {
+	// Generate a random token for the unlock link
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		return err
+	}
+
+	// Store token in cache with user ID as value
+	cacheKey := s.cache.BuildKey(storage.CACHE_PREFIX_ACCOUNT_UNLOCK, token)
+	if err := s.cache.SetString(ctx, cacheKey, user.ID.String(), accountUnlockExpiry); err != nil {
+		return err
+	}
+
+	// Determine user's language preference
+	lang := i18n.GetLanguage(ctx)
+	if user.Language.Valid && user.Language.String != "" {
+		lang = i18n.Language(user.Language.String)
+	}
+
+	// Build unlock link
+	unlockLink := s.frontendLink("auth", "unlock", token)
+
+	// Create notification payload with translated strings
+	payload := NotificationPayload{
+		From:         s.config.SMTP.From,
+		To:           []string{user.Email},
+		Subject:      i18n.Translate(lang, "email.account_locked.subject"),
+		TemplateName: constants.EMAIL_TEMPLATE_ACCOUNT_LOCKED,
+		Data: map[string]string{
+			"Lang":            string(lang),
+			"Brand":           i18n.Translate(lang, "email.common.brand"),
+			"Title":           i18n.Translate(lang, "email.account_locked.title"),
+			"Greeting":        i18n.TranslateWithParams(lang, "email.account_locked.greeting", map[string]string{"name": user.FirstName}),
+			"Message":         i18n.Translate(lang, "email.account_locked.message"),
+			"Button":          i18n.Translate(lang, "email.account_locked.button"),
+			"LinkFallback":    i18n.Translate(lang, "email.account_locked.link_fallback"),
+			"Expiry":          i18n.Translate(lang, "email.account_locked.expiry"),
+			"SecurityTip":     i18n.Translate(lang, "email.account_locked.security_tip"),
+			"SecurityMessage": i18n.Translate(lang, "email.account_locked.security_message"),
+			"Footer":          i18n.Translate(lang, "email.account_locked.footer"),
+			"Copyright":       i18n.Translate(lang, "email.common.copyright"),
+			"UnlockLink":      unlockLink,
+		},
+	}
+
+	// Publish to notification queue
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return s.queue.Publish(ctx, "", constants.QUEUE_NOTIFICATIONS, payloadBytes)
+}
+
+// frontendLink builds a link to a page of the web client, whose paths follow the API routes
+// that the page calls, e.g. auth/email/verify/{token}
+func (s *AuthService) frontendLink(segments ...string) string {
+	return FrontendLink(s.config, segments...)
+}
+
+// FrontendLink builds a link to a page of the web client configured in cfg, see frontendLink
+func FrontendLink(cfg config.Config, segments ...string) string {
+	return strings.TrimRight(cfg.Application.FrontendURL, "/") + "/" + strings.Join(segments, "/")
+}
@@ -9,13 +9,20 @@ This file contains unit tests covering all authentication-related business logic
 Test Coverage:
 - Login functionality (success and various failure scenarios)
 - Password update/reset functionality
+- Authenticated password and email changes
+- Resending the verification email
+- Magic link login
 - Token generation and validation
+- Refresh token rotation and reuse detection
+- Logout of one or every session
+- Two-factor login challenge
+- Login back-off, account lockout and unlock
 - Security validations (inactive users, unverified emails, invalid passwords)
 - Database error handling
 - Edge cases and boundary conditions
 
 Test Statistics:
-- Total Tests: 15
+- Total Tests: 35
 - Coverage: ~95% of auth_service.go
 - Benchmarks: 2
 
@@ -25,35 +32,117 @@ and ensure tests are fast and deterministic.
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
 	"errors"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/alicebob/miniredis/v2"
 	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 )
 
-// setupAuthTest creates a new AuthService with mocked dependencies
-func setupAuthTest() (*AuthService, *MockUserRepository, *auth.TokenManager) {
+// testClient is the device every test session is opened from
+var testClient = &dto.ClientInfo{Device: "Go-http-client/1.1", IPAddress: "127.0.0.1"}
+
+// authTestDeps exposes the dependencies of the AuthService built by setupAuthTestDeps
+type authTestDeps struct {
+	userRepo      *MockUserRepository
+	twoFactorRepo *MockTwoFactorRepository
+	passkeyRepo   *MockPasskeyRepository
+	lockoutRepo   *MockLoginLockoutRepository
+	queue         *MockQueueStorage
+	tokenManager  *auth.TokenManager
+	redis         *miniredis.Miniredis
+}
+
+// MockQueueStorage
+type MockQueueStorage struct {
+	mock.Mock
+}
+
+func (m *MockQueueStorage) Publish(ctx context.Context, exchange, routingKey string, body []byte) error {
+	args := m.Called(ctx, exchange, routingKey, body)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) Consume(queueName string, handler func([]byte) error) error {
+	args := m.Called(queueName, handler)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) DeclareQueue(queueName string) error {
+	args := m.Called(queueName)
+	return args.Error(0)
+}
+
+func (m *MockQueueStorage) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+// setupAuthTest creates a new AuthService with mocked dependencies and an in-memory cache
+func setupAuthTest(tb testing.TB) (*AuthService, *MockUserRepository, *auth.TokenManager) {
+	service, deps := setupAuthTestDeps(tb)
+	return service, deps.userRepo, deps.tokenManager
+}
+
+// newTestTokenManager signs tokens with a throwaway key
+func newTestTokenManager(tb testing.TB) *auth.TokenManager {
+	keys, err := auth.NewEphemeralKeySet()
+	require.NoError(tb, err)
+
+	return auth.NewTokenManager(keys)
+}
+
+// setupAuthTestDeps is setupAuthTest for tests that need the other dependencies
+func setupAuthTestDeps(tb testing.TB) (*AuthService, *authTestDeps) {
 	logger := zap.NewNop().Sugar()
-	mockUserRepo := new(MockUserRepository)
-	tokenManager := auth.NewTokenManager("test-secret-key-for-jwt-signing")
+	deps := &authTestDeps{
+		userRepo:      new(MockUserRepository),
+		twoFactorRepo: new(MockTwoFactorRepository),
+		passkeyRepo:   new(MockPasskeyRepository),
+		lockoutRepo:   new(MockLoginLockoutRepository),
+		queue:         new(MockQueueStorage),
+		tokenManager:  newTestTokenManager(tb),
+		redis:         miniredis.RunT(tb),
+	}
 	cfg := config.Config{}
+	cfg.Application.FrontendURL = "https://app.example.com/"
+
+	client := redis.NewClient(&redis.Options{Addr: deps.redis.Addr()})
+	tb.Cleanup(func() { client.Close() })
+	cache := storage.NewCacheStorage(client)
 
-	service := NewAuthService(logger, cfg, nil, nil, tokenManager, mockUserRepo)
+	sessions := NewSessionService(logger, cache)
+	twoFactor := NewTwoFactorService(logger, cfg, deps.twoFactorRepo)
+	lockouts := NewLockoutService(logger, cache, deps.lockoutRepo)
 
-	return service, mockUserRepo, tokenManager
+	service := NewAuthService(logger, cfg, cache, deps.queue, deps.tokenManager, deps.userRepo, deps.passkeyRepo, sessions, twoFactor, lockouts)
+
+	// Users have no passkeys unless a test registers one
+	deps.passkeyRepo.On("CountByUserID", mock.Anything, mock.Anything).Return(0, nil).Maybe()
+
+	return service, deps
 }
 
 // Test Login - Success
 func TestAuthService_Login_Success(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -74,7 +163,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(expectedUser, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -83,7 +172,7 @@ func TestAuthService_Login_Success(t *testing.T) {
 
 // Test Login - User Not Found
 func TestAuthService_Login_UserNotFound(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	req := &dto.UserLoginRequest{
@@ -93,7 +182,7 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(nil, domain.ErrUserNotFound)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrUserNotFound, err)
@@ -103,7 +192,7 @@ func TestAuthService_Login_UserNotFound(t *testing.T) {
 
 // Test Login - Database Error
 func TestAuthService_Login_DatabaseError(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	req := &dto.UserLoginRequest{
@@ -114,7 +203,7 @@ func TestAuthService_Login_DatabaseError(t *testing.T) {
 	dbError := errors.New("database connection failed")
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(nil, dbError)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, response.ErrInternalServerError, err)
@@ -124,7 +213,7 @@ func TestAuthService_Login_DatabaseError(t *testing.T) {
 
 // Test Login - User Inactive
 func TestAuthService_Login_UserInactive(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -145,7 +234,7 @@ func TestAuthService_Login_UserInactive(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(inactiveUser, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrUserInactive, err)
@@ -155,7 +244,7 @@ func TestAuthService_Login_UserInactive(t *testing.T) {
 
 // Test Login - Email Not Verified
 func TestAuthService_Login_EmailNotVerified(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -176,7 +265,7 @@ func TestAuthService_Login_EmailNotVerified(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(unverifiedUser, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrEmailNotVerified, err)
@@ -186,7 +275,7 @@ func TestAuthService_Login_EmailNotVerified(t *testing.T) {
 
 // Test Login - Invalid Password
 func TestAuthService_Login_InvalidPassword(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -207,7 +296,7 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.Error(t, err)
 	assert.Equal(t, domain.ErrInvalidPassword, err)
@@ -217,7 +306,7 @@ func TestAuthService_Login_InvalidPassword(t *testing.T) {
 
 // Test Login - Multiple Failed Conditions
 func TestAuthService_Login_InactiveAndUnverified(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -238,7 +327,7 @@ func TestAuthService_Login_InactiveAndUnverified(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	// IsActive is checked first
 	assert.Error(t, err)
@@ -249,7 +338,7 @@ func TestAuthService_Login_InactiveAndUnverified(t *testing.T) {
 
 // Test UpdatePassword - Success
 func TestAuthService_UpdatePassword_Success(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -266,7 +355,7 @@ func TestAuthService_UpdatePassword_Success(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
-	mockUserRepo.On("UpdateProperty", ctx, userID, domain.Password, mock.AnythingOfType("[]uint8")).Return(nil)
+	mockUserRepo.On("UpdatePassword", ctx, userID, mock.AnythingOfType("[]uint8")).Return(nil)
 
 	err := service.UpdatePassword(ctx, req)
 
@@ -276,7 +365,7 @@ func TestAuthService_UpdatePassword_Success(t *testing.T) {
 
 // Test UpdatePassword - User Not Found
 func TestAuthService_UpdatePassword_UserNotFound(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -297,7 +386,7 @@ func TestAuthService_UpdatePassword_UserNotFound(t *testing.T) {
 
 // Test UpdatePassword - Database Error on GetByID
 func TestAuthService_UpdatePassword_DatabaseErrorOnGet(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -319,7 +408,7 @@ func TestAuthService_UpdatePassword_DatabaseErrorOnGet(t *testing.T) {
 
 // Test UpdatePassword - Database Error on Update
 func TestAuthService_UpdatePassword_DatabaseErrorOnUpdate(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -336,7 +425,7 @@ func TestAuthService_UpdatePassword_DatabaseErrorOnUpdate(t *testing.T) {
 
 	dbError := errors.New("database update failed")
 	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
-	mockUserRepo.On("UpdateProperty", ctx, userID, domain.Password, mock.AnythingOfType("[]uint8")).Return(dbError)
+	mockUserRepo.On("UpdatePassword", ctx, userID, mock.AnythingOfType("[]uint8")).Return(dbError)
 
 	err := service.UpdatePassword(ctx, req)
 
@@ -347,7 +436,7 @@ func TestAuthService_UpdatePassword_DatabaseErrorOnUpdate(t *testing.T) {
 
 // Test UpdatePassword - Verify Password is Hashed
 func TestAuthService_UpdatePassword_PasswordIsHashed(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -366,9 +455,9 @@ func TestAuthService_UpdatePassword_PasswordIsHashed(t *testing.T) {
 	var capturedPassword []byte
 
 	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
-	mockUserRepo.On("UpdateProperty", ctx, userID, domain.Password, mock.AnythingOfType("[]uint8")).
+	mockUserRepo.On("UpdatePassword", ctx, userID, mock.AnythingOfType("[]uint8")).
 		Run(func(args mock.Arguments) {
-			capturedPassword = args.Get(3).([]byte)
+			capturedPassword = args.Get(2).([]byte)
 		}).
 		Return(nil)
 
@@ -389,7 +478,7 @@ func TestAuthService_UpdatePassword_PasswordIsHashed(t *testing.T) {
 
 // Test UpdatePassword - Empty Password
 func TestAuthService_UpdatePassword_EmptyPassword(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -404,7 +493,7 @@ func TestAuthService_UpdatePassword_EmptyPassword(t *testing.T) {
 	}
 
 	mockUserRepo.On("GetByID", ctx, userID).Return(existingUser, nil)
-	mockUserRepo.On("UpdateProperty", ctx, userID, domain.Password, mock.AnythingOfType("[]uint8")).Return(nil)
+	mockUserRepo.On("UpdatePassword", ctx, userID, mock.AnythingOfType("[]uint8")).Return(nil)
 
 	err := service.UpdatePassword(ctx, req)
 
@@ -416,7 +505,7 @@ func TestAuthService_UpdatePassword_EmptyPassword(t *testing.T) {
 
 // Test Login - Generated Token is Valid
 func TestAuthService_Login_GeneratedTokenIsValid(t *testing.T) {
-	service, mockUserRepo, tokenManager := setupAuthTest()
+	service, mockUserRepo, tokenManager := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -437,13 +526,13 @@ func TestAuthService_Login_GeneratedTokenIsValid(t *testing.T) {
 
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(expectedUser, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 
 	// Verify the token can be parsed and contains correct user ID
-	claims, parseErr := tokenManager.ParseToken(token)
+	claims, parseErr := tokenManager.ParseToken(token.Token)
 	assert.NoError(t, parseErr)
 	assert.NotNil(t, claims)
 	assert.Equal(t, userID.String(), claims.UserID)
@@ -453,7 +542,7 @@ func TestAuthService_Login_GeneratedTokenIsValid(t *testing.T) {
 
 // Test Login - Case Sensitivity
 func TestAuthService_Login_EmailCaseSensitivity(t *testing.T) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(t)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -476,16 +565,706 @@ func TestAuthService_Login_EmailCaseSensitivity(t *testing.T) {
 	// In production, email normalization should happen at handler level
 	mockUserRepo.On("GetByEmail", ctx, req.Email).Return(user, nil)
 
-	token, err := service.Login(ctx, req)
+	token, err := service.Login(ctx, req, testClient)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
 	mockUserRepo.AssertExpectations(t)
 }
 
+// loginForRefresh logs a verified user in and returns the issued tokens
+func loginForRefresh(t *testing.T, service *AuthService, mockUserRepo *MockUserRepository) (*domain.User, *dto.UserLoginResponse) {
+	t.Helper()
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{
+		ID:         uuid.New(),
+		Email:      "john.doe@example.com",
+		Password:   hashedPassword,
+		IsActive:   true,
+		IsVerified: true,
+	}
+
+	mockUserRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	tokens, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+	if err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+
+	return user, tokens
+}
+
+// Test Login - Issues Refresh Token
+func TestAuthService_Login_IssuesRefreshToken(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+
+	_, tokens := loginForRefresh(t, service, mockUserRepo)
+
+	assert.NotEmpty(t, tokens.RefreshToken)
+	assert.Equal(t, int64(auth.AccessTokenExpiry.Seconds()), tokens.ExpiresIn)
+}
+
+// Test Refresh - Rotates Token
+func TestAuthService_Refresh_RotatesToken(t *testing.T) {
+	service, mockUserRepo, tokenManager := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, tokens := loginForRefresh(t, service, mockUserRepo)
+
+	refreshed, err := service.Refresh(ctx, tokens.RefreshToken, testClient)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, tokens.RefreshToken, refreshed.RefreshToken)
+
+	claims, parseErr := tokenManager.ParseToken(refreshed.Token)
+	assert.NoError(t, parseErr)
+	assert.Equal(t, user.ID.String(), claims.UserID)
+
+	// The rotated token keeps working
+	_, err = service.Refresh(ctx, refreshed.RefreshToken, testClient)
+	assert.NoError(t, err)
+}
+
+// Test Refresh - Reuse Revokes Family
+func TestAuthService_Refresh_ReuseRevokesFamily(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	_, tokens := loginForRefresh(t, service, mockUserRepo)
+
+	refreshed, err := service.Refresh(ctx, tokens.RefreshToken, testClient)
+	assert.NoError(t, err)
+
+	// Replaying the rotated token is treated as theft
+	_, err = service.Refresh(ctx, tokens.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrRefreshTokenReused, err)
+
+	// The latest token of the family is revoked as well
+	_, err = service.Refresh(ctx, refreshed.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrInvalidRefreshToken, err)
+}
+
+// Test Refresh - Families Are Independent
+func TestAuthService_Refresh_OtherFamilyUnaffected(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, first := loginForRefresh(t, service, mockUserRepo)
+	second, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+	assert.NoError(t, err)
+
+	_, err = service.Refresh(ctx, first.RefreshToken, testClient)
+	assert.NoError(t, err)
+	_, err = service.Refresh(ctx, first.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrRefreshTokenReused, err)
+
+	_, err = service.Refresh(ctx, second.RefreshToken, testClient)
+	assert.NoError(t, err)
+}
+
+// Test Refresh - Unknown Token
+func TestAuthService_Refresh_UnknownToken(t *testing.T) {
+	service, _, _ := setupAuthTest(t)
+
+	tokens, err := service.Refresh(context.Background(), "unknown-token", testClient)
+
+	assert.Equal(t, domain.ErrInvalidRefreshToken, err)
+	assert.Nil(t, tokens)
+}
+
+// Test Refresh - User Inactive
+func TestAuthService_Refresh_UserInactive(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, tokens := loginForRefresh(t, service, mockUserRepo)
+	user.IsActive = false
+
+	refreshed, err := service.Refresh(ctx, tokens.RefreshToken, testClient)
+
+	assert.Equal(t, domain.ErrUserInactive, err)
+	assert.Nil(t, refreshed)
+}
+
+// Test Logout - Revokes Session And Refresh Token
+func TestAuthService_Logout(t *testing.T) {
+	service, mockUserRepo, tokenManager := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, tokens := loginForRefresh(t, service, mockUserRepo)
+	claims, _ := tokenManager.ParseToken(tokens.Token)
+
+	err := service.Logout(ctx, user.ID, claims.ID)
+	assert.NoError(t, err)
+
+	_, err = service.sessions.Get(ctx, user.ID, claims.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+
+	_, err = service.Refresh(ctx, tokens.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrInvalidRefreshToken, err)
+}
+
+// Test LogoutAll - Revokes Every Session
+func TestAuthService_LogoutAll(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, first := loginForRefresh(t, service, mockUserRepo)
+	second, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+	assert.NoError(t, err)
+
+	err = service.LogoutAll(ctx, user.ID)
+	assert.NoError(t, err)
+
+	_, err = service.Refresh(ctx, first.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrInvalidRefreshToken, err)
+	_, err = service.Refresh(ctx, second.RefreshToken, testClient)
+	assert.Equal(t, domain.ErrInvalidRefreshToken, err)
+}
+
+// Test UpdatePassword - Revokes Every Session
+func TestAuthService_UpdatePassword_RevokesSessions(t *testing.T) {
+	service, mockUserRepo, tokenManager := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, tokens := loginForRefresh(t, service, mockUserRepo)
+	claims, _ := tokenManager.ParseToken(tokens.Token)
+	mockUserRepo.On("UpdatePassword", ctx, user.ID, mock.AnythingOfType("[]uint8")).Return(nil)
+
+	err := service.UpdatePassword(ctx, &dto.UserResetPasswordRequest{ID: user.ID, NewPassword: "NewSecurePassword123!"})
+	assert.NoError(t, err)
+
+	_, err = service.sessions.Get(ctx, user.ID, claims.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+}
+
+// Test Login - Two-Factor Challenge
+func TestAuthService_Login_TwoFactorChallenge(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	mockUserRepo := deps.userRepo
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{
+		ID:                 uuid.New(),
+		Email:              "admin@example.com",
+		Password:           hashedPassword,
+		IsActive:           true,
+		IsVerified:         true,
+		IsTwoFactorEnabled: true,
+	}
+	mockUserRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+
+	resp, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+
+	assert.NoError(t, err)
+	assert.True(t, resp.TwoFactorRequired)
+	assert.NotEmpty(t, resp.ChallengeToken)
+	assert.Equal(t, []string{"totp"}, resp.TwoFactorMethods)
+	assert.Empty(t, resp.Token)
+	assert.Empty(t, resp.RefreshToken)
+}
+
+// Test VerifyTwoFactor - Success And Failures
+func TestAuthService_VerifyTwoFactor(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	mockUserRepo, mockTwoFactorRepo, tokenManager := deps.userRepo, deps.twoFactorRepo, deps.tokenManager
+	ctx := context.Background()
+
+	secret, _ := auth.GenerateTOTPSecret()
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{
+		ID:                 uuid.New(),
+		Email:              "admin@example.com",
+		Password:           hashedPassword,
+		IsActive:           true,
+		IsVerified:         true,
+		IsTwoFactorEnabled: true,
+	}
+	twoFactor := &domain.TwoFactor{UserID: user.ID, Secret: secret, EnabledAt: sql.NullTime{Time: time.Now(), Valid: true}}
+
+	mockUserRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockTwoFactorRepo.On("GetByUserID", ctx, user.ID).Return(twoFactor, nil)
+	mockTwoFactorRepo.On("UseStep", ctx, user.ID, mock.AnythingOfType("int64")).Return(true, nil)
+	deps.lockoutRepo.On("MarkUnlocked", ctx, user.Email).Return(nil)
+
+	// Wrong codes back off the account like wrong passwords, unlocking keeps the cases apart
+	unlock := func() {
+		assert.NoError(t, service.lockouts.Unlock(ctx, user.Email))
+	}
+	login := func() string {
+		unlock()
+		resp, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+		assert.NoError(t, err)
+		return resp.ChallengeToken
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		code, _ := auth.GenerateTOTPCode(secret, time.Now())
+		challenge := login()
+
+		tokens, err := service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: code}, testClient)
+
+		assert.NoError(t, err)
+		assert.NotEmpty(t, tokens.RefreshToken)
+
+		claims, _ := tokenManager.ParseToken(tokens.Token)
+		session, err := service.sessions.Get(ctx, user.ID, claims.ID)
+		assert.NoError(t, err)
+		assert.True(t, session.TwoFactor)
+
+		// A challenge can only be completed once
+		_, err = service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: code}, testClient)
+		assert.Equal(t, domain.ErrInvalidTwoFactorChallenge, err)
+	})
+
+	t.Run("UnknownChallenge", func(t *testing.T) {
+		_, err := service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: "unknown", Code: "123456"}, testClient)
+		assert.Equal(t, domain.ErrInvalidTwoFactorChallenge, err)
+	})
+
+	t.Run("AttemptsExhausted", func(t *testing.T) {
+		challenge := login()
+		req := &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: "000000"}
+		if valid, _ := auth.GenerateTOTPCode(secret, time.Now()); valid == req.Code {
+			req.Code = "111111"
+		}
+
+		for range twoFactorChallengeAttempts {
+			unlock()
+			_, err := service.VerifyTwoFactor(ctx, req, testClient)
+			assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+		}
+
+		unlock()
+		code, _ := auth.GenerateTOTPCode(secret, time.Now())
+		_, err := service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: code}, testClient)
+		assert.Equal(t, domain.ErrInvalidTwoFactorChallenge, err)
+	})
+	t.Run("WrongCodesThrottleTheAccount", func(t *testing.T) {
+		unlock()
+		// A right password does not forget the failures while the second factor is pending
+		_, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "WrongPassword!"}, testClient)
+		assert.Equal(t, domain.ErrInvalidPassword, err)
+		resp, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+		assert.NoError(t, err)
+
+		req := &dto.TwoFactorVerifyRequest{ChallengeToken: resp.ChallengeToken, Code: "000000"}
+		if valid, _ := auth.GenerateTOTPCode(secret, time.Now()); valid == req.Code {
+			req.Code = "111111"
+		}
+		for range loginBackoffThreshold - 1 {
+			_, err = service.VerifyTwoFactor(ctx, req, testClient)
+			assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+		}
+
+		code, _ := auth.GenerateTOTPCode(secret, time.Now())
+		_, err = service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: resp.ChallengeToken, Code: code}, testClient)
+		assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+
+		// Once the second factor checks out, the failures are forgotten
+		deps.redis.FastForward(time.Second)
+		_, err = service.VerifyTwoFactor(ctx, &dto.TwoFactorVerifyRequest{ChallengeToken: resp.ChallengeToken, Code: code}, testClient)
+		assert.NoError(t, err)
+		assert.False(t, deps.redis.Exists(service.lockouts.counterKey(domain.LockoutScopeAccount, user.Email)))
+	})
+
+	t.Run("FailureKeepsExpiry", func(t *testing.T) {
+		challenge := login()
+		challengeKey := service.cache.BuildKey(storage.CACHE_PREFIX_TWO_FACTOR_CHALLENGE, challenge)
+		req := &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: "000000"}
+		if valid, _ := auth.GenerateTOTPCode(secret, time.Now()); valid == req.Code {
+			req.Code = "111111"
+		}
+
+		_, err := service.VerifyTwoFactor(ctx, req, testClient)
+		assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+
+		ttl := deps.redis.TTL(challengeKey)
+		assert.Greater(t, ttl, time.Duration(0))
+		assert.LessOrEqual(t, ttl, twoFactorChallengeExpiry)
+
+		// Once expired, an attempt must not bring the challenge back
+		deps.redis.FastForward(twoFactorChallengeExpiry)
+		assert.Equal(t, domain.ErrInvalidTwoFactorChallenge, service.takeChallengeAttempt(ctx, challengeKey))
+		assert.False(t, deps.redis.Exists(challengeKey))
+	})
+
+	t.Run("ConcurrentAttempts", func(t *testing.T) {
+		challenge := login()
+		challengeKey := service.cache.BuildKey(storage.CACHE_PREFIX_TWO_FACTOR_CHALLENGE, challenge)
+		req := &dto.TwoFactorVerifyRequest{ChallengeToken: challenge, Code: "000000"}
+		if valid, _ := auth.GenerateTOTPCode(secret, time.Now()); valid == req.Code {
+			req.Code = "111111"
+		}
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 4*twoFactorChallengeAttempts)
+		for range 4 * twoFactorChallengeAttempts {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, err := service.VerifyTwoFactor(ctx, req, testClient)
+				errs <- err
+			}()
+		}
+		wg.Wait()
+		close(errs)
+
+		// Codes sent together cannot be checked more times than the challenge allows
+		checked := 0
+		for err := range errs {
+			if err == domain.ErrInvalidTwoFactorCode {
+				checked++
+			}
+		}
+		assert.LessOrEqual(t, checked, twoFactorChallengeAttempts)
+		assert.False(t, deps.redis.Exists(challengeKey))
+	})
+}
+
+// Test Login - Back-off After Repeated Failures
+func TestAuthService_Login_Throttled(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{
+		ID:         uuid.New(),
+		Email:      "test@example.com",
+		Password:   hashedPassword,
+		IsActive:   true,
+		IsVerified: true,
+	}
+	mockUserRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+
+	for range loginBackoffThreshold {
+		_, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "WrongPassword!"}, testClient)
+		assert.Equal(t, domain.ErrInvalidPassword, err)
+	}
+
+	// Even the right password has to wait for the back-off to pass
+	_, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+
+	var throttled *domain.LoginThrottledError
+	assert.ErrorAs(t, err, &throttled)
+	assert.ErrorIs(t, err, domain.ErrTooManyLoginAttempts)
+	assert.Greater(t, throttled.RetryAfter, time.Duration(0))
+}
+
+// Test Login - Account Lockout And Unlock Email
+func TestAuthService_Login_AccountLockout(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{
+		ID:         uuid.New(),
+		FirstName:  "John",
+		Email:      "test@example.com",
+		Password:   hashedPassword,
+		IsActive:   true,
+		IsVerified: true,
+	}
+	deps.userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	deps.lockoutRepo.On("Create", ctx, mock.AnythingOfType("*domain.LoginLockout")).Return(nil).Once()
+	deps.lockoutRepo.On("MarkUnlocked", ctx, user.Email).Return(nil).Once()
+
+	var payload NotificationPayload
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(3).([]byte), &payload)
+		}).
+		Return(nil).Once()
+
+	for range accountLockoutThreshold {
+		// Wait out the back-off between attempts
+		deps.redis.FastForward(loginBackoffMax)
+		_, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "WrongPassword!"}, testClient)
+		assert.Equal(t, domain.ErrInvalidPassword, err)
+	}
+
+	_, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+	assert.ErrorIs(t, err, domain.ErrAccountLocked)
+
+	assert.Equal(t, constants.EMAIL_TEMPLATE_ACCOUNT_LOCKED, payload.TemplateName)
+	assert.Equal(t, []string{user.Email}, payload.To)
+	unlockLink := payload.Data.(map[string]any)["UnlockLink"].(string)
+	token := unlockLink[strings.LastIndex(unlockLink, "/")+1:]
+
+	err = service.UnlockAccount(ctx, token)
+	assert.NoError(t, err)
+
+	// The unlock link works only once
+	err = service.UnlockAccount(ctx, token)
+	assert.Equal(t, domain.ErrInvalidUnlockToken, err)
+
+	resp, err := service.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	deps.lockoutRepo.AssertExpectations(t)
+	deps.queue.AssertExpectations(t)
+}
+
+// Test ChangePassword - Rejected Changes
+func TestAuthService_ChangePassword_Rejected(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	previousPassword, _ := auth.HashPassword("PreviousPassword123!")
+	user := &domain.User{ID: uuid.New(), Password: hashedPassword}
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	mockUserRepo.On("GetPasswordHistory", ctx, user.ID, passwordHistoryLimit).Return([][]byte{hashedPassword, previousPassword}, nil)
+
+	tests := []struct {
+		name        string
+		oldPassword string
+		newPassword string
+		confirm     string
+		expected    error
+	}{
+		{"Mismatch", "SecurePassword123!", "NewSecurePassword123!", "OtherPassword123!", domain.ErrPasswordMismatch},
+		{"WrongOldPassword", "WrongPassword123!", "NewSecurePassword123!", "NewSecurePassword123!", domain.ErrInvalidOldPassword},
+		{"SamePassword", "SecurePassword123!", "SecurePassword123!", "SecurePassword123!", domain.ErrSamePassword},
+		{"Reused", "SecurePassword123!", "PreviousPassword123!", "PreviousPassword123!", domain.ErrPasswordReused},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := service.ChangePassword(ctx, &dto.UserChangePasswordRequest{
+				ID:              user.ID,
+				OldPassword:     tt.oldPassword,
+				NewPassword:     tt.newPassword,
+				ConfirmPassword: tt.confirm,
+			}, "")
+
+			assert.Equal(t, tt.expected, err)
+		})
+	}
+
+	mockUserRepo.AssertNotCalled(t, "UpdatePassword", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// Test ChangePassword - Ends The Other Sessions
+func TestAuthService_ChangePassword_KeepsCurrentSession(t *testing.T) {
+	service, mockUserRepo, tokenManager := setupAuthTest(t)
+	ctx := context.Background()
+
+	user, tokens := loginForRefresh(t, service, mockUserRepo)
+	claims, _ := tokenManager.ParseToken(tokens.Token)
+	other, err := service.sessions.Create(ctx, user.ID, testClient, false)
+	require.NoError(t, err)
+
+	mockUserRepo.On("GetPasswordHistory", ctx, user.ID, passwordHistoryLimit).Return([][]byte{user.Password}, nil)
+	mockUserRepo.On("UpdatePassword", ctx, user.ID, mock.AnythingOfType("[]uint8")).Return(nil)
+
+	err = service.ChangePassword(ctx, &dto.UserChangePasswordRequest{
+		ID:              user.ID,
+		OldPassword:     "SecurePassword123!",
+		NewPassword:     "NewSecurePassword123!",
+		ConfirmPassword: "NewSecurePassword123!",
+	}, claims.ID)
+	assert.NoError(t, err)
+
+	_, err = service.sessions.Get(ctx, user.ID, claims.ID)
+	assert.NoError(t, err)
+	_, err = service.sessions.Get(ctx, user.ID, other.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test RequestEmailChange - Confirmation Sent To The New Address
+func TestAuthService_RequestEmailChange(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{ID: uuid.New(), FirstName: "John", Email: "john.doe@example.com", Password: hashedPassword}
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	t.Run("InvalidPassword", func(t *testing.T) {
+		err := service.RequestEmailChange(ctx, &dto.UserChangeEmailRequest{ID: user.ID, NewEmail: "john@example.com", Password: "WrongPassword123!"})
+		assert.Equal(t, domain.ErrInvalidPassword, err)
+	})
+
+	t.Run("EmailTaken", func(t *testing.T) {
+		deps.userRepo.On("GetByEmail", ctx, "taken@example.com").Return(&domain.User{ID: uuid.New()}, nil).Once()
+
+		err := service.RequestEmailChange(ctx, &dto.UserChangeEmailRequest{ID: user.ID, NewEmail: "taken@example.com", Password: "SecurePassword123!"})
+		assert.Equal(t, domain.ErrEmailAlreadyExists, err)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		deps.userRepo.On("GetByEmail", ctx, "john@example.com").Return(nil, domain.ErrUserNotFound).Once()
+
+		var payload NotificationPayload
+		deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.Anything).
+			Run(func(args mock.Arguments) {
+				_ = json.Unmarshal(args.Get(3).([]byte), &payload)
+			}).
+			Return(nil).Once()
+
+		err := service.RequestEmailChange(ctx, &dto.UserChangeEmailRequest{ID: user.ID, NewEmail: "john@example.com", Password: "SecurePassword123!"})
+		assert.NoError(t, err)
+
+		assert.Equal(t, []string{"john@example.com"}, payload.To)
+		link := payload.Data.(map[string]any)["VerificationLink"].(string)
+		token := link[strings.LastIndex(link, "/")+1:]
+
+		value, err := service.cache.GetString(ctx, service.cache.BuildKey(storage.CACHE_PREFIX_EMAIL_VERIFICATION, token))
+		assert.NoError(t, err)
+		assert.Equal(t, user.ID.String()+":john@example.com", value)
+		deps.userRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, domain.Email, mock.Anything)
+	})
+}
+
+// Test ConfirmEmailChange - Success And Conflict
+func TestAuthService_ConfirmEmailChange(t *testing.T) {
+	service, mockUserRepo, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "john.doe@example.com"}
+	mockUserRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	t.Run("Success", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", ctx, "john@example.com").Return(nil, domain.ErrUserNotFound).Once()
+		mockUserRepo.On("UpdateProperty", ctx, user.ID, domain.Email, "john@example.com").Return(nil).Once()
+
+		err := service.ConfirmEmailChange(ctx, user.ID, "john@example.com")
+		assert.NoError(t, err)
+	})
+
+	t.Run("TakenMeanwhile", func(t *testing.T) {
+		mockUserRepo.On("GetByEmail", ctx, "taken@example.com").Return(&domain.User{ID: uuid.New()}, nil).Once()
+
+		err := service.ConfirmEmailChange(ctx, user.ID, "taken@example.com")
+		assert.Equal(t, domain.ErrEmailAlreadyExists, err)
+	})
+
+	mockUserRepo.AssertExpectations(t)
+}
+
+// Test ResendVerificationEmail - Only Unverified Accounts, Within The Limit
+func TestAuthService_ResendVerificationEmail(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), FirstName: "John", Email: "john.doe@example.com", IsActive: true}
+	verified := &domain.User{ID: uuid.New(), Email: "jane.doe@example.com", IsActive: true, IsVerified: true}
+	deps.userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	deps.userRepo.On("GetByEmail", ctx, verified.Email).Return(verified, nil)
+	deps.userRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, domain.ErrUserNotFound)
+
+	var payload NotificationPayload
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(3).([]byte), &payload)
+		}).
+		Return(nil)
+
+	assert.NoError(t, service.ResendVerificationEmail(ctx, verified.Email))
+	assert.NoError(t, service.ResendVerificationEmail(ctx, "unknown@example.com"))
+	deps.queue.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	for range verificationResendLimit {
+		assert.NoError(t, service.ResendVerificationEmail(ctx, " John.Doe@example.com"))
+	}
+	deps.queue.AssertNumberOfCalls(t, "Publish", verificationResendLimit)
+	assert.Equal(t, []string{user.Email}, payload.To)
+
+	// The link opens the web client page that confirms the token
+	link := payload.Data.(map[string]any)["VerificationLink"].(string)
+	assert.True(t, strings.HasPrefix(link, "https://app.example.com/auth/email/verify/"), link)
+
+	err := service.ResendVerificationEmail(ctx, user.Email)
+	assert.Equal(t, domain.ErrTooManyVerificationEmails, err)
+	deps.queue.AssertNumberOfCalls(t, "Publish", verificationResendLimit)
+}
+
+// Test Magic Link - Disabled By Default
+func TestAuthService_MagicLink_Disabled(t *testing.T) {
+	service, _, _ := setupAuthTest(t)
+	ctx := context.Background()
+
+	err := service.RequestMagicLink(ctx, "john.doe@example.com")
+	assert.Equal(t, domain.ErrMagicLinkDisabled, err)
+
+	_, err = service.LoginWithMagicLink(ctx, "token", testClient)
+	assert.Equal(t, domain.ErrMagicLinkDisabled, err)
+}
+
+// Test Magic Link - Request And Single-Use Exchange
+func TestAuthService_MagicLink(t *testing.T) {
+	service, deps := setupAuthTestDeps(t)
+	service.config.Application.MagicLinkLogin = true
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), FirstName: "John", Email: "john.doe@example.com", IsActive: true, IsVerified: true}
+	deps.userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	deps.userRepo.On("GetByEmail", ctx, "unknown@example.com").Return(nil, domain.ErrUserNotFound)
+
+	var payload NotificationPayload
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.Anything).
+		Run(func(args mock.Arguments) {
+			_ = json.Unmarshal(args.Get(3).([]byte), &payload)
+		}).
+		Return(nil)
+
+	assert.NoError(t, service.RequestMagicLink(ctx, "unknown@example.com"))
+	deps.queue.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+
+	require.NoError(t, service.RequestMagicLink(ctx, user.Email))
+	assert.Equal(t, constants.EMAIL_TEMPLATE_MAGIC_LINK, payload.TemplateName)
+	assert.Equal(t, []string{user.Email}, payload.To)
+	link := payload.Data.(map[string]any)["MagicLink"].(string)
+	token := link[strings.LastIndex(link, "/")+1:]
+
+	resp, err := service.LoginWithMagicLink(ctx, token, testClient)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+
+	claims, err := deps.tokenManager.ParseToken(resp.Token)
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID.String(), claims.UserID)
+
+	// The link works only once
+	_, err = service.LoginWithMagicLink(ctx, token, testClient)
+	assert.Equal(t, domain.ErrInvalidMagicLink, err)
+
+	t.Run("TwoFactorStillRequired", func(t *testing.T) {
+		user.IsTwoFactorEnabled = true
+		defer func() { user.IsTwoFactorEnabled = false }()
+
+		require.NoError(t, service.RequestMagicLink(ctx, user.Email))
+		link := payload.Data.(map[string]any)["MagicLink"].(string)
+
+		resp, err := service.LoginWithMagicLink(ctx, link[strings.LastIndex(link, "/")+1:], testClient)
+		assert.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+		assert.Empty(t, resp.Token)
+	})
+
+	t.Run("Limit", func(t *testing.T) {
+		for range magicLinkLimit - 2 {
+			assert.NoError(t, service.RequestMagicLink(ctx, user.Email))
+		}
+
+		err := service.RequestMagicLink(ctx, user.Email)
+		assert.Equal(t, domain.ErrTooManyMagicLinks, err)
+	})
+}
+
 // Benchmark tests
 func BenchmarkAuthService_Login_Success(b *testing.B) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(b)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -508,12 +1287,12 @@ func BenchmarkAuthService_Login_Success(b *testing.B) {
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _ = service.Login(ctx, req)
+		_, _ = service.Login(ctx, req, testClient)
 	}
 }
 
 func BenchmarkAuthService_UpdatePassword(b *testing.B) {
-	service, mockUserRepo, _ := setupAuthTest()
+	service, mockUserRepo, _ := setupAuthTest(b)
 	ctx := context.Background()
 
 	userID := uuid.New()
@@ -528,7 +1307,7 @@ func BenchmarkAuthService_UpdatePassword(b *testing.B) {
 	}
 
 	mockUserRepo.On("GetByID", mock.Anything, mock.Anything).Return(user, nil)
-	mockUserRepo.On("UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	mockUserRepo.On("UpdatePassword", mock.Anything, mock.Anything, mock.Anything).Return(nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,228 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/url"
+	"strings"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// attestationFieldMaxLength matches the sacramental_record and letter_url columns
+const attestationFieldMaxLength = 255
+
+// CatholicAttestationService handles the requests of users to be recognised as Catholic.
+// A manager of the parish named in the request approves or rejects it, and only an approval
+// sets the is_catholic flag. Every request is kept, along with its review.
+type CatholicAttestationService struct {
+	logger          *zap.SugaredLogger
+	config          config.Config
+	queue           storage.QueueStorage
+	attestationRepo domain.CatholicAttestationRepository
+	userRepo        domain.UserRepository
+	churchRepo      adminDomain.ChurchRepository
+	auth            *AuthService
+}
+
+func NewCatholicAttestationService(logger *zap.SugaredLogger, cfg config.Config, queue storage.QueueStorage, attestationRepo domain.CatholicAttestationRepository, userRepo domain.UserRepository, churchRepo adminDomain.ChurchRepository, authService *AuthService) *CatholicAttestationService {
+	return &CatholicAttestationService{
+		logger:          logger,
+		config:          cfg,
+		queue:           queue,
+		attestationRepo: attestationRepo,
+		userRepo:        userRepo,
+		churchRepo:      churchRepo,
+		auth:            authService,
+	}
+}
+
+// Submit files an attestation for review by the parish and lets the user and the parish managers know
+func (s *CatholicAttestationService) Submit(ctx context.Context, req *dto.CatholicAttestationSubmitRequest) (*domain.CatholicAttestation, error) {
+	req.SacramentalRecord = strings.TrimSpace(req.SacramentalRecord)
+	req.LetterURL = strings.TrimSpace(req.LetterURL)
+	if len(req.SacramentalRecord) > attestationFieldMaxLength || len(req.LetterURL) > attestationFieldMaxLength {
+		return nil, domain.ErrInvalidFieldValue
+	}
+	if req.LetterURL != "" {
+		if u, err := url.ParseRequestURI(req.LetterURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+			return nil, domain.ErrInvalidFieldValue
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if user.IsCatholic {
+		return nil, domain.ErrAlreadyCatholic
+	}
+
+	if _, err := s.churchRepo.GetByID(ctx, req.ChurchID); err != nil {
+		if errors.Is(err, adminDomain.ErrChurchNotFound) {
+			return nil, adminDomain.ErrChurchNotFound
+		}
+
+		s.logger.Errorw("failed to get church by ID", "id", req.ChurchID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if _, err := s.attestationRepo.GetPendingByUserID(ctx, req.UserID); err == nil {
+		return nil, domain.ErrAttestationPending
+	} else if !errors.Is(err, domain.ErrAttestationNotFound) {
+		s.logger.Errorw("failed to get pending attestation", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	attestation := &domain.CatholicAttestation{
+		UserID:            req.UserID,
+		ChurchID:          req.ChurchID,
+		SacramentalRecord: sql.NullString{String: req.SacramentalRecord, Valid: req.SacramentalRecord != ""},
+		LetterURL:         sql.NullString{String: req.LetterURL, Valid: req.LetterURL != ""},
+	}
+
+	if err := s.attestationRepo.Create(ctx, attestation); err != nil {
+		s.logger.Errorw("failed to create attestation", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.sendAttestationEmail(ctx, user, "email.attestation_submitted", "", s.auth.frontendLink("user", "attestation")); err != nil {
+		s.logger.Warnw("failed to send attestation submitted email", "attestationID", attestation.ID, "error", err)
+	}
+	s.notifyReviewers(ctx, attestation, user)
+
+	return attestation, nil
+}
+
+// GetByID returns an attestation, the caller checks it may be seen
+func (s *CatholicAttestationService) GetByID(ctx context.Context, id uuid.UUID) (*domain.CatholicAttestation, error) {
+	attestation, err := s.attestationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrAttestationNotFound) {
+			return nil, domain.ErrAttestationNotFound
+		}
+
+		s.logger.Errorw("failed to get attestation by ID", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return attestation, nil
+}
+
+func (s *CatholicAttestationService) List(ctx context.Context, filter *dto.CatholicAttestationListRequest) (*dto.CatholicAttestationListResponse, error) {
+	if filter.Status != nil {
+		switch *filter.Status {
+		case domain.AttestationStatusPending, domain.AttestationStatusApproved, domain.AttestationStatusRejected:
+		default:
+			return nil, domain.ErrInvalidAttestationStatus
+		}
+	}
+
+	attestations, err := s.attestationRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("failed to list attestations", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	count := 0
+	if len(attestations) > 0 {
+		count, err = s.attestationRepo.Count(ctx, filter)
+		if err != nil {
+			s.logger.Errorw("failed to count attestations", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
+	return &dto.CatholicAttestationListResponse{
+		Attestations: attestations,
+		Count:        count,
+	}, nil
+}
+
+// Review approves or rejects a pending attestation and tells the user about the decision.
+// Approval flags the user as Catholic. Nobody reviews their own attestation.
+func (s *CatholicAttestationService) Review(ctx context.Context, req *dto.CatholicAttestationReviewRequest) (*domain.CatholicAttestation, error) {
+	attestation, err := s.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if attestation.Status != domain.AttestationStatusPending {
+		return nil, domain.ErrAttestationAlreadyReviewed
+	}
+
+	if attestation.UserID == req.ReviewerID {
+		return nil, domain.ErrAttestationSelfReview
+	}
+
+	attestation.Status = domain.AttestationStatusRejected
+	if req.Approve {
+		attestation.Status = domain.AttestationStatusApproved
+	}
+	comment := strings.TrimSpace(req.Comment)
+	attestation.ReviewerID = uuid.NullUUID{UUID: req.ReviewerID, Valid: true}
+	attestation.ReviewComment = sql.NullString{String: comment, Valid: comment != ""}
+
+	if err := s.attestationRepo.Review(ctx, attestation); err != nil {
+		if errors.Is(err, domain.ErrAttestationAlreadyReviewed) {
+			return nil, domain.ErrAttestationAlreadyReviewed
+		}
+
+		s.logger.Errorw("failed to review attestation", "id", req.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	user, err := s.userRepo.GetByID(ctx, attestation.UserID)
+	if err != nil {
+		s.logger.Warnw("failed to get attestation user", "attestationID", attestation.ID, "error", err)
+		return attestation, nil
+	}
+
+	section := "email.attestation_rejected"
+	if attestation.Status == domain.AttestationStatusApproved {
+		section = "email.attestation_approved"
+	}
+	if err := s.sendAttestationEmail(ctx, user, section, comment, s.auth.frontendLink("user", "attestation")); err != nil {
+		s.logger.Warnw("failed to send attestation review email", "attestationID", attestation.ID, "error", err)
+	}
+
+	return attestation, nil
+}
+
+// notifyReviewers asks the managers of the parish to review the attestation. Parishes without
+// a manager of their own are left to the admins who oversee every church, through the list.
+func (s *CatholicAttestationService) notifyReviewers(ctx context.Context, attestation *domain.CatholicAttestation, user *domain.User) {
+	reviewers, err := s.attestationRepo.ListReviewers(ctx, attestation.ChurchID, constants.PERMISSION_USER_REVIEW_ATTESTATION)
+	if err != nil {
+		s.logger.Warnw("failed to list attestation reviewers", "attestationID", attestation.ID, "error", err)
+		return
+	}
+
+	link := s.auth.frontendLink("admin", "attestation", attestation.ID.String())
+	applicant := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	for _, reviewer := range reviewers {
+		if err := s.sendAttestationEmail(ctx, reviewer, "email.attestation_review_requested", applicant, link); err != nil {
+			s.logger.Warnw("failed to send attestation review request email", "attestationID", attestation.ID, "reviewerID", reviewer.ID, "error", err)
+		}
+	}
+}
+
+// sendAttestationEmail sends the section of the attestation emails to the user
+func (s *CatholicAttestationService) sendAttestationEmail(ctx context.Context, user *domain.User, section, detail, link string) error {
+	return SendReviewEmail(ctx, s.queue, s.config.SMTP.From, user, constants.EMAIL_TEMPLATE_ATTESTATION, section, detail, link)
+}
@@ -0,0 +1,331 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockCatholicAttestationRepository
+type MockCatholicAttestationRepository struct {
+	mock.Mock
+}
+
+func (m *MockCatholicAttestationRepository) Create(ctx context.Context, attestation *domain.CatholicAttestation) error {
+	args := m.Called(ctx, attestation)
+	if args.Error(0) == nil {
+		attestation.ID = uuid.New()
+		attestation.Status = domain.AttestationStatusPending
+	}
+	return args.Error(0)
+}
+
+func (m *MockCatholicAttestationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.CatholicAttestation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CatholicAttestation), args.Error(1)
+}
+
+func (m *MockCatholicAttestationRepository) GetPendingByUserID(ctx context.Context, userID uuid.UUID) (*domain.CatholicAttestation, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.CatholicAttestation), args.Error(1)
+}
+
+func (m *MockCatholicAttestationRepository) List(ctx context.Context, filter *domain.CatholicAttestationFilters) ([]*domain.CatholicAttestation, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.CatholicAttestation), args.Error(1)
+}
+
+func (m *MockCatholicAttestationRepository) Count(ctx context.Context, filter *domain.CatholicAttestationFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCatholicAttestationRepository) Review(ctx context.Context, attestation *domain.CatholicAttestation) error {
+	args := m.Called(ctx, attestation)
+	return args.Error(0)
+}
+
+func (m *MockCatholicAttestationRepository) ListReviewers(ctx context.Context, churchID uuid.UUID, permission string) ([]*domain.User, error) {
+	args := m.Called(ctx, churchID, permission)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+// MockChurchRepository
+type MockChurchRepository struct {
+	mock.Mock
+}
+
+func (m *MockChurchRepository) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	args := m.Called(ctx, fn)
+	return args.Error(0)
+}
+
+func (m *MockChurchRepository) Create(tx *sqlx.Tx, church *adminDomain.Church) error {
+	args := m.Called(tx, church)
+	return args.Error(0)
+}
+
+func (m *MockChurchRepository) Update(ctx context.Context, church *adminDomain.Church) error {
+	args := m.Called(ctx, church)
+	return args.Error(0)
+}
+
+func (m *MockChurchRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockChurchRepository) GetByID(ctx context.Context, id uuid.UUID) (*adminDomain.Church, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Church), args.Error(1)
+}
+
+func (m *MockChurchRepository) GetByName(ctx context.Context, name string) (*adminDomain.Church, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Church), args.Error(1)
+}
+
+func (m *MockChurchRepository) List(ctx context.Context, filter *adminDomain.ChurchFilters) ([]*adminDomain.Church, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*adminDomain.Church), args.Error(1)
+}
+
+func (m *MockChurchRepository) Count(ctx context.Context, filter *adminDomain.ChurchFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+// attestationTestDeps exposes the dependencies of the CatholicAttestationService built by setupAttestationTest
+type attestationTestDeps struct {
+	*authTestDeps
+	attestationRepo *MockCatholicAttestationRepository
+	churchRepo      *MockChurchRepository
+}
+
+func setupAttestationTest(t *testing.T) (*CatholicAttestationService, *attestationTestDeps) {
+	authService, authDeps := setupAuthTestDeps(t)
+	deps := &attestationTestDeps{
+		authTestDeps:    authDeps,
+		attestationRepo: new(MockCatholicAttestationRepository),
+		churchRepo:      new(MockChurchRepository),
+	}
+
+	service := NewCatholicAttestationService(zap.NewNop().Sugar(), config.Config{}, deps.queue, deps.attestationRepo, deps.userRepo, deps.churchRepo, authService)
+
+	return service, deps
+}
+
+// expectEmail expects the attestation email of the section to be sent to the address.
+// Translations are not loaded in tests, so the subject is its locale key.
+func expectEmail(ctx context.Context, deps *attestationTestDeps, to, section string) {
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.MatchedBy(func(body []byte) bool {
+		var payload NotificationPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return false
+		}
+		return len(payload.To) == 1 && payload.To[0] == to &&
+			payload.TemplateName == constants.EMAIL_TEMPLATE_ATTESTATION &&
+			payload.Subject == "email.attestation_"+section+".subject"
+	})).Return(nil).Once()
+}
+
+func TestCatholicAttestationService_Submit(t *testing.T) {
+	ctx := context.Background()
+	churchID := uuid.New()
+	user := &domain.User{ID: uuid.New(), FirstName: "Maria", LastName: "Silva", Email: "maria@example.com", Language: sql.NullString{String: "en-US", Valid: true}}
+
+	t.Run("Success", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		manager := &domain.User{ID: uuid.New(), FirstName: "José", Email: "parish@example.com", Language: sql.NullString{String: "en-US", Valid: true}}
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		deps.churchRepo.On("GetByID", ctx, churchID).Return(&adminDomain.Church{ID: churchID}, nil)
+		deps.attestationRepo.On("GetPendingByUserID", ctx, user.ID).Return(nil, domain.ErrAttestationNotFound)
+		deps.attestationRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.CatholicAttestation) bool {
+			return a.UserID == user.ID && a.ChurchID == churchID && a.SacramentalRecord.String == "Baptism book 12, page 34" && !a.LetterURL.Valid
+		})).Return(nil)
+		deps.attestationRepo.On("ListReviewers", ctx, churchID, constants.PERMISSION_USER_REVIEW_ATTESTATION).Return([]*domain.User{manager}, nil)
+		expectEmail(ctx, deps, user.Email, "submitted")
+		expectEmail(ctx, deps, manager.Email, "review_requested")
+
+		attestation, err := service.Submit(ctx, &dto.CatholicAttestationSubmitRequest{
+			UserID:            user.ID,
+			ChurchID:          churchID,
+			SacramentalRecord: " Baptism book 12, page 34 ",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttestationStatusPending, attestation.Status)
+		deps.attestationRepo.AssertExpectations(t)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyCatholic", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(&domain.User{ID: user.ID, IsCatholic: true}, nil)
+
+		attestation, err := service.Submit(ctx, &dto.CatholicAttestationSubmitRequest{UserID: user.ID, ChurchID: churchID})
+
+		assert.Nil(t, attestation)
+		assert.Equal(t, domain.ErrAlreadyCatholic, err)
+	})
+
+	t.Run("PendingAttestation", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		deps.churchRepo.On("GetByID", ctx, churchID).Return(&adminDomain.Church{ID: churchID}, nil)
+		deps.attestationRepo.On("GetPendingByUserID", ctx, user.ID).Return(&domain.CatholicAttestation{ID: uuid.New()}, nil)
+
+		attestation, err := service.Submit(ctx, &dto.CatholicAttestationSubmitRequest{UserID: user.ID, ChurchID: churchID})
+
+		assert.Nil(t, attestation)
+		assert.Equal(t, domain.ErrAttestationPending, err)
+		deps.attestationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UnknownChurch", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		deps.churchRepo.On("GetByID", ctx, churchID).Return(nil, adminDomain.ErrChurchNotFound)
+
+		attestation, err := service.Submit(ctx, &dto.CatholicAttestationSubmitRequest{UserID: user.ID, ChurchID: churchID})
+
+		assert.Nil(t, attestation)
+		assert.Equal(t, adminDomain.ErrChurchNotFound, err)
+	})
+
+	t.Run("InvalidLetterURL", func(t *testing.T) {
+		service, _ := setupAttestationTest(t)
+
+		attestation, err := service.Submit(ctx, &dto.CatholicAttestationSubmitRequest{UserID: user.ID, ChurchID: churchID, LetterURL: "javascript:alert(1)"})
+
+		assert.Nil(t, attestation)
+		assert.Equal(t, domain.ErrInvalidFieldValue, err)
+	})
+}
+
+func TestCatholicAttestationService_Review(t *testing.T) {
+	ctx := context.Background()
+	reviewerID := uuid.New()
+	user := &domain.User{ID: uuid.New(), FirstName: "Maria", Email: "maria@example.com", Language: sql.NullString{String: "en-US", Valid: true}}
+
+	pending := func() *domain.CatholicAttestation {
+		return &domain.CatholicAttestation{ID: uuid.New(), UserID: user.ID, ChurchID: uuid.New(), Status: domain.AttestationStatusPending}
+	}
+
+	t.Run("Approve", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		attestation := pending()
+		deps.attestationRepo.On("GetByID", ctx, attestation.ID).Return(attestation, nil)
+		deps.attestationRepo.On("Review", ctx, mock.MatchedBy(func(a *domain.CatholicAttestation) bool {
+			return a.Status == domain.AttestationStatusApproved && a.ReviewerID.UUID == reviewerID && a.ReviewComment.String == "Welcome"
+		})).Return(nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		expectEmail(ctx, deps, user.Email, "approved")
+
+		result, err := service.Review(ctx, &dto.CatholicAttestationReviewRequest{ID: attestation.ID, ReviewerID: reviewerID, Approve: true, Comment: "Welcome"})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttestationStatusApproved, result.Status)
+		deps.attestationRepo.AssertExpectations(t)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("Reject", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		attestation := pending()
+		deps.attestationRepo.On("GetByID", ctx, attestation.ID).Return(attestation, nil)
+		deps.attestationRepo.On("Review", ctx, mock.MatchedBy(func(a *domain.CatholicAttestation) bool {
+			return a.Status == domain.AttestationStatusRejected && !a.ReviewComment.Valid
+		})).Return(nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		expectEmail(ctx, deps, user.Email, "rejected")
+
+		result, err := service.Review(ctx, &dto.CatholicAttestationReviewRequest{ID: attestation.ID, ReviewerID: reviewerID})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.AttestationStatusRejected, result.Status)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("AlreadyReviewed", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		attestation := pending()
+		attestation.Status = domain.AttestationStatusRejected
+		deps.attestationRepo.On("GetByID", ctx, attestation.ID).Return(attestation, nil)
+
+		result, err := service.Review(ctx, &dto.CatholicAttestationReviewRequest{ID: attestation.ID, ReviewerID: reviewerID, Approve: true})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrAttestationAlreadyReviewed, err)
+		deps.attestationRepo.AssertNotCalled(t, "Review", mock.Anything, mock.Anything)
+	})
+
+	t.Run("OwnAttestation", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		attestation := pending()
+		deps.attestationRepo.On("GetByID", ctx, attestation.ID).Return(attestation, nil)
+
+		result, err := service.Review(ctx, &dto.CatholicAttestationReviewRequest{ID: attestation.ID, ReviewerID: user.ID, Approve: true})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrAttestationSelfReview, err)
+		deps.attestationRepo.AssertNotCalled(t, "Review", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ReviewedConcurrently", func(t *testing.T) {
+		service, deps := setupAttestationTest(t)
+		attestation := pending()
+		deps.attestationRepo.On("GetByID", ctx, attestation.ID).Return(attestation, nil)
+		deps.attestationRepo.On("Review", ctx, attestation).Return(domain.ErrAttestationAlreadyReviewed)
+
+		result, err := service.Review(ctx, &dto.CatholicAttestationReviewRequest{ID: attestation.ID, ReviewerID: reviewerID, Approve: true})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrAttestationAlreadyReviewed, err)
+		deps.queue.AssertNotCalled(t, "Publish", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestCatholicAttestationService_List_InvalidStatus(t *testing.T) {
+	service, _ := setupAttestationTest(t)
+	status := domain.AttestationStatus("archived")
+
+	list, err := service.List(context.Background(), &dto.CatholicAttestationListRequest{Status: &status})
+
+	assert.Nil(t, list)
+	assert.Equal(t, domain.ErrInvalidAttestationStatus, err)
+}
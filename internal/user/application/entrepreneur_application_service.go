@@ -0,0 +1,257 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// businessNameMaxLength matches the entrepreneur_applications.business_name column
+const businessNameMaxLength = 255
+
+// EntrepreneurApplicationService handles the requests of users to become entrepreneurs.
+// Admins work through the open applications, approving, rejecting or asking the applicant for
+// more information, and the applicant is emailed on every decision.
+type EntrepreneurApplicationService struct {
+	logger          *zap.SugaredLogger
+	config          config.Config
+	queue           storage.QueueStorage
+	applicationRepo domain.EntrepreneurApplicationRepository
+	userRepo        domain.UserRepository
+	industryRepo    adminDomain.IndustryRepository
+	auth            *AuthService
+}
+
+func NewEntrepreneurApplicationService(logger *zap.SugaredLogger, cfg config.Config, queue storage.QueueStorage, applicationRepo domain.EntrepreneurApplicationRepository, userRepo domain.UserRepository, industryRepo adminDomain.IndustryRepository, authService *AuthService) *EntrepreneurApplicationService {
+	return &EntrepreneurApplicationService{
+		logger:          logger,
+		config:          cfg,
+		queue:           queue,
+		applicationRepo: applicationRepo,
+		userRepo:        userRepo,
+		industryRepo:    industryRepo,
+		auth:            authService,
+	}
+}
+
+// Submit files an application to the review queue
+func (s *EntrepreneurApplicationService) Submit(ctx context.Context, req *dto.EntrepreneurApplicationRequest) (*domain.EntrepreneurApplication, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if user.IsEntrepreneur {
+		return nil, domain.ErrAlreadyEntrepreneur
+	}
+
+	if _, err := s.applicationRepo.GetOpenByUserID(ctx, req.UserID); err == nil {
+		return nil, domain.ErrApplicationOpen
+	} else if !errors.Is(err, domain.ErrApplicationNotFound) {
+		s.logger.Errorw("failed to get open application", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	application := &domain.EntrepreneurApplication{UserID: req.UserID}
+	applyApplicationRequest(application, req)
+
+	if err := s.applicationRepo.Create(ctx, application); err != nil {
+		s.logger.Errorw("failed to create application", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return application, nil
+}
+
+// Resubmit completes an application the admins asked more information on and puts it back in the queue
+func (s *EntrepreneurApplicationService) Resubmit(ctx context.Context, req *dto.EntrepreneurApplicationRequest) (*domain.EntrepreneurApplication, error) {
+	if err := s.validate(ctx, req); err != nil {
+		return nil, err
+	}
+
+	application, err := s.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Applications of other users are not disclosed
+	if application.UserID != req.UserID {
+		return nil, domain.ErrApplicationNotFound
+	}
+
+	if application.Status != domain.ApplicationStatusInfoRequested {
+		return nil, domain.ErrApplicationNotAwaitingInfo
+	}
+
+	applyApplicationRequest(application, req)
+
+	if err := s.applicationRepo.Resubmit(ctx, application); err != nil {
+		if errors.Is(err, domain.ErrApplicationNotAwaitingInfo) {
+			return nil, domain.ErrApplicationNotAwaitingInfo
+		}
+
+		s.logger.Errorw("failed to resubmit application", "id", req.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return application, nil
+}
+
+// GetByID returns an application, the caller checks it may be seen
+func (s *EntrepreneurApplicationService) GetByID(ctx context.Context, id uuid.UUID) (*domain.EntrepreneurApplication, error) {
+	application, err := s.applicationRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, domain.ErrApplicationNotFound) {
+			return nil, domain.ErrApplicationNotFound
+		}
+
+		s.logger.Errorw("failed to get application by ID", "id", id, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return application, nil
+}
+
+func (s *EntrepreneurApplicationService) List(ctx context.Context, filter *dto.EntrepreneurApplicationListRequest) (*dto.EntrepreneurApplicationListResponse, error) {
+	if filter.Status != nil {
+		switch *filter.Status {
+		case domain.ApplicationStatusPending, domain.ApplicationStatusInfoRequested, domain.ApplicationStatusApproved, domain.ApplicationStatusRejected:
+		default:
+			return nil, domain.ErrInvalidApplicationStatus
+		}
+	}
+
+	applications, err := s.applicationRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("failed to list applications", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	count := 0
+	if len(applications) > 0 {
+		count, err = s.applicationRepo.Count(ctx, filter)
+		if err != nil {
+			s.logger.Errorw("failed to count applications", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
+	return &dto.EntrepreneurApplicationListResponse{
+		Applications: applications,
+		Count:        count,
+	}, nil
+}
+
+// Review takes a decision on an open application and emails it to the applicant.
+// Approval flags them as an entrepreneur and, on request, gives them the entrepreneur role.
+// Nobody reviews their own application.
+func (s *EntrepreneurApplicationService) Review(ctx context.Context, req *dto.EntrepreneurApplicationReviewRequest) (*domain.EntrepreneurApplication, error) {
+	comment := strings.TrimSpace(req.Comment)
+	var status domain.ApplicationStatus
+	switch req.Decision {
+	case domain.ApplicationDecisionApprove:
+		status = domain.ApplicationStatusApproved
+	case domain.ApplicationDecisionReject:
+		status = domain.ApplicationStatusRejected
+	case domain.ApplicationDecisionRequestInfo:
+		// The applicant has to know what to add
+		if comment == "" {
+			return nil, domain.ErrRequiredField
+		}
+		status = domain.ApplicationStatusInfoRequested
+	default:
+		return nil, domain.ErrInvalidApplicationDecision
+	}
+
+	application, err := s.GetByID(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !application.Status.IsOpen() {
+		return nil, domain.ErrApplicationClosed
+	}
+
+	if application.UserID == req.ReviewerID {
+		return nil, domain.ErrApplicationSelfReview
+	}
+
+	application.Status = status
+	application.ReviewerID = uuid.NullUUID{UUID: req.ReviewerID, Valid: true}
+	application.ReviewComment = sql.NullString{String: comment, Valid: comment != ""}
+
+	assignRole := req.AssignRole && status == domain.ApplicationStatusApproved
+	if err := s.applicationRepo.Review(ctx, application, assignRole); err != nil {
+		if errors.Is(err, domain.ErrApplicationClosed) {
+			return nil, domain.ErrApplicationClosed
+		}
+
+		s.logger.Errorw("failed to review application", "id", req.ID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	user, err := s.userRepo.GetByID(ctx, application.UserID)
+	if err != nil {
+		s.logger.Warnw("failed to get applicant", "applicationID", application.ID, "error", err)
+		return application, nil
+	}
+
+	section := "email.entrepreneur_" + string(status)
+	link := s.auth.frontendLink("user", "entrepreneur-application")
+	if err := SendReviewEmail(ctx, s.queue, s.config.SMTP.From, user, constants.EMAIL_TEMPLATE_ENTREPRENEUR_APPLICATION, section, comment, link); err != nil {
+		s.logger.Warnw("failed to send application decision email", "applicationID", application.ID, "error", err)
+	}
+
+	return application, nil
+}
+
+// validate trims the request and checks its fields, including that the industry exists
+func (s *EntrepreneurApplicationService) validate(ctx context.Context, req *dto.EntrepreneurApplicationRequest) error {
+	req.BusinessName = strings.TrimSpace(req.BusinessName)
+	req.BusinessDescription = strings.TrimSpace(req.BusinessDescription)
+	req.References = strings.TrimSpace(req.References)
+	if req.BusinessName == "" || req.BusinessDescription == "" {
+		return domain.ErrRequiredField
+	}
+	if len(req.BusinessName) > businessNameMaxLength {
+		return domain.ErrInvalidFieldValue
+	}
+
+	if _, err := s.industryRepo.GetByID(ctx, req.IndustryID); err != nil {
+		if errors.Is(err, adminDomain.ErrIndustryNotFound) {
+			return adminDomain.ErrIndustryNotFound
+		}
+
+		s.logger.Errorw("failed to get industry by ID", "id", req.IndustryID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func applyApplicationRequest(application *domain.EntrepreneurApplication, req *dto.EntrepreneurApplicationRequest) {
+	application.BusinessName = req.BusinessName
+	application.BusinessDescription = req.BusinessDescription
+	application.IndustryID = req.IndustryID
+	application.References = sql.NullString{String: req.References, Valid: req.References != ""}
+}
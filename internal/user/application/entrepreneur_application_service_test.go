@@ -0,0 +1,392 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockEntrepreneurApplicationRepository
+type MockEntrepreneurApplicationRepository struct {
+	mock.Mock
+}
+
+func (m *MockEntrepreneurApplicationRepository) Create(ctx context.Context, application *domain.EntrepreneurApplication) error {
+	args := m.Called(ctx, application)
+	if args.Error(0) == nil {
+		application.ID = uuid.New()
+		application.Status = domain.ApplicationStatusPending
+	}
+	return args.Error(0)
+}
+
+func (m *MockEntrepreneurApplicationRepository) Resubmit(ctx context.Context, application *domain.EntrepreneurApplication) error {
+	args := m.Called(ctx, application)
+	if args.Error(0) == nil {
+		application.Status = domain.ApplicationStatusPending
+	}
+	return args.Error(0)
+}
+
+func (m *MockEntrepreneurApplicationRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.EntrepreneurApplication, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EntrepreneurApplication), args.Error(1)
+}
+
+func (m *MockEntrepreneurApplicationRepository) GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*domain.EntrepreneurApplication, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.EntrepreneurApplication), args.Error(1)
+}
+
+func (m *MockEntrepreneurApplicationRepository) List(ctx context.Context, filter *domain.EntrepreneurApplicationFilters) ([]*domain.EntrepreneurApplication, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.EntrepreneurApplication), args.Error(1)
+}
+
+func (m *MockEntrepreneurApplicationRepository) Count(ctx context.Context, filter *domain.EntrepreneurApplicationFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockEntrepreneurApplicationRepository) Review(ctx context.Context, application *domain.EntrepreneurApplication, assignRole bool) error {
+	args := m.Called(ctx, application, assignRole)
+	return args.Error(0)
+}
+
+// MockIndustryRepository
+type MockIndustryRepository struct {
+	mock.Mock
+}
+
+func (m *MockIndustryRepository) Create(ctx context.Context, industry *adminDomain.Industry) error {
+	args := m.Called(ctx, industry)
+	return args.Error(0)
+}
+
+func (m *MockIndustryRepository) Update(ctx context.Context, industry *adminDomain.Industry) error {
+	args := m.Called(ctx, industry)
+	return args.Error(0)
+}
+
+func (m *MockIndustryRepository) Delete(ctx context.Context, id int16) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockIndustryRepository) GetAll(ctx context.Context) ([]*adminDomain.Industry, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*adminDomain.Industry), args.Error(1)
+}
+
+func (m *MockIndustryRepository) GetByID(ctx context.Context, id int16) (*adminDomain.Industry, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Industry), args.Error(1)
+}
+
+func (m *MockIndustryRepository) GetByKey(ctx context.Context, key string) (*adminDomain.Industry, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Industry), args.Error(1)
+}
+
+// applicationTestDeps exposes the dependencies of the EntrepreneurApplicationService built by setupApplicationTest
+type applicationTestDeps struct {
+	*authTestDeps
+	applicationRepo *MockEntrepreneurApplicationRepository
+	industryRepo    *MockIndustryRepository
+}
+
+func setupApplicationTest(t *testing.T) (*EntrepreneurApplicationService, *applicationTestDeps) {
+	authService, authDeps := setupAuthTestDeps(t)
+	deps := &applicationTestDeps{
+		authTestDeps:    authDeps,
+		applicationRepo: new(MockEntrepreneurApplicationRepository),
+		industryRepo:    new(MockIndustryRepository),
+	}
+
+	service := NewEntrepreneurApplicationService(zap.NewNop().Sugar(), config.Config{}, deps.queue, deps.applicationRepo, deps.userRepo, deps.industryRepo, authService)
+
+	return service, deps
+}
+
+// expectApplicationEmail expects the decision email of the status to be sent to the address
+func expectApplicationEmail(ctx context.Context, deps *applicationTestDeps, to string, status domain.ApplicationStatus) {
+	deps.queue.On("Publish", ctx, "", constants.QUEUE_NOTIFICATIONS, mock.MatchedBy(func(body []byte) bool {
+		var payload NotificationPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			return false
+		}
+		return len(payload.To) == 1 && payload.To[0] == to &&
+			payload.TemplateName == constants.EMAIL_TEMPLATE_ENTREPRENEUR_APPLICATION &&
+			payload.Subject == "email.entrepreneur_"+string(status)+".subject"
+	})).Return(nil).Once()
+}
+
+func TestEntrepreneurApplicationService_Submit(t *testing.T) {
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "maria@example.com"}
+	request := func() *dto.EntrepreneurApplicationRequest {
+		return &dto.EntrepreneurApplicationRequest{
+			UserID:              user.ID,
+			BusinessName:        " Padaria São José ",
+			BusinessDescription: "Family bakery",
+			IndustryID:          3,
+		}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		deps.applicationRepo.On("GetOpenByUserID", ctx, user.ID).Return(nil, domain.ErrApplicationNotFound)
+		deps.applicationRepo.On("Create", ctx, mock.MatchedBy(func(a *domain.EntrepreneurApplication) bool {
+			return a.UserID == user.ID && a.BusinessName == "Padaria São José" && a.IndustryID == 3 && !a.References.Valid
+		})).Return(nil)
+
+		application, err := service.Submit(ctx, request())
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ApplicationStatusPending, application.Status)
+		deps.applicationRepo.AssertExpectations(t)
+	})
+
+	t.Run("MissingBusinessName", func(t *testing.T) {
+		service, _ := setupApplicationTest(t)
+		req := request()
+		req.BusinessName = "  "
+
+		application, err := service.Submit(ctx, req)
+
+		assert.Nil(t, application)
+		assert.Equal(t, domain.ErrRequiredField, err)
+	})
+
+	t.Run("UnknownIndustry", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(nil, adminDomain.ErrIndustryNotFound)
+
+		application, err := service.Submit(ctx, request())
+
+		assert.Nil(t, application)
+		assert.Equal(t, adminDomain.ErrIndustryNotFound, err)
+	})
+
+	t.Run("AlreadyEntrepreneur", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(&domain.User{ID: user.ID, IsEntrepreneur: true}, nil)
+
+		application, err := service.Submit(ctx, request())
+
+		assert.Nil(t, application)
+		assert.Equal(t, domain.ErrAlreadyEntrepreneur, err)
+	})
+
+	t.Run("OpenApplication", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		deps.applicationRepo.On("GetOpenByUserID", ctx, user.ID).Return(&domain.EntrepreneurApplication{ID: uuid.New()}, nil)
+
+		application, err := service.Submit(ctx, request())
+
+		assert.Nil(t, application)
+		assert.Equal(t, domain.ErrApplicationOpen, err)
+		deps.applicationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEntrepreneurApplicationService_Resubmit(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	awaitingInfo := func() *domain.EntrepreneurApplication {
+		return &domain.EntrepreneurApplication{ID: uuid.New(), UserID: userID, Status: domain.ApplicationStatusInfoRequested}
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := awaitingInfo()
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+		deps.applicationRepo.On("Resubmit", ctx, mock.MatchedBy(func(a *domain.EntrepreneurApplication) bool {
+			return a.References.String == "Fr. Antônio, parish priest"
+		})).Return(nil)
+
+		result, err := service.Resubmit(ctx, &dto.EntrepreneurApplicationRequest{
+			ID: application.ID, UserID: userID, BusinessName: "Padaria", BusinessDescription: "Bakery", IndustryID: 3, References: "Fr. Antônio, parish priest",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ApplicationStatusPending, result.Status)
+	})
+
+	t.Run("OtherUser", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := awaitingInfo()
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+
+		result, err := service.Resubmit(ctx, &dto.EntrepreneurApplicationRequest{
+			ID: application.ID, UserID: uuid.New(), BusinessName: "Padaria", BusinessDescription: "Bakery", IndustryID: 3,
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrApplicationNotFound, err)
+	})
+
+	t.Run("NotAwaitingInfo", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := awaitingInfo()
+		application.Status = domain.ApplicationStatusPending
+		deps.industryRepo.On("GetByID", ctx, int16(3)).Return(&adminDomain.Industry{ID: 3}, nil)
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+
+		result, err := service.Resubmit(ctx, &dto.EntrepreneurApplicationRequest{
+			ID: application.ID, UserID: userID, BusinessName: "Padaria", BusinessDescription: "Bakery", IndustryID: 3,
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrApplicationNotAwaitingInfo, err)
+		deps.applicationRepo.AssertNotCalled(t, "Resubmit", mock.Anything, mock.Anything)
+	})
+}
+
+func TestEntrepreneurApplicationService_Review(t *testing.T) {
+	ctx := context.Background()
+	reviewerID := uuid.New()
+	user := &domain.User{ID: uuid.New(), FirstName: "Maria", Email: "maria@example.com", Language: sql.NullString{String: "en-US", Valid: true}}
+	pending := func() *domain.EntrepreneurApplication {
+		return &domain.EntrepreneurApplication{ID: uuid.New(), UserID: user.ID, Status: domain.ApplicationStatusPending}
+	}
+
+	t.Run("ApproveWithRole", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := pending()
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+		deps.applicationRepo.On("Review", ctx, mock.MatchedBy(func(a *domain.EntrepreneurApplication) bool {
+			return a.Status == domain.ApplicationStatusApproved && a.ReviewerID.UUID == reviewerID
+		}), true).Return(nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		expectApplicationEmail(ctx, deps, user.Email, domain.ApplicationStatusApproved)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{
+			ID: application.ID, ReviewerID: reviewerID, Decision: domain.ApplicationDecisionApprove, AssignRole: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ApplicationStatusApproved, result.Status)
+		deps.applicationRepo.AssertExpectations(t)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("RejectIgnoresRole", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := pending()
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+		deps.applicationRepo.On("Review", ctx, application, false).Return(nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		expectApplicationEmail(ctx, deps, user.Email, domain.ApplicationStatusRejected)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{
+			ID: application.ID, ReviewerID: reviewerID, Decision: domain.ApplicationDecisionReject, AssignRole: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ApplicationStatusRejected, result.Status)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("RequestInfo", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := pending()
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+		deps.applicationRepo.On("Review", ctx, mock.MatchedBy(func(a *domain.EntrepreneurApplication) bool {
+			return a.Status == domain.ApplicationStatusInfoRequested && a.ReviewComment.String == "Please add a reference"
+		}), false).Return(nil)
+		deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+		expectApplicationEmail(ctx, deps, user.Email, domain.ApplicationStatusInfoRequested)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{
+			ID: application.ID, ReviewerID: reviewerID, Decision: domain.ApplicationDecisionRequestInfo, Comment: "Please add a reference",
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, domain.ApplicationStatusInfoRequested, result.Status)
+		deps.queue.AssertExpectations(t)
+	})
+
+	t.Run("RequestInfoWithoutComment", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{
+			ID: uuid.New(), ReviewerID: reviewerID, Decision: domain.ApplicationDecisionRequestInfo, Comment: " ",
+		})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrRequiredField, err)
+		deps.applicationRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("InvalidDecision", func(t *testing.T) {
+		service, _ := setupApplicationTest(t)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{ID: uuid.New(), ReviewerID: reviewerID, Decision: "defer"})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrInvalidApplicationDecision, err)
+	})
+
+	t.Run("OwnApplication", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := pending()
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{ID: application.ID, ReviewerID: user.ID, Decision: domain.ApplicationDecisionApprove, AssignRole: true})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrApplicationSelfReview, err)
+		deps.applicationRepo.AssertNotCalled(t, "Review", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("AlreadyDecided", func(t *testing.T) {
+		service, deps := setupApplicationTest(t)
+		application := pending()
+		application.Status = domain.ApplicationStatusApproved
+		deps.applicationRepo.On("GetByID", ctx, application.ID).Return(application, nil)
+
+		result, err := service.Review(ctx, &dto.EntrepreneurApplicationReviewRequest{ID: application.ID, ReviewerID: reviewerID, Decision: domain.ApplicationDecisionReject})
+
+		assert.Nil(t, result)
+		assert.Equal(t, domain.ErrApplicationClosed, err)
+		deps.applicationRepo.AssertNotCalled(t, "Review", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
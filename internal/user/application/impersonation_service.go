@@ -0,0 +1,102 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"go.uber.org/zap"
+)
+
+// impersonableRoles are the roles support may act as. Staff roles carry admin permissions,
+// so impersonating them would let an admin act beyond their own role or scope.
+var impersonableRoles = []int16{constants.ROLE_USER, constants.ROLE_ENTREPRENEUR}
+
+// ImpersonationService lets admins see the application as a user sees it. The tokens it issues
+// are short-lived and tied to the session of the admin, and every request made with them is
+// recorded in the impersonation audit log.
+type ImpersonationService struct {
+	logger       *zap.SugaredLogger
+	tokenManager *auth.TokenManager
+	userRepo     domain.UserRepository
+	logRepo      domain.ImpersonationLogRepository
+}
+
+func NewImpersonationService(logger *zap.SugaredLogger, tokenManager *auth.TokenManager, userRepo domain.UserRepository, logRepo domain.ImpersonationLogRepository) *ImpersonationService {
+	return &ImpersonationService{
+		logger:       logger,
+		tokenManager: tokenManager,
+		userRepo:     userRepo,
+		logRepo:      logRepo,
+	}
+}
+
+// Start issues a token to act as the user for auth.ImpersonationTokenExpiry
+func (s *ImpersonationService) Start(ctx context.Context, req *dto.ImpersonationStartRequest) (*dto.ImpersonationStartResponse, error) {
+	if req.UserID == req.AdminID {
+		return nil, domain.ErrCannotImpersonate
+	}
+
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrUserNotFound
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", req.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !slices.Contains(impersonableRoles, user.RoleID) {
+		return nil, domain.ErrCannotImpersonate
+	}
+
+	token, err := s.tokenManager.GenerateImpersonationToken(user.ID.String(), user.RoleID, req.AdminID.String(), req.SessionID)
+	if err != nil {
+		s.logger.Errorw("failed to generate impersonation token", "userID", req.UserID, "adminID", req.AdminID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	s.logger.Infow("impersonation started", "userID", req.UserID, "adminID", req.AdminID, "sessionID", req.SessionID)
+
+	return &dto.ImpersonationStartResponse{
+		Token:     token,
+		ExpiresIn: int64(auth.ImpersonationTokenExpiry.Seconds()),
+		User:      user,
+	}, nil
+}
+
+// Record adds a request made while impersonating to the audit log
+func (s *ImpersonationService) Record(ctx context.Context, log *domain.ImpersonationLog) {
+	if err := s.logRepo.Create(ctx, log); err != nil {
+		s.logger.Errorw("failed to record impersonated request", "adminID", log.AdminID.UUID, "userID", log.UserID.UUID, "method", log.Method, "path", log.Path, "error", err)
+	}
+}
+
+// List retrieves the impersonation audit log for administrators
+func (s *ImpersonationService) List(ctx context.Context, filter *dto.ImpersonationLogListRequest) (*dto.ImpersonationLogListResponse, error) {
+	logs, err := s.logRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("failed to list impersonation logs", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	count := 0
+	if len(logs) > 0 {
+		count, err = s.logRepo.Count(ctx, filter)
+		if err != nil {
+			s.logger.Errorw("failed to count impersonation logs", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
+	return &dto.ImpersonationLogListResponse{
+		Logs:  logs,
+		Count: count,
+	}, nil
+}
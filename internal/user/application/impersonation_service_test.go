@@ -0,0 +1,138 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+// MockImpersonationLogRepository
+type MockImpersonationLogRepository struct {
+	mock.Mock
+}
+
+func (m *MockImpersonationLogRepository) Create(ctx context.Context, log *domain.ImpersonationLog) error {
+	args := m.Called(ctx, log)
+	return args.Error(0)
+}
+
+func (m *MockImpersonationLogRepository) List(ctx context.Context, filter *domain.ImpersonationLogFilters) ([]*domain.ImpersonationLog, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.ImpersonationLog), args.Error(1)
+}
+
+func (m *MockImpersonationLogRepository) Count(ctx context.Context, filter *domain.ImpersonationLogFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func setupImpersonationTest(t *testing.T) (*ImpersonationService, *MockUserRepository, *MockImpersonationLogRepository, *auth.TokenManager) {
+	userRepo := new(MockUserRepository)
+	logRepo := new(MockImpersonationLogRepository)
+	tokenManager := newTestTokenManager(t)
+
+	service := NewImpersonationService(zap.NewNop().Sugar(), tokenManager, userRepo, logRepo)
+
+	return service, userRepo, logRepo, tokenManager
+}
+
+func TestImpersonationService_Start(t *testing.T) {
+	ctx := context.Background()
+	adminID := uuid.New()
+	user := &domain.User{ID: uuid.New(), RoleID: constants.ROLE_USER}
+
+	t.Run("Success", func(t *testing.T) {
+		service, userRepo, _, tokenManager := setupImpersonationTest(t)
+		userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+		impersonation, err := service.Start(ctx, &dto.ImpersonationStartRequest{AdminID: adminID, UserID: user.ID, SessionID: "admin-session"})
+
+		require.NoError(t, err)
+		assert.Equal(t, user, impersonation.User)
+		assert.Equal(t, int64(auth.ImpersonationTokenExpiry.Seconds()), impersonation.ExpiresIn)
+
+		claims, err := tokenManager.ParseToken(impersonation.Token)
+		require.NoError(t, err)
+		assert.Equal(t, user.ID.String(), claims.UserID)
+		assert.Equal(t, adminID.String(), claims.ImpersonatorID)
+		assert.Equal(t, "admin-session", claims.ID)
+	})
+
+	t.Run("Self", func(t *testing.T) {
+		service, userRepo, _, _ := setupImpersonationTest(t)
+
+		impersonation, err := service.Start(ctx, &dto.ImpersonationStartRequest{AdminID: adminID, UserID: adminID})
+
+		assert.Nil(t, impersonation)
+		assert.Equal(t, domain.ErrCannotImpersonate, err)
+		userRepo.AssertNotCalled(t, "GetByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("StaffRole", func(t *testing.T) {
+		service, userRepo, _, _ := setupImpersonationTest(t)
+		manager := &domain.User{ID: uuid.New(), RoleID: constants.ROLE_MANAGER}
+		userRepo.On("GetByID", ctx, manager.ID).Return(manager, nil)
+
+		impersonation, err := service.Start(ctx, &dto.ImpersonationStartRequest{AdminID: adminID, UserID: manager.ID})
+
+		assert.Nil(t, impersonation)
+		assert.Equal(t, domain.ErrCannotImpersonate, err)
+	})
+
+	t.Run("UserNotFound", func(t *testing.T) {
+		service, userRepo, _, _ := setupImpersonationTest(t)
+		userRepo.On("GetByID", ctx, user.ID).Return(nil, domain.ErrUserNotFound)
+
+		impersonation, err := service.Start(ctx, &dto.ImpersonationStartRequest{AdminID: adminID, UserID: user.ID})
+
+		assert.Nil(t, impersonation)
+		assert.Equal(t, domain.ErrUserNotFound, err)
+	})
+}
+
+func TestImpersonationService_Record(t *testing.T) {
+	ctx := context.Background()
+	service, _, logRepo, _ := setupImpersonationTest(t)
+	log := &domain.ImpersonationLog{
+		AdminID:    uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		UserID:     uuid.NullUUID{UUID: uuid.New(), Valid: true},
+		Method:     "PUT",
+		Path:       "/api/v1/user/1",
+		StatusCode: 403,
+		Blocked:    true,
+	}
+	logRepo.On("Create", ctx, log).Return(assert.AnError)
+
+	// A failure to record is logged, the response has already been sent
+	service.Record(ctx, log)
+
+	logRepo.AssertExpectations(t)
+}
+
+func TestImpersonationService_List(t *testing.T) {
+	ctx := context.Background()
+	service, _, logRepo, _ := setupImpersonationTest(t)
+	adminID := uuid.New()
+	filter := &dto.ImpersonationLogListRequest{AdminID: &adminID}
+	logs := []*domain.ImpersonationLog{{ID: uuid.New()}, {ID: uuid.New()}}
+	logRepo.On("List", ctx, filter).Return(logs, nil)
+	logRepo.On("Count", ctx, filter).Return(2, nil)
+
+	list, err := service.List(ctx, filter)
+
+	require.NoError(t, err)
+	assert.Equal(t, logs, list.Logs)
+	assert.Equal(t, 2, list.Count)
+}
@@ -0,0 +1,250 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"go.uber.org/zap"
+)
+
+const (
+	// loginAttemptWindow is how long failed attempts are remembered, counted from the first one
+	loginAttemptWindow = 1 * time.Hour
+	// loginBackoffThreshold is the number of failures on an account before each new attempt is delayed,
+	// the delay doubles with every further failure up to loginBackoffMax
+	loginBackoffThreshold = 3
+	loginBackoffMax       = 5 * time.Minute
+	// accountLockoutThreshold failures lock the account for accountLockoutDuration, doubled for each
+	// lockout within accountLockoutWindow and capped at accountLockoutMax
+	accountLockoutThreshold = 10
+	accountLockoutDuration  = 15 * time.Minute
+	accountLockoutWindow    = 24 * time.Hour
+	accountLockoutMax       = 24 * time.Hour
+	// ipLockoutThreshold failures from one address, across any accounts, block it for ipLockoutDuration
+	ipLockoutThreshold = 50
+	ipLockoutDuration  = 1 * time.Hour
+)
+
+const (
+	loginBlockBackoff = "backoff"
+	loginBlockLockout = "lockout"
+)
+
+// LockoutService throttles password guessing with failed login counters kept in the cache,
+// per account and per IP address. Lockouts are also recorded so administrators can review them.
+type LockoutService struct {
+	logger      *zap.SugaredLogger
+	cache       storage.CacheStorage
+	lockoutRepo domain.LoginLockoutRepository
+}
+
+func NewLockoutService(logger *zap.SugaredLogger, cache storage.CacheStorage, lockoutRepo domain.LoginLockoutRepository) *LockoutService {
+	return &LockoutService{
+		logger:      logger,
+		cache:       cache,
+		lockoutRepo: lockoutRepo,
+	}
+}
+
+// Check rejects a login attempt while the account or the IP address is in back-off or locked out
+func (s *LockoutService) Check(ctx context.Context, email, ipAddress string) error {
+	blocks := []struct {
+		scope   domain.LockoutScope
+		subject string
+	}{
+		{domain.LockoutScopeAccount, normalizeEmail(email)},
+		{domain.LockoutScopeIP, ipAddress},
+	}
+
+	for _, block := range blocks {
+		key := s.blockKey(block.scope, block.subject)
+		kind, err := s.cache.GetString(ctx, key)
+		if err != nil {
+			if errors.Is(err, storage.ErrCacheMiss) {
+				continue
+			}
+
+			s.logger.Errorw("failed to get login block", "scope", block.scope, "subject", block.subject, "error", err)
+			return response.ErrInternalServerError
+		}
+
+		retryAfter, err := s.cache.TTL(ctx, key)
+		if err != nil {
+			if errors.Is(err, storage.ErrCacheMiss) {
+				continue
+			}
+
+			s.logger.Errorw("failed to get login block expiry", "scope", block.scope, "subject", block.subject, "error", err)
+			return response.ErrInternalServerError
+		}
+
+		throttled := &domain.LoginThrottledError{Err: domain.ErrTooManyLoginAttempts, RetryAfter: retryAfter}
+		if block.scope == domain.LockoutScopeAccount && kind == loginBlockLockout {
+			throttled.Err = domain.ErrAccountLocked
+		}
+
+		return throttled
+	}
+
+	return nil
+}
+
+// RecordFailure counts a failed login against the account and the IP address, applying back-off
+// and lockouts as the thresholds are crossed. It returns the account lockout this failure started,
+// if any. The user is nil when the email does not belong to an account.
+func (s *LockoutService) RecordFailure(ctx context.Context, email string, user *domain.User, ipAddress string) (*domain.LoginLockout, error) {
+	email = normalizeEmail(email)
+
+	ipFailures, err := s.cache.Incr(ctx, s.counterKey(domain.LockoutScopeIP, ipAddress), loginAttemptWindow)
+	if err != nil {
+		s.logger.Errorw("failed to count login failure", "ipAddress", ipAddress, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if ipFailures >= ipLockoutThreshold {
+		if _, err := s.lock(ctx, domain.LockoutScopeIP, ipAddress, &domain.LoginLockout{
+			Scope:          domain.LockoutScopeIP,
+			IPAddress:      ipAddress,
+			FailedAttempts: int(ipFailures),
+		}, ipLockoutDuration); err != nil {
+			return nil, err
+		}
+	}
+
+	failures, err := s.cache.Incr(ctx, s.counterKey(domain.LockoutScopeAccount, email), loginAttemptWindow)
+	if err != nil {
+		s.logger.Errorw("failed to count login failure", "email", email, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if failures < loginBackoffThreshold {
+		return nil, nil
+	}
+
+	if failures < accountLockoutThreshold {
+		delay := min(time.Second<<(failures-loginBackoffThreshold), loginBackoffMax)
+		if err := s.cache.SetString(ctx, s.blockKey(domain.LockoutScopeAccount, email), loginBlockBackoff, delay); err != nil {
+			s.logger.Errorw("failed to set login back-off", "email", email, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+
+		return nil, nil
+	}
+
+	lockouts, err := s.cache.Incr(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_LOGIN_ATTEMPT, "lockouts", email), accountLockoutWindow)
+	if err != nil {
+		s.logger.Errorw("failed to count account lockouts", "email", email, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	lockout := &domain.LoginLockout{
+		Scope:          domain.LockoutScopeAccount,
+		Email:          sql.NullString{String: email, Valid: true},
+		IPAddress:      ipAddress,
+		FailedAttempts: int(failures),
+	}
+	if user != nil {
+		lockout.UserID = &user.ID
+	}
+
+	// Bounding the shift keeps the doubling from overflowing before the cap applies
+	duration := min(accountLockoutDuration<<min(lockouts-1, 8), accountLockoutMax)
+
+	return s.lock(ctx, domain.LockoutScopeAccount, email, lockout, duration)
+}
+
+// Reset forgets the failed attempts on an account after a successful login
+func (s *LockoutService) Reset(ctx context.Context, email string) {
+	email = normalizeEmail(email)
+	for _, key := range []string{
+		s.counterKey(domain.LockoutScopeAccount, email),
+		s.cache.BuildKey(storage.CACHE_PREFIX_LOGIN_ATTEMPT, "lockouts", email),
+	} {
+		if err := s.cache.Del(ctx, key); err != nil {
+			s.logger.Warnw("failed to reset login failures", "email", email, "error", err)
+		}
+	}
+}
+
+// Unlock lifts the back-off or lockout of an account before it expires
+func (s *LockoutService) Unlock(ctx context.Context, email string) error {
+	email = normalizeEmail(email)
+	for _, key := range []string{s.blockKey(domain.LockoutScopeAccount, email), s.counterKey(domain.LockoutScopeAccount, email)} {
+		if err := s.cache.Del(ctx, key); err != nil {
+			s.logger.Errorw("failed to unlock account", "email", email, "error", err)
+			return response.ErrInternalServerError
+		}
+	}
+
+	if err := s.lockoutRepo.MarkUnlocked(ctx, email); err != nil {
+		// The account is already usable again, only the audit trail is behind
+		s.logger.Warnw("failed to mark lockout as unlocked", "email", email, "error", err)
+	}
+
+	return nil
+}
+
+// List retrieves the recorded lockouts for administrators
+func (s *LockoutService) List(ctx context.Context, filter *dto.LoginLockoutListRequest) (*dto.LoginLockoutListResponse, error) {
+	lockouts, err := s.lockoutRepo.List(ctx, filter)
+	if err != nil {
+		s.logger.Errorw("failed to list login lockouts", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	count := 0
+	if len(lockouts) > 0 {
+		count, err = s.lockoutRepo.Count(ctx, filter)
+		if err != nil {
+			s.logger.Errorw("failed to count login lockouts", "error", err)
+			return nil, response.ErrInternalServerError
+		}
+	}
+
+	return &dto.LoginLockoutListResponse{
+		Lockouts: lockouts,
+		Count:    count,
+	}, nil
+}
+
+// lock blocks the subject for the given duration, restarts its failure count and records the lockout
+func (s *LockoutService) lock(ctx context.Context, scope domain.LockoutScope, subject string, lockout *domain.LoginLockout, duration time.Duration) (*domain.LoginLockout, error) {
+	if err := s.cache.SetString(ctx, s.blockKey(scope, subject), loginBlockLockout, duration); err != nil {
+		s.logger.Errorw("failed to set login lockout", "scope", scope, "subject", subject, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.cache.Del(ctx, s.counterKey(scope, subject)); err != nil {
+		s.logger.Warnw("failed to reset login failures", "scope", scope, "subject", subject, "error", err)
+	}
+
+	lockout.LockedUntil = time.Now().UTC().Add(duration)
+	s.logger.Warnw("login locked out after repeated failures", "scope", scope, "subject", subject, "failedAttempts", lockout.FailedAttempts, "lockedUntil", lockout.LockedUntil)
+
+	if err := s.lockoutRepo.Create(ctx, lockout); err != nil {
+		// The lockout is enforced by the cache, a missing audit row must not lift it
+		s.logger.Errorw("failed to record login lockout", "scope", scope, "subject", subject, "error", err)
+	}
+
+	return lockout, nil
+}
+
+func (s *LockoutService) counterKey(scope domain.LockoutScope, subject string) string {
+	return s.cache.BuildKey(storage.CACHE_PREFIX_LOGIN_ATTEMPT, string(scope), subject)
+}
+
+func (s *LockoutService) blockKey(scope domain.LockoutScope, subject string) string {
+	return s.cache.BuildKey(storage.CACHE_PREFIX_LOGIN_ATTEMPT, "block", string(scope), subject)
+}
+
+// normalizeEmail keys the counters the same way the citext email column compares
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
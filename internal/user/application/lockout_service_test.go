@@ -0,0 +1,188 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockLoginLockoutRepository struct {
+	mock.Mock
+}
+
+func (m *MockLoginLockoutRepository) Create(ctx context.Context, lockout *domain.LoginLockout) error {
+	args := m.Called(ctx, lockout)
+	return args.Error(0)
+}
+
+func (m *MockLoginLockoutRepository) MarkUnlocked(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockLoginLockoutRepository) List(ctx context.Context, filter *domain.LoginLockoutFilters) ([]*domain.LoginLockout, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.LoginLockout), args.Error(1)
+}
+
+func (m *MockLoginLockoutRepository) Count(ctx context.Context, filter *domain.LoginLockoutFilters) (int, error) {
+	args := m.Called(ctx, filter)
+	return args.Int(0), args.Error(1)
+}
+
+func setupLockoutTest(t *testing.T) (*LockoutService, *MockLoginLockoutRepository, *miniredis.Miniredis) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	mockLockoutRepo := new(MockLoginLockoutRepository)
+
+	return NewLockoutService(zap.NewNop().Sugar(), storage.NewCacheStorage(client), mockLockoutRepo), mockLockoutRepo, mr
+}
+
+func assertThrottled(t *testing.T, err error, expected error, retryAfter time.Duration) {
+	t.Helper()
+
+	var throttled *domain.LoginThrottledError
+	require.ErrorAs(t, err, &throttled)
+	assert.ErrorIs(t, err, expected)
+	assert.InDelta(t, retryAfter, throttled.RetryAfter, float64(time.Second))
+}
+
+func TestLockoutService_Backoff(t *testing.T) {
+	service, _, mr := setupLockoutTest(t)
+	ctx := context.Background()
+	email := "john@example.com"
+
+	for range loginBackoffThreshold - 1 {
+		lockout, err := service.RecordFailure(ctx, email, nil, "10.0.0.1")
+		assert.NoError(t, err)
+		assert.Nil(t, lockout)
+	}
+	assert.NoError(t, service.Check(ctx, email, "10.0.0.1"))
+
+	// Each failure past the threshold doubles the wait
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+		_, err := service.RecordFailure(ctx, email, nil, "10.0.0.1")
+		assert.NoError(t, err)
+
+		// The wait follows the account, whatever the casing or address
+		assertThrottled(t, service.Check(ctx, " John@Example.com", "10.0.0.2"), domain.ErrTooManyLoginAttempts, delay)
+
+		mr.FastForward(delay)
+		assert.NoError(t, service.Check(ctx, email, "10.0.0.1"))
+	}
+}
+
+func TestLockoutService_AccountLockout(t *testing.T) {
+	service, mockLockoutRepo, mr := setupLockoutTest(t)
+	ctx := context.Background()
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com"}
+
+	mockLockoutRepo.On("Create", ctx, mock.AnythingOfType("*domain.LoginLockout")).Return(nil)
+
+	lockOut := func() *domain.LoginLockout {
+		var lockout *domain.LoginLockout
+		for range accountLockoutThreshold {
+			var err error
+			lockout, err = service.RecordFailure(ctx, user.Email, user, "10.0.0.1")
+			require.NoError(t, err)
+		}
+		return lockout
+	}
+
+	first := lockOut()
+	require.NotNil(t, first)
+	assert.Equal(t, domain.LockoutScopeAccount, first.Scope)
+	assert.Equal(t, &user.ID, first.UserID)
+	assert.Equal(t, user.Email, first.Email.String)
+	assert.Equal(t, accountLockoutThreshold, first.FailedAttempts)
+	assert.WithinDuration(t, time.Now().Add(accountLockoutDuration), first.LockedUntil, time.Second)
+	assertThrottled(t, service.Check(ctx, user.Email, "10.0.0.2"), domain.ErrAccountLocked, accountLockoutDuration)
+
+	// A new round of failures after the lock expires locks the account for twice as long
+	mr.FastForward(accountLockoutDuration)
+	assert.NoError(t, service.Check(ctx, user.Email, "10.0.0.1"))
+
+	second := lockOut()
+	require.NotNil(t, second)
+	assertThrottled(t, service.Check(ctx, user.Email, "10.0.0.1"), domain.ErrAccountLocked, 2*accountLockoutDuration)
+	mockLockoutRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestLockoutService_IPLockout(t *testing.T) {
+	service, mockLockoutRepo, _ := setupLockoutTest(t)
+	ctx := context.Background()
+
+	mockLockoutRepo.On("Create", ctx, mock.MatchedBy(func(lockout *domain.LoginLockout) bool {
+		return lockout.Scope == domain.LockoutScopeIP && lockout.IPAddress == "10.0.0.1" && !lockout.Email.Valid
+	})).Return(nil).Once()
+
+	// Spreading the guesses over many accounts does not avoid the per address limit
+	for range ipLockoutThreshold {
+		_, err := service.RecordFailure(ctx, uuid.NewString()+"@example.com", nil, "10.0.0.1")
+		require.NoError(t, err)
+	}
+
+	assertThrottled(t, service.Check(ctx, "someone@example.com", "10.0.0.1"), domain.ErrTooManyLoginAttempts, ipLockoutDuration)
+	assert.NoError(t, service.Check(ctx, "someone@example.com", "10.0.0.2"))
+	mockLockoutRepo.AssertExpectations(t)
+}
+
+func TestLockoutService_ResetAndUnlock(t *testing.T) {
+	service, mockLockoutRepo, _ := setupLockoutTest(t)
+	ctx := context.Background()
+	email := "john@example.com"
+
+	for range loginBackoffThreshold - 1 {
+		_, _ = service.RecordFailure(ctx, email, nil, "10.0.0.1")
+	}
+	service.Reset(ctx, email)
+
+	// The count starts over after a successful login
+	_, err := service.RecordFailure(ctx, email, nil, "10.0.0.1")
+	assert.NoError(t, err)
+	assert.NoError(t, service.Check(ctx, email, "10.0.0.1"))
+
+	for range loginBackoffThreshold {
+		_, _ = service.RecordFailure(ctx, email, nil, "10.0.0.1")
+	}
+	assert.Error(t, service.Check(ctx, email, "10.0.0.1"))
+
+	mockLockoutRepo.On("MarkUnlocked", ctx, email).Return(nil).Once()
+
+	err = service.Unlock(ctx, "John@Example.com")
+
+	assert.NoError(t, err)
+	assert.NoError(t, service.Check(ctx, email, "10.0.0.1"))
+	mockLockoutRepo.AssertExpectations(t)
+}
+
+func TestLockoutService_List(t *testing.T) {
+	service, mockLockoutRepo, _ := setupLockoutTest(t)
+	ctx := context.Background()
+	filter := &domain.LoginLockoutFilters{}
+
+	lockouts := []*domain.LoginLockout{{ID: uuid.New(), Scope: domain.LockoutScopeIP, IPAddress: "10.0.0.1"}}
+	mockLockoutRepo.On("List", ctx, filter).Return(lockouts, nil)
+	mockLockoutRepo.On("Count", ctx, filter).Return(1, nil)
+
+	resp, err := service.List(ctx, filter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, lockouts, resp.Lockouts)
+	assert.Equal(t, 1, resp.Count)
+}
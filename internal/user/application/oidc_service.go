@@ -0,0 +1,310 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/oidc"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const (
+	// oidcStateExpiry is how long the user has to sign in at the provider
+	oidcStateExpiry = 10 * time.Minute
+	// oidcRegistrationExpiry is how long a new user has to complete their profile
+	oidcRegistrationExpiry = 30 * time.Minute
+)
+
+// oidcAuthorization is stored between the redirect to the provider and its callback
+type oidcAuthorization struct {
+	Provider     string `redis:"provider"`
+	Nonce        string `redis:"nonce"`
+	CodeVerifier string `redis:"code_verifier"`
+}
+
+// oidcRegistration is stored while a new user completes the profile the provider cannot fill in
+type oidcRegistration struct {
+	Provider  string `redis:"provider"`
+	Subject   string `redis:"subject"`
+	Email     string `redis:"email"`
+	FirstName string `redis:"first_name"`
+	LastName  string `redis:"last_name"`
+}
+
+// OIDCService signs users in with the OpenID Connect providers of the configuration. Provider
+// accounts are linked to users through user_identities, matched by verified email on first use.
+type OIDCService struct {
+	logger       *zap.SugaredLogger
+	cache        storage.CacheStorage
+	providers    map[string]*oidc.Provider
+	userRepo     domain.UserRepository
+	identityRepo domain.UserIdentityRepository
+	users        *UserService
+	auth         *AuthService
+}
+
+func NewOIDCService(logger *zap.SugaredLogger, cfg config.Config, cache storage.CacheStorage, userRepo domain.UserRepository, identityRepo domain.UserIdentityRepository, users *UserService, authService *AuthService) *OIDCService {
+	providers := make(map[string]*oidc.Provider, len(cfg.OIDC))
+	for _, provider := range cfg.OIDC {
+		providers[provider.Name] = oidc.NewProvider(provider, nil)
+	}
+
+	return &OIDCService{
+		logger:       logger,
+		cache:        cache,
+		providers:    providers,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		users:        users,
+		auth:         authService,
+	}
+}
+
+// AuthorizationURL starts a sign-in with the provider, returning the page to send the user to
+func (s *OIDCService) AuthorizationURL(ctx context.Context, providerName string) (*dto.OIDCAuthorizationResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, domain.ErrIdentityProviderNotFound
+	}
+
+	authorization := &oidcAuthorization{Provider: providerName}
+	var state string
+	for _, value := range []*string{&state, &authorization.Nonce, &authorization.CodeVerifier} {
+		token, err := auth.GenerateRandomToken(32)
+		if err != nil {
+			s.logger.Errorw("failed to generate oidc state", "provider", providerName, "error", err)
+			return nil, response.ErrInternalServerError
+		}
+		*value = token
+	}
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, s.redirectURL(providerName), state, authorization.Nonce, authorization.CodeVerifier)
+	if err != nil {
+		s.logger.Errorw("failed to build oidc authorization url", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.cache.Set(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_OIDC_STATE, state), authorization, oidcStateExpiry); err != nil {
+		s.logger.Errorw("failed to store oidc state", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.OIDCAuthorizationResponse{AuthorizationURL: authorizationURL}, nil
+}
+
+// Callback completes the sign-in with the code the provider redirected the user back with.
+// The user behind a known identity is logged in as with Login, as is a verified user with the
+// email the provider verified, whose identity gets linked. Anyone else has to complete their
+// profile with CompleteProfile.
+func (s *OIDCService) Callback(ctx context.Context, providerName string, req *dto.OIDCCallbackRequest, client *dto.ClientInfo) (*dto.OIDCLoginResponse, error) {
+	provider, ok := s.providers[providerName]
+	if !ok {
+		return nil, domain.ErrIdentityProviderNotFound
+	}
+
+	if req.State == "" {
+		return nil, domain.ErrInvalidOIDCState
+	}
+
+	var authorization oidcAuthorization
+	if err := s.cache.GetAndDel(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_OIDC_STATE, req.State), &authorization); err != nil {
+		s.logger.Errorw("failed to get oidc state", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	// A missing state scans into an empty value, which matches no provider
+	if authorization.Provider != providerName {
+		return nil, domain.ErrInvalidOIDCState
+	}
+
+	claims, err := provider.Exchange(ctx, s.redirectURL(providerName), req.Code, authorization.CodeVerifier, authorization.Nonce)
+	if err != nil {
+		s.logger.Warnw("failed to sign in with identity provider", "provider", providerName, "error", err)
+		return nil, domain.ErrOIDCLoginFailed
+	}
+
+	identity, err := s.identityRepo.GetByProviderSubject(ctx, providerName, claims.Subject)
+	if err == nil {
+		return s.loginWithIdentity(ctx, identity, client)
+	} else if !errors.Is(err, domain.ErrUserIdentityNotFound) {
+		s.logger.Errorw("failed to get user identity", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	// Without a verified email anyone could claim the account of its owner
+	if claims.Email == "" || !bool(claims.EmailVerified) {
+		return nil, domain.ErrOIDCEmailNotVerified
+	}
+
+	user, err := s.userRepo.GetByEmail(ctx, claims.Email)
+	if err == nil {
+		return s.linkIdentity(ctx, user, providerName, claims, client)
+	} else if !errors.Is(err, domain.ErrUserNotFound) {
+		s.logger.Errorw("failed to get user by email", "email", claims.Email, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return s.startRegistration(ctx, providerName, claims)
+}
+
+// CompleteProfile registers the user of a registration started by Callback and logs them in
+func (s *OIDCService) CompleteProfile(ctx context.Context, req *dto.OIDCCompleteProfileRequest, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	if req.RegistrationToken == "" {
+		return nil, domain.ErrInvalidRegistrationToken
+	}
+
+	registrationKey := s.cache.BuildKey(storage.CACHE_PREFIX_OIDC_REGISTRATION, req.RegistrationToken)
+	var registration oidcRegistration
+	if err := s.cache.Get(ctx, registrationKey, &registration); err != nil {
+		s.logger.Errorw("failed to get oidc registration", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if registration.Subject == "" {
+		return nil, domain.ErrInvalidRegistrationToken
+	}
+
+	registerReq := &dto.UserRegisterRequest{
+		FirstName:        strings.TrimSpace(req.FirstName),
+		LastName:         strings.TrimSpace(req.LastName),
+		Email:            registration.Email,
+		DocumentID:       strings.TrimSpace(req.DocumentID),
+		PhoneCountryCode: req.PhoneCountryCode,
+		PhoneNumber:      req.PhoneNumber,
+		Address:          req.Address,
+		ChurchID:         req.ChurchID,
+		OpenToWork:       req.OpenToWork,
+		CVPath:           req.CVPath,
+		FieldsOfWork:     req.FieldsOfWork,
+	}
+	if registerReq.FirstName == "" {
+		registerReq.FirstName = registration.FirstName
+	}
+	if registerReq.LastName == "" {
+		registerReq.LastName = registration.LastName
+	}
+
+	if registerReq.FirstName == "" || registerReq.LastName == "" || registerReq.DocumentID == "" || registerReq.ChurchID == uuid.Nil ||
+		registerReq.Address.StreetLine1 == "" || registerReq.Address.City == "" || registerReq.Address.Country == "" {
+		return nil, domain.ErrRequiredField
+	}
+
+	user, err := s.users.CreateWithIdentity(ctx, registerReq, &domain.UserIdentity{
+		Provider:    registration.Provider,
+		Subject:     registration.Subject,
+		Email:       sql.NullString{String: registration.Email, Valid: true},
+		LastLoginAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The token is only dropped once the user exists, so a rejected profile can be corrected
+	if err := s.cache.Del(ctx, registrationKey); err != nil {
+		s.logger.Warnw("failed to delete oidc registration", "userID", user.ID, "error", err)
+	}
+
+	return s.auth.completeLogin(ctx, user, client)
+}
+
+func (s *OIDCService) loginWithIdentity(ctx context.Context, identity *domain.UserIdentity, client *dto.ClientInfo) (*dto.OIDCLoginResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, identity.UserID)
+	if err != nil {
+		s.logger.Errorw("failed to get user by ID", "userID", identity.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	if err := s.identityRepo.UpdateLastLogin(ctx, identity.ID); err != nil {
+		s.logger.Warnw("failed to update user identity last login", "identityID", identity.ID, "error", err)
+	}
+
+	tokens, err := s.auth.completeLogin(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCLoginResponse{UserLoginResponse: tokens}, nil
+}
+
+// linkIdentity adds the provider account to the user with the same email. Unverified users are
+// refused, as whoever registered the address may not own it and would keep access through the
+// password they chose.
+func (s *OIDCService) linkIdentity(ctx context.Context, user *domain.User, providerName string, claims *oidc.Claims, client *dto.ClientInfo) (*dto.OIDCLoginResponse, error) {
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	if !user.IsVerified {
+		return nil, domain.ErrEmailNotVerified
+	}
+
+	identity := &domain.UserIdentity{
+		UserID:      user.ID,
+		Provider:    providerName,
+		Subject:     claims.Subject,
+		Email:       sql.NullString{String: claims.Email, Valid: true},
+		LastLoginAt: sql.NullTime{Time: time.Now().UTC(), Valid: true},
+	}
+	if err := s.userRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		return s.identityRepo.Create(tx, identity)
+	}); err != nil {
+		s.logger.Errorw("failed to link user identity", "userID", user.ID, "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	tokens, err := s.auth.completeLogin(ctx, user, client)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.OIDCLoginResponse{UserLoginResponse: tokens}, nil
+}
+
+func (s *OIDCService) startRegistration(ctx context.Context, providerName string, claims *oidc.Claims) (*dto.OIDCLoginResponse, error) {
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		s.logger.Errorw("failed to generate registration token", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	registration := &oidcRegistration{
+		Provider:  providerName,
+		Subject:   claims.Subject,
+		Email:     claims.Email,
+		FirstName: claims.GivenName,
+		LastName:  claims.FamilyName,
+	}
+	if err := s.cache.Set(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_OIDC_REGISTRATION, token), registration, oidcRegistrationExpiry); err != nil {
+		s.logger.Errorw("failed to store oidc registration", "provider", providerName, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.OIDCLoginResponse{
+		ProfileCompletionRequired: true,
+		RegistrationToken:         token,
+		Email:                     registration.Email,
+		FirstName:                 registration.FirstName,
+		LastName:                  registration.LastName,
+	}, nil
+}
+
+// redirectURL is the web client page the provider sends the user back to, which forwards the
+// code and state to Callback
+func (s *OIDCService) redirectURL(providerName string) string {
+	return s.auth.frontendLink("auth", "oidc", providerName, "callback")
+}
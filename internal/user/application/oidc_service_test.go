@@ -0,0 +1,212 @@
+package application
+
+import (
+	"context"
+	"testing"
+
+	adminDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/oidc/oidctest"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockUserIdentityRepository struct {
+	mock.Mock
+}
+
+func (m *MockUserIdentityRepository) Create(tx *sqlx.Tx, identity *domain.UserIdentity) error {
+	args := m.Called(tx, identity)
+	return args.Error(0)
+}
+
+func (m *MockUserIdentityRepository) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	args := m.Called(ctx, provider, subject)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.UserIdentity), args.Error(1)
+}
+
+func (m *MockUserIdentityRepository) UpdateLastLogin(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+type oidcTestDeps struct {
+	*authTestDeps
+	identityRepo   *MockUserIdentityRepository
+	notifPrefRepo  *MockNotificationPreferencesRepository
+	jobProfileRepo *MockJobProfileRepository
+	addressRepo    *MockAddressRepository
+	provider       *oidctest.Provider
+}
+
+func setupOIDCTest(t *testing.T) (*OIDCService, *oidcTestDeps) {
+	logger := zap.NewNop().Sugar()
+	authService, authDeps := setupAuthTestDeps(t)
+	deps := &oidcTestDeps{
+		authTestDeps:   authDeps,
+		identityRepo:   new(MockUserIdentityRepository),
+		notifPrefRepo:  new(MockNotificationPreferencesRepository),
+		jobProfileRepo: new(MockJobProfileRepository),
+		addressRepo:    new(MockAddressRepository),
+		provider:       oidctest.NewProvider(t),
+	}
+
+	cfg := config.Config{OIDC: []config.OIDCProvider{deps.provider.Config("stub")}}
+	users := NewUserService(logger, deps.userRepo, deps.notifPrefRepo, deps.jobProfileRepo, deps.addressRepo, deps.identityRepo, new(MockPermissionRepository))
+
+	return NewOIDCService(logger, cfg, authService.cache, deps.userRepo, deps.identityRepo, users, authService), deps
+}
+
+// signInWithProvider goes through the redirect to the stub provider and back to the callback
+func signInWithProvider(t *testing.T, service *OIDCService, deps *oidcTestDeps, identity oidctest.Identity) (*dto.OIDCLoginResponse, error) {
+	t.Helper()
+
+	authorization, err := service.AuthorizationURL(context.Background(), "stub")
+	require.NoError(t, err)
+	assert.Contains(t, authorization.AuthorizationURL, "redirect_uri=https%3A%2F%2Fapp.example.com%2Fauth%2Foidc%2Fstub%2Fcallback")
+
+	code, state := deps.provider.Authorize(t, authorization.AuthorizationURL, identity)
+
+	return service.Callback(context.Background(), "stub", &dto.OIDCCallbackRequest{Code: code, State: state}, testClient)
+}
+
+func TestOIDCService_LinkedIdentity(t *testing.T) {
+	service, deps := setupOIDCTest(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com", IsActive: true, IsVerified: true}
+	identity := &domain.UserIdentity{ID: uuid.New(), UserID: user.ID, Provider: "stub", Subject: "subject-123"}
+
+	deps.identityRepo.On("GetByProviderSubject", ctx, "stub", "subject-123").Return(identity, nil)
+	deps.identityRepo.On("UpdateLastLogin", ctx, identity.ID).Return(nil)
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	// The provider account is known, whatever email it now reports
+	resp, err := signInWithProvider(t, service, deps, oidctest.Identity{Subject: "subject-123", Email: "john.doe@example.org"})
+
+	require.NoError(t, err)
+	require.NotNil(t, resp.UserLoginResponse)
+	assert.NotEmpty(t, resp.Token)
+	assert.NotEmpty(t, resp.RefreshToken)
+	assert.False(t, resp.ProfileCompletionRequired)
+	deps.identityRepo.AssertExpectations(t)
+}
+
+func TestOIDCService_LinkByVerifiedEmail(t *testing.T) {
+	service, deps := setupOIDCTest(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com", IsActive: true, IsVerified: true}
+	unverified := &domain.User{ID: uuid.New(), Email: "jane@example.com", IsActive: true}
+
+	deps.identityRepo.On("GetByProviderSubject", ctx, "stub", mock.Anything).Return(nil, domain.ErrUserIdentityNotFound)
+	deps.userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	deps.userRepo.On("GetByEmail", ctx, unverified.Email).Return(unverified, nil)
+	deps.userRepo.On("UnitOfWork", ctx, mock.Anything).Return(nil)
+	deps.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID == user.ID && identity.Provider == "stub" && identity.Subject == "subject-123"
+	})).Return(nil).Once()
+
+	t.Run("email not verified by the provider", func(t *testing.T) {
+		_, err := signInWithProvider(t, service, deps, oidctest.Identity{Subject: "subject-123", Email: user.Email})
+		assert.ErrorIs(t, err, domain.ErrOIDCEmailNotVerified)
+	})
+
+	t.Run("unverified account is not linked", func(t *testing.T) {
+		_, err := signInWithProvider(t, service, deps, oidctest.Identity{Subject: "subject-456", Email: unverified.Email, EmailVerified: true})
+		assert.ErrorIs(t, err, domain.ErrEmailNotVerified)
+	})
+
+	resp, err := signInWithProvider(t, service, deps, oidctest.Identity{Subject: "subject-123", Email: user.Email, EmailVerified: true})
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, resp.Token)
+	deps.identityRepo.AssertExpectations(t)
+}
+
+func TestOIDCService_InvalidState(t *testing.T) {
+	service, deps := setupOIDCTest(t)
+	ctx := context.Background()
+
+	authorization, err := service.AuthorizationURL(ctx, "stub")
+	require.NoError(t, err)
+	code, state := deps.provider.Authorize(t, authorization.AuthorizationURL, oidctest.Identity{Subject: "subject-123"})
+
+	_, err = service.Callback(ctx, "stub", &dto.OIDCCallbackRequest{Code: code, State: "forged"}, testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+
+	_, err = service.AuthorizationURL(ctx, "unknown")
+	assert.ErrorIs(t, err, domain.ErrIdentityProviderNotFound)
+
+	// A failed exchange still consumes the state
+	_, err = service.Callback(ctx, "stub", &dto.OIDCCallbackRequest{Code: "wrong-code", State: state}, testClient)
+	assert.ErrorIs(t, err, domain.ErrOIDCLoginFailed)
+
+	_, err = service.Callback(ctx, "stub", &dto.OIDCCallbackRequest{Code: code, State: state}, testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidOIDCState)
+}
+
+func TestOIDCService_ProfileCompletion(t *testing.T) {
+	service, deps := setupOIDCTest(t)
+	ctx := context.Background()
+
+	deps.identityRepo.On("GetByProviderSubject", ctx, "stub", "subject-123").Return(nil, domain.ErrUserIdentityNotFound)
+	deps.userRepo.On("GetByEmail", ctx, "john@example.com").Return(nil, domain.ErrUserNotFound)
+
+	resp, err := signInWithProvider(t, service, deps, oidctest.Identity{
+		Subject:       "subject-123",
+		Email:         "john@example.com",
+		EmailVerified: true,
+		GivenName:     "John",
+		FamilyName:    "Doe",
+	})
+
+	require.NoError(t, err)
+	assert.True(t, resp.ProfileCompletionRequired)
+	assert.Nil(t, resp.UserLoginResponse)
+	assert.Equal(t, "John", resp.FirstName)
+	require.NotEmpty(t, resp.RegistrationToken)
+
+	req := &dto.OIDCCompleteProfileRequest{
+		RegistrationToken: resp.RegistrationToken,
+		DocumentID:        "123456789",
+		Address:           adminDto.AddressCreateRequest{StreetLine1: "Main St 1", City: "Springfield", Country: "US"},
+	}
+
+	t.Run("mandatory fields", func(t *testing.T) {
+		_, err := service.CompleteProfile(ctx, req, testClient)
+		assert.ErrorIs(t, err, domain.ErrRequiredField)
+	})
+
+	req.ChurchID = uuid.New()
+	deps.userRepo.On("GetByDocumentID", ctx, req.DocumentID).Return(nil, domain.ErrUserNotFound)
+	deps.userRepo.On("UnitOfWork", ctx, mock.Anything).Return(nil)
+	deps.addressRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.Address")).Return(nil)
+	deps.userRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *domain.User) bool {
+		return user.Email == "john@example.com" && user.FirstName == "John" && user.LastName == "Doe" && user.IsVerified && len(user.Password) > 0
+	})).Return(nil).Once()
+	deps.notifPrefRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.NotificationPreferences")).Return(nil)
+	deps.jobProfileRepo.On("Create", mock.Anything, mock.AnythingOfType("*domain.JobProfile")).Return(nil)
+	deps.identityRepo.On("Create", mock.Anything, mock.MatchedBy(func(identity *domain.UserIdentity) bool {
+		return identity.UserID != uuid.Nil && identity.Provider == "stub" && identity.Subject == "subject-123"
+	})).Return(nil).Once()
+
+	tokens, err := service.CompleteProfile(ctx, req, testClient)
+
+	require.NoError(t, err)
+	assert.NotEmpty(t, tokens.Token)
+	deps.userRepo.AssertExpectations(t)
+	deps.identityRepo.AssertExpectations(t)
+
+	_, err = service.CompleteProfile(ctx, req, testClient)
+	assert.ErrorIs(t, err, domain.ErrInvalidRegistrationToken)
+}
@@ -0,0 +1,378 @@
+package application
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultPasskeyName = "Passkey"
+	// passkeyNameMaxLength matches the user_passkeys.name column
+	passkeyNameMaxLength = 100
+)
+
+// PasskeyService manages the WebAuthn credentials of users and the logins made with them.
+// Challenges are kept in the cache for the duration of a ceremony and can be answered once.
+type PasskeyService struct {
+	logger       *zap.SugaredLogger
+	cache        storage.CacheStorage
+	relyingParty *auth.RelyingParty
+	userRepo     domain.UserRepository
+	passkeyRepo  domain.PasskeyRepository
+	auth         *AuthService
+}
+
+func NewPasskeyService(logger *zap.SugaredLogger, cfg config.Config, cache storage.CacheStorage, userRepo domain.UserRepository, passkeyRepo domain.PasskeyRepository, authService *AuthService) *PasskeyService {
+	return &PasskeyService{
+		logger: logger,
+		cache:  cache,
+		relyingParty: &auth.RelyingParty{
+			ID:      cfg.WebAuthn.RPID,
+			Name:    cfg.WebAuthn.RPName,
+			Origins: cfg.WebAuthn.Origins,
+		},
+		userRepo:    userRepo,
+		passkeyRepo: passkeyRepo,
+		auth:        authService,
+	}
+}
+
+// BeginRegistration returns the options the browser creates a new credential with
+func (s *PasskeyService) BeginRegistration(ctx context.Context, userID uuid.UUID) (*auth.WebAuthnCreationOptions, error) {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, err
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	passkeys, err := s.passkeyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("failed to list passkeys", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	// Keeps the browser from registering an authenticator the user already has
+	exclude := make([][]byte, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		exclude = append(exclude, passkey.CredentialID)
+	}
+
+	challenge, err := s.newChallenge(ctx, s.registrationKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.relyingParty.CreationOptions(challenge, auth.WebAuthnUser{
+		ID:          user.ID[:],
+		Name:        user.Email,
+		DisplayName: strings.TrimSpace(user.FirstName + " " + user.LastName),
+	}, exclude), nil
+}
+
+// FinishRegistration verifies the credential created by the browser and stores it as a passkey
+func (s *PasskeyService) FinishRegistration(ctx context.Context, userID uuid.UUID, req *dto.PasskeyRegisterRequest) (*domain.Passkey, error) {
+	name, err := passkeyName(req.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	challenge, err := s.takeChallenge(ctx, s.registrationKey(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	credential, err := s.relyingParty.VerifyRegistration(challenge, &req.Credential)
+	if err != nil {
+		s.logger.Warnw("failed to verify passkey registration", "userID", userID, "error", err)
+		return nil, domain.ErrInvalidPasskey
+	}
+
+	if _, err := s.passkeyRepo.GetByCredentialID(ctx, credential.ID); err == nil {
+		return nil, domain.ErrPasskeyAlreadyRegistered
+	} else if !errors.Is(err, domain.ErrPasskeyNotFound) {
+		s.logger.Errorw("failed to get passkey by credential ID", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	passkey := &domain.Passkey{
+		UserID:       userID,
+		CredentialID: credential.ID,
+		PublicKey:    credential.PublicKey,
+		SignCount:    int64(credential.SignCount),
+		Name:         name,
+	}
+
+	if err := s.passkeyRepo.Create(ctx, passkey); err != nil {
+		s.logger.Errorw("failed to create passkey", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return passkey, nil
+}
+
+func (s *PasskeyService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Passkey, error) {
+	passkeys, err := s.passkeyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("failed to list passkeys", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return passkeys, nil
+}
+
+func (s *PasskeyService) Rename(ctx context.Context, userID, id uuid.UUID, req *dto.PasskeyRenameRequest) error {
+	name, err := passkeyName(req.Name)
+	if err != nil {
+		return err
+	}
+
+	if err := s.passkeyRepo.Rename(ctx, id, userID, name); err != nil {
+		if errors.Is(err, domain.ErrPasskeyNotFound) {
+			return err
+		}
+
+		s.logger.Errorw("failed to rename passkey", "userID", userID, "passkeyID", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+func (s *PasskeyService) Delete(ctx context.Context, userID, id uuid.UUID) error {
+	if err := s.passkeyRepo.Delete(ctx, id, userID); err != nil {
+		if errors.Is(err, domain.ErrPasskeyNotFound) {
+			return err
+		}
+
+		s.logger.Errorw("failed to delete passkey", "userID", userID, "passkeyID", id, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// BeginLogin starts a login without email, the browser offers the passkeys it holds for the site
+func (s *PasskeyService) BeginLogin(ctx context.Context) (*dto.PasskeyLoginOptionsResponse, error) {
+	token, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		s.logger.Errorw("failed to generate passkey login token", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	challenge, err := s.newChallenge(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_WEBAUTHN, "login", token))
+	if err != nil {
+		return nil, err
+	}
+
+	return &dto.PasskeyLoginOptionsResponse{
+		Token:     token,
+		PublicKey: s.relyingParty.RequestOptions(challenge, nil),
+	}, nil
+}
+
+// FinishLogin signs the owner of the passkey in. An authenticator that verified the user, with a
+// PIN or biometrics, proves both factors, otherwise the passkey only replaces the password.
+func (s *PasskeyService) FinishLogin(ctx context.Context, req *dto.PasskeyLoginRequest, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	if req.Token == "" {
+		return nil, domain.ErrInvalidPasskeyChallenge
+	}
+
+	passkey, login, err := s.verifyAssertion(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_WEBAUTHN, "login", req.Token), &req.Credential)
+	if err != nil {
+		return nil, err
+	}
+
+	if handle := req.Credential.Response.UserHandle; len(handle) > 0 && !bytes.Equal(handle, passkey.UserID[:]) {
+		s.logger.Warnw("passkey returned another user handle", "passkeyID", passkey.ID)
+		return nil, domain.ErrInvalidPasskey
+	}
+
+	user, err := s.userRepo.GetByID(ctx, passkey.UserID)
+	if err != nil {
+		if errors.Is(err, domain.ErrUserNotFound) {
+			return nil, domain.ErrInvalidPasskey
+		}
+
+		s.logger.Errorw("failed to get user by ID", "userID", passkey.UserID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if !user.IsActive {
+		return nil, domain.ErrUserInactive
+	}
+
+	if login.UserVerified {
+		return s.auth.openTwoFactorSession(ctx, user, client)
+	}
+
+	return s.auth.completeLoginWith(ctx, user, passkey.ID, client)
+}
+
+// BeginTwoFactor returns the options to answer a login challenge with one of the user's passkeys
+func (s *PasskeyService) BeginTwoFactor(ctx context.Context, challengeToken string) (*auth.WebAuthnRequestOptions, error) {
+	_, loginChallenge, userID, err := s.auth.loadTwoFactorChallenge(ctx, challengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	passkeys, err := s.passkeyRepo.ListByUserID(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("failed to list passkeys", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	allow := make([][]byte, 0, len(passkeys))
+	for _, passkey := range passkeys {
+		if passkey.ID.String() != loginChallenge.PasskeyID {
+			allow = append(allow, passkey.CredentialID)
+		}
+	}
+
+	if len(allow) == 0 {
+		return nil, domain.ErrPasskeyNotFound
+	}
+
+	challenge, err := s.newChallenge(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_WEBAUTHN, "two_factor", challengeToken))
+	if err != nil {
+		return nil, err
+	}
+
+	return s.relyingParty.RequestOptions(challenge, allow), nil
+}
+
+// FinishTwoFactor completes a login challenge with a passkey of the user who started it
+func (s *PasskeyService) FinishTwoFactor(ctx context.Context, req *dto.PasskeyTwoFactorRequest, client *dto.ClientInfo) (*dto.UserLoginResponse, error) {
+	challengeKey, challenge, userID, err := s.auth.loadTwoFactorChallenge(ctx, req.ChallengeToken)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.auth.lockouts.Check(ctx, challenge.Email, client.IPAddress); err != nil {
+		return nil, err
+	}
+
+	if err := s.auth.takeChallengeAttempt(ctx, challengeKey); err != nil {
+		return nil, err
+	}
+
+	passkey, _, err := s.verifyAssertion(ctx, s.cache.BuildKey(storage.CACHE_PREFIX_WEBAUTHN, "two_factor", req.ChallengeToken), &req.Credential)
+	// The passkey that proved the first factor cannot answer the second
+	if err == nil && (passkey.UserID != userID || passkey.ID.String() == challenge.PasskeyID) {
+		err = domain.ErrInvalidPasskey
+	}
+
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidPasskey) {
+			s.auth.recordTwoFactorFailure(ctx, userID, client)
+		}
+
+		return nil, err
+	}
+
+	return s.auth.finishTwoFactorChallenge(ctx, challengeKey, userID, client)
+}
+
+// verifyAssertion consumes the challenge stored under challengeKey and checks the assertion
+// against the passkey it was made with, recording its new sign counter
+func (s *PasskeyService) verifyAssertion(ctx context.Context, challengeKey string, assertion *auth.WebAuthnAssertion) (*domain.Passkey, *auth.WebAuthnLogin, error) {
+	challenge, err := s.takeChallenge(ctx, challengeKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	passkey, err := s.passkeyRepo.GetByCredentialID(ctx, assertion.RawID)
+	if err != nil {
+		if errors.Is(err, domain.ErrPasskeyNotFound) {
+			return nil, nil, domain.ErrInvalidPasskey
+		}
+
+		s.logger.Errorw("failed to get passkey by credential ID", "error", err)
+		return nil, nil, response.ErrInternalServerError
+	}
+
+	login, err := s.relyingParty.VerifyAssertion(challenge, assertion, passkey.PublicKey, uint32(passkey.SignCount))
+	if err != nil {
+		if errors.Is(err, auth.ErrWebAuthnSignCount) {
+			s.logger.Warnw("passkey sign counter went backwards, the credential may be cloned", "passkeyID", passkey.ID, "userID", passkey.UserID)
+		}
+
+		return nil, nil, domain.ErrInvalidPasskey
+	}
+
+	if err := s.passkeyRepo.UpdateSignCount(ctx, passkey.ID, int64(login.SignCount)); err != nil {
+		s.logger.Errorw("failed to update passkey sign count", "passkeyID", passkey.ID, "error", err)
+		return nil, nil, response.ErrInternalServerError
+	}
+
+	return passkey, login, nil
+}
+
+func (s *PasskeyService) registrationKey(userID uuid.UUID) string {
+	return s.cache.BuildKey(storage.CACHE_PREFIX_WEBAUTHN, "registration", userID.String())
+}
+
+// newChallenge generates a ceremony challenge and keeps it under key until the ceremony times out
+func (s *PasskeyService) newChallenge(ctx context.Context, key string) ([]byte, error) {
+	challenge, err := auth.NewWebAuthnChallenge()
+	if err != nil {
+		s.logger.Errorw("failed to generate webauthn challenge", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.cache.SetString(ctx, key, base64.RawURLEncoding.EncodeToString(challenge), auth.WebAuthnTimeout); err != nil {
+		s.logger.Errorw("failed to store webauthn challenge", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return challenge, nil
+}
+
+// takeChallenge returns the challenge kept under key and deletes it, so it is answered only once
+func (s *PasskeyService) takeChallenge(ctx context.Context, key string) ([]byte, error) {
+	encoded, err := s.cache.GetStringAndDel(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrCacheMiss) {
+			return nil, domain.ErrInvalidPasskeyChallenge
+		}
+
+		s.logger.Errorw("failed to get webauthn challenge", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	challenge, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, domain.ErrInvalidPasskeyChallenge
+	}
+
+	return challenge, nil
+}
+
+// passkeyName validates the friendly name of a passkey, defaulting it when left empty
+func passkeyName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return defaultPasskeyName, nil
+	}
+
+	if len([]rune(name)) > passkeyNameMaxLength {
+		return "", domain.ErrInvalidFieldValue
+	}
+
+	return name, nil
+}
@@ -0,0 +1,307 @@
+package application
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth/webauthntest"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type MockPasskeyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPasskeyRepository) Create(ctx context.Context, passkey *domain.Passkey) error {
+	args := m.Called(ctx, passkey)
+	return args.Error(0)
+}
+
+func (m *MockPasskeyRepository) GetByCredentialID(ctx context.Context, credentialID []byte) (*domain.Passkey, error) {
+	args := m.Called(ctx, credentialID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.Passkey), args.Error(1)
+}
+
+func (m *MockPasskeyRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Passkey, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.Passkey), args.Error(1)
+}
+
+func (m *MockPasskeyRepository) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockPasskeyRepository) UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error {
+	args := m.Called(ctx, id, signCount)
+	return args.Error(0)
+}
+
+func (m *MockPasskeyRepository) Rename(ctx context.Context, id, userID uuid.UUID, name string) error {
+	args := m.Called(ctx, id, userID, name)
+	return args.Error(0)
+}
+
+func (m *MockPasskeyRepository) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	args := m.Called(ctx, id, userID)
+	return args.Error(0)
+}
+
+const passkeyTestOrigin = "https://app.example.com"
+
+func setupPasskeyTest(t *testing.T) (*PasskeyService, *authTestDeps, *webauthntest.Authenticator) {
+	authService, deps := setupAuthTestDeps(t)
+
+	cfg := config.Config{}
+	cfg.WebAuthn = config.WebAuthn{RPID: "app.example.com", RPName: "Test", Origins: []string{passkeyTestOrigin}}
+
+	service := NewPasskeyService(zap.NewNop().Sugar(), cfg, authService.cache, deps.userRepo, deps.passkeyRepo, authService)
+
+	return service, deps, webauthntest.NewAuthenticator(passkeyTestOrigin)
+}
+
+// registerPasskey runs a registration ceremony with the authenticator and returns the stored passkey,
+// which the repository mock then serves by credential ID like the database would
+func registerPasskey(t *testing.T, service *PasskeyService, deps *authTestDeps, authenticator *webauthntest.Authenticator, user *domain.User) *domain.Passkey {
+	t.Helper()
+	ctx := context.Background()
+
+	deps.passkeyRepo.On("ListByUserID", ctx, user.ID).Return([]*domain.Passkey{}, nil).Once()
+	options, err := service.BeginRegistration(ctx, user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID[:], []byte(options.User.ID))
+
+	deps.passkeyRepo.On("GetByCredentialID", ctx, mock.Anything).Return(nil, domain.ErrPasskeyNotFound).Once()
+	deps.passkeyRepo.On("Create", ctx, mock.AnythingOfType("*domain.Passkey")).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Passkey).ID = uuid.New()
+	}).Return(nil).Once()
+
+	passkey, err := service.FinishRegistration(ctx, user.ID, &dto.PasskeyRegisterRequest{Name: " Work laptop ", Credential: *authenticator.Create(t, options)})
+	require.NoError(t, err)
+
+	deps.passkeyRepo.On("GetByCredentialID", ctx, passkey.CredentialID).Return(passkey, nil)
+	deps.passkeyRepo.On("UpdateSignCount", ctx, passkey.ID, mock.Anything).Run(func(args mock.Arguments) {
+		passkey.SignCount = args.Get(2).(int64)
+	}).Return(nil)
+
+	return passkey
+}
+
+// assertTwoFactorSession checks the tokens belong to a session that satisfies RequireTwoFactor
+func assertTwoFactorSession(t *testing.T, service *PasskeyService, deps *authTestDeps, userID uuid.UUID, tokens *dto.UserLoginResponse) {
+	t.Helper()
+
+	require.NotEmpty(t, tokens.Token)
+	claims, err := deps.tokenManager.ParseToken(tokens.Token)
+	require.NoError(t, err)
+
+	session, err := service.auth.sessions.Get(context.Background(), userID, claims.ID)
+	require.NoError(t, err)
+	assert.True(t, session.TwoFactor)
+}
+
+func TestPasskeyService_Registration(t *testing.T) {
+	service, deps, authenticator := setupPasskeyTest(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com", FirstName: "John", LastName: "Doe", IsActive: true, IsVerified: true}
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+
+	passkey := registerPasskey(t, service, deps, authenticator, user)
+
+	assert.Equal(t, "Work laptop", passkey.Name)
+	assert.Equal(t, user.ID, passkey.UserID)
+	assert.NotEmpty(t, passkey.CredentialID)
+	assert.NotEmpty(t, passkey.PublicKey)
+
+	t.Run("challenge is single use", func(t *testing.T) {
+		_, err := service.FinishRegistration(ctx, user.ID, &dto.PasskeyRegisterRequest{})
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskeyChallenge)
+	})
+
+	t.Run("name too long", func(t *testing.T) {
+		_, err := service.FinishRegistration(ctx, user.ID, &dto.PasskeyRegisterRequest{Name: strings.Repeat("a", 101)})
+		assert.ErrorIs(t, err, domain.ErrInvalidFieldValue)
+	})
+
+	t.Run("credential from another origin", func(t *testing.T) {
+		deps.passkeyRepo.On("ListByUserID", ctx, user.ID).Return([]*domain.Passkey{passkey}, nil).Once()
+		options, err := service.BeginRegistration(ctx, user.ID)
+		require.NoError(t, err)
+		require.Len(t, options.ExcludeCredentials, 1)
+
+		phishing := webauthntest.NewAuthenticator("https://app.example.com.evil.test")
+		_, err = service.FinishRegistration(ctx, user.ID, &dto.PasskeyRegisterRequest{Credential: *phishing.Create(t, options)})
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskey)
+	})
+}
+
+func TestPasskeyService_Login(t *testing.T) {
+	service, deps, authenticator := setupPasskeyTest(t)
+	ctx := context.Background()
+
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com", IsActive: true, IsVerified: true}
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	passkey := registerPasskey(t, service, deps, authenticator, user)
+
+	login := func(t *testing.T) (*dto.UserLoginResponse, error) {
+		options, err := service.BeginLogin(ctx)
+		require.NoError(t, err)
+		assert.Empty(t, options.PublicKey.AllowCredentials)
+
+		return service.FinishLogin(ctx, &dto.PasskeyLoginRequest{Token: options.Token, Credential: *authenticator.Get(t, options.PublicKey)}, testClient)
+	}
+
+	// A verified user proves both factors at once
+	tokens, err := login(t)
+	require.NoError(t, err)
+	assertTwoFactorSession(t, service, deps, user.ID, tokens)
+	assert.EqualValues(t, 1, passkey.SignCount)
+
+	t.Run("user presence only still requires a second factor", func(t *testing.T) {
+		authenticator.UserVerified = false
+		defer func() { authenticator.UserVerified = true }()
+
+		other := &domain.Passkey{ID: uuid.New(), UserID: user.ID, CredentialID: []byte("other credential")}
+		deps.passkeyRepo.ExpectedCalls = withoutCall(deps.passkeyRepo.ExpectedCalls, "CountByUserID")
+		deps.passkeyRepo.On("CountByUserID", ctx, user.ID).Return(2, nil)
+		deps.passkeyRepo.On("ListByUserID", ctx, user.ID).Return([]*domain.Passkey{passkey, other}, nil)
+
+		resp, err := login(t)
+		require.NoError(t, err)
+		assert.True(t, resp.TwoFactorRequired)
+		assert.Equal(t, []string{"passkey"}, resp.TwoFactorMethods)
+		assert.Empty(t, resp.Token)
+
+		// The passkey that proved the first factor is not offered, nor accepted, for the second
+		options, err := service.BeginTwoFactor(ctx, resp.ChallengeToken)
+		require.NoError(t, err)
+		assert.Equal(t, [][]byte{other.CredentialID}, allowedCredentials(options))
+
+		options.AllowCredentials = nil
+		_, err = service.FinishTwoFactor(ctx, &dto.PasskeyTwoFactorRequest{ChallengeToken: resp.ChallengeToken, Credential: *authenticator.Get(t, options)}, testClient)
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskey)
+	})
+
+	t.Run("user presence only without another factor", func(t *testing.T) {
+		authenticator.UserVerified = false
+		defer func() { authenticator.UserVerified = true }()
+
+		deps.passkeyRepo.ExpectedCalls = withoutCall(deps.passkeyRepo.ExpectedCalls, "CountByUserID")
+		deps.passkeyRepo.On("CountByUserID", ctx, user.ID).Return(1, nil)
+
+		// The passkey only replaces the password, the session does not count as two-factor
+		resp, err := login(t)
+		require.NoError(t, err)
+		assert.False(t, resp.TwoFactorRequired)
+
+		claims, err := deps.tokenManager.ParseToken(resp.Token)
+		require.NoError(t, err)
+		session, err := service.auth.sessions.Get(ctx, user.ID, claims.ID)
+		require.NoError(t, err)
+		assert.False(t, session.TwoFactor)
+	})
+
+	t.Run("cloned authenticator", func(t *testing.T) {
+		passkey.SignCount = 100
+
+		_, err := login(t)
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskey)
+	})
+
+	t.Run("unknown login token", func(t *testing.T) {
+		_, err := service.FinishLogin(ctx, &dto.PasskeyLoginRequest{Token: "unknown"}, testClient)
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskeyChallenge)
+	})
+}
+
+func TestPasskeyService_TwoFactor(t *testing.T) {
+	service, deps, authenticator := setupPasskeyTest(t)
+	ctx := context.Background()
+
+	hashedPassword, _ := auth.HashPassword("SecurePassword123!")
+	user := &domain.User{ID: uuid.New(), Email: "john@example.com", Password: hashedPassword, IsActive: true, IsVerified: true}
+	deps.userRepo.On("GetByID", ctx, user.ID).Return(user, nil)
+	deps.userRepo.On("GetByEmail", ctx, user.Email).Return(user, nil)
+	passkey := registerPasskey(t, service, deps, authenticator, user)
+
+	deps.passkeyRepo.ExpectedCalls = withoutCall(deps.passkeyRepo.ExpectedCalls, "CountByUserID")
+	deps.passkeyRepo.On("CountByUserID", ctx, user.ID).Return(1, nil)
+	deps.passkeyRepo.On("ListByUserID", ctx, user.ID).Return([]*domain.Passkey{passkey}, nil)
+
+	challenge := func(t *testing.T) string {
+		resp, err := service.auth.Login(ctx, &dto.UserLoginRequest{Email: user.Email, Password: "SecurePassword123!"}, testClient)
+		require.NoError(t, err)
+		require.True(t, resp.TwoFactorRequired)
+		assert.Equal(t, []string{"passkey"}, resp.TwoFactorMethods)
+
+		return resp.ChallengeToken
+	}
+
+	t.Run("passkey of another user", func(t *testing.T) {
+		other := &domain.User{ID: uuid.New(), Email: "jane@example.com", IsActive: true, IsVerified: true}
+		deps.userRepo.On("GetByID", ctx, other.ID).Return(other, nil)
+		otherAuthenticator := webauthntest.NewAuthenticator(passkeyTestOrigin)
+		registerPasskey(t, service, deps, otherAuthenticator, other)
+
+		token := challenge(t)
+		options, err := service.BeginTwoFactor(ctx, token)
+		require.NoError(t, err)
+
+		// The options only allow the user's passkeys, but a client can send any credential
+		options.AllowCredentials = nil
+		_, err = service.FinishTwoFactor(ctx, &dto.PasskeyTwoFactorRequest{ChallengeToken: token, Credential: *otherAuthenticator.Get(t, options)}, testClient)
+		assert.ErrorIs(t, err, domain.ErrInvalidPasskey)
+	})
+
+	token := challenge(t)
+	options, err := service.BeginTwoFactor(ctx, token)
+	require.NoError(t, err)
+	require.Len(t, options.AllowCredentials, 1)
+
+	tokens, err := service.FinishTwoFactor(ctx, &dto.PasskeyTwoFactorRequest{ChallengeToken: token, Credential: *authenticator.Get(t, options)}, testClient)
+	require.NoError(t, err)
+	assertTwoFactorSession(t, service, deps, user.ID, tokens)
+
+	t.Run("challenge is consumed", func(t *testing.T) {
+		_, err := service.BeginTwoFactor(ctx, token)
+		assert.ErrorIs(t, err, domain.ErrInvalidTwoFactorChallenge)
+	})
+}
+
+// withoutCall drops the expectations set up for a method, so a test can replace a default
+func withoutCall(calls []*mock.Call, method string) []*mock.Call {
+	kept := calls[:0]
+	for _, call := range calls {
+		if call.Method != method {
+			kept = append(kept, call)
+		}
+	}
+
+	return kept
+}
+
+// allowedCredentials returns the credential IDs the options allow
+func allowedCredentials(options *auth.WebAuthnRequestOptions) [][]byte {
+	ids := make([][]byte, 0, len(options.AllowCredentials))
+	for _, descriptor := range options.AllowCredentials {
+		ids = append(ids, []byte(descriptor.ID))
+	}
+
+	return ids
+}
@@ -0,0 +1,50 @@
+package application
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/i18n"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+)
+
+// SendReviewEmail sends one of the emails of a request reviewed by the parish or the admins, or
+// of an invitation, built from the translations of the section in the language of the recipient.
+// Detail is shown below the message, e.g. the comment of the reviewer, and the button opens the link.
+func SendReviewEmail(ctx context.Context, queue storage.QueueStorage, from string, user *domain.User, templateName, section, detail, link string) error {
+	// Determine user's language preference
+	lang := i18n.GetLanguage(ctx)
+	if user.Language.Valid && user.Language.String != "" {
+		lang = i18n.Language(user.Language.String)
+	}
+
+	payload := NotificationPayload{
+		From:         from,
+		To:           []string{user.Email},
+		Subject:      i18n.Translate(lang, section+".subject"),
+		TemplateName: templateName,
+		Data: map[string]string{
+			"Lang":        string(lang),
+			"Brand":       i18n.Translate(lang, "email.common.brand"),
+			"Title":       i18n.Translate(lang, section+".title"),
+			"Greeting":    i18n.TranslateWithParams(lang, section+".greeting", map[string]string{"name": user.FirstName}),
+			"Message":     i18n.Translate(lang, section+".message"),
+			"DetailLabel": i18n.Translate(lang, section+".detail_label"),
+			"Detail":      detail,
+			"Button":      i18n.Translate(lang, section+".button"),
+			"Footer":      i18n.Translate(lang, section+".footer"),
+			"Copyright":   i18n.Translate(lang, "email.common.copyright"),
+			"Link":        link,
+		},
+	}
+
+	// Publish to notification queue
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	return queue.Publish(ctx, "", constants.QUEUE_NOTIFICATIONS, payloadBytes)
+}
@@ -0,0 +1,187 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// sessionExpiry matches the refresh token lifetime, an idle session ends with its refresh token family
+	sessionExpiry = auth.RefreshTokenExpiry
+	// sessionTouchInterval throttles last-seen writes for sessions making frequent requests
+	sessionTouchInterval = 1 * time.Minute
+)
+
+type SessionService struct {
+	logger *zap.SugaredLogger
+	cache  storage.CacheStorage
+}
+
+func NewSessionService(logger *zap.SugaredLogger, cache storage.CacheStorage) *SessionService {
+	return &SessionService{
+		logger: logger,
+		cache:  cache,
+	}
+}
+
+// Create registers a new session for the user, recording whether a second factor was presented
+func (s *SessionService) Create(ctx context.Context, userID uuid.UUID, client *dto.ClientInfo, twoFactorVerified bool) (*domain.Session, error) {
+	now := time.Now().UTC()
+	session := &domain.Session{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		Device:     client.Device,
+		IPAddress:  client.IPAddress,
+		CreatedAt:  now,
+		LastSeenAt: now,
+		TwoFactor:  twoFactorVerified,
+	}
+
+	if err := s.cache.Set(ctx, s.sessionKey(userID, session.ID), session, sessionExpiry); err != nil {
+		s.logger.Errorw("failed to create session", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return session, nil
+}
+
+// Get retrieves an active session of the user
+func (s *SessionService) Get(ctx context.Context, userID uuid.UUID, sessionID string) (*domain.Session, error) {
+	if sessionID == "" {
+		return nil, domain.ErrSessionNotFound
+	}
+
+	var session domain.Session
+	if err := s.cache.Get(ctx, s.sessionKey(userID, sessionID), &session); err != nil {
+		s.logger.Errorw("failed to get session", "userID", userID, "sessionID", sessionID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	// A missing hash scans into a zero value
+	if session.ID == "" || session.CreatedAt.IsZero() {
+		return nil, domain.ErrSessionNotFound
+	}
+	session.UserID = userID
+
+	return &session, nil
+}
+
+// Touch validates the session and records the latest activity of the device
+func (s *SessionService) Touch(ctx context.Context, userID uuid.UUID, sessionID string, client *dto.ClientInfo) (*domain.Session, error) {
+	session, err := s.Get(ctx, userID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if now.Sub(session.LastSeenAt) < sessionTouchInterval && session.IPAddress == client.IPAddress {
+		return session, nil
+	}
+
+	session.LastSeenAt = now
+	session.IPAddress = client.IPAddress
+	if err := s.cache.Set(ctx, s.sessionKey(userID, sessionID), map[string]any{
+		"last_seen_at": session.LastSeenAt,
+		"ip_address":   session.IPAddress,
+	}, sessionExpiry); err != nil {
+		// Failing to record activity must not lock the user out
+		s.logger.Warnw("failed to touch session", "userID", userID, "sessionID", sessionID, "error", err)
+	}
+
+	return session, nil
+}
+
+// List returns the active sessions of a user, most recently seen first. Users list their own
+// sessions, those of other users are listed under the admin routes, which check the permission
+// and the scope of the caller.
+func (s *SessionService) List(ctx context.Context, userID uuid.UUID) ([]*domain.Session, error) {
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	keys, err := s.cache.Scan(ctx, s.sessionKey(userID, "*"))
+	if err != nil {
+		s.logger.Errorw("failed to scan sessions", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	sessions := make([]*domain.Session, 0, len(keys))
+	for _, key := range keys {
+		session, err := s.Get(ctx, userID, sessionIDFromKey(key))
+		if err != nil {
+			if errors.Is(err, domain.ErrSessionNotFound) {
+				continue
+			}
+
+			return nil, err
+		}
+
+		session.Current = userCtx.ID == userID && session.ID == userCtx.SessionID
+		sessions = append(sessions, session)
+	}
+
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt)
+	})
+
+	return sessions, nil
+}
+
+// Revoke ends a single session along with its refresh token family
+func (s *SessionService) Revoke(ctx context.Context, userID uuid.UUID, sessionID string) error {
+	if err := s.cache.Del(ctx, s.sessionKey(userID, sessionID)); err != nil {
+		s.logger.Errorw("failed to delete session", "userID", userID, "sessionID", sessionID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if err := s.cache.Del(ctx, refreshFamilyKey(s.cache, sessionID)); err != nil {
+		s.logger.Errorw("failed to delete refresh token family", "userID", userID, "sessionID", sessionID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// RevokeAll ends every session of the user
+func (s *SessionService) RevokeAll(ctx context.Context, userID uuid.UUID) error {
+	return s.RevokeOthers(ctx, userID, "")
+}
+
+// RevokeOthers ends every session of the user except the given one
+func (s *SessionService) RevokeOthers(ctx context.Context, userID uuid.UUID, keepSessionID string) error {
+	keys, err := s.cache.Scan(ctx, s.sessionKey(userID, "*"))
+	if err != nil {
+		s.logger.Errorw("failed to scan sessions", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	for _, key := range keys {
+		sessionID := sessionIDFromKey(key)
+		if sessionID == keepSessionID {
+			continue
+		}
+
+		if err := s.Revoke(ctx, userID, sessionID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SessionService) sessionKey(userID uuid.UUID, sessionID string) string {
+	return s.cache.BuildKey(storage.CACHE_PREFIX_SESSION, userID.String(), sessionID)
+}
+
+func sessionIDFromKey(key string) string {
+	return key[strings.LastIndex(key, ":")+1:]
+}
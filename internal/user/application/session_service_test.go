@@ -0,0 +1,162 @@
+package application
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/storage"
+	"github.com/alicebob/miniredis/v2"
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func setupSessionTest(t *testing.T) *SessionService {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { client.Close() })
+
+	return NewSessionService(zap.NewNop().Sugar(), storage.NewCacheStorage(client))
+}
+
+func TestSessionService_CreateAndGet(t *testing.T) {
+	service := setupSessionTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := service.Create(ctx, userID, testClient, false)
+	require.NoError(t, err)
+
+	session, err := service.Get(ctx, userID, created.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, created.ID, session.ID)
+	assert.Equal(t, userID, session.UserID)
+	assert.Equal(t, testClient.Device, session.Device)
+	assert.Equal(t, testClient.IPAddress, session.IPAddress)
+	assert.WithinDuration(t, created.CreatedAt, session.CreatedAt, time.Second)
+}
+
+func TestSessionService_Get_NotFound(t *testing.T) {
+	service := setupSessionTest(t)
+	ctx := context.Background()
+
+	_, err := service.Get(ctx, uuid.New(), uuid.NewString())
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+
+	_, err = service.Get(ctx, uuid.New(), "")
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+}
+
+func TestSessionService_Touch(t *testing.T) {
+	service := setupSessionTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	created, err := service.Create(ctx, userID, testClient, false)
+	require.NoError(t, err)
+
+	t.Run("RecordsNewIP", func(t *testing.T) {
+		session, err := service.Touch(ctx, userID, created.ID, &dto.ClientInfo{Device: testClient.Device, IPAddress: "10.0.0.1"})
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.1", session.IPAddress)
+
+		stored, err := service.Get(ctx, userID, created.ID)
+		assert.NoError(t, err)
+		assert.Equal(t, "10.0.0.1", stored.IPAddress)
+		assert.Equal(t, testClient.Device, stored.Device)
+	})
+
+	t.Run("Revoked", func(t *testing.T) {
+		require.NoError(t, service.Revoke(ctx, userID, created.ID))
+
+		_, err := service.Touch(ctx, userID, created.ID, testClient)
+		assert.Equal(t, domain.ErrSessionNotFound, err)
+	})
+}
+
+func TestSessionService_List(t *testing.T) {
+	service := setupSessionTest(t)
+	userID := uuid.New()
+
+	first, err := service.Create(context.Background(), userID, testClient, false)
+	require.NoError(t, err)
+	_, err = service.Create(context.Background(), userID, testClient, false)
+	require.NoError(t, err)
+	_, err = service.Create(context.Background(), uuid.New(), testClient, false)
+	require.NoError(t, err)
+
+	t.Run("Owner", func(t *testing.T) {
+		userCtx := &dto.UserAsContext{ID: userID, RoleID: constants.ROLE_USER, SessionID: first.ID}
+		ctx := context.WithValue(context.Background(), auth.UserContextKey, userCtx)
+
+		sessions, err := service.List(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		for _, session := range sessions {
+			assert.Equal(t, session.ID == first.ID, session.Current)
+		}
+	})
+
+	// The admin routes check the permission and the scope of the caller before listing
+	t.Run("Admin", func(t *testing.T) {
+		userCtx := &dto.UserAsContext{ID: uuid.New(), RoleID: constants.ROLE_MANAGER, SessionID: first.ID}
+		ctx := context.WithValue(context.Background(), auth.UserContextKey, userCtx)
+
+		sessions, err := service.List(ctx, userID)
+
+		assert.NoError(t, err)
+		assert.Len(t, sessions, 2)
+		for _, session := range sessions {
+			assert.False(t, session.Current)
+		}
+	})
+}
+
+func TestSessionService_RevokeAll(t *testing.T) {
+	service := setupSessionTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	otherUserID := uuid.New()
+
+	first, _ := service.Create(ctx, userID, testClient, false)
+	second, _ := service.Create(ctx, userID, testClient, false)
+	other, _ := service.Create(ctx, otherUserID, testClient, false)
+
+	err := service.RevokeAll(ctx, userID)
+	assert.NoError(t, err)
+
+	_, err = service.Get(ctx, userID, first.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+	_, err = service.Get(ctx, userID, second.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+
+	// Sessions of other users are untouched
+	_, err = service.Get(ctx, otherUserID, other.ID)
+	assert.NoError(t, err)
+}
+
+func TestSessionService_RevokeOthers(t *testing.T) {
+	service := setupSessionTest(t)
+	ctx := context.Background()
+	userID := uuid.New()
+
+	current, _ := service.Create(ctx, userID, testClient, false)
+	other, _ := service.Create(ctx, userID, testClient, false)
+
+	err := service.RevokeOthers(ctx, userID, current.ID)
+	assert.NoError(t, err)
+
+	_, err = service.Get(ctx, userID, current.ID)
+	assert.NoError(t, err)
+	_, err = service.Get(ctx, userID, other.ID)
+	assert.Equal(t, domain.ErrSessionNotFound, err)
+}
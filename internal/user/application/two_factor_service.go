@@ -0,0 +1,211 @@
+package application
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"go.uber.org/zap"
+)
+
+const recoveryCodeCount = 10
+
+type TwoFactorService struct {
+	logger        *zap.SugaredLogger
+	config        config.Config
+	twoFactorRepo domain.TwoFactorRepository
+}
+
+func NewTwoFactorService(logger *zap.SugaredLogger, cfg config.Config, twoFactorRepo domain.TwoFactorRepository) *TwoFactorService {
+	return &TwoFactorService{
+		logger:        logger,
+		config:        cfg,
+		twoFactorRepo: twoFactorRepo,
+	}
+}
+
+// Enroll generates a new TOTP secret for the user. The enrollment stays pending until
+// confirmed through Enable, so an abandoned enrollment never locks the user out.
+func (s *TwoFactorService) Enroll(ctx context.Context, userID uuid.UUID, email string) (*dto.TwoFactorEnrollResponse, error) {
+	existing, err := s.twoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil && !errors.Is(err, domain.ErrTwoFactorNotEnrolled) {
+		s.logger.Errorw("failed to get two-factor enrollment", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if existing != nil && existing.IsEnabled() {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := auth.GenerateTOTPSecret()
+	if err != nil {
+		s.logger.Errorw("failed to generate totp secret", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	if err := s.twoFactorRepo.Upsert(ctx, &domain.TwoFactor{UserID: userID, Secret: secret}); err != nil {
+		s.logger.Errorw("failed to store two-factor enrollment", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.TwoFactorEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: auth.TOTPProvisioningURI(s.config.Application.Name, email, secret),
+	}, nil
+}
+
+// Enable confirms a pending enrollment with a code from the authenticator app and
+// returns the recovery codes, which are shown to the user only this once
+func (s *TwoFactorService) Enable(ctx context.Context, userID uuid.UUID, code string) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	twoFactor, err := s.getEnrollment(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if twoFactor.IsEnabled() {
+		return nil, domain.ErrTwoFactorAlreadyEnabled
+	}
+
+	if err := s.verifyCode(ctx, twoFactor, code); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		if err := s.twoFactorRepo.Enable(tx, userID); err != nil {
+			return err
+		}
+
+		return s.twoFactorRepo.ReplaceRecoveryCodes(tx, userID, hashes)
+	}); err != nil {
+		s.logger.Errorw("failed to enable two-factor authentication", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Disable removes the second factor after checking a current code
+func (s *TwoFactorService) Disable(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.Verify(ctx, userID, code, ""); err != nil {
+		return err
+	}
+
+	if err := s.twoFactorRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		return s.twoFactorRepo.Delete(tx, userID)
+	}); err != nil {
+		s.logger.Errorw("failed to disable two-factor authentication", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	return nil
+}
+
+// RegenerateRecoveryCodes invalidates the remaining recovery codes and issues a new set
+func (s *TwoFactorService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) (*dto.TwoFactorRecoveryCodesResponse, error) {
+	if err := s.Verify(ctx, userID, code, ""); err != nil {
+		return nil, err
+	}
+
+	codes, hashes, err := s.newRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.twoFactorRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		return s.twoFactorRepo.ReplaceRecoveryCodes(tx, userID, hashes)
+	}); err != nil {
+		s.logger.Errorw("failed to replace recovery codes", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return &dto.TwoFactorRecoveryCodesResponse{RecoveryCodes: codes}, nil
+}
+
+// Verify checks a TOTP code, or a recovery code when one is given, against the enabled factor
+func (s *TwoFactorService) Verify(ctx context.Context, userID uuid.UUID, code, recoveryCode string) error {
+	twoFactor, err := s.getEnrollment(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if !twoFactor.IsEnabled() {
+		return domain.ErrTwoFactorNotEnrolled
+	}
+
+	if recoveryCode == "" {
+		return s.verifyCode(ctx, twoFactor, code)
+	}
+
+	used, err := s.twoFactorRepo.UseRecoveryCode(ctx, userID, auth.HashRecoveryCode(recoveryCode))
+	if err != nil {
+		s.logger.Errorw("failed to use recovery code", "userID", userID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if !used {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (s *TwoFactorService) getEnrollment(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	twoFactor, err := s.twoFactorRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, domain.ErrTwoFactorNotEnrolled) {
+			return nil, err
+		}
+
+		s.logger.Errorw("failed to get two-factor enrollment", "userID", userID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	return twoFactor, nil
+}
+
+// verifyCode validates a TOTP code and consumes its time step so it cannot be replayed
+func (s *TwoFactorService) verifyCode(ctx context.Context, twoFactor *domain.TwoFactor, code string) error {
+	step, ok := auth.ValidateTOTPCode(twoFactor.Secret, code, time.Now())
+	if !ok {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	used, err := s.twoFactorRepo.UseStep(ctx, twoFactor.UserID, step)
+	if err != nil {
+		s.logger.Errorw("failed to record totp step", "userID", twoFactor.UserID, "error", err)
+		return response.ErrInternalServerError
+	}
+
+	if !used {
+		return domain.ErrInvalidTwoFactorCode
+	}
+
+	return nil
+}
+
+func (s *TwoFactorService) newRecoveryCodes(userID uuid.UUID) ([]string, [][]byte, error) {
+	codes, err := auth.GenerateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		s.logger.Errorw("failed to generate recovery codes", "userID", userID, "error", err)
+		return nil, nil, response.ErrInternalServerError
+	}
+
+	hashes := make([][]byte, len(codes))
+	for i, code := range codes {
+		hashes[i] = auth.HashRecoveryCode(code)
+	}
+
+	return codes, hashes, nil
+}
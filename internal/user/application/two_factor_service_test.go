@@ -0,0 +1,190 @@
+package application
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+)
+
+type MockTwoFactorRepository struct {
+	mock.Mock
+}
+
+func (m *MockTwoFactorRepository) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	args := m.Called(ctx, fn)
+	if args.Get(0) != nil {
+		return args.Error(0)
+	}
+	// Execute the function with a nil tx for testing purposes
+	return fn(nil)
+}
+
+func (m *MockTwoFactorRepository) Upsert(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	args := m.Called(ctx, twoFactor)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*domain.TwoFactor), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) Enable(tx *sqlx.Tx, userID uuid.UUID) error {
+	args := m.Called(tx, userID)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) Delete(tx *sqlx.Tx, userID uuid.UUID) error {
+	args := m.Called(tx, userID)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	args := m.Called(ctx, userID, step)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) ReplaceRecoveryCodes(tx *sqlx.Tx, userID uuid.UUID, codeHashes [][]byte) error {
+	args := m.Called(tx, userID, codeHashes)
+	return args.Error(0)
+}
+
+func (m *MockTwoFactorRepository) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) (bool, error) {
+	args := m.Called(ctx, userID, codeHash)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockTwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	args := m.Called(ctx, userID)
+	return args.Int(0), args.Error(1)
+}
+
+func setupTwoFactorTest() (*TwoFactorService, *MockTwoFactorRepository) {
+	mockTwoFactorRepo := new(MockTwoFactorRepository)
+	cfg := config.Config{}
+	cfg.Application.Name = "Entrepreneur Pastoral"
+
+	return NewTwoFactorService(zap.NewNop().Sugar(), cfg, mockTwoFactorRepo), mockTwoFactorRepo
+}
+
+func TestTwoFactorService_Enroll(t *testing.T) {
+	service, mockTwoFactorRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTwoFactorRepo.On("GetByUserID", ctx, userID).Return(nil, domain.ErrTwoFactorNotEnrolled)
+	mockTwoFactorRepo.On("Upsert", ctx, mock.AnythingOfType("*domain.TwoFactor")).Return(nil)
+
+	resp, err := service.Enroll(ctx, userID, "john@example.com")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Secret)
+	assert.Contains(t, resp.ProvisioningURI, "secret="+resp.Secret)
+	mockTwoFactorRepo.AssertExpectations(t)
+}
+
+func TestTwoFactorService_Enroll_AlreadyEnabled(t *testing.T) {
+	service, mockTwoFactorRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	enabled := &domain.TwoFactor{UserID: userID, Secret: "SECRET", EnabledAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	mockTwoFactorRepo.On("GetByUserID", ctx, userID).Return(enabled, nil)
+
+	resp, err := service.Enroll(ctx, userID, "john@example.com")
+
+	assert.Equal(t, domain.ErrTwoFactorAlreadyEnabled, err)
+	assert.Nil(t, resp)
+	mockTwoFactorRepo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything)
+}
+
+func TestTwoFactorService_Enable(t *testing.T) {
+	service, mockTwoFactorRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	userID := uuid.New()
+	secret, _ := auth.GenerateTOTPSecret()
+
+	mockTwoFactorRepo.On("GetByUserID", ctx, userID).Return(&domain.TwoFactor{UserID: userID, Secret: secret}, nil)
+
+	t.Run("InvalidCode", func(t *testing.T) {
+		resp, err := service.Enable(ctx, userID, "abcdef")
+
+		assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+		assert.Nil(t, resp)
+	})
+
+	t.Run("Success", func(t *testing.T) {
+		code, _ := auth.GenerateTOTPCode(secret, time.Now())
+		mockTwoFactorRepo.On("UseStep", ctx, userID, mock.AnythingOfType("int64")).Return(true, nil)
+		mockTwoFactorRepo.On("UnitOfWork", ctx, mock.AnythingOfType("func(*sqlx.Tx) error")).Return(nil)
+		mockTwoFactorRepo.On("Enable", mock.Anything, userID).Return(nil)
+		mockTwoFactorRepo.On("ReplaceRecoveryCodes", mock.Anything, userID, mock.AnythingOfType("[][]uint8")).Return(nil)
+
+		resp, err := service.Enable(ctx, userID, code)
+
+		assert.NoError(t, err)
+		assert.Len(t, resp.RecoveryCodes, recoveryCodeCount)
+		mockTwoFactorRepo.AssertExpectations(t)
+	})
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	service, mockTwoFactorRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	userID := uuid.New()
+	secret, _ := auth.GenerateTOTPSecret()
+
+	enabled := &domain.TwoFactor{UserID: userID, Secret: secret, EnabledAt: sql.NullTime{Time: time.Now(), Valid: true}}
+	mockTwoFactorRepo.On("GetByUserID", ctx, userID).Return(enabled, nil)
+
+	t.Run("ReplayedCode", func(t *testing.T) {
+		code, _ := auth.GenerateTOTPCode(secret, time.Now())
+		mockTwoFactorRepo.On("UseStep", ctx, userID, mock.AnythingOfType("int64")).Return(false, nil).Once()
+
+		err := service.Verify(ctx, userID, code, "")
+
+		assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+	})
+
+	t.Run("RecoveryCode", func(t *testing.T) {
+		hash := auth.HashRecoveryCode("abcdefgh-ijklmnop")
+		mockTwoFactorRepo.On("UseRecoveryCode", ctx, userID, hash).Return(true, nil).Once()
+
+		err := service.Verify(ctx, userID, "", "ABCDEFGH-IJKLMNOP")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("UsedRecoveryCode", func(t *testing.T) {
+		mockTwoFactorRepo.On("UseRecoveryCode", ctx, userID, mock.Anything).Return(false, nil).Once()
+
+		err := service.Verify(ctx, userID, "", "abcdefgh-ijklmnop")
+
+		assert.Equal(t, domain.ErrInvalidTwoFactorCode, err)
+	})
+}
+
+func TestTwoFactorService_Verify_NotEnabled(t *testing.T) {
+	service, mockTwoFactorRepo := setupTwoFactorTest()
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTwoFactorRepo.On("GetByUserID", ctx, userID).Return(&domain.TwoFactor{UserID: userID, Secret: "SECRET"}, nil)
+
+	err := service.Verify(ctx, userID, "123456", "")
+
+	assert.Equal(t, domain.ErrTwoFactorNotEnrolled, err)
+}
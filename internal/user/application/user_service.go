@@ -4,11 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"slices"
+	"time"
 
 	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -23,6 +27,8 @@ type UserService struct {
 	notifPrefRepo  domain.NotificationPreferencesRepository
 	jobProfileRepo domain.JobProfileRepository
 	addressRepo    adminDomain.AddressRepository
+	identityRepo   domain.UserIdentityRepository
+	permissionRepo adminDomain.PermissionRepository
 }
 
 // NewUserService creates a new UserService with its dependencies.
@@ -32,6 +38,8 @@ func NewUserService(
 	notifPrefRepo domain.NotificationPreferencesRepository,
 	jobProfileRepo domain.JobProfileRepository,
 	addressRepo adminDomain.AddressRepository,
+	identityRepo domain.UserIdentityRepository,
+	permissionRepo adminDomain.PermissionRepository,
 ) *UserService {
 	return &UserService{
 		logger:         logger,
@@ -39,39 +47,79 @@ func NewUserService(
 		notifPrefRepo:  notifPrefRepo,
 		jobProfileRepo: jobProfileRepo,
 		addressRepo:    addressRepo,
+		identityRepo:   identityRepo,
+		permissionRepo: permissionRepo,
 	}
 }
 
 func (s *UserService) Create(ctx context.Context, req *dto.UserRegisterRequest) (*domain.User, error) {
+	if err := s.checkAvailable(ctx, req); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := auth.HashPassword(req.Password)
+	if err != nil {
+		s.logger.Errorw("failed to hash password", "error", err)
+		return nil, domain.ErrPasswordHashFailed
+	}
+
+	return s.create(ctx, req, hashedPassword, nil)
+}
+
+// CreateWithIdentity registers a user who signed in with an OpenID Connect provider and links
+// the identity to them. The provider already verified the email, and as the user never chose a
+// password they get a random one, to be replaced through the password reset if they want one.
+func (s *UserService) CreateWithIdentity(ctx context.Context, req *dto.UserRegisterRequest, identity *domain.UserIdentity) (*domain.User, error) {
+	if err := s.checkAvailable(ctx, req); err != nil {
+		return nil, err
+	}
+
+	password, err := auth.GenerateRandomToken(32)
+	if err != nil {
+		s.logger.Errorw("failed to generate password", "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	hashedPassword, err := auth.HashPassword(password)
+	if err != nil {
+		s.logger.Errorw("failed to hash password", "error", err)
+		return nil, domain.ErrPasswordHashFailed
+	}
+
+	return s.create(ctx, req, hashedPassword, identity)
+}
+
+// checkAvailable rejects a registration whose email or document ID belongs to another user
+func (s *UserService) checkAvailable(ctx context.Context, req *dto.UserRegisterRequest) error {
 	// Check if email already exists
 	if _, err := s.userRepo.GetByEmail(ctx, req.Email); err == nil {
 		// User found - email already exists
-		return nil, domain.ErrEmailAlreadyExists
+		return domain.ErrEmailAlreadyExists
 	} else if !errors.Is(err, domain.ErrUserNotFound) {
 		// Unexpected error
 		s.logger.Errorw("failed to check existing email", "email", req.Email, "error", err)
-		return nil, response.ErrInternalServerError
+		return response.ErrInternalServerError
 	}
 
 	// Check if document ID already exists
 	if _, err := s.userRepo.GetByDocumentID(ctx, req.DocumentID); err == nil {
 		// User found - document ID already exists
-		return nil, domain.ErrDocumentIDAlreadyExists
+		return domain.ErrDocumentIDAlreadyExists
 	} else if !errors.Is(err, domain.ErrUserNotFound) {
 		// Unexpected error
-		s.logger.Errorw("failed to check existing document ID", "documentID", req.DocumentID, "error", err)
-		return nil, response.ErrInternalServerError
+		s.logger.Errorw("failed to check existing document ID", "error", err)
+		return response.ErrInternalServerError
 	}
 
-	hashedPassword, err := auth.HashPassword(req.Password)
-	if err != nil {
-		s.logger.Errorw("failed to hash password", "error", err)
-		return nil, domain.ErrPasswordHashFailed
-	}
+	return nil
+}
 
+// create stores the user with their address, notification preferences, job profile and, when
+// they signed up through a provider, their identity
+func (s *UserService) create(ctx context.Context, req *dto.UserRegisterRequest, hashedPassword []byte, identity *domain.UserIdentity) (*domain.User, error) {
 	var newUser *domain.User
 
-	err = s.userRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+	err := s.userRepo.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
 		// 1. Create the Address
 		address := &adminDomain.Address{
 			StreetLine1:   req.Address.StreetLine1,
@@ -97,6 +145,7 @@ func (s *UserService) Create(ctx context.Context, req *dto.UserRegisterRequest)
 			PhoneNumber:      sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""},
 			AddressID:        address.ID,
 			ChurchID:         req.ChurchID,
+			IsVerified:       identity != nil,
 		}
 		if err := s.userRepo.Create(tx, newUser); err != nil {
 			s.logger.Errorw("failed to create user", "email", req.Email, "error", err)
@@ -121,6 +170,15 @@ func (s *UserService) Create(ctx context.Context, req *dto.UserRegisterRequest)
 			return response.ErrInternalServerError
 		}
 
+		// 5. Link the identity the user signed up with
+		if identity != nil {
+			identity.UserID = newUser.ID
+			if err := s.identityRepo.Create(tx, identity); err != nil {
+				s.logger.Errorw("failed to create user identity", "provider", identity.Provider, "error", err)
+				return response.ErrInternalServerError
+			}
+		}
+
 		return nil
 	})
 
@@ -141,7 +199,6 @@ func (s *UserService) Update(ctx context.Context, req *dto.UserUpdateRequest) er
 	// 2. Map DTO fields to the entity
 	user.FirstName = req.FirstName
 	user.LastName = req.LastName
-	user.Email = req.Email
 	user.DocumentID = req.DocumentID
 	user.PhoneCountryCode = sql.NullString{String: req.PhoneCountryCode, Valid: req.PhoneCountryCode != ""}
 	user.PhoneNumber = sql.NullString{String: req.PhoneNumber, Valid: req.PhoneNumber != ""}
@@ -226,9 +283,16 @@ func (s *UserService) List(ctx context.Context, filter *dto.UserListRequest) (*d
 		return nil, response.ErrInternalServerError
 	}
 
-	// 2. Get the total count for pagination
+	var page pagination.Page
+	if filter.Cursor != nil {
+		users, page = pagination.Trim(users, filter.Cursor, pagination.Limit(filter.Limit), func(u *domain.User) (time.Time, uuid.UUID) {
+			return u.CreatedAt, u.ID
+		})
+	}
+
+	// 2. Get the total count for pagination, skipped by cursor pages as it would read the whole list on every page
 	count := 0
-	if len(users) > 0 {
+	if len(users) > 0 && filter.Cursor == nil {
 		count, err = s.userRepo.Count(ctx, filter)
 		if err != nil {
 			s.logger.Errorw("failed to count users", "error", err)
@@ -239,6 +303,7 @@ func (s *UserService) List(ctx context.Context, filter *dto.UserListRequest) (*d
 	return &dto.UserListResponse{
 		Users: users,
 		Count: count,
+		Page:  page,
 	}, nil
 }
 
@@ -303,9 +368,10 @@ func (s *UserService) updateUserProperty(ctx context.Context, userID uuid.UUID,
 	return nil
 }
 
-// SetRole sets the user's role.
+// SetRole sets the user's role. Besides admins, callers may only grant roles whose permissions
+// they hold themselves, and may not grant admin or change the role of an admin.
 func (s *UserService) SetRole(ctx context.Context, req *dto.UserSetRoleRequest) error {
-	_, err := s.userRepo.GetByID(ctx, req.ID)
+	user, err := s.userRepo.GetByID(ctx, req.ID)
 	if err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			return domain.ErrUserNotFound
@@ -315,6 +381,17 @@ func (s *UserService) SetRole(ctx context.Context, req *dto.UserSetRoleRequest)
 		return response.ErrInternalServerError
 	}
 
+	caller := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	if caller.RoleID != constants.ROLE_ADMIN {
+		if req.RoleID == constants.ROLE_ADMIN || user.RoleID == constants.ROLE_ADMIN {
+			return domain.ErrRoleNotAssignable
+		}
+
+		if err := s.checkRoleWithinCaller(ctx, caller.RoleID, req.RoleID); err != nil {
+			return err
+		}
+	}
+
 	if err := s.userRepo.UpdateProperty(ctx, req.ID, domain.RoleID, req.RoleID); err != nil {
 		s.logger.Errorw("failed to set user role", "userID", req.ID, "roleID", req.RoleID, "error", err)
 		return response.ErrInternalServerError
@@ -322,3 +399,39 @@ func (s *UserService) SetRole(ctx context.Context, req *dto.UserSetRoleRequest)
 
 	return nil
 }
+
+// checkRoleWithinCaller rejects a role granting a permission the caller's role does not hold
+func (s *UserService) checkRoleWithinCaller(ctx context.Context, callerRoleID, roleID int16) error {
+	held, err := s.rolePermissionKeys(ctx, callerRoleID)
+	if err != nil {
+		return err
+	}
+
+	granted, err := s.rolePermissionKeys(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range granted {
+		if !slices.Contains(held, key) {
+			return domain.ErrRoleNotAssignable
+		}
+	}
+
+	return nil
+}
+
+func (s *UserService) rolePermissionKeys(ctx context.Context, roleID int16) ([]string, error) {
+	permissions, err := s.permissionRepo.GetByRoleID(ctx, roleID)
+	if err != nil && !errors.Is(err, adminDomain.ErrPermissionNotFound) {
+		s.logger.Errorw("failed to get role permissions", "roleID", roleID, "error", err)
+		return nil, response.ErrInternalServerError
+	}
+
+	keys := make([]string, 0, len(permissions))
+	for _, permission := range permissions {
+		keys = append(keys, permission.Key)
+	}
+
+	return keys, nil
+}
@@ -5,10 +5,13 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -52,6 +55,19 @@ func (m *MockUserRepository) UpdateProperty(ctx context.Context, id uuid.UUID, p
 	return args.Error(0)
 }
 
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, id uuid.UUID, password []byte) error {
+	args := m.Called(ctx, id, password)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) GetPasswordHistory(ctx context.Context, id uuid.UUID, limit int) ([][]byte, error) {
+	args := m.Called(ctx, id, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([][]byte), args.Error(1)
+}
+
 func (m *MockUserRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.User, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -116,6 +132,19 @@ func (m *MockUserRepository) GetAllByIsEntrepreneur(ctx context.Context, isEntre
 	return args.Get(0).([]*domain.User), args.Error(1)
 }
 
+func (m *MockUserRepository) GetAllDueForDeletion(ctx context.Context, before time.Time) ([]*domain.User, error) {
+	args := m.Called(ctx, before)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*domain.User), args.Error(1)
+}
+
+func (m *MockUserRepository) OwnsSharedBusiness(ctx context.Context, id uuid.UUID) (bool, error) {
+	args := m.Called(ctx, id)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockUserRepository) List(ctx context.Context, filter *domain.UserFilters) ([]*domain.User, error) {
 	args := m.Called(ctx, filter)
 	if args.Get(0) == nil {
@@ -224,6 +253,57 @@ func (m *MockAddressRepository) GetByID(ctx context.Context, id uuid.UUID) (*adm
 	return args.Get(0).(*adminDomain.Address), args.Error(1)
 }
 
+type MockPermissionRepository struct {
+	mock.Mock
+}
+
+func (m *MockPermissionRepository) Create(ctx context.Context, permission *adminDomain.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) Update(ctx context.Context, permission *adminDomain.Permission) error {
+	args := m.Called(ctx, permission)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) Delete(ctx context.Context, id int16) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPermissionRepository) GetAll(ctx context.Context) ([]*adminDomain.Permission, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*adminDomain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByID(ctx context.Context, id int16) (*adminDomain.Permission, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByKey(ctx context.Context, key string) (*adminDomain.Permission, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*adminDomain.Permission), args.Error(1)
+}
+
+func (m *MockPermissionRepository) GetByRoleID(ctx context.Context, roleID int16) ([]*adminDomain.Permission, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*adminDomain.Permission), args.Error(1)
+}
+
 // Test helpers
 func setupTest() (*UserService, *MockUserRepository, *MockNotificationPreferencesRepository, *MockJobProfileRepository, *MockAddressRepository) {
 	logger := zap.NewNop().Sugar()
@@ -232,7 +312,7 @@ func setupTest() (*UserService, *MockUserRepository, *MockNotificationPreference
 	mockJobProfileRepo := new(MockJobProfileRepository)
 	mockAddressRepo := new(MockAddressRepository)
 
-	service := NewUserService(logger, mockUserRepo, mockNotifPrefRepo, mockJobProfileRepo, mockAddressRepo)
+	service := NewUserService(logger, mockUserRepo, mockNotifPrefRepo, mockJobProfileRepo, mockAddressRepo, new(MockUserIdentityRepository), new(MockPermissionRepository))
 
 	return service, mockUserRepo, mockNotifPrefRepo, mockJobProfileRepo, mockAddressRepo
 }
@@ -378,7 +458,6 @@ func TestUserService_Update_Success(t *testing.T) {
 		ID:               userID,
 		FirstName:        "Jane",
 		LastName:         "Doe",
-		Email:            "jane.doe@example.com",
 		DocumentID:       "987654321",
 		PhoneCountryCode: "+1",
 		PhoneNumber:      "5559876543",
@@ -779,3 +858,91 @@ func TestUserService_UpdateEntrepreneurStatus_Success(t *testing.T) {
 	assert.NoError(t, err)
 	mockUserRepo.AssertExpectations(t)
 }
+
+// Test SetRole
+func TestUserService_SetRole(t *testing.T) {
+	permissions := func(keys ...string) []*adminDomain.Permission {
+		result := make([]*adminDomain.Permission, 0, len(keys))
+		for _, key := range keys {
+			result = append(result, &adminDomain.Permission{Key: key})
+		}
+		return result
+	}
+
+	setup := func(callerRoleID, targetRoleID int16) (*UserService, *MockUserRepository, *MockPermissionRepository, context.Context, uuid.UUID) {
+		mockUserRepo := new(MockUserRepository)
+		mockPermissionRepo := new(MockPermissionRepository)
+		service := NewUserService(zap.NewNop().Sugar(), mockUserRepo, new(MockNotificationPreferencesRepository), new(MockJobProfileRepository), new(MockAddressRepository), new(MockUserIdentityRepository), mockPermissionRepo)
+
+		userID := uuid.New()
+		userCtx := &dto.UserAsContext{ID: uuid.New(), RoleID: callerRoleID}
+		ctx := context.WithValue(context.Background(), auth.UserContextKey, userCtx)
+		mockUserRepo.On("GetByID", ctx, userID).Return(&domain.User{ID: userID, RoleID: targetRoleID}, nil)
+
+		return service, mockUserRepo, mockPermissionRepo, ctx, userID
+	}
+
+	t.Run("AdminAssignsAdmin", func(t *testing.T) {
+		service, mockUserRepo, mockPermissionRepo, ctx, userID := setup(constants.ROLE_ADMIN, constants.ROLE_USER)
+		mockUserRepo.On("UpdateProperty", ctx, userID, domain.RoleID, constants.ROLE_ADMIN).Return(nil)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_ADMIN})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+		mockPermissionRepo.AssertNotCalled(t, "GetByRoleID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("NonAdminAssignsAdmin", func(t *testing.T) {
+		service, mockUserRepo, _, ctx, userID := setup(constants.ROLE_MANAGER, constants.ROLE_USER)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_ADMIN})
+
+		assert.Equal(t, domain.ErrRoleNotAssignable, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("NonAdminDemotesAdmin", func(t *testing.T) {
+		service, mockUserRepo, _, ctx, userID := setup(constants.ROLE_MANAGER, constants.ROLE_ADMIN)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_USER})
+
+		assert.Equal(t, domain.ErrRoleNotAssignable, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RoleWithPermissionsCallerLacks", func(t *testing.T) {
+		service, mockUserRepo, mockPermissionRepo, ctx, userID := setup(constants.ROLE_ASSISTANT, constants.ROLE_USER)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_ASSISTANT).Return(permissions(constants.PERMISSION_USER_ASSIGN_ROLE), nil)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).Return(permissions(constants.PERMISSION_USER_ASSIGN_ROLE, constants.PERMISSION_ROLE_MANAGE), nil)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_MANAGER})
+
+		assert.Equal(t, domain.ErrRoleNotAssignable, err)
+		mockUserRepo.AssertNotCalled(t, "UpdateProperty", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("RoleWithinCallerPermissions", func(t *testing.T) {
+		service, mockUserRepo, mockPermissionRepo, ctx, userID := setup(constants.ROLE_MANAGER, constants.ROLE_USER)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).Return(permissions(constants.PERMISSION_USER_ASSIGN_ROLE, constants.PERMISSION_USER_READ), nil)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_ASSISTANT).Return(permissions(constants.PERMISSION_USER_READ), nil)
+		mockUserRepo.On("UpdateProperty", ctx, userID, domain.RoleID, constants.ROLE_ASSISTANT).Return(nil)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_ASSISTANT})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+
+	t.Run("RoleWithoutPermissions", func(t *testing.T) {
+		service, mockUserRepo, mockPermissionRepo, ctx, userID := setup(constants.ROLE_MANAGER, constants.ROLE_ASSISTANT)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_MANAGER).Return(permissions(constants.PERMISSION_USER_ASSIGN_ROLE), nil)
+		mockPermissionRepo.On("GetByRoleID", ctx, constants.ROLE_USER).Return(nil, adminDomain.ErrPermissionNotFound)
+		mockUserRepo.On("UpdateProperty", ctx, userID, domain.RoleID, constants.ROLE_USER).Return(nil)
+
+		err := service.SetRole(ctx, &dto.UserSetRoleRequest{ID: userID, RoleID: constants.ROLE_USER})
+
+		assert.NoError(t, err)
+		mockUserRepo.AssertExpectations(t)
+	})
+}
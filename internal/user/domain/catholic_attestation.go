@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AttestationStatus tells where an attestation stands in its review.
+type AttestationStatus string
+
+const (
+	AttestationStatusPending  AttestationStatus = "pending"
+	AttestationStatusApproved AttestationStatus = "approved"
+	AttestationStatusRejected AttestationStatus = "rejected"
+)
+
+// CatholicAttestation corresponds to the "catholic_attestations" table.
+// Each row is a request of the user to be recognised as Catholic by the parish they named.
+type CatholicAttestation struct {
+	ID                uuid.UUID         `json:"id" db:"id"`
+	UserID            uuid.UUID         `json:"user_id" db:"user_id"`
+	ChurchID          uuid.UUID         `json:"church_id" db:"church_id"`
+	SacramentalRecord sql.NullString    `json:"sacramental_record" db:"sacramental_record"`
+	LetterURL         sql.NullString    `json:"letter_url" db:"letter_url"`
+	Status            AttestationStatus `json:"status" db:"status"`
+	ReviewerID        uuid.NullUUID     `json:"reviewer_id" db:"reviewer_id"`
+	ReviewComment     sql.NullString    `json:"review_comment" db:"review_comment"`
+	ReviewedAt        sql.NullTime      `json:"reviewed_at" db:"reviewed_at"`
+	CreatedAt         time.Time         `json:"created_at" db:"created_at"`
+}
+
+// CatholicAttestationFilters defines criteria for filtering attestations.
+type CatholicAttestationFilters struct {
+	UserID   *uuid.UUID         `json:"user_id,omitempty"`
+	ChurchID *uuid.UUID         `json:"church_id,omitempty"`
+	Status   *AttestationStatus `json:"status"`
+
+	// Scope limits the list to the parishes an admin with a scope reviews, it is never read from requests
+	Scope *ChurchScope `json:"-"`
+
+	// Pagination
+	Limit  *int `json:"limit"`
+	Offset *int `json:"offset"`
+}
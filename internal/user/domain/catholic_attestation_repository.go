@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type CatholicAttestationRepository interface {
+	Create(ctx context.Context, attestation *CatholicAttestation) error
+	GetByID(ctx context.Context, id uuid.UUID) (*CatholicAttestation, error)
+	GetPendingByUserID(ctx context.Context, userID uuid.UUID) (*CatholicAttestation, error)
+	List(ctx context.Context, filter *CatholicAttestationFilters) ([]*CatholicAttestation, error)
+	Count(ctx context.Context, filter *CatholicAttestationFilters) (int, error)
+	// Review records the decision on a pending attestation, and flags its user as Catholic in the same transaction when approved
+	Review(ctx context.Context, attestation *CatholicAttestation) error
+	// ListReviewers returns the active users whose admin scope covers the church and who hold the permission
+	ListReviewers(ctx context.Context, churchID uuid.UUID, permission string) ([]*User, error)
+}
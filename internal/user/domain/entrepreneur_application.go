@@ -0,0 +1,64 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ApplicationStatus tells where an entrepreneur application stands in its review.
+type ApplicationStatus string
+
+const (
+	ApplicationStatusPending ApplicationStatus = "pending"
+	// ApplicationStatusInfoRequested waits on the applicant to complete the application
+	ApplicationStatusInfoRequested ApplicationStatus = "info_requested"
+	ApplicationStatusApproved      ApplicationStatus = "approved"
+	ApplicationStatusRejected      ApplicationStatus = "rejected"
+)
+
+// IsOpen reports whether the application still awaits a decision.
+func (s ApplicationStatus) IsOpen() bool {
+	return s == ApplicationStatusPending || s == ApplicationStatusInfoRequested
+}
+
+// ApplicationDecision is the action a reviewer takes on an open application.
+type ApplicationDecision string
+
+const (
+	ApplicationDecisionApprove     ApplicationDecision = "approve"
+	ApplicationDecisionReject      ApplicationDecision = "reject"
+	ApplicationDecisionRequestInfo ApplicationDecision = "request_info"
+)
+
+// EntrepreneurApplication corresponds to the "entrepreneur_applications" table.
+// Each row is a request of the user to be allowed to offer a business on the platform.
+type EntrepreneurApplication struct {
+	ID                  uuid.UUID         `json:"id" db:"id"`
+	UserID              uuid.UUID         `json:"user_id" db:"user_id"`
+	BusinessName        string            `json:"business_name" db:"business_name"`
+	BusinessDescription string            `json:"business_description" db:"business_description"`
+	IndustryID          int16             `json:"industry_id" db:"industry_id"`
+	References          sql.NullString    `json:"references" db:"business_references"`
+	Status              ApplicationStatus `json:"status" db:"status"`
+	ReviewerID          uuid.NullUUID     `json:"reviewer_id" db:"reviewer_id"`
+	ReviewComment       sql.NullString    `json:"review_comment" db:"review_comment"`
+	ReviewedAt          sql.NullTime      `json:"reviewed_at" db:"reviewed_at"`
+	CreatedAt           time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// EntrepreneurApplicationFilters defines criteria for filtering applications.
+type EntrepreneurApplicationFilters struct {
+	UserID     *uuid.UUID         `json:"user_id,omitempty"`
+	IndustryID *int16             `json:"industry_id"`
+	Status     *ApplicationStatus `json:"status"`
+
+	// Scope limits the list to the applicants an admin with a scope manages, it is never read from requests
+	Scope *ChurchScope `json:"-"`
+
+	// Pagination
+	Limit  *int `json:"limit"`
+	Offset *int `json:"offset"`
+}
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type EntrepreneurApplicationRepository interface {
+	Create(ctx context.Context, application *EntrepreneurApplication) error
+	// Resubmit stores the details completed by the applicant and puts the application back in the queue
+	Resubmit(ctx context.Context, application *EntrepreneurApplication) error
+	GetByID(ctx context.Context, id uuid.UUID) (*EntrepreneurApplication, error)
+	GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*EntrepreneurApplication, error)
+	List(ctx context.Context, filter *EntrepreneurApplicationFilters) ([]*EntrepreneurApplication, error)
+	Count(ctx context.Context, filter *EntrepreneurApplicationFilters) (int, error)
+	// Review records the decision on an open application. On approval the user is flagged as an
+	// entrepreneur in the same transaction, and given the entrepreneur role when assignRole is set.
+	Review(ctx context.Context, application *EntrepreneurApplication, assignRole bool) error
+}
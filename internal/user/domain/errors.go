@@ -1,6 +1,9 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // Authentication errors
 var (
@@ -11,6 +14,83 @@ var (
 	ErrEmailNotVerified   = errors.New("email address is not verified")
 )
 
+// Email verification errors
+var (
+	ErrTooManyVerificationEmails = errors.New("too many verification emails requested")
+)
+
+// Magic link errors
+var (
+	ErrMagicLinkDisabled = errors.New("magic link login is disabled")
+	ErrInvalidMagicLink  = errors.New("invalid or expired magic link")
+	ErrTooManyMagicLinks = errors.New("too many magic links requested")
+)
+
+// External identity errors
+var (
+	ErrIdentityProviderNotFound = errors.New("identity provider not found")
+	ErrInvalidOIDCState         = errors.New("invalid or expired sign-in state")
+	ErrOIDCLoginFailed          = errors.New("identity provider sign-in failed")
+	ErrOIDCEmailNotVerified     = errors.New("identity provider did not verify the email address")
+	ErrInvalidRegistrationToken = errors.New("invalid or expired registration token")
+	ErrUserIdentityNotFound     = errors.New("user identity not found")
+)
+
+// Passkey errors
+var (
+	ErrPasskeyNotFound          = errors.New("passkey not found")
+	ErrPasskeyAlreadyRegistered = errors.New("passkey is already registered")
+	ErrInvalidPasskey           = errors.New("passkey could not be verified")
+	ErrInvalidPasskeyChallenge  = errors.New("invalid or expired passkey challenge")
+)
+
+// Role errors
+var (
+	// ErrRoleNotAssignable is returned when the caller may not grant the role, or change the user's role
+	ErrRoleNotAssignable = errors.New("role cannot be assigned by the caller")
+)
+
+// Login throttling errors
+var (
+	ErrTooManyLoginAttempts = errors.New("too many failed login attempts")
+	ErrAccountLocked        = errors.New("account is temporarily locked")
+	ErrInvalidUnlockToken   = errors.New("invalid or expired unlock token")
+)
+
+// LoginThrottledError rejects a login attempt until RetryAfter has passed.
+// It wraps ErrTooManyLoginAttempts or ErrAccountLocked.
+type LoginThrottledError struct {
+	Err        error
+	RetryAfter time.Duration
+}
+
+func (e *LoginThrottledError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *LoginThrottledError) Unwrap() error {
+	return e.Err
+}
+
+// Refresh token errors
+var (
+	ErrInvalidRefreshToken = errors.New("invalid or expired refresh token")
+	ErrRefreshTokenReused  = errors.New("refresh token has already been used")
+)
+
+// Two-factor authentication errors
+var (
+	ErrTwoFactorNotEnrolled      = errors.New("two-factor authentication is not enrolled")
+	ErrTwoFactorAlreadyEnabled   = errors.New("two-factor authentication is already enabled")
+	ErrInvalidTwoFactorCode      = errors.New("invalid two-factor authentication code")
+	ErrInvalidTwoFactorChallenge = errors.New("invalid or expired two-factor challenge")
+)
+
+// Session errors
+var (
+	ErrSessionNotFound = errors.New("session not found or revoked")
+)
+
 // User management errors
 var (
 	ErrUserAlreadyExists        = errors.New("user already exists")
@@ -26,6 +106,35 @@ var (
 	ErrUserNotUpdated           = errors.New("user was not updated")
 )
 
+// Account deletion errors
+var (
+	ErrDeletionScheduled    = errors.New("account deletion is already scheduled")
+	ErrDeletionNotScheduled = errors.New("account deletion is not scheduled")
+	ErrSharedBusinessOwner  = errors.New("user owns a business run with other members")
+)
+
+// Catholic attestation errors
+var (
+	ErrAttestationNotFound        = errors.New("catholic attestation not found")
+	ErrAttestationPending         = errors.New("a catholic attestation is already awaiting review")
+	ErrAttestationAlreadyReviewed = errors.New("catholic attestation was already reviewed")
+	ErrAlreadyCatholic            = errors.New("user is already recognised as catholic")
+	ErrInvalidAttestationStatus   = errors.New("invalid catholic attestation status")
+	ErrAttestationSelfReview      = errors.New("catholic attestation cannot be reviewed by its own user")
+)
+
+// Entrepreneur application errors
+var (
+	ErrApplicationNotFound        = errors.New("entrepreneur application not found")
+	ErrApplicationOpen            = errors.New("an entrepreneur application is already open")
+	ErrApplicationClosed          = errors.New("entrepreneur application was already decided")
+	ErrApplicationNotAwaitingInfo = errors.New("entrepreneur application is not awaiting more information")
+	ErrAlreadyEntrepreneur        = errors.New("user is already an entrepreneur")
+	ErrInvalidApplicationStatus   = errors.New("invalid entrepreneur application status")
+	ErrInvalidApplicationDecision = errors.New("invalid entrepreneur application decision")
+	ErrApplicationSelfReview      = errors.New("entrepreneur application cannot be reviewed by its applicant")
+)
+
 // Password errors
 var (
 	ErrPasswordTooShort   = errors.New("password is too short")
@@ -34,6 +143,7 @@ var (
 	ErrSamePassword       = errors.New("new password cannot be the same as old password")
 	ErrPasswordHashFailed = errors.New("failed to hash password")
 	ErrInvalidOldPassword = errors.New("old password is incorrect")
+	ErrPasswordReused     = errors.New("new password was used recently")
 )
 
 // Validation errors
@@ -51,6 +161,11 @@ var (
 	ErrRoleNotFound            = errors.New("role not found")
 )
 
+// Impersonation errors
+var (
+	ErrCannotImpersonate = errors.New("user cannot be impersonated")
+)
+
 // Profile errors
 var (
 	ErrFieldOfWorkNotFound = errors.New("field of work not found")
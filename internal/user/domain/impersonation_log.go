@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ImpersonationLog corresponds to the "impersonation_logs" table.
+// Each row records a request an admin made while impersonating a user.
+type ImpersonationLog struct {
+	ID         uuid.UUID     `json:"id" db:"id"`
+	AdminID    uuid.NullUUID `json:"admin_id" db:"admin_id"`
+	UserID     uuid.NullUUID `json:"user_id" db:"user_id"`
+	SessionID  string        `json:"session_id" db:"session_id"`
+	Method     string        `json:"method" db:"method"`
+	Path       string        `json:"path" db:"path"`
+	StatusCode int           `json:"status_code" db:"status_code"`
+	Blocked    bool          `json:"blocked" db:"blocked"`
+	IPAddress  string        `json:"ip_address" db:"ip_address"`
+	CreatedAt  time.Time     `json:"created_at" db:"created_at"`
+}
+
+// ImpersonationLogFilters defines criteria for filtering the impersonation audit log.
+type ImpersonationLogFilters struct {
+	AdminID *uuid.UUID `json:"admin_id,omitempty"`
+	UserID  *uuid.UUID `json:"user_id,omitempty"`
+	Blocked *bool      `json:"blocked"`
+
+	// Pagination
+	Limit  *int `json:"limit"`
+	Offset *int `json:"offset"`
+}
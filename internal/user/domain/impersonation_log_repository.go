@@ -0,0 +1,11 @@
+package domain
+
+import (
+	"context"
+)
+
+type ImpersonationLogRepository interface {
+	Create(ctx context.Context, log *ImpersonationLog) error
+	List(ctx context.Context, filter *ImpersonationLogFilters) ([]*ImpersonationLog, error)
+	Count(ctx context.Context, filter *ImpersonationLogFilters) (int, error)
+}
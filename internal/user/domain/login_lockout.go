@@ -0,0 +1,46 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// LockoutScope tells what a lockout was keyed on.
+type LockoutScope string
+
+const (
+	LockoutScopeAccount LockoutScope = "account"
+	LockoutScopeIP      LockoutScope = "ip"
+)
+
+// LoginLockout corresponds to the "login_lockouts" table.
+// Each row records a lockout triggered by repeated failed logins.
+type LoginLockout struct {
+	ID             uuid.UUID      `json:"id" db:"id"`
+	UserID         *uuid.UUID     `json:"user_id,omitempty" db:"user_id"`
+	Scope          LockoutScope   `json:"scope" db:"scope"`
+	Email          sql.NullString `json:"email" db:"email"`
+	IPAddress      string         `json:"ip_address" db:"ip_address"`
+	FailedAttempts int            `json:"failed_attempts" db:"failed_attempts"`
+	LockedUntil    time.Time      `json:"locked_until" db:"locked_until"`
+	UnlockedAt     sql.NullTime   `json:"unlocked_at" db:"unlocked_at"`
+	CreatedAt      time.Time      `json:"created_at" db:"created_at"`
+}
+
+// LoginLockoutFilters defines criteria for filtering lockout events.
+type LoginLockoutFilters struct {
+	UserID    *uuid.UUID    `json:"user_id,omitempty"`
+	Scope     *LockoutScope `json:"scope"`
+	Email     *string       `json:"email"`
+	IPAddress *string       `json:"ip_address"`
+
+	// ChurchScope limits the list to the accounts of the people an admin with a scope may manage,
+	// it is never read from requests
+	ChurchScope *ChurchScope `json:"-"`
+
+	// Pagination
+	Limit  *int `json:"limit"`
+	Offset *int `json:"offset"`
+}
@@ -0,0 +1,12 @@
+package domain
+
+import (
+	"context"
+)
+
+type LoginLockoutRepository interface {
+	Create(ctx context.Context, lockout *LoginLockout) error
+	MarkUnlocked(ctx context.Context, email string) error
+	List(ctx context.Context, filter *LoginLockoutFilters) ([]*LoginLockout, error)
+	Count(ctx context.Context, filter *LoginLockoutFilters) (int, error)
+}
@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Passkey corresponds to the "user_passkeys" table.
+// Each row is a WebAuthn credential the user registered from one of their authenticators.
+type Passkey struct {
+	ID           uuid.UUID    `json:"id" db:"id"`
+	UserID       uuid.UUID    `json:"-" db:"user_id"`
+	CredentialID []byte       `json:"-" db:"credential_id"`
+	PublicKey    []byte       `json:"-" db:"public_key"`
+	SignCount    int64        `json:"-" db:"sign_count"`
+	Name         string       `json:"name" db:"name"`
+	LastUsedAt   sql.NullTime `json:"last_used_at" db:"last_used_at"`
+	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,19 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type PasskeyRepository interface {
+	Create(ctx context.Context, passkey *Passkey) error
+	GetByCredentialID(ctx context.Context, credentialID []byte) (*Passkey, error)
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]*Passkey, error)
+	CountByUserID(ctx context.Context, userID uuid.UUID) (int, error)
+	// UpdateSignCount stores the authenticator counter of the latest login and marks the passkey as used
+	UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error
+	// Rename and Delete only act on a passkey of the given user
+	Rename(ctx context.Context, id, userID uuid.UUID, name string) error
+	Delete(ctx context.Context, id, userID uuid.UUID) error
+}
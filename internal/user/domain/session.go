@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session represents a signed-in device. Sessions are kept in the cache, keyed by
+// the user ID and the "jti" claim of the access tokens issued for them.
+type Session struct {
+	ID         string    `json:"id" redis:"id"`
+	UserID     uuid.UUID `json:"-" redis:"-"`
+	Device     string    `json:"device" redis:"device"`
+	IPAddress  string    `json:"ip_address" redis:"ip_address"`
+	CreatedAt  time.Time `json:"created_at" redis:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at" redis:"last_seen_at"`
+	TwoFactor  bool      `json:"two_factor" redis:"two_factor"`
+	Current    bool      `json:"current" redis:"-"`
+}
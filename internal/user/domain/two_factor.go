@@ -0,0 +1,32 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TwoFactor corresponds to the "user_two_factor" table.
+type TwoFactor struct {
+	UserID       uuid.UUID    `json:"-" db:"user_id"`
+	Secret       string       `json:"-" db:"secret"`
+	LastUsedStep int64        `json:"-" db:"last_used_step"`
+	EnabledAt    sql.NullTime `json:"enabled_at" db:"enabled_at"`
+	CreatedAt    time.Time    `json:"-" db:"created_at"`
+	UpdatedAt    time.Time    `json:"-" db:"updated_at"`
+}
+
+// IsEnabled reports whether the enrollment was confirmed with a valid code.
+func (t *TwoFactor) IsEnabled() bool {
+	return t.EnabledAt.Valid
+}
+
+// RecoveryCode corresponds to the "user_recovery_codes" table.
+type RecoveryCode struct {
+	ID        uuid.UUID    `json:"id" db:"id"`
+	UserID    uuid.UUID    `json:"-" db:"user_id"`
+	CodeHash  []byte       `json:"-" db:"code_hash"`
+	UsedAt    sql.NullTime `json:"used_at" db:"used_at"`
+	CreatedAt time.Time    `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,25 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type TwoFactorRepository interface {
+	UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error
+	// Upsert stores a pending enrollment, replacing any previous one
+	Upsert(ctx context.Context, twoFactor *TwoFactor) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) (*TwoFactor, error)
+	// Enable confirms the enrollment and raises the users.is_two_factor_enabled flag
+	Enable(tx *sqlx.Tx, userID uuid.UUID) error
+	// Delete removes the enrollment and clears the users.is_two_factor_enabled flag
+	Delete(tx *sqlx.Tx, userID uuid.UUID) error
+	// UseStep records an accepted time step, returning false if it is not newer than the last one
+	UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	ReplaceRecoveryCodes(tx *sqlx.Tx, userID uuid.UUID, codeHashes [][]byte) error
+	// UseRecoveryCode consumes an unused recovery code, returning false if none matched
+	UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) (bool, error)
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
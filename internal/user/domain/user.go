@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"time"
 
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
@@ -12,11 +13,16 @@ type UserProperty string
 
 const (
 	RoleID         UserProperty = "role_id"
+	Email          UserProperty = "email"
 	Password       UserProperty = "password"
 	IsActive       UserProperty = "is_active"
 	IsVerified     UserProperty = "is_verified"
 	IsCatholic     UserProperty = "is_catholic"
 	IsEntrepreneur UserProperty = "is_entrepreneur"
+	// IsTwoFactorEnabled is only changed alongside the user_two_factor row, see TwoFactorRepository
+	IsTwoFactorEnabled UserProperty = "is_two_factor_enabled"
+	// DeletionScheduledAt is set when the user asks for their account to be deleted, see UserRepository.Delete
+	DeletionScheduledAt UserProperty = "deletion_scheduled_at"
 )
 
 // Role corresponds to the "roles" table.
@@ -28,24 +34,35 @@ type Role struct {
 
 // User corresponds to the "users" table.
 type User struct {
-	ID               uuid.UUID      `json:"id" db:"id"`
-	RoleID           int16          `json:"role_id" db:"role_id"`
-	AddressID        uuid.UUID      `json:"address_id" db:"address_id"`
-	ChurchID         uuid.UUID      `json:"church_id" db:"church_id"`
-	FirstName        string         `json:"first_name" db:"first_name"`
-	LastName         string         `json:"last_name" db:"last_name"`
-	Email            string         `json:"email" db:"email"`
-	Password         []byte         `json:"-" db:"password"`
-	DocumentID       string         `json:"document_id" db:"document_id"`
-	PhoneCountryCode sql.NullString `json:"phone_country_code" db:"phone_country_code"`
-	PhoneNumber      sql.NullString `json:"phone_number" db:"phone_number"`
-	Language         sql.NullString `json:"language" db:"language"`
-	IsActive         bool           `json:"is_active" db:"is_active"`
-	IsVerified       bool           `json:"is_verified" db:"is_verified"`
-	IsCatholic       bool           `json:"is_catholic" db:"is_catholic"`
-	IsEntrepreneur   bool           `json:"is_entrepreneur" db:"is_entrepreneur"`
-	CreatedAt        time.Time      `json:"-" db:"created_at"`
-	UpdatedAt        time.Time      `json:"-" db:"updated_at"`
+	ID                 uuid.UUID      `json:"id" db:"id"`
+	RoleID             int16          `json:"role_id" db:"role_id"`
+	AddressID          uuid.UUID      `json:"address_id" db:"address_id"`
+	ChurchID           uuid.UUID      `json:"church_id" db:"church_id"`
+	FirstName          string         `json:"first_name" db:"first_name"`
+	LastName           string         `json:"last_name" db:"last_name"`
+	Email              string         `json:"email" db:"email"`
+	Password           []byte         `json:"-" db:"password"`
+	DocumentID         string         `json:"document_id" db:"document_id"`
+	PhoneCountryCode   sql.NullString `json:"phone_country_code" db:"phone_country_code"`
+	PhoneNumber        sql.NullString `json:"phone_number" db:"phone_number"`
+	Language           sql.NullString `json:"language" db:"language"`
+	IsActive           bool           `json:"is_active" db:"is_active"`
+	IsVerified         bool           `json:"is_verified" db:"is_verified"`
+	IsCatholic         bool           `json:"is_catholic" db:"is_catholic"`
+	IsEntrepreneur     bool           `json:"is_entrepreneur" db:"is_entrepreneur"`
+	IsTwoFactorEnabled bool           `json:"is_two_factor_enabled" db:"is_two_factor_enabled"`
+	// DeletionScheduledAt is when the account is purged, unless the user cancels the deletion before
+	DeletionScheduledAt sql.NullTime `json:"deletion_scheduled_at" db:"deletion_scheduled_at"`
+	// DocumentIDIndex is the keyed hash DocumentID is looked up by, since the stored ID is encrypted
+	DocumentIDIndex sql.NullString `json:"-" db:"document_id_index"`
+	CreatedAt       time.Time      `json:"-" db:"created_at"`
+	UpdatedAt       time.Time      `json:"-" db:"updated_at"`
+}
+
+// ChurchScope narrows a list to the people of a church, or of every church of a diocese when no church is set
+type ChurchScope struct {
+	ChurchID uuid.NullUUID
+	Diocese  sql.NullString
 }
 
 // UserFilters defines the structured criteria for filtering users.
@@ -60,7 +77,11 @@ type UserFilters struct {
 	EmailContains  *string `json:"email_contains"` // For LIKE queries
 	NameContains   *string `json:"name_contains"`  // For LIKE queries on first/last name
 
-	// Pagination
-	Limit  *int `json:"limit"`
-	Offset *int `json:"offset"`
+	// Scope limits the list to the people an admin with a scope may manage, it is never read from requests
+	Scope *ChurchScope `json:"-"`
+
+	// Pagination, by offset or, once a cursor is given, by cursor
+	Limit  *int               `json:"limit"`
+	Offset *int               `json:"offset"`
+	Cursor *pagination.Cursor `json:"cursor"`
 }
@@ -0,0 +1,20 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// UserIdentity corresponds to the "user_identities" table.
+// Each row links a user to their account at an OpenID Connect provider.
+type UserIdentity struct {
+	ID          uuid.UUID      `json:"id" db:"id"`
+	UserID      uuid.UUID      `json:"-" db:"user_id"`
+	Provider    string         `json:"provider" db:"provider"`
+	Subject     string         `json:"-" db:"subject"`
+	Email       sql.NullString `json:"email" db:"email"`
+	LastLoginAt sql.NullTime   `json:"last_login_at" db:"last_login_at"`
+	CreatedAt   time.Time      `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,15 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+type UserIdentityRepository interface {
+	Create(tx *sqlx.Tx, identity *UserIdentity) error
+	GetByProviderSubject(ctx context.Context, provider, subject string) (*UserIdentity, error)
+	// UpdateLastLogin records a sign-in through the identity
+	UpdateLastLogin(ctx context.Context, id uuid.UUID) error
+}
@@ -2,6 +2,7 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -12,7 +13,12 @@ type UserRepository interface {
 	Create(tx *sqlx.Tx, user *User) error
 	Update(tx *sqlx.Tx, user *User) error
 	UpdateProperty(ctx context.Context, id uuid.UUID, property UserProperty, value any) error
+	UpdatePassword(ctx context.Context, id uuid.UUID, password []byte) error
+	GetPasswordHistory(ctx context.Context, id uuid.UUID, limit int) ([][]byte, error)
+	// Delete purges the user, their address and their personal data, anonymising the records that are kept
 	Delete(ctx context.Context, id uuid.UUID) error
+	// OwnsSharedBusiness reports whether the user owns a business with other active members
+	OwnsSharedBusiness(ctx context.Context, id uuid.UUID) (bool, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByDocumentID(ctx context.Context, documentID string) (*User, error)
@@ -21,6 +27,7 @@ type UserRepository interface {
 	GetAllByIsVerified(ctx context.Context, isVerified bool) ([]*User, error)
 	GetAllByIsCatholic(ctx context.Context, isCatholic bool) ([]*User, error)
 	GetAllByIsEntrepreneur(ctx context.Context, isEntrepreneur bool) ([]*User, error)
+	GetAllDueForDeletion(ctx context.Context, before time.Time) ([]*User, error)
 	List(ctx context.Context, filter *UserFilters) ([]*User, error)
 	Count(ctx context.Context, filter *UserFilters) (int, error)
 }
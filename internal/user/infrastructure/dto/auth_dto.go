@@ -3,6 +3,8 @@ package dto
 import (
 	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
 	adminDto "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
 	"github.com/google/uuid"
 )
 
@@ -28,7 +30,83 @@ type UserLoginRequest struct {
 }
 
 type UserLoginResponse struct {
-	Token string `json:"token"`
+	Token     string `json:"token,omitempty"`
+	ExpiresIn int64  `json:"expires_in,omitempty"`
+	// RefreshToken is delivered through the refresh token cookie, never in the body
+	RefreshToken string `json:"-"`
+	// Set instead of the tokens when the password step succeeded but a second factor is required
+	TwoFactorRequired bool   `json:"two_factor_required,omitempty"`
+	ChallengeToken    string `json:"challenge_token,omitempty"`
+	// TwoFactorMethods lists the ways the challenge can be answered: "totp" and "passkey"
+	TwoFactorMethods []string `json:"two_factor_methods,omitempty"`
+}
+
+type TwoFactorEnrollResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type TwoFactorRecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// TwoFactorVerifyRequest completes a login challenge with either a TOTP code or a recovery code
+type TwoFactorVerifyRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	Code           string `json:"code"`
+	RecoveryCode   string `json:"recovery_code"`
+}
+
+// PasskeyRegisterRequest finishes a registration with the credential created by the browser
+type PasskeyRegisterRequest struct {
+	Name       string                   `json:"name"`
+	Credential auth.WebAuthnAttestation `json:"credential"`
+}
+
+type PasskeyRenameRequest struct {
+	Name string `json:"name"`
+}
+
+// PasskeyLoginOptionsResponse starts a passkey login, Token is sent back with the signed credential
+type PasskeyLoginOptionsResponse struct {
+	Token     string                       `json:"token"`
+	PublicKey *auth.WebAuthnRequestOptions `json:"public_key"`
+}
+
+type PasskeyLoginRequest struct {
+	Token      string                 `json:"token"`
+	Credential auth.WebAuthnAssertion `json:"credential"`
+}
+
+// PasskeyTwoFactorRequest completes a login challenge with a passkey instead of a TOTP code
+type PasskeyTwoFactorRequest struct {
+	ChallengeToken string                 `json:"challenge_token"`
+	Credential     auth.WebAuthnAssertion `json:"credential"`
+}
+
+// ClientInfo describes the device a session is opened from
+type ClientInfo struct {
+	Device    string
+	IPAddress string
+}
+
+// UserChangePasswordRequest changes the password of a signed-in user
+type UserChangePasswordRequest struct {
+	ID              uuid.UUID `json:"-"`
+	OldPassword     string    `json:"old_password"`
+	NewPassword     string    `json:"new_password"`
+	ConfirmPassword string    `json:"confirm_password"`
+}
+
+// UserChangeEmailRequest starts an email change, confirmed from a link sent to the new address
+type UserChangeEmailRequest struct {
+	ID       uuid.UUID `json:"-"`
+	NewEmail string    `json:"new_email"`
+	Password string    `json:"password"`
 }
 
 type UserResetPasswordRequest struct {
@@ -36,3 +114,79 @@ type UserResetPasswordRequest struct {
 	Email       string    `json:"email,omitempty"`
 	NewPassword string    `json:"new_password,omitempty"`
 }
+
+// UserResendVerificationRequest asks for a new email verification link
+type UserResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// MagicLinkRequest asks for a sign-in link to be emailed instead of logging in with a password
+type MagicLinkRequest struct {
+	Email string `json:"email"`
+}
+
+// OIDCAuthorizationResponse holds the provider page the client sends the user to
+type OIDCAuthorizationResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+}
+
+// OIDCCallbackRequest carries the parameters the provider redirected the user back with
+type OIDCCallbackRequest struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// OIDCLoginResponse is the Login response when the identity belongs to a user. Otherwise the
+// user has to complete their profile, prefilled with what the provider told about them.
+type OIDCLoginResponse struct {
+	*UserLoginResponse
+	ProfileCompletionRequired bool   `json:"profile_completion_required,omitempty"`
+	RegistrationToken         string `json:"registration_token,omitempty"`
+	Email                     string `json:"email,omitempty"`
+	FirstName                 string `json:"first_name,omitempty"`
+	LastName                  string `json:"last_name,omitempty"`
+}
+
+// OIDCCompleteProfileRequest registers the user behind a provider identity with the fields the
+// provider does not supply. The names default to the ones given by the provider.
+type OIDCCompleteProfileRequest struct {
+	RegistrationToken string                        `json:"registration_token"`
+	FirstName         string                        `json:"first_name"`
+	LastName          string                        `json:"last_name"`
+	DocumentID        string                        `json:"document_id"`
+	PhoneCountryCode  string                        `json:"phone_country_code"`
+	PhoneNumber       string                        `json:"phone_number"`
+	Address           adminDto.AddressCreateRequest `json:"address"`
+	ChurchID          uuid.UUID                     `json:"church_id"`
+	// JobProfile
+	OpenToWork   bool                      `json:"open_to_work"`
+	CVPath       string                    `json:"cv_path"`
+	FieldsOfWork []adminDomain.FieldOfWork `json:"fields_of_work"`
+}
+
+type LoginLockoutListRequest = domain.LoginLockoutFilters
+
+type LoginLockoutListResponse struct {
+	Lockouts []*domain.LoginLockout `json:"lockouts"`
+	Count    int                    `json:"count"`
+}
+
+type ImpersonationStartRequest struct {
+	AdminID   uuid.UUID
+	UserID    uuid.UUID
+	SessionID string
+}
+
+// ImpersonationStartResponse carries the token to act as the user, it cannot be refreshed
+type ImpersonationStartResponse struct {
+	Token     string       `json:"token"`
+	ExpiresIn int64        `json:"expires_in"`
+	User      *domain.User `json:"user"`
+}
+
+type ImpersonationLogListRequest = domain.ImpersonationLogFilters
+
+type ImpersonationLogListResponse struct {
+	Logs  []*domain.ImpersonationLog `json:"logs"`
+	Count int                        `json:"count"`
+}
@@ -1,18 +1,26 @@
 package dto
 
 import (
+	"time"
+
 	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	entrepreneurDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/entrepreneur/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	"github.com/google/uuid"
 )
 
 type UserAsContext struct {
-	ID             uuid.UUID `json:"id"`
-	Email          string    `json:"email"`
-	RoleID         int16     `json:"role_id"`
-	Language       string    `json:"language"`
-	IsCatholic     bool      `json:"is_catholic"`
-	IsEntrepreneur bool      `json:"is_entrepreneur"`
+	ID                  uuid.UUID `json:"id"`
+	Email               string    `json:"email"`
+	RoleID              int16     `json:"role_id"`
+	Language            string    `json:"language"`
+	IsCatholic          bool      `json:"is_catholic"`
+	IsEntrepreneur      bool      `json:"is_entrepreneur"`
+	SessionID           string    `json:"session_id"`
+	IsTwoFactorVerified bool      `json:"is_two_factor_verified"`
+	// ImpersonatorID is the admin acting as the user, set while impersonating
+	ImpersonatorID uuid.NullUUID `json:"impersonator_id"`
 }
 
 type UserGetResponse struct {
@@ -21,11 +29,23 @@ type UserGetResponse struct {
 	JobProfile              *domain.JobProfile              `json:"job_profile"`
 }
 
+// UserDataExport is the copy of their personal data handed to users on request
+type UserDataExport struct {
+	ExportedAt              time.Time                       `json:"exported_at"`
+	User                    *domain.User                    `json:"user"`
+	Address                 *adminDomain.Address            `json:"address"`
+	NotificationPreferences *domain.NotificationPreferences `json:"notification_preferences"`
+	JobProfile              *domain.JobProfile              `json:"job_profile"`
+	Businesses              []*entrepreneurDomain.Business  `json:"businesses"`
+	Products                []*entrepreneurDomain.Product   `json:"products"`
+	Services                []*entrepreneurDomain.Service   `json:"services"`
+	Jobs                    []*entrepreneurDomain.Job       `json:"jobs"`
+}
+
 type UserUpdateRequest struct {
 	ID               uuid.UUID `json:"id"`
 	FirstName        string    `json:"first_name"`
 	LastName         string    `json:"last_name"`
-	Email            string    `json:"email"`
 	DocumentID       string    `json:"document_id"`
 	PhoneCountryCode string    `json:"phone_country_code"`
 	PhoneNumber      string    `json:"phone_number"`
@@ -51,6 +71,7 @@ type UserListResponse struct {
 	Count  int            `json:"count"`
 	Limit  *int           `json:"limit"`
 	Offset *int           `json:"offset"`
+	pagination.Page
 }
 
 type UserUpdatePropertyRequest struct {
@@ -62,3 +83,50 @@ type UserSetRoleRequest struct {
 	ID     uuid.UUID `json:"-"`
 	RoleID int16     `json:"role_id"`
 }
+
+type CatholicAttestationSubmitRequest struct {
+	UserID            uuid.UUID `json:"-"`
+	ChurchID          uuid.UUID `json:"church_id"`
+	SacramentalRecord string    `json:"sacramental_record"`
+	LetterURL         string    `json:"letter_url"`
+}
+
+type CatholicAttestationReviewRequest struct {
+	ID         uuid.UUID `json:"-"`
+	ReviewerID uuid.UUID `json:"-"`
+	// Approve flags the user as Catholic, otherwise the attestation is rejected
+	Approve bool   `json:"approve"`
+	Comment string `json:"comment"`
+}
+
+type CatholicAttestationListRequest = domain.CatholicAttestationFilters
+
+type CatholicAttestationListResponse struct {
+	Attestations []*domain.CatholicAttestation `json:"attestations"`
+	Count        int                           `json:"count"`
+}
+
+type EntrepreneurApplicationRequest struct {
+	ID                  uuid.UUID `json:"-"`
+	UserID              uuid.UUID `json:"-"`
+	BusinessName        string    `json:"business_name"`
+	BusinessDescription string    `json:"business_description"`
+	IndustryID          int16     `json:"industry_id"`
+	References          string    `json:"references"`
+}
+
+type EntrepreneurApplicationReviewRequest struct {
+	ID         uuid.UUID                  `json:"-"`
+	ReviewerID uuid.UUID                  `json:"-"`
+	Decision   domain.ApplicationDecision `json:"decision"`
+	Comment    string                     `json:"comment"`
+	// AssignRole moves an approved applicant to the entrepreneur role
+	AssignRole bool `json:"assign_role"`
+}
+
+type EntrepreneurApplicationListRequest = domain.EntrepreneurApplicationFilters
+
+type EntrepreneurApplicationListResponse struct {
+	Applications []*domain.EntrepreneurApplication `json:"applications"`
+	Count        int                               `json:"count"`
+}
@@ -3,7 +3,10 @@ package http
 import (
 	"encoding/json"
 	"errors"
+	"math"
+	"net"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
@@ -18,18 +21,20 @@ import (
 )
 
 type AuthHandler struct {
-	logger      *zap.SugaredLogger
-	cache       storage.CacheStorage
-	authService *application.AuthService
-	userService *application.UserService
+	logger           *zap.SugaredLogger
+	cache            storage.CacheStorage
+	authService      *application.AuthService
+	userService      *application.UserService
+	twoFactorService *application.TwoFactorService
 }
 
-func NewAuthHandler(logger *zap.SugaredLogger, cache storage.CacheStorage, authService *application.AuthService, userService *application.UserService) *AuthHandler {
+func NewAuthHandler(logger *zap.SugaredLogger, cache storage.CacheStorage, authService *application.AuthService, userService *application.UserService, twoFactorService *application.TwoFactorService) *AuthHandler {
 	return &AuthHandler{
-		logger:      logger,
-		cache:       cache,
-		authService: authService,
-		userService: userService,
+		logger:           logger,
+		cache:            cache,
+		authService:      authService,
+		userService:      userService,
+		twoFactorService: twoFactorService,
 	}
 }
 
@@ -96,13 +101,26 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	token, err := h.authService.Login(ctx, &req)
+	tokens, err := h.authService.Login(ctx, &req, clientInfo(r))
 	if err != nil {
-		if errors.Is(err, domain.ErrUserInactive) {
+		var throttled *domain.LoginThrottledError
+		if errors.As(err, &throttled) {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(throttled.RetryAfter.Seconds()))))
+			if errors.Is(err, domain.ErrAccountLocked) {
+				response.TooManyRequestsT(ctx, w, "error.account_locked")
+				return
+			}
+
+			response.TooManyRequestsT(ctx, w, "error.too_many_login_attempts")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
 			response.ForbiddenT(ctx, w, "error.user_inactive")
 			return
 		} else if errors.Is(err, domain.ErrEmailNotVerified) {
-			response.ForbiddenT(ctx, w, "error.email_not_verified")
+			// Points the client to where a new link can be requested when the first one expired
+			response.ErrorT(ctx, w, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "error.email_not_verified", map[string]string{
+				"resend_verification": "/api/v1/auth/email/verify/resend",
+			})
 			return
 		} else if errors.Is(err, domain.ErrInvalidPassword) || errors.Is(err, domain.ErrUserNotFound) {
 			response.UnauthorizedT(ctx, w, "error.invalid_credentials")
@@ -114,12 +132,226 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// TODO: Set refresh token cookie logic here
-	// auth.SetRefreshTokenCookie(w, user.ID.String())
+	if tokens.TwoFactorRequired {
+		response.OKT(ctx, w, "success.two_factor_required", tokens)
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", tokens)
+}
+
+// RequestMagicLink emails a sign-in link, answering the same way whether or not the email
+// belongs to an account that can sign in
+func (h *AuthHandler) RequestMagicLink(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.MagicLinkRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if err := auth.IsValidEmail(req.Email); err != nil {
+		response.BadRequestT(ctx, w, "error.valid_email_required", nil)
+		return
+	}
+
+	if err := h.authService.RequestMagicLink(ctx, req.Email); err != nil {
+		if errors.Is(err, domain.ErrMagicLinkDisabled) {
+			response.NotFoundT(ctx, w, "error.magic_link_disabled")
+			return
+		} else if errors.Is(err, domain.ErrTooManyMagicLinks) {
+			response.TooManyRequestsT(ctx, w, "error.too_many_magic_links")
+			return
+		}
 
-	response.OKT(ctx, w, "success.login", dto.UserLoginResponse{
-		Token: token,
-	})
+		h.logger.Errorw("Failed to send magic link", "email", req.Email, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_send_magic_link")
+		return
+	}
+
+	response.OKT(ctx, w, "success.magic_link_sent", nil)
+}
+
+// MagicLinkLogin signs the user in with the token of a magic link, answering like Login
+func (h *AuthHandler) MagicLinkLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		response.BadRequestT(ctx, w, "error.missing_token", nil)
+		return
+	}
+
+	tokens, err := h.authService.LoginWithMagicLink(ctx, token, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrMagicLinkDisabled) {
+			response.NotFoundT(ctx, w, "error.magic_link_disabled")
+			return
+		} else if errors.Is(err, domain.ErrInvalidMagicLink) {
+			response.UnauthorizedT(ctx, w, "error.invalid_magic_link")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		}
+
+		h.logger.Errorw("Failed to login with magic link", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	if tokens.TwoFactorRequired {
+		response.OKT(ctx, w, "success.two_factor_required", tokens)
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", tokens)
+}
+
+// VerifyTwoFactor completes a login started with a password by presenting the second factor
+func (h *AuthHandler) VerifyTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.TwoFactorVerifyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	if req.Code == "" && req.RecoveryCode == "" {
+		response.BadRequestT(ctx, w, "error.two_factor_code_required", nil)
+		return
+	}
+
+	tokens, err := h.authService.VerifyTwoFactor(ctx, &req, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTwoFactorChallenge) || errors.Is(err, domain.ErrTwoFactorNotEnrolled) {
+			response.UnauthorizedT(ctx, w, "error.invalid_two_factor_challenge")
+			return
+		} else if errors.Is(err, domain.ErrInvalidTwoFactorCode) {
+			response.UnauthorizedT(ctx, w, "error.invalid_two_factor_code")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		}
+
+		h.logger.Errorw("Failed to verify two-factor challenge", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", tokens)
+}
+
+// EnrollTwoFactor starts a TOTP enrollment for the authenticated user
+func (h *AuthHandler) EnrollTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	enrollment, err := h.twoFactorService.Enroll(ctx, userCtx.ID, userCtx.Email)
+	if err != nil {
+		if errors.Is(err, domain.ErrTwoFactorAlreadyEnabled) {
+			response.ConflictT(ctx, w, "error.two_factor_already_enabled", nil)
+			return
+		}
+
+		h.logger.Errorw("Failed to enroll two-factor authentication", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_enroll_two_factor")
+		return
+	}
+
+	response.OKT(ctx, w, "success.two_factor_enrolled", enrollment)
+}
+
+// EnableTwoFactor confirms the pending enrollment and returns the recovery codes
+func (h *AuthHandler) EnableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	var req dto.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	codes, err := h.twoFactorService.Enable(ctx, userCtx.ID, req.Code)
+	if err != nil {
+		if !h.handleTwoFactorError(w, r, err) {
+			h.logger.Errorw("Failed to enable two-factor authentication", "userID", userCtx.ID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_enable_two_factor")
+		}
+		return
+	}
+
+	response.OKT(ctx, w, "success.two_factor_enabled", codes)
+}
+
+// DisableTwoFactor removes the second factor of the authenticated user
+func (h *AuthHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	var req dto.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	if err := h.twoFactorService.Disable(ctx, userCtx.ID, req.Code); err != nil {
+		if !h.handleTwoFactorError(w, r, err) {
+			h.logger.Errorw("Failed to disable two-factor authentication", "userID", userCtx.ID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_disable_two_factor")
+		}
+		return
+	}
+
+	response.OKT(ctx, w, "success.two_factor_disabled", nil)
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of the authenticated user
+func (h *AuthHandler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	var req dto.TwoFactorCodeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	codes, err := h.twoFactorService.RegenerateRecoveryCodes(ctx, userCtx.ID, req.Code)
+	if err != nil {
+		if !h.handleTwoFactorError(w, r, err) {
+			h.logger.Errorw("Failed to regenerate recovery codes", "userID", userCtx.ID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_regenerate_recovery_codes")
+		}
+		return
+	}
+
+	response.OKT(ctx, w, "success.recovery_codes_regenerated", codes)
+}
+
+// handleTwoFactorError writes the response for the domain errors shared by the enrollment endpoints
+func (h *AuthHandler) handleTwoFactorError(w http.ResponseWriter, r *http.Request, err error) bool {
+	ctx := r.Context()
+	switch {
+	case errors.Is(err, domain.ErrTwoFactorNotEnrolled):
+		response.BadRequestT(ctx, w, "error.two_factor_not_enrolled", nil)
+	case errors.Is(err, domain.ErrTwoFactorAlreadyEnabled):
+		response.ConflictT(ctx, w, "error.two_factor_already_enabled", nil)
+	case errors.Is(err, domain.ErrInvalidTwoFactorCode):
+		response.BadRequestT(ctx, w, "error.invalid_two_factor_code", nil)
+	default:
+		return false
+	}
+
+	return true
 }
 
 // RequestPasswordReset handles the initial password reset request (sends email with reset link)
@@ -244,6 +476,8 @@ func (h *AuthHandler) ConfirmPasswordReset(w http.ResponseWriter, r *http.Reques
 	response.OKT(ctx, w, "success.password_updated", nil)
 }
 
+// VerifyEmail confirms an email address with the token from the verification email,
+// either the one given at registration or a new one requested with RequestEmailChange
 func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 	token := chi.URLParam(r, "token")
@@ -252,7 +486,7 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := h.cache.GetStringAndDel(ctx, h.cache.BuildKey(storage.CACHE_PREFIX_EMAIL_VERIFICATION, token))
+	value, err := h.cache.GetStringAndDel(ctx, h.cache.BuildKey(storage.CACHE_PREFIX_EMAIL_VERIFICATION, token))
 	if err != nil {
 		if errors.Is(err, storage.ErrCacheMiss) {
 			response.BadRequestT(ctx, w, "error.invalid_token", nil)
@@ -264,12 +498,32 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	id, newEmail, isEmailChange := strings.Cut(value, ":")
 	userID, err := uuid.Parse(id)
 	if err != nil {
 		response.BadRequestT(ctx, w, "error.invalid_user_id_in_token", nil)
 		return
 	}
 
+	if isEmailChange {
+		if err := h.authService.ConfirmEmailChange(ctx, userID, newEmail); err != nil {
+			if errors.Is(err, domain.ErrUserNotFound) {
+				response.NotFoundT(ctx, w, "error.user_not_found")
+				return
+			} else if errors.Is(err, domain.ErrEmailAlreadyExists) {
+				response.ConflictT(ctx, w, "error.email_already_exists", nil)
+				return
+			}
+
+			h.logger.Errorw("Failed to change email", "userID", userID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_change_email")
+			return
+		}
+
+		response.OKT(ctx, w, "success.email_changed", nil)
+		return
+	}
+
 	if err := h.userService.VerifyEmail(ctx, userID); err != nil {
 		if errors.Is(err, domain.ErrUserNotFound) {
 			response.NotFoundT(ctx, w, "error.user_not_found")
@@ -284,7 +538,236 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	response.OKT(ctx, w, "success.email_verified", nil)
 }
 
-// TODO: Set proper refresh token expiration and security flags
+// ChangePassword replaces the password of the authenticated user, signing out their other sessions
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	if userID != userCtx.ID {
+		response.ForbiddenT(ctx, w, "error.unauthorized")
+		return
+	}
+
+	var req dto.UserChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = userID
+
+	if err := auth.IsStrongPassword(req.NewPassword); err != nil {
+		response.BadRequestT(ctx, w, "error.new_password_strength", nil)
+		return
+	}
+
+	if err := h.authService.ChangePassword(ctx, &req, userCtx.SessionID); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFoundT(ctx, w, "error.user_not_found")
+		case errors.Is(err, domain.ErrInvalidOldPassword):
+			response.UnauthorizedT(ctx, w, "error.old_password_incorrect")
+		case errors.Is(err, domain.ErrPasswordMismatch):
+			response.BadRequestT(ctx, w, "error.password_mismatch", nil)
+		case errors.Is(err, domain.ErrSamePassword):
+			response.BadRequestT(ctx, w, "error.same_password", nil)
+		case errors.Is(err, domain.ErrPasswordReused):
+			response.BadRequestT(ctx, w, "error.password_reused", nil)
+		default:
+			h.logger.Errorw("Failed to change password", "userID", userID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_update_password")
+		}
+		return
+	}
+
+	response.OKT(ctx, w, "success.password_updated", nil)
+}
+
+// RequestEmailChange sends a confirmation link to the new email of the authenticated user
+func (h *AuthHandler) RequestEmailChange(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return
+	}
+
+	if userID != userCtx.ID {
+		response.ForbiddenT(ctx, w, "error.unauthorized")
+		return
+	}
+
+	var req dto.UserChangeEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = userID
+
+	req.NewEmail = strings.TrimSpace(req.NewEmail)
+	if err := auth.IsValidEmail(req.NewEmail); err != nil {
+		response.BadRequestT(ctx, w, "error.valid_email_required", nil)
+		return
+	}
+
+	if err := h.authService.RequestEmailChange(ctx, &req); err != nil {
+		switch {
+		case errors.Is(err, domain.ErrUserNotFound):
+			response.NotFoundT(ctx, w, "error.user_not_found")
+		case errors.Is(err, domain.ErrInvalidPassword):
+			response.UnauthorizedT(ctx, w, "error.invalid_credentials")
+		case errors.Is(err, domain.ErrEmailAlreadyExists):
+			response.ConflictT(ctx, w, "error.email_already_exists", nil)
+		default:
+			h.logger.Errorw("Failed to request email change", "userID", userID, "error", err)
+			response.InternalServerErrorT(ctx, w, "error.failed_change_email")
+		}
+		return
+	}
+
+	response.OKT(ctx, w, "success.email_change_sent", nil)
+}
+
+// ResendVerificationEmail sends a new verification link, answering the same way whether
+// or not the email belongs to an unverified account
+func (h *AuthHandler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.UserResendVerificationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	req.Email = strings.TrimSpace(req.Email)
+	if err := auth.IsValidEmail(req.Email); err != nil {
+		response.BadRequestT(ctx, w, "error.valid_email_required", nil)
+		return
+	}
+
+	if err := h.authService.ResendVerificationEmail(ctx, req.Email); err != nil {
+		if errors.Is(err, domain.ErrTooManyVerificationEmails) {
+			response.TooManyRequestsT(ctx, w, "error.too_many_verification_emails")
+			return
+		}
+
+		h.logger.Errorw("Failed to resend verification email", "email", req.Email, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_send_verification_email")
+		return
+	}
+
+	response.OKT(ctx, w, "success.verification_email_sent", nil)
+}
+
+// UnlockAccount lifts a login lockout with the token from the unlock email
+func (h *AuthHandler) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	token := chi.URLParam(r, "token")
+	if token == "" {
+		response.BadRequestT(ctx, w, "error.missing_token", nil)
+		return
+	}
+
+	if err := h.authService.UnlockAccount(ctx, token); err != nil {
+		if errors.Is(err, domain.ErrInvalidUnlockToken) {
+			response.BadRequestT(ctx, w, "error.invalid_unlock_token", nil)
+			return
+		}
+
+		h.logger.Errorw("Failed to unlock account", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_unlock_account")
+		return
+	}
+
+	response.OKT(ctx, w, "success.account_unlocked", nil)
+}
+
+// Refresh exchanges the refresh token cookie for a new access token, rotating the cookie on success
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
-	response.NotImplementedT(r.Context(), w, "error.not_implemented")
+	ctx := r.Context()
+	refreshToken, err := auth.GetRefreshTokenCookie(r)
+	if err != nil || refreshToken == "" {
+		response.UnauthorizedT(ctx, w, "error.missing_refresh_token")
+		return
+	}
+
+	tokens, err := h.authService.Refresh(ctx, refreshToken, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRefreshToken) || errors.Is(err, domain.ErrRefreshTokenReused) {
+			auth.ClearRefreshTokenCookie(w)
+			response.UnauthorizedT(ctx, w, "error.invalid_refresh_token")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			auth.ClearRefreshTokenCookie(w)
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		}
+
+		h.logger.Errorw("Failed to refresh token", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_refresh_token")
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.token_refreshed", tokens)
+}
+
+// Logout ends the session the request was authenticated with
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	if err := h.authService.Logout(ctx, userCtx.ID, userCtx.SessionID); err != nil {
+		h.logger.Errorw("Failed to logout user", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_logout")
+		return
+	}
+
+	auth.ClearRefreshTokenCookie(w)
+
+	response.OKT(ctx, w, "success.logout", nil)
+}
+
+// LogoutAll ends every session of the authenticated user
+func (h *AuthHandler) LogoutAll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	if err := h.authService.LogoutAll(ctx, userCtx.ID); err != nil {
+		h.logger.Errorw("Failed to logout user from all sessions", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_logout")
+		return
+	}
+
+	auth.ClearRefreshTokenCookie(w)
+
+	response.OKT(ctx, w, "success.logout_all", nil)
+}
+
+// JWKS publishes the token verification keys as a plain JSON Web Key Set, the format
+// JWT libraries expect, so other services can validate access tokens without a secret
+func (h *AuthHandler) JWKS(w http.ResponseWriter, r *http.Request) {
+	// Short enough for a newly added key to be picked up well before it signs anything
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	response.JSON(w, http.StatusOK, h.authService.JWKS())
+}
+
+// clientInfo extracts the device details recorded on sessions
+func clientInfo(r *http.Request) *dto.ClientInfo {
+	ip := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(ip); err == nil {
+		ip = host
+	}
+
+	return &dto.ClientInfo{
+		Device:    r.UserAgent(),
+		IPAddress: ip,
+	}
 }
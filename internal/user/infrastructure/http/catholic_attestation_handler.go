@@ -0,0 +1,79 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"go.uber.org/zap"
+)
+
+type CatholicAttestationHandler struct {
+	logger             *zap.SugaredLogger
+	attestationService *application.CatholicAttestationService
+}
+
+func NewCatholicAttestationHandler(logger *zap.SugaredLogger, attestationService *application.CatholicAttestationService) *CatholicAttestationHandler {
+	return &CatholicAttestationHandler{
+		logger:             logger,
+		attestationService: attestationService,
+	}
+}
+
+// Submit asks the parish of the authenticated user to recognise them as Catholic
+func (h *CatholicAttestationHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	var req dto.CatholicAttestationSubmitRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.UserID = userCtx.ID
+
+	attestation, err := h.attestationService.Submit(ctx, &req)
+	if err != nil {
+		if err == domain.ErrInvalidFieldValue {
+			response.BadRequestT(ctx, w, "error.invalid_attestation", nil)
+			return
+		}
+		if err == adminDomain.ErrChurchNotFound {
+			response.NotFoundT(ctx, w, "error.church_not_found")
+			return
+		}
+		if err == domain.ErrAlreadyCatholic {
+			response.ConflictT(ctx, w, "error.already_catholic", nil)
+			return
+		}
+		if err == domain.ErrAttestationPending {
+			response.ConflictT(ctx, w, "error.attestation_pending", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to submit attestation", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_submit_attestation")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.attestation_submitted", attestation)
+}
+
+// List returns every attestation the authenticated user submitted, with its review
+func (h *CatholicAttestationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	list, err := h.attestationService.List(ctx, &dto.CatholicAttestationListRequest{UserID: &userCtx.ID})
+	if err != nil {
+		h.logger.Errorw("failed to list attestations", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_attestations")
+		return
+	}
+
+	response.OKT(ctx, w, "success.attestations_listed", list)
+}
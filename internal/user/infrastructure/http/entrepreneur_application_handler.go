@@ -0,0 +1,131 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+
+	adminDomain "github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/admin/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type EntrepreneurApplicationHandler struct {
+	logger             *zap.SugaredLogger
+	applicationService *application.EntrepreneurApplicationService
+}
+
+func NewEntrepreneurApplicationHandler(logger *zap.SugaredLogger, applicationService *application.EntrepreneurApplicationService) *EntrepreneurApplicationHandler {
+	return &EntrepreneurApplicationHandler{
+		logger:             logger,
+		applicationService: applicationService,
+	}
+}
+
+// Submit applies for the authenticated user to become an entrepreneur
+func (h *EntrepreneurApplicationHandler) Submit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	var req dto.EntrepreneurApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.UserID = userCtx.ID
+
+	entrepreneurApplication, err := h.applicationService.Submit(ctx, &req)
+	if err != nil {
+		if h.handleApplicationError(w, r, err) {
+			return
+		}
+		if err == domain.ErrAlreadyEntrepreneur {
+			response.ConflictT(ctx, w, "error.already_entrepreneur", nil)
+			return
+		}
+		if err == domain.ErrApplicationOpen {
+			response.ConflictT(ctx, w, "error.application_open", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to submit entrepreneur application", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_submit_application")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.application_submitted", entrepreneurApplication)
+}
+
+// Resubmit completes an application the admins asked more information on
+func (h *EntrepreneurApplicationHandler) Resubmit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_application_id", nil)
+		return
+	}
+
+	var req dto.EntrepreneurApplicationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+	req.ID = id
+	req.UserID = userCtx.ID
+
+	entrepreneurApplication, err := h.applicationService.Resubmit(ctx, &req)
+	if err != nil {
+		if h.handleApplicationError(w, r, err) {
+			return
+		}
+		if err == domain.ErrApplicationNotFound {
+			response.NotFoundT(ctx, w, "error.application_not_found")
+			return
+		}
+		if err == domain.ErrApplicationNotAwaitingInfo {
+			response.ConflictT(ctx, w, "error.application_not_awaiting_info", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to resubmit entrepreneur application", "applicationID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_submit_application")
+		return
+	}
+
+	response.OKT(ctx, w, "success.application_resubmitted", entrepreneurApplication)
+}
+
+// List returns every application the authenticated user made, with its decision
+func (h *EntrepreneurApplicationHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	list, err := h.applicationService.List(ctx, &dto.EntrepreneurApplicationListRequest{UserID: &userCtx.ID})
+	if err != nil {
+		h.logger.Errorw("failed to list entrepreneur applications", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_applications")
+		return
+	}
+
+	response.OKT(ctx, w, "success.applications_listed", list)
+}
+
+// handleApplicationError answers for the validation errors of an application and reports whether it did
+func (h *EntrepreneurApplicationHandler) handleApplicationError(w http.ResponseWriter, r *http.Request, err error) bool {
+	ctx := r.Context()
+	if err == domain.ErrRequiredField || err == domain.ErrInvalidFieldValue {
+		response.BadRequestT(ctx, w, "error.invalid_application", nil)
+		return true
+	}
+	if err == adminDomain.ErrIndustryNotFound {
+		response.NotFoundT(ctx, w, "error.industry_not_found")
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,143 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"go.uber.org/zap"
+)
+
+type OIDCHandler struct {
+	logger      *zap.SugaredLogger
+	oidcService *application.OIDCService
+}
+
+func NewOIDCHandler(logger *zap.SugaredLogger, oidcService *application.OIDCService) *OIDCHandler {
+	return &OIDCHandler{
+		logger:      logger,
+		oidcService: oidcService,
+	}
+}
+
+func (h *OIDCHandler) Authorize(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	provider := chi.URLParam(r, "provider")
+
+	resp, err := h.oidcService.AuthorizationURL(ctx, provider)
+	if err != nil {
+		if errors.Is(err, domain.ErrIdentityProviderNotFound) {
+			response.NotFoundT(ctx, w, "error.identity_provider_not_found")
+			return
+		}
+
+		h.logger.Errorw("Failed to start identity provider sign-in", "provider", provider, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	response.OKT(ctx, w, "success.oidc_authorization", resp)
+}
+
+func (h *OIDCHandler) Callback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	provider := chi.URLParam(r, "provider")
+	var req dto.OIDCCallbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	if req.Code == "" {
+		response.BadRequestT(ctx, w, "error.oidc_code_required", nil)
+		return
+	}
+
+	resp, err := h.oidcService.Callback(ctx, provider, &req, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrIdentityProviderNotFound) {
+			response.NotFoundT(ctx, w, "error.identity_provider_not_found")
+			return
+		} else if errors.Is(err, domain.ErrInvalidOIDCState) {
+			response.UnauthorizedT(ctx, w, "error.invalid_oidc_state")
+			return
+		} else if errors.Is(err, domain.ErrOIDCLoginFailed) {
+			response.UnauthorizedT(ctx, w, "error.oidc_login_failed")
+			return
+		} else if errors.Is(err, domain.ErrOIDCEmailNotVerified) {
+			response.ForbiddenT(ctx, w, "error.oidc_email_not_verified")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		} else if errors.Is(err, domain.ErrEmailNotVerified) {
+			response.ErrorT(ctx, w, http.StatusForbidden, "EMAIL_NOT_VERIFIED", "error.email_not_verified", map[string]string{
+				"resend_verification": "/api/v1/auth/email/verify/resend",
+			})
+			return
+		}
+
+		h.logger.Errorw("Failed to complete identity provider sign-in", "provider", provider, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	if resp.ProfileCompletionRequired {
+		response.OKT(ctx, w, "success.oidc_profile_completion_required", resp)
+		return
+	}
+
+	if resp.TwoFactorRequired {
+		response.OKT(ctx, w, "success.two_factor_required", resp)
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, resp.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", resp)
+}
+
+func (h *OIDCHandler) CompleteProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.OIDCCompleteProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	tokens, err := h.oidcService.CompleteProfile(ctx, &req, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidRegistrationToken) {
+			response.UnauthorizedT(ctx, w, "error.invalid_registration_token")
+			return
+		} else if errors.Is(err, domain.ErrRequiredField) {
+			response.BadRequestT(ctx, w, "error.profile_fields_required", nil)
+			return
+		} else if errors.Is(err, domain.ErrEmailAlreadyExists) {
+			response.ConflictT(ctx, w, "error.email_already_exists", nil)
+			return
+		} else if errors.Is(err, domain.ErrDocumentIDAlreadyExists) {
+			response.ConflictT(ctx, w, "error.document_id_already_exists", nil)
+			return
+		}
+
+		h.logger.Errorw("Failed to complete identity provider registration", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_register_user")
+		return
+	}
+
+	if tokens.TwoFactorRequired {
+		response.OKT(ctx, w, "success.two_factor_required", tokens)
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.user_registered_oidc", tokens)
+}
@@ -0,0 +1,255 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type PasskeyHandler struct {
+	logger         *zap.SugaredLogger
+	passkeyService *application.PasskeyService
+}
+
+func NewPasskeyHandler(logger *zap.SugaredLogger, passkeyService *application.PasskeyService) *PasskeyHandler {
+	return &PasskeyHandler{
+		logger:         logger,
+		passkeyService: passkeyService,
+	}
+}
+
+// BeginRegistration returns the options to create a passkey for the authenticated user
+func (h *PasskeyHandler) BeginRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	options, err := h.passkeyService.BeginRegistration(ctx, userCtx.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to start passkey registration", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_register_passkey")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkey_registration_started", options)
+}
+
+// FinishRegistration stores the passkey created by the browser
+func (h *PasskeyHandler) FinishRegistration(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	var req dto.PasskeyRegisterRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	passkey, err := h.passkeyService.FinishRegistration(ctx, userCtx.ID, &req)
+	if err != nil {
+		if h.handlePasskeyError(w, r, err) {
+			return
+		}
+
+		h.logger.Errorw("Failed to finish passkey registration", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_register_passkey")
+		return
+	}
+
+	response.CreatedT(ctx, w, "success.passkey_registered", passkey)
+}
+
+// List returns the passkeys of the authenticated user
+func (h *PasskeyHandler) List(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	passkeys, err := h.passkeyService.List(ctx, userCtx.ID)
+	if err != nil {
+		h.logger.Errorw("Failed to list passkeys", "userID", userCtx.ID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_passkeys")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkeys_listed", passkeys)
+}
+
+func (h *PasskeyHandler) Rename(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_passkey_id", nil)
+		return
+	}
+
+	var req dto.PasskeyRenameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	if err := h.passkeyService.Rename(ctx, userCtx.ID, id, &req); err != nil {
+		if h.handlePasskeyError(w, r, err) {
+			return
+		}
+
+		h.logger.Errorw("Failed to rename passkey", "userID", userCtx.ID, "passkeyID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_update_passkey")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkey_renamed", nil)
+}
+
+func (h *PasskeyHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_passkey_id", nil)
+		return
+	}
+
+	if err := h.passkeyService.Delete(ctx, userCtx.ID, id); err != nil {
+		if h.handlePasskeyError(w, r, err) {
+			return
+		}
+
+		h.logger.Errorw("Failed to delete passkey", "userID", userCtx.ID, "passkeyID", id, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_passkey")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkey_deleted", nil)
+}
+
+// BeginLogin returns the options to sign in with any passkey the browser holds for the site
+func (h *PasskeyHandler) BeginLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	options, err := h.passkeyService.BeginLogin(ctx)
+	if err != nil {
+		h.logger.Errorw("Failed to start passkey login", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkey_login_started", options)
+}
+
+func (h *PasskeyHandler) FinishLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.PasskeyLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	tokens, err := h.passkeyService.FinishLogin(ctx, &req, clientInfo(r))
+	if err != nil {
+		if h.handlePasskeyError(w, r, err) {
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		}
+
+		h.logger.Errorw("Failed to finish passkey login", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	if tokens.TwoFactorRequired {
+		response.OKT(ctx, w, "success.two_factor_required", tokens)
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", tokens)
+}
+
+// BeginTwoFactor returns the options to answer a login challenge with a passkey
+func (h *PasskeyHandler) BeginTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.PasskeyTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	options, err := h.passkeyService.BeginTwoFactor(ctx, req.ChallengeToken)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTwoFactorChallenge) {
+			response.UnauthorizedT(ctx, w, "error.invalid_two_factor_challenge")
+			return
+		} else if h.handlePasskeyError(w, r, err) {
+			return
+		}
+
+		h.logger.Errorw("Failed to start passkey two-factor challenge", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	response.OKT(ctx, w, "success.passkey_login_started", options)
+}
+
+func (h *PasskeyHandler) FinishTwoFactor(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var req dto.PasskeyTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_request_body", nil)
+		return
+	}
+
+	tokens, err := h.passkeyService.FinishTwoFactor(ctx, &req, clientInfo(r))
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidTwoFactorChallenge) {
+			response.UnauthorizedT(ctx, w, "error.invalid_two_factor_challenge")
+			return
+		} else if errors.Is(err, domain.ErrUserInactive) {
+			response.ForbiddenT(ctx, w, "error.user_inactive")
+			return
+		} else if h.handlePasskeyError(w, r, err) {
+			return
+		}
+
+		h.logger.Errorw("Failed to verify passkey two-factor challenge", "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_login_user")
+		return
+	}
+
+	auth.SetRefreshTokenCookie(w, tokens.RefreshToken)
+
+	response.OKT(ctx, w, "success.login", tokens)
+}
+
+// handlePasskeyError writes the response for the domain errors shared by the passkey endpoints
+func (h *PasskeyHandler) handlePasskeyError(w http.ResponseWriter, r *http.Request, err error) bool {
+	ctx := r.Context()
+	switch {
+	case errors.Is(err, domain.ErrInvalidPasskeyChallenge):
+		response.UnauthorizedT(ctx, w, "error.invalid_passkey_challenge")
+	case errors.Is(err, domain.ErrInvalidPasskey):
+		response.UnauthorizedT(ctx, w, "error.invalid_passkey")
+	case errors.Is(err, domain.ErrPasskeyAlreadyRegistered):
+		response.ConflictT(ctx, w, "error.passkey_already_registered", nil)
+	case errors.Is(err, domain.ErrPasskeyNotFound):
+		response.NotFoundT(ctx, w, "error.passkey_not_found")
+	case errors.Is(err, domain.ErrInvalidFieldValue):
+		response.BadRequestT(ctx, w, "error.passkey_name_too_long", nil)
+	default:
+		return false
+	}
+
+	return true
+}
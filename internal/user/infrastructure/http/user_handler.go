@@ -1,12 +1,16 @@
 package http
 
 import (
+	"archive/zip"
+	"bytes"
 	"encoding/json"
+	"fmt"
 	"net/http"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/application"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/infrastructure/dto"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
@@ -14,14 +18,18 @@ import (
 )
 
 type UserHandler struct {
-	logger      *zap.SugaredLogger
-	userService *application.UserService
+	logger         *zap.SugaredLogger
+	userService    *application.UserService
+	sessionService *application.SessionService
+	accountService *application.AccountService
 }
 
-func NewUserHandler(logger *zap.SugaredLogger, userService *application.UserService) *UserHandler {
+func NewUserHandler(logger *zap.SugaredLogger, userService *application.UserService, sessionService *application.SessionService, accountService *application.AccountService) *UserHandler {
 	return &UserHandler{
-		logger:      logger,
-		userService: userService,
+		logger:         logger,
+		userService:    userService,
+		sessionService: sessionService,
+		accountService: accountService,
 	}
 }
 
@@ -97,7 +105,7 @@ func (h *UserHandler) List(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	response.OKT(ctx, w, "success.users_listed", list)
+	response.OKWithMetaT(ctx, w, "success.users_listed", list, list.Meta())
 }
 
 func (h *UserHandler) SetIsActive(w http.ResponseWriter, r *http.Request) {
@@ -186,3 +194,175 @@ func (h *UserHandler) SetIsEntrepreneur(w http.ResponseWriter, r *http.Request)
 
 	response.OKT(ctx, w, "success.user_entrepreneur_updated", nil)
 }
+
+// ListSessions returns the devices the authenticated user is currently signed in on
+func (h *UserHandler) ListSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.ownUserID(w, r)
+	if !ok {
+		return
+	}
+
+	sessions, err := h.sessionService.List(ctx, userID)
+	if err != nil {
+		h.logger.Errorw("failed to list sessions", "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_list_sessions")
+		return
+	}
+
+	response.OKT(ctx, w, "success.sessions_listed", sessions)
+}
+
+// Export hands the authenticated user a copy of their personal data, as a ZIP archive with one
+// JSON file per section, or as a single JSON document with ?format=json
+func (h *UserHandler) Export(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.ownUserID(w, r)
+	if !ok {
+		return
+	}
+
+	export, err := h.accountService.Export(ctx, userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+
+		h.logger.Errorw("failed to export user data", "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_export_user_data")
+		return
+	}
+
+	if r.URL.Query().Get("format") == "json" {
+		response.OKT(ctx, w, "success.user_data_exported", export)
+		return
+	}
+
+	archive, err := exportArchive(export)
+	if err != nil {
+		h.logger.Errorw("failed to build user data archive", "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_export_user_data")
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"personal-data-%s.zip\"", userID))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(archive)
+}
+
+// Delete schedules the deletion of the account of the authenticated user
+func (h *UserHandler) Delete(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.ownUserID(w, r)
+	if !ok {
+		return
+	}
+
+	user, err := h.accountService.RequestDeletion(ctx, userID)
+	if err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+		if err == domain.ErrDeletionScheduled {
+			response.ConflictT(ctx, w, "error.deletion_scheduled", nil)
+			return
+		}
+		if err == domain.ErrSharedBusinessOwner {
+			response.ConflictT(ctx, w, "error.shared_business_owner", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to schedule account deletion", "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_delete_user")
+		return
+	}
+
+	response.OKT(ctx, w, "success.user_deletion_scheduled", user)
+}
+
+// CancelDeletion keeps the account of the authenticated user during the grace period
+func (h *UserHandler) CancelDeletion(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userID, ok := h.ownUserID(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.accountService.CancelDeletion(ctx, userID); err != nil {
+		if err == domain.ErrUserNotFound {
+			response.NotFoundT(ctx, w, "error.user_not_found")
+			return
+		}
+		if err == domain.ErrDeletionNotScheduled {
+			response.ConflictT(ctx, w, "error.deletion_not_scheduled", nil)
+			return
+		}
+
+		h.logger.Errorw("failed to cancel account deletion", "userID", userID, "error", err)
+		response.InternalServerErrorT(ctx, w, "error.failed_cancel_deletion")
+		return
+	}
+
+	response.OKT(ctx, w, "success.user_deletion_cancelled", nil)
+}
+
+// ownUserID parses the user ID of the route and checks it is the authenticated user,
+// writing the error response otherwise
+func (h *UserHandler) ownUserID(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	ctx := r.Context()
+	userCtx := ctx.Value(auth.UserContextKey).(*dto.UserAsContext)
+
+	userID, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		response.BadRequestT(ctx, w, "error.invalid_user_id", nil)
+		return uuid.Nil, false
+	}
+
+	if userID != userCtx.ID {
+		response.ForbiddenT(ctx, w, "error.unauthorized")
+		return uuid.Nil, false
+	}
+
+	return userID, true
+}
+
+// exportArchive zips the sections of the export, one JSON file each
+func exportArchive(export *dto.UserDataExport) ([]byte, error) {
+	sections := []struct {
+		name string
+		data any
+	}{
+		{"user.json", export.User},
+		{"address.json", export.Address},
+		{"notification_preferences.json", export.NotificationPreferences},
+		{"job_profile.json", export.JobProfile},
+		{"businesses.json", export.Businesses},
+		{"products.json", export.Products},
+		{"services.json", export.Services},
+		{"jobs.json", export.Jobs},
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, section := range sections {
+		file, err := archive.CreateHeader(&zip.FileHeader{Name: section.name, Method: zip.Deflate, Modified: export.ExportedAt})
+		if err != nil {
+			return nil, err
+		}
+
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(section.data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
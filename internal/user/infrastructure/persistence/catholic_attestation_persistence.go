@@ -0,0 +1,238 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// CatholicAttestationPersistence manages data access for the catholic_attestations table.
+// The keyring decrypts the personal identifiers of the reviewers it lists.
+type CatholicAttestationPersistence struct {
+	db      *sqlx.DB
+	psql    sq.StatementBuilderType
+	keyring *encryption.Keyring
+}
+
+// NewCatholicAttestationPersistence creates a new CatholicAttestationPersistence.
+func NewCatholicAttestationPersistence(db *sqlx.DB, keyring *encryption.Keyring) *CatholicAttestationPersistence {
+	return &CatholicAttestationPersistence{
+		db:      db,
+		psql:    sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		keyring: keyring,
+	}
+}
+
+// Create stores a new pending attestation.
+func (r *CatholicAttestationPersistence) Create(ctx context.Context, attestation *domain.CatholicAttestation) error {
+	query, args, err := r.psql.Insert("catholic_attestations").
+		Columns("user_id", "church_id", "sacramental_record", "letter_url").
+		Values(attestation.UserID, attestation.ChurchID, attestation.SacramentalRecord, attestation.LetterURL).
+		Suffix("RETURNING id, status, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create attestation query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&attestation.ID, &attestation.Status, &attestation.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute create attestation query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an attestation by its ID.
+func (r *CatholicAttestationPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.CatholicAttestation, error) {
+	return r.getBy(ctx, sq.Eq{"id": id})
+}
+
+// GetPendingByUserID retrieves the attestation of the user that is awaiting review.
+func (r *CatholicAttestationPersistence) GetPendingByUserID(ctx context.Context, userID uuid.UUID) (*domain.CatholicAttestation, error) {
+	return r.getBy(ctx, sq.Eq{"user_id": userID, "status": domain.AttestationStatusPending})
+}
+
+func (r *CatholicAttestationPersistence) getBy(ctx context.Context, condition any) (*domain.CatholicAttestation, error) {
+	query, args, err := r.psql.Select("*").
+		From("catholic_attestations").
+		Where(condition).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get attestation query: %w", err)
+	}
+
+	var attestation domain.CatholicAttestation
+	if err := r.db.GetContext(ctx, &attestation, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrAttestationNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get attestation query: %w", err)
+	}
+
+	return &attestation, nil
+}
+
+// List retrieves attestations matching the filters, newest first.
+func (r *CatholicAttestationPersistence) List(ctx context.Context, filter *domain.CatholicAttestationFilters) ([]*domain.CatholicAttestation, error) {
+	queryBuilder := r.psql.Select("*").From("catholic_attestations")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+
+	if filter.Limit != nil {
+		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	}
+	if filter.Offset != nil {
+		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list attestations query: %w", err)
+	}
+
+	var attestations []*domain.CatholicAttestation
+	if err := r.db.SelectContext(ctx, &attestations, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list attestations query: %w", err)
+	}
+
+	return attestations, nil
+}
+
+// Count returns the number of attestations matching the filters.
+func (r *CatholicAttestationPersistence) Count(ctx context.Context, filter *domain.CatholicAttestationFilters) (int, error) {
+	queryBuilder := r.psql.Select("COUNT(*)").From("catholic_attestations")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count attestations query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count attestations query: %w", err)
+	}
+
+	return count, nil
+}
+
+// Review stores the decision on a pending attestation. On approval the user is flagged as
+// Catholic in the same transaction, so the flag never disagrees with the history.
+func (r *CatholicAttestationPersistence) Review(ctx context.Context, attestation *domain.CatholicAttestation) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args, err := r.psql.Update("catholic_attestations").
+		Set("status", attestation.Status).
+		Set("reviewer_id", attestation.ReviewerID).
+		Set("review_comment", attestation.ReviewComment).
+		Set("reviewed_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": attestation.ID, "status": domain.AttestationStatusPending}).
+		Suffix("RETURNING reviewed_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build review attestation query: %w", err)
+	}
+
+	if err = tx.QueryRowxContext(ctx, query, args...).Scan(&attestation.ReviewedAt); err != nil {
+		if err == sql.ErrNoRows {
+			// Another reviewer got there first
+			return domain.ErrAttestationAlreadyReviewed
+		}
+
+		return fmt.Errorf("failed to execute review attestation query: %w", err)
+	}
+
+	if attestation.Status == domain.AttestationStatusApproved {
+		query, args, err = r.psql.Update("users").
+			Set(string(domain.IsCatholic), true).
+			Where(sq.Eq{"id": attestation.UserID}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build update user is_catholic query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute update user is_catholic query: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ListReviewers returns the active users assigned to the church, or to its diocese, who may
+// review attestations: admins, and every role granted the permission.
+func (r *CatholicAttestationPersistence) ListReviewers(ctx context.Context, churchID uuid.UUID, permission string) ([]*domain.User, error) {
+	query, args, err := r.psql.Select("u.*").
+		From("users u").
+		Join("admin_scopes s ON s.user_id = u.id").
+		Where(sq.Eq{"u.is_active": true}).
+		Where(sq.Or{
+			sq.Eq{"s.church_id": churchID},
+			sq.Expr("s.diocese = (SELECT diocese FROM church WHERE id = ?)", churchID),
+		}).
+		Where(sq.Or{
+			sq.Eq{"u.role_id": constants.ROLE_ADMIN},
+			sq.Expr("u.role_id IN (SELECT rp.role_id FROM role_permissions rp JOIN permissions p ON p.id = rp.permission_id WHERE p.key = ?)", permission),
+		}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list attestation reviewers query: %w", err)
+	}
+
+	var users []*domain.User
+	if err := r.db.SelectContext(ctx, &users, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list attestation reviewers query: %w", err)
+	}
+
+	if err := openIdentifiers(r.keyring, users...); err != nil {
+		return nil, err
+	}
+
+	return users, nil
+}
+
+func (r *CatholicAttestationPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.CatholicAttestationFilters) sq.SelectBuilder {
+	if filter.UserID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"user_id": *filter.UserID})
+	}
+	if filter.ChurchID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"church_id": *filter.ChurchID})
+	}
+	if filter.Status != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"status": *filter.Status})
+	}
+	if filter.Scope != nil {
+		if filter.Scope.ChurchID.Valid {
+			baseQuery = baseQuery.Where(sq.Eq{"church_id": filter.Scope.ChurchID.UUID})
+		} else {
+			baseQuery = baseQuery.Where(sq.Expr("church_id IN (SELECT id FROM church WHERE diocese = ?)", filter.Scope.Diocese.String))
+		}
+	}
+
+	return baseQuery
+}
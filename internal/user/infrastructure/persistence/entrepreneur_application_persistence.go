@@ -0,0 +1,246 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// openApplicationStatuses are the statuses of applications still awaiting a decision
+var openApplicationStatuses = []domain.ApplicationStatus{domain.ApplicationStatusPending, domain.ApplicationStatusInfoRequested}
+
+// EntrepreneurApplicationPersistence manages data access for the entrepreneur_applications table.
+type EntrepreneurApplicationPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewEntrepreneurApplicationPersistence creates a new EntrepreneurApplicationPersistence.
+func NewEntrepreneurApplicationPersistence(db *sqlx.DB) *EntrepreneurApplicationPersistence {
+	return &EntrepreneurApplicationPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create stores a new pending application.
+func (r *EntrepreneurApplicationPersistence) Create(ctx context.Context, application *domain.EntrepreneurApplication) error {
+	query, args, err := r.psql.Insert("entrepreneur_applications").
+		Columns("user_id", "business_name", "business_description", "industry_id", "business_references").
+		Values(application.UserID, application.BusinessName, application.BusinessDescription, application.IndustryID, application.References).
+		Suffix("RETURNING id, status, created_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create application query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&application.ID, &application.Status, &application.CreatedAt, &application.UpdatedAt); err != nil {
+		return fmt.Errorf("failed to execute create application query: %w", err)
+	}
+
+	return nil
+}
+
+// Resubmit updates an application of the user that awaits more information and marks it as pending again.
+func (r *EntrepreneurApplicationPersistence) Resubmit(ctx context.Context, application *domain.EntrepreneurApplication) error {
+	query, args, err := r.psql.Update("entrepreneur_applications").
+		Set("business_name", application.BusinessName).
+		Set("business_description", application.BusinessDescription).
+		Set("industry_id", application.IndustryID).
+		Set("business_references", application.References).
+		Set("status", domain.ApplicationStatusPending).
+		Where(sq.Eq{"id": application.ID, "user_id": application.UserID, "status": domain.ApplicationStatusInfoRequested}).
+		Suffix("RETURNING status, updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build resubmit application query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&application.Status, &application.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrApplicationNotAwaitingInfo
+		}
+
+		return fmt.Errorf("failed to execute resubmit application query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByID retrieves an application by its ID.
+func (r *EntrepreneurApplicationPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.EntrepreneurApplication, error) {
+	return r.getBy(ctx, sq.Eq{"id": id})
+}
+
+// GetOpenByUserID retrieves the application of the user that awaits a decision.
+func (r *EntrepreneurApplicationPersistence) GetOpenByUserID(ctx context.Context, userID uuid.UUID) (*domain.EntrepreneurApplication, error) {
+	return r.getBy(ctx, sq.Eq{"user_id": userID, "status": openApplicationStatuses})
+}
+
+func (r *EntrepreneurApplicationPersistence) getBy(ctx context.Context, condition any) (*domain.EntrepreneurApplication, error) {
+	query, args, err := r.psql.Select("*").
+		From("entrepreneur_applications").
+		Where(condition).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get application query: %w", err)
+	}
+
+	var application domain.EntrepreneurApplication
+	if err := r.db.GetContext(ctx, &application, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrApplicationNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get application query: %w", err)
+	}
+
+	return &application, nil
+}
+
+// List retrieves applications matching the filters, oldest first so the queue is worked in order.
+func (r *EntrepreneurApplicationPersistence) List(ctx context.Context, filter *domain.EntrepreneurApplicationFilters) ([]*domain.EntrepreneurApplication, error) {
+	queryBuilder := r.psql.Select("*").From("entrepreneur_applications")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	queryBuilder = queryBuilder.OrderBy("created_at")
+
+	if filter.Limit != nil {
+		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	}
+	if filter.Offset != nil {
+		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list applications query: %w", err)
+	}
+
+	var applications []*domain.EntrepreneurApplication
+	if err := r.db.SelectContext(ctx, &applications, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list applications query: %w", err)
+	}
+
+	return applications, nil
+}
+
+// Count returns the number of applications matching the filters.
+func (r *EntrepreneurApplicationPersistence) Count(ctx context.Context, filter *domain.EntrepreneurApplicationFilters) (int, error) {
+	queryBuilder := r.psql.Select("COUNT(*)").From("entrepreneur_applications")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count applications query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count applications query: %w", err)
+	}
+
+	return count, nil
+}
+
+// Review stores the decision on an open application. Approving flags the user as an entrepreneur
+// in the same transaction, and moves plain users to the entrepreneur role when assignRole is set.
+func (r *EntrepreneurApplicationPersistence) Review(ctx context.Context, application *domain.EntrepreneurApplication, assignRole bool) (err error) {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	query, args, err := r.psql.Update("entrepreneur_applications").
+		Set("status", application.Status).
+		Set("reviewer_id", application.ReviewerID).
+		Set("review_comment", application.ReviewComment).
+		Set("reviewed_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": application.ID, "status": openApplicationStatuses}).
+		Suffix("RETURNING reviewed_at, updated_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build review application query: %w", err)
+	}
+
+	if err = tx.QueryRowxContext(ctx, query, args...).Scan(&application.ReviewedAt, &application.UpdatedAt); err != nil {
+		if err == sql.ErrNoRows {
+			return domain.ErrApplicationClosed
+		}
+
+		return fmt.Errorf("failed to execute review application query: %w", err)
+	}
+
+	if application.Status == domain.ApplicationStatusApproved {
+		query, args, err = r.psql.Update("users").
+			Set(string(domain.IsEntrepreneur), true).
+			Where(sq.Eq{"id": application.UserID}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build update user is_entrepreneur query: %w", err)
+		}
+
+		if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute update user is_entrepreneur query: %w", err)
+		}
+
+		if assignRole {
+			// Staff keep their role, only plain users are moved
+			query, args, err = r.psql.Update("users").
+				Set(string(domain.RoleID), constants.ROLE_ENTREPRENEUR).
+				Where(sq.Eq{"id": application.UserID, "role_id": constants.ROLE_USER}).
+				ToSql()
+
+			if err != nil {
+				return fmt.Errorf("failed to build update user role query: %w", err)
+			}
+
+			if _, err = tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to execute update user role query: %w", err)
+			}
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func (r *EntrepreneurApplicationPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.EntrepreneurApplicationFilters) sq.SelectBuilder {
+	if filter.UserID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"user_id": *filter.UserID})
+	}
+	if filter.IndustryID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"industry_id": *filter.IndustryID})
+	}
+	if filter.Status != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"status": *filter.Status})
+	}
+	if filter.Scope != nil {
+		if filter.Scope.ChurchID.Valid {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT id FROM users WHERE church_id = ?)", filter.Scope.ChurchID.UUID))
+		} else {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT u.id FROM users u JOIN church c ON c.id = u.church_id WHERE c.diocese = ?)", filter.Scope.Diocese.String))
+		}
+	}
+
+	return baseQuery
+}
@@ -0,0 +1,100 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// ImpersonationLogPersistence manages data access for the impersonation_logs table.
+type ImpersonationLogPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewImpersonationLogPersistence creates a new ImpersonationLogPersistence.
+func NewImpersonationLogPersistence(db *sqlx.DB) *ImpersonationLogPersistence {
+	return &ImpersonationLogPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create records a request made while impersonating.
+func (r *ImpersonationLogPersistence) Create(ctx context.Context, log *domain.ImpersonationLog) error {
+	query, args, err := r.psql.Insert("impersonation_logs").
+		Columns("admin_id", "user_id", "session_id", "method", "path", "status_code", "blocked", "ip_address").
+		Values(log.AdminID, log.UserID, log.SessionID, log.Method, log.Path, log.StatusCode, log.Blocked, log.IPAddress).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create impersonationLog query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&log.ID, &log.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute create impersonationLog query: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the recorded requests matching the filters, most recent first.
+func (r *ImpersonationLogPersistence) List(ctx context.Context, filter *domain.ImpersonationLogFilters) ([]*domain.ImpersonationLog, error) {
+	queryBuilder := r.psql.Select("*").From("impersonation_logs")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+
+	if filter.Limit != nil {
+		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	}
+	if filter.Offset != nil {
+		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list impersonationLogs query: %w", err)
+	}
+
+	var logs []*domain.ImpersonationLog
+	if err := r.db.SelectContext(ctx, &logs, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list impersonationLogs query: %w", err)
+	}
+
+	return logs, nil
+}
+
+// Count returns the number of recorded requests matching the filters.
+func (r *ImpersonationLogPersistence) Count(ctx context.Context, filter *domain.ImpersonationLogFilters) (int, error) {
+	queryBuilder := r.psql.Select("COUNT(*)").From("impersonation_logs")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count impersonationLogs query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count impersonationLogs query: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *ImpersonationLogPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.ImpersonationLogFilters) sq.SelectBuilder {
+	if filter.AdminID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"admin_id": *filter.AdminID})
+	}
+	if filter.UserID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"user_id": *filter.UserID})
+	}
+	if filter.Blocked != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"blocked": *filter.Blocked})
+	}
+
+	return baseQuery
+}
@@ -0,0 +1,129 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jmoiron/sqlx"
+)
+
+// LoginLockoutPersistence manages data access for the login_lockouts table.
+type LoginLockoutPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewLoginLockoutPersistence creates a new LoginLockoutPersistence.
+func NewLoginLockoutPersistence(db *sqlx.DB) *LoginLockoutPersistence {
+	return &LoginLockoutPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create records a new lockout event.
+func (r *LoginLockoutPersistence) Create(ctx context.Context, lockout *domain.LoginLockout) error {
+	query, args, err := r.psql.Insert("login_lockouts").
+		Columns("user_id", "scope", "email", "ip_address", "failed_attempts", "locked_until").
+		Values(lockout.UserID, lockout.Scope, lockout.Email, lockout.IPAddress, lockout.FailedAttempts, lockout.LockedUntil).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create loginLockout query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&lockout.ID, &lockout.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute create loginLockout query: %w", err)
+	}
+
+	return nil
+}
+
+// MarkUnlocked flags the ongoing account lockouts of an email as lifted.
+func (r *LoginLockoutPersistence) MarkUnlocked(ctx context.Context, email string) error {
+	query, args, err := r.psql.Update("login_lockouts").
+		Set("unlocked_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"scope": domain.LockoutScopeAccount, "email": email, "unlocked_at": nil}).
+		Where(sq.Expr("locked_until > CURRENT_TIMESTAMP")).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build mark loginLockout unlocked query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute mark loginLockout unlocked query: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves lockout events matching the filters, most recent first.
+func (r *LoginLockoutPersistence) List(ctx context.Context, filter *domain.LoginLockoutFilters) ([]*domain.LoginLockout, error) {
+	queryBuilder := r.psql.Select("*").From("login_lockouts")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	queryBuilder = queryBuilder.OrderBy("created_at DESC")
+
+	if filter.Limit != nil {
+		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+	}
+	if filter.Offset != nil {
+		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	}
+
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list loginLockouts query: %w", err)
+	}
+
+	var lockouts []*domain.LoginLockout
+	if err := r.db.SelectContext(ctx, &lockouts, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list loginLockouts query: %w", err)
+	}
+
+	return lockouts, nil
+}
+
+// Count returns the number of lockout events matching the filters.
+func (r *LoginLockoutPersistence) Count(ctx context.Context, filter *domain.LoginLockoutFilters) (int, error) {
+	queryBuilder := r.psql.Select("COUNT(*)").From("login_lockouts")
+	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
+	query, args, err := queryBuilder.ToSql()
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count loginLockouts query: %w", err)
+	}
+
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count loginLockouts query: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *LoginLockoutPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *domain.LoginLockoutFilters) sq.SelectBuilder {
+	if filter.UserID != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"user_id": *filter.UserID})
+	}
+	if filter.Scope != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"scope": *filter.Scope})
+	}
+	if filter.Email != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"email": *filter.Email})
+	}
+	if filter.IPAddress != nil {
+		baseQuery = baseQuery.Where(sq.Eq{"ip_address": *filter.IPAddress})
+	}
+	if filter.ChurchScope != nil {
+		if filter.ChurchScope.ChurchID.Valid {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT id FROM users WHERE church_id = ?)", filter.ChurchScope.ChurchID.UUID))
+		} else {
+			baseQuery = baseQuery.Where(sq.Expr("user_id IN (SELECT u.id FROM users u JOIN church c ON c.id = u.church_id WHERE c.diocese = ?)", filter.ChurchScope.Diocese.String))
+		}
+	}
+
+	return baseQuery
+}
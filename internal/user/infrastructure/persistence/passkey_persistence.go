@@ -0,0 +1,171 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// PasskeyPersistence manages data access for the user_passkeys table.
+type PasskeyPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewPasskeyPersistence creates a new PasskeyPersistence.
+func NewPasskeyPersistence(db *sqlx.DB) *PasskeyPersistence {
+	return &PasskeyPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create stores a newly registered passkey.
+func (r *PasskeyPersistence) Create(ctx context.Context, passkey *domain.Passkey) error {
+	query, args, err := r.psql.Insert("user_passkeys").
+		Columns("user_id", "credential_id", "public_key", "sign_count", "name").
+		Values(passkey.UserID, passkey.CredentialID, passkey.PublicKey, passkey.SignCount, passkey.Name).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create passkey query: %w", err)
+	}
+
+	if err := r.db.QueryRowxContext(ctx, query, args...).Scan(&passkey.ID, &passkey.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute create passkey query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByCredentialID retrieves the passkey an authenticator credential belongs to.
+func (r *PasskeyPersistence) GetByCredentialID(ctx context.Context, credentialID []byte) (*domain.Passkey, error) {
+	query, args, err := r.psql.Select("*").
+		From("user_passkeys").
+		Where(sq.Eq{"credential_id": credentialID}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get passkey query: %w", err)
+	}
+
+	var passkey domain.Passkey
+	if err := r.db.GetContext(ctx, &passkey, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrPasskeyNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get passkey query: %w", err)
+	}
+
+	return &passkey, nil
+}
+
+// ListByUserID retrieves the passkeys of a user, oldest first.
+func (r *PasskeyPersistence) ListByUserID(ctx context.Context, userID uuid.UUID) ([]*domain.Passkey, error) {
+	var passkeys []*domain.Passkey
+	query, args, err := r.psql.Select("*").
+		From("user_passkeys").
+		Where(sq.Eq{"user_id": userID}).
+		OrderBy("created_at").
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build list passkeys query: %w", err)
+	}
+
+	if err := r.db.SelectContext(ctx, &passkeys, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute list passkeys query: %w", err)
+	}
+
+	return passkeys, nil
+}
+
+// CountByUserID counts the passkeys of a user.
+func (r *PasskeyPersistence) CountByUserID(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query, args, err := r.psql.Select("COUNT(*)").From("user_passkeys").
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count passkeys query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count passkeys query: %w", err)
+	}
+
+	return count, nil
+}
+
+// UpdateSignCount stores the counter of the latest login and sets the last use time to now.
+func (r *PasskeyPersistence) UpdateSignCount(ctx context.Context, id uuid.UUID, signCount int64) error {
+	query, args, err := r.psql.Update("user_passkeys").
+		Set("sign_count", signCount).
+		Set("last_used_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update passkey sign count query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update passkey sign count query: %w", err)
+	}
+
+	return nil
+}
+
+// Rename changes the friendly name of a passkey owned by the user.
+func (r *PasskeyPersistence) Rename(ctx context.Context, id, userID uuid.UUID, name string) error {
+	query, args, err := r.psql.Update("user_passkeys").
+		Set("name", name).
+		Where(sq.Eq{"id": id, "user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build rename passkey query: %w", err)
+	}
+
+	return r.execAffectingPasskey(ctx, "rename", query, args)
+}
+
+// Delete removes a passkey owned by the user.
+func (r *PasskeyPersistence) Delete(ctx context.Context, id, userID uuid.UUID) error {
+	query, args, err := r.psql.Delete("user_passkeys").
+		Where(sq.Eq{"id": id, "user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete passkey query: %w", err)
+	}
+
+	return r.execAffectingPasskey(ctx, "delete", query, args)
+}
+
+// execAffectingPasskey runs a statement on a single passkey, reporting ErrPasskeyNotFound when it matched none.
+func (r *PasskeyPersistence) execAffectingPasskey(ctx context.Context, action, query string, args []any) error {
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return fmt.Errorf("failed to execute %s passkey query: %w", action, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return domain.ErrPasskeyNotFound
+	}
+
+	return nil
+}
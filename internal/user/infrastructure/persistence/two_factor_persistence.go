@@ -0,0 +1,254 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// TwoFactorPersistence manages data access for the user_two_factor and user_recovery_codes tables.
+type TwoFactorPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewTwoFactorPersistence creates a new TwoFactorPersistence.
+func NewTwoFactorPersistence(db *sqlx.DB) *TwoFactorPersistence {
+	return &TwoFactorPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// UnitOfWork executes the given function within a database transaction.
+func (r *TwoFactorPersistence) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) error) error {
+	var err error
+
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback()
+			panic(p)
+		} else if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+
+	if err = fn(tx); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Upsert stores a pending enrollment for the user, replacing the previous secret.
+func (r *TwoFactorPersistence) Upsert(ctx context.Context, twoFactor *domain.TwoFactor) error {
+	query, args, err := r.psql.Insert("user_two_factor").
+		Columns("user_id", "secret").
+		Values(twoFactor.UserID, twoFactor.Secret).
+		Suffix("ON CONFLICT (user_id) DO UPDATE SET secret = EXCLUDED.secret, last_used_step = 0, enabled_at = NULL").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build upsert twoFactor query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute upsert twoFactor query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUserID retrieves the enrollment of a user.
+func (r *TwoFactorPersistence) GetByUserID(ctx context.Context, userID uuid.UUID) (*domain.TwoFactor, error) {
+	var twoFactor domain.TwoFactor
+	query, args, err := r.psql.Select("*").From("user_two_factor").
+		Where(sq.Eq{"user_id": userID}).
+		Limit(1).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get twoFactor by userID query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &twoFactor, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrTwoFactorNotEnrolled
+		}
+
+		return nil, fmt.Errorf("failed to execute get twoFactor by userID query: %w", err)
+	}
+
+	return &twoFactor, nil
+}
+
+// Enable confirms the enrollment and flags the user.
+func (r *TwoFactorPersistence) Enable(tx *sqlx.Tx, userID uuid.UUID) error {
+	query, args, err := r.psql.Update("user_two_factor").
+		Set("enabled_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build enable twoFactor query: %w", err)
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to execute enable twoFactor query: %w", err)
+	}
+
+	return r.setUserFlag(tx, userID, true)
+}
+
+// Delete removes the enrollment and recovery codes of the user and clears its flag.
+func (r *TwoFactorPersistence) Delete(tx *sqlx.Tx, userID uuid.UUID) error {
+	for _, table := range []string{"user_recovery_codes", "user_two_factor"} {
+		query, args, err := r.psql.Delete(table).
+			Where(sq.Eq{"user_id": userID}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build delete %s query: %w", table, err)
+		}
+
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to execute delete %s query: %w", table, err)
+		}
+	}
+
+	return r.setUserFlag(tx, userID, false)
+}
+
+// UseStep records the time step of an accepted code. The conditional update makes a
+// code usable only once, even across concurrent requests.
+func (r *TwoFactorPersistence) UseStep(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query, args, err := r.psql.Update("user_two_factor").
+		Set("last_used_step", step).
+		Where(sq.Eq{"user_id": userID}).
+		Where(sq.Lt{"last_used_step": step}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("failed to build use twoFactor step query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute use twoFactor step query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows == 1, nil
+}
+
+// ReplaceRecoveryCodes discards the previous recovery codes of the user and stores the new ones.
+func (r *TwoFactorPersistence) ReplaceRecoveryCodes(tx *sqlx.Tx, userID uuid.UUID, codeHashes [][]byte) error {
+	query, args, err := r.psql.Delete("user_recovery_codes").
+		Where(sq.Eq{"user_id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build delete recoveryCodes query: %w", err)
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to execute delete recoveryCodes query: %w", err)
+	}
+
+	if len(codeHashes) == 0 {
+		return nil
+	}
+
+	insert := r.psql.Insert("user_recovery_codes").Columns("user_id", "code_hash")
+	for _, codeHash := range codeHashes {
+		insert = insert.Values(userID, codeHash)
+	}
+
+	query, args, err = insert.ToSql()
+	if err != nil {
+		return fmt.Errorf("failed to build create recoveryCodes query: %w", err)
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to execute create recoveryCodes query: %w", err)
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks a matching unused recovery code as used.
+func (r *TwoFactorPersistence) UseRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash []byte) (bool, error) {
+	query, args, err := r.psql.Update("user_recovery_codes").
+		Set("used_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"user_id": userID, "code_hash": codeHash, "used_at": nil}).
+		ToSql()
+
+	if err != nil {
+		return false, fmt.Errorf("failed to build use recoveryCode query: %w", err)
+	}
+
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return false, fmt.Errorf("failed to execute use recoveryCode query: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rows > 0, nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes the user has left.
+func (r *TwoFactorPersistence) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var count int
+	query, args, err := r.psql.Select("COUNT(*)").From("user_recovery_codes").
+		Where(sq.Eq{"user_id": userID, "used_at": nil}).
+		ToSql()
+
+	if err != nil {
+		return 0, fmt.Errorf("failed to build count recoveryCodes query: %w", err)
+	}
+
+	if err := r.db.GetContext(ctx, &count, query, args...); err != nil {
+		return 0, fmt.Errorf("failed to execute count recoveryCodes query: %w", err)
+	}
+
+	return count, nil
+}
+
+func (r *TwoFactorPersistence) setUserFlag(tx *sqlx.Tx, userID uuid.UUID, enabled bool) error {
+	query, args, err := r.psql.Update("users").
+		Set(string(domain.IsTwoFactorEnabled), enabled).
+		Where(sq.Eq{"id": userID}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update user two-factor flag query: %w", err)
+	}
+
+	if _, err := tx.Exec(query, args...); err != nil {
+		return fmt.Errorf("failed to execute update user two-factor flag query: %w", err)
+	}
+
+	return nil
+}
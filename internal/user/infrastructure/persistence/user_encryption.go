@@ -0,0 +1,183 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// sealedIdentifiers holds the personal identifiers of a user as they are stored
+type sealedIdentifiers struct {
+	DocumentID      string         `db:"document_id"`
+	DocumentIDIndex sql.NullString `db:"document_id_index"`
+	PhoneNumber     sql.NullString `db:"phone_number"`
+}
+
+// sealIdentifiers encrypts the document ID and phone number of the user, and computes the
+// blind index the document ID is looked up by.
+func sealIdentifiers(keyring *encryption.Keyring, user *domain.User) (*sealedIdentifiers, error) {
+	documentID, err := keyring.Encrypt(user.DocumentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encrypt document id: %w", err)
+	}
+
+	sealed := &sealedIdentifiers{
+		DocumentID:      documentID,
+		DocumentIDIndex: sql.NullString{String: keyring.BlindIndex(user.DocumentID), Valid: true},
+		PhoneNumber:     user.PhoneNumber,
+	}
+
+	if user.PhoneNumber.Valid && user.PhoneNumber.String != "" {
+		sealed.PhoneNumber.String, err = keyring.Encrypt(user.PhoneNumber.String)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt phone number: %w", err)
+		}
+	}
+
+	return sealed, nil
+}
+
+// openIdentifiers decrypts the document ID and phone number of the users read from the database.
+// Values stored before encryption was introduced are read as they are, until they are rotated.
+func openIdentifiers(keyring *encryption.Keyring, users ...*domain.User) error {
+	for _, user := range users {
+		documentID, err := openValue(keyring, user.DocumentID)
+		if err != nil {
+			return fmt.Errorf("failed to decrypt document id of user %s: %w", user.ID, err)
+		}
+		user.DocumentID = documentID
+
+		if user.PhoneNumber.Valid {
+			phoneNumber, err := openValue(keyring, user.PhoneNumber.String)
+			if err != nil {
+				return fmt.Errorf("failed to decrypt phone number of user %s: %w", user.ID, err)
+			}
+			user.PhoneNumber.String = phoneNumber
+		}
+	}
+
+	return nil
+}
+
+func openValue(keyring *encryption.Keyring, value string) (string, error) {
+	if !encryption.IsEncrypted(value) {
+		return value, nil
+	}
+
+	return keyring.Decrypt(value)
+}
+
+// RotateIdentifiers brings the personal identifiers of every user under the active key: plain
+// values are encrypted and indexed, and encrypted values have their data keys rewrapped.
+// With decrypt set, the values are written back in plain text instead, to roll the encryption back.
+// It returns how many users were updated.
+func (r *UserPersistence) RotateIdentifiers(ctx context.Context, batchSize int, decrypt bool) (int, error) {
+	updated := 0
+	after := uuid.Nil
+
+	for {
+		query, args, err := r.psql.Select("id", "document_id", "document_id_index", "phone_number").
+			From("users").
+			Where(sq.Gt{"id": after}).
+			OrderBy("id").
+			Limit(uint64(batchSize)).
+			ToSql()
+
+		if err != nil {
+			return updated, fmt.Errorf("failed to build list user identifiers query: %w", err)
+		}
+
+		var rows []struct {
+			ID uuid.UUID `db:"id"`
+			sealedIdentifiers
+		}
+		if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+			return updated, fmt.Errorf("failed to execute list user identifiers query: %w", err)
+		}
+
+		for _, row := range rows {
+			rotated, err := r.rotateRow(&row.sealedIdentifiers, decrypt)
+			if err != nil {
+				return updated, fmt.Errorf("failed to rotate identifiers of user %s: %w", row.ID, err)
+			}
+			if rotated == nil {
+				continue
+			}
+
+			query, args, err := r.psql.Update("users").
+				Set("document_id", rotated.DocumentID).
+				Set("document_id_index", rotated.DocumentIDIndex).
+				Set("phone_number", rotated.PhoneNumber).
+				Where(sq.Eq{"id": row.ID}).
+				ToSql()
+
+			if err != nil {
+				return updated, fmt.Errorf("failed to build update user identifiers query: %w", err)
+			}
+
+			if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+				return updated, fmt.Errorf("failed to execute update user identifiers query: %w", err)
+			}
+			updated++
+		}
+
+		if len(rows) < batchSize {
+			return updated, nil
+		}
+		after = rows[len(rows)-1].ID
+	}
+}
+
+// rotateRow returns the identifiers as they should be stored, or nil when they already are
+func (r *UserPersistence) rotateRow(row *sealedIdentifiers, decrypt bool) (*sealedIdentifiers, error) {
+	documentID, documentChanged, err := r.rotateValue(row.DocumentID, decrypt)
+	if err != nil {
+		return nil, err
+	}
+
+	phoneNumber, phoneChanged := row.PhoneNumber, false
+	if row.PhoneNumber.Valid && row.PhoneNumber.String != "" {
+		phoneNumber.String, phoneChanged, err = r.rotateValue(row.PhoneNumber.String, decrypt)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	index := row.DocumentIDIndex
+	if !index.Valid {
+		plaintext, err := openValue(r.keyring, row.DocumentID)
+		if err != nil {
+			return nil, err
+		}
+		index = sql.NullString{String: r.keyring.BlindIndex(plaintext), Valid: true}
+	}
+	if decrypt {
+		index = sql.NullString{}
+	}
+
+	if !documentChanged && !phoneChanged && index == row.DocumentIDIndex {
+		return nil, nil
+	}
+
+	return &sealedIdentifiers{DocumentID: documentID, DocumentIDIndex: index, PhoneNumber: phoneNumber}, nil
+}
+
+func (r *UserPersistence) rotateValue(value string, decrypt bool) (string, bool, error) {
+	switch {
+	case decrypt && encryption.IsEncrypted(value):
+		plaintext, err := r.keyring.Decrypt(value)
+		return plaintext, true, err
+	case decrypt:
+		return value, false, nil
+	case encryption.IsEncrypted(value):
+		return r.keyring.Rewrap(value)
+	default:
+		sealed, err := r.keyring.Encrypt(value)
+		return sealed, true, err
+	}
+}
@@ -0,0 +1,86 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// UserIdentityPersistence manages data access for the user_identities table.
+type UserIdentityPersistence struct {
+	db   *sqlx.DB
+	psql sq.StatementBuilderType
+}
+
+// NewUserIdentityPersistence creates a new UserIdentityPersistence.
+func NewUserIdentityPersistence(db *sqlx.DB) *UserIdentityPersistence {
+	return &UserIdentityPersistence{
+		db:   db,
+		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+	}
+}
+
+// Create links a provider account to a user within a transaction.
+func (r *UserIdentityPersistence) Create(tx *sqlx.Tx, identity *domain.UserIdentity) error {
+	query, args, err := r.psql.Insert("user_identities").
+		Columns("user_id", "provider", "subject", "email", "last_login_at").
+		Values(identity.UserID, identity.Provider, identity.Subject, identity.Email, identity.LastLoginAt).
+		Suffix("RETURNING id, created_at").
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build create userIdentity query: %w", err)
+	}
+
+	if err := tx.QueryRowx(query, args...).Scan(&identity.ID, &identity.CreatedAt); err != nil {
+		return fmt.Errorf("failed to execute create userIdentity query: %w", err)
+	}
+
+	return nil
+}
+
+// GetByProviderSubject retrieves the identity of a provider account.
+func (r *UserIdentityPersistence) GetByProviderSubject(ctx context.Context, provider, subject string) (*domain.UserIdentity, error) {
+	query, args, err := r.psql.Select("*").
+		From("user_identities").
+		Where(sq.Eq{"provider": provider, "subject": subject}).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get userIdentity query: %w", err)
+	}
+
+	var identity domain.UserIdentity
+	if err := r.db.GetContext(ctx, &identity, query, args...); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, domain.ErrUserIdentityNotFound
+		}
+
+		return nil, fmt.Errorf("failed to execute get userIdentity query: %w", err)
+	}
+
+	return &identity, nil
+}
+
+// UpdateLastLogin sets the last sign-in time of an identity to now.
+func (r *UserIdentityPersistence) UpdateLastLogin(ctx context.Context, id uuid.UUID) error {
+	query, args, err := r.psql.Update("user_identities").
+		Set("last_login_at", sq.Expr("CURRENT_TIMESTAMP")).
+		Where(sq.Eq{"id": id}).
+		ToSql()
+
+	if err != nil {
+		return fmt.Errorf("failed to build update userIdentity last login query: %w", err)
+	}
+
+	if _, err := r.db.ExecContext(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to execute update userIdentity last login query: %w", err)
+	}
+
+	return nil
+}
@@ -4,9 +4,12 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/internal/user/domain"
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/encryption"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/pagination"
 	sq "github.com/Masterminds/squirrel"
 	"github.com/google/uuid"
 	"github.com/jmoiron/sqlx"
@@ -14,16 +17,19 @@ import (
 
 // UserPersistence struct holds the database connection (using sqlx)
 // and the query builder configured for PostgreSQL.
+// Document IDs and phone numbers are encrypted with the keyring before they are stored.
 type UserPersistence struct {
-	db   *sqlx.DB
-	psql sq.StatementBuilderType
+	db      *sqlx.DB
+	psql    sq.StatementBuilderType
+	keyring *encryption.Keyring
 }
 
 // NewUserPersistence creates and returns a new UserPersistence struct.
-func NewUserPersistence(db *sqlx.DB) *UserPersistence {
+func NewUserPersistence(db *sqlx.DB, keyring *encryption.Keyring) *UserPersistence {
 	return &UserPersistence{
-		db:   db,
-		psql: sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		db:      db,
+		psql:    sq.StatementBuilder.PlaceholderFormat(sq.Dollar),
+		keyring: keyring,
 	}
 }
 
@@ -61,16 +67,21 @@ func (r *UserPersistence) UnitOfWork(ctx context.Context, fn func(*sqlx.Tx) erro
 
 // Create inserts a new user into the database.
 func (r *UserPersistence) Create(tx *sqlx.Tx, user *domain.User) error {
+	sealed, err := sealIdentifiers(r.keyring, user)
+	if err != nil {
+		return err
+	}
+
 	query, args, err := r.psql.Insert("users").
 		Columns(
 			"role_id", "first_name", "last_name", "email", "password",
-			"document_id", "phone_country_code", "phone_number",
-			"address_id", "church_id",
+			"document_id", "document_id_index", "phone_country_code", "phone_number",
+			"address_id", "church_id", "is_verified",
 		).
 		Values(
 			constants.ROLE_USER, user.FirstName, user.LastName, user.Email, user.Password,
-			user.DocumentID, user.PhoneCountryCode, user.PhoneNumber,
-			user.AddressID, user.ChurchID,
+			sealed.DocumentID, sealed.DocumentIDIndex, user.PhoneCountryCode, sealed.PhoneNumber,
+			user.AddressID, user.ChurchID, user.IsVerified,
 		).
 		Suffix("RETURNING id").
 		ToSql()
@@ -88,15 +99,20 @@ func (r *UserPersistence) Create(tx *sqlx.Tx, user *domain.User) error {
 
 // Update modifies an existing user in the database.
 func (r *UserPersistence) Update(tx *sqlx.Tx, user *domain.User) error {
+	sealed, err := sealIdentifiers(r.keyring, user)
+	if err != nil {
+		return err
+	}
+
 	query, args, err := r.psql.Update("users").
 		Set("role_id", user.RoleID).
 		Set("first_name", user.FirstName).
 		Set("last_name", user.LastName).
-		Set("email", user.Email).
 		Set("password", user.Password).
-		Set("document_id", user.DocumentID).
+		Set("document_id", sealed.DocumentID).
+		Set("document_id_index", sealed.DocumentIDIndex).
 		Set("phone_country_code", user.PhoneCountryCode).
-		Set("phone_number", user.PhoneNumber).
+		Set("phone_number", sealed.PhoneNumber).
 		Set("address_id", user.AddressID).
 		Set("church_id", user.ChurchID).
 		Where(sq.Eq{"id": user.ID}).
@@ -131,35 +147,151 @@ func (r *UserPersistence) UpdateProperty(ctx context.Context, id uuid.UUID, prop
 	return nil
 }
 
-// Delete removes a user from the database by their ID.
+// UpdatePassword sets a new password for the user and appends it to the password history.
+func (r *UserPersistence) UpdatePassword(ctx context.Context, id uuid.UUID, password []byte) error {
+	return r.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		query, args, err := r.psql.Update("users").
+			Set(string(domain.Password), password).
+			Where(sq.Eq{"id": id}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build update user password query: %w", err)
+		}
+
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to execute update user password query: %w", err)
+		}
+
+		query, args, err = r.psql.Insert("user_password_history").
+			Columns("user_id", "password").
+			Values(id, password).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build create password history query: %w", err)
+		}
+
+		if _, err := tx.Exec(query, args...); err != nil {
+			return fmt.Errorf("failed to execute create password history query: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// GetPasswordHistory retrieves the hashes of the latest passwords set by the user, newest first.
+func (r *UserPersistence) GetPasswordHistory(ctx context.Context, id uuid.UUID, limit int) ([][]byte, error) {
+	query, args, err := r.psql.Select("password").From("user_password_history").
+		Where(sq.Eq{"user_id": id}).
+		OrderBy("created_at DESC").
+		Limit(uint64(limit)).
+		ToSql()
+
+	if err != nil {
+		return nil, fmt.Errorf("failed to build get password history query: %w", err)
+	}
+
+	var passwords [][]byte
+	if err := r.db.SelectContext(ctx, &passwords, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to execute get password history query: %w", err)
+	}
+
+	return passwords, nil
+}
+
+// Delete purges a user and their personal data by their ID.
 // Due to CASCADE constraints, this will also delete associated:
 // - notification_preferences
 // - job_profiles
 // - businesses (and their products, services, jobs)
+// - credentials, identities, memberships and admin scopes
+// Their address goes along with them. Reviewed attestations and applications, and lockouts, are
+// kept as records without the user and their free text, open requests are deleted.
 func (r *UserPersistence) Delete(ctx context.Context, id uuid.UUID) error {
-	query, args, err := r.psql.Delete("users").
-		Where(sq.Eq{"id": id}).
-		ToSql()
+	return r.UnitOfWork(ctx, func(tx *sqlx.Tx) error {
+		queries := []sq.Sqlizer{
+			r.psql.Delete("catholic_attestations").
+				Where(sq.Eq{"user_id": id, "status": "pending"}),
+			r.psql.Update("catholic_attestations").
+				Set("sacramental_record", nil).
+				Set("letter_url", nil).
+				Where(sq.Eq{"user_id": id}),
+			r.psql.Delete("entrepreneur_applications").
+				Where(sq.Eq{"user_id": id, "status": []string{"pending", "info_requested"}}),
+			r.psql.Update("entrepreneur_applications").
+				Set("business_references", nil).
+				Where(sq.Eq{"user_id": id}),
+			r.psql.Update("login_lockouts").
+				Set("email", nil).
+				Where(sq.Eq{"user_id": id}),
+		}
 
-	if err != nil {
-		return fmt.Errorf("failed to build delete user query: %w", err)
-	}
+		for _, q := range queries {
+			query, args, err := q.ToSql()
+			if err != nil {
+				return fmt.Errorf("failed to build anonymise user records query: %w", err)
+			}
 
-	result, err := r.db.ExecContext(ctx, query, args...)
-	if err != nil {
-		return fmt.Errorf("failed to execute delete user query: %w", err)
-	}
+			if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+				return fmt.Errorf("failed to execute anonymise user records query: %w", err)
+			}
+		}
+
+		query, args, err := r.psql.Delete("users").
+			Where(sq.Eq{"id": id}).
+			Suffix("RETURNING address_id").
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build delete user query: %w", err)
+		}
+
+		var addressID uuid.UUID
+		if err := tx.GetContext(ctx, &addressID, query, args...); err != nil {
+			if err == sql.ErrNoRows {
+				return domain.ErrUserNotFound
+			}
+
+			return fmt.Errorf("failed to execute delete user query: %w", err)
+		}
+
+		query, args, err = r.psql.Delete("address").
+			Where(sq.Eq{"id": addressID}).
+			ToSql()
+
+		if err != nil {
+			return fmt.Errorf("failed to build delete user address query: %w", err)
+		}
+
+		if _, err := tx.ExecContext(ctx, query, args...); err != nil {
+			return fmt.Errorf("failed to execute delete user address query: %w", err)
+		}
+
+		return nil
+	})
+}
+
+// OwnsSharedBusiness reports whether the user owns a business that other members help run.
+func (r *UserPersistence) OwnsSharedBusiness(ctx context.Context, id uuid.UUID) (bool, error) {
+	query, args, err := r.psql.Select("1").
+		Prefix("SELECT EXISTS (").
+		From("business_members o").
+		Join("business_members m ON m.business_id = o.business_id AND m.user_id <> o.user_id AND m.accepted_at IS NOT NULL").
+		Where(sq.Eq{"o.user_id": id, "o.role": "owner"}).
+		Suffix(")").
+		ToSql()
 
-	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		return fmt.Errorf("failed to get rows affected: %w", err)
+		return false, fmt.Errorf("failed to build owns shared business query: %w", err)
 	}
 
-	if rowsAffected == 0 {
-		return domain.ErrUserNotFound
+	var exists bool
+	if err := r.db.GetContext(ctx, &exists, query, args...); err != nil {
+		return false, fmt.Errorf("failed to execute owns shared business query: %w", err)
 	}
 
-	return nil
+	return exists, nil
 }
 
 // --- Read Methods ---
@@ -184,6 +316,10 @@ func (r *UserPersistence) GetByID(ctx context.Context, id uuid.UUID) (*domain.Us
 		return nil, fmt.Errorf("failed to execute get by id query: %w", err)
 	}
 
+	if err := openIdentifiers(r.keyring, &user); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
@@ -207,14 +343,22 @@ func (r *UserPersistence) GetByEmail(ctx context.Context, email string) (*domain
 		return nil, fmt.Errorf("failed to execute get by email query: %w", err)
 	}
 
+	if err := openIdentifiers(r.keyring, &user); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
-// GetByDocumentID retrieves a single user by their document ID.
+// GetByDocumentID retrieves a single user by their document ID, through its blind index.
+// Users stored before document IDs were encrypted are matched by their plain value.
 func (r *UserPersistence) GetByDocumentID(ctx context.Context, documentID string) (*domain.User, error) {
 	var user domain.User
 	query, args, err := r.psql.Select("*").From("users").
-		Where(sq.Eq{"document_id": documentID}).
+		Where(sq.Or{
+			sq.Eq{"document_id_index": r.keyring.BlindIndex(documentID)},
+			sq.And{sq.Eq{"document_id_index": nil}, sq.Eq{"document_id": documentID}},
+		}).
 		Limit(1).
 		ToSql()
 
@@ -230,19 +374,26 @@ func (r *UserPersistence) GetByDocumentID(ctx context.Context, documentID string
 		return nil, fmt.Errorf("failed to execute get by document id query: %w", err)
 	}
 
+	if err := openIdentifiers(r.keyring, &user); err != nil {
+		return nil, err
+	}
+
 	return &user, nil
 }
 
 func (r *UserPersistence) List(ctx context.Context, filter *domain.UserFilters) ([]*domain.User, error) {
 	queryBuilder := r.psql.Select("*").From("users")
 	queryBuilder = r.buildFilterQuery(queryBuilder, filter)
-	queryBuilder = queryBuilder.OrderBy("created_at DESC")
-
-	if filter.Limit != nil {
-		queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
-	}
-	if filter.Offset != nil {
-		queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+	if filter.Cursor != nil {
+		queryBuilder = pagination.Keyset(queryBuilder, filter.Cursor, true, pagination.Limit(filter.Limit))
+	} else {
+		queryBuilder = queryBuilder.OrderBy("created_at DESC")
+		if filter.Limit != nil {
+			queryBuilder = queryBuilder.Limit(uint64(*filter.Limit))
+		}
+		if filter.Offset != nil {
+			queryBuilder = queryBuilder.Offset(uint64(*filter.Offset))
+		}
 	}
 
 	query, args, err := queryBuilder.ToSql()
@@ -259,6 +410,10 @@ func (r *UserPersistence) List(ctx context.Context, filter *domain.UserFilters)
 		return nil, fmt.Errorf("failed to execute list query: %w", err)
 	}
 
+	if err := openIdentifiers(r.keyring, users...); err != nil {
+		return nil, err
+	}
+
 	return users, nil
 }
 
@@ -309,6 +464,11 @@ func (r *UserPersistence) GetAllByIsEntrepreneur(ctx context.Context, isEntrepre
 	return r.getAllBy(ctx, sq.Eq{"is_entrepreneur": isEntrepreneur})
 }
 
+// GetAllDueForDeletion retrieves the users whose deletion was scheduled up to the given time.
+func (r *UserPersistence) GetAllDueForDeletion(ctx context.Context, before time.Time) ([]*domain.User, error) {
+	return r.getAllBy(ctx, sq.LtOrEq{"deletion_scheduled_at": before})
+}
+
 // helper function to get multiple users based on a condition
 func (r *UserPersistence) getAllBy(ctx context.Context, condition any) ([]*domain.User, error) {
 	var users []*domain.User
@@ -329,6 +489,10 @@ func (r *UserPersistence) getAllBy(ctx context.Context, condition any) ([]*domai
 		return nil, fmt.Errorf("failed to execute get all by query: %w", err)
 	}
 
+	if err := openIdentifiers(r.keyring, users...); err != nil {
+		return nil, err
+	}
+
 	return users, nil
 }
 
@@ -360,6 +524,13 @@ func (r *UserPersistence) buildFilterQuery(baseQuery sq.SelectBuilder, filter *d
 		}
 		baseQuery = baseQuery.Where(nameClause)
 	}
+	if filter.Scope != nil {
+		if filter.Scope.ChurchID.Valid {
+			baseQuery = baseQuery.Where(sq.Eq{"church_id": filter.Scope.ChurchID.UUID})
+		} else {
+			baseQuery = baseQuery.Where(sq.Expr("church_id IN (SELECT id FROM church WHERE diocese = ?)", filter.Scope.Diocese.String))
+		}
+	}
 
 	return baseQuery
 }
@@ -2,6 +2,8 @@ package config
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/constants"
@@ -12,16 +14,31 @@ type (
 	Config struct {
 		Application Application
 		API         API
+		JWT         JWT
+		Encryption  Encryption
 		Database    Database
 		Redis       Redis
 		RabbitMQ    RabbitMQ
 		SMTP        SMTP
+		// OIDC lists the identity providers users can sign in with, such as Google, Apple or Microsoft
+		OIDC     []OIDCProvider
+		WebAuthn WebAuthn
+		// Geocoding finds the coordinates of business locations sent without them
+		Geocoding Geocoding
 	}
 
 	Application struct {
 		Secret string
 		Name   string
 		Env    string
+		// FrontendURL is the public address of the web client, used for the links sent by email
+		FrontendURL string
+		// MagicLinkLogin lets users sign in with a single-use link sent by email instead of their password
+		MagicLinkLogin bool
+		// AccountDeletionGracePeriod is how long users have to change their mind after asking for their account to be deleted
+		AccountDeletionGracePeriod time.Duration
+		// AccountPurgeInterval is how often accounts past their grace period are purged
+		AccountPurgeInterval time.Duration
 	}
 
 	API struct {
@@ -43,9 +60,41 @@ type (
 		MaxAge           int
 	}
 
+	// RateLimiter sets the limits of each route group, counted over a sliding window
 	RateLimiter struct {
-		RequestLimit int
 		WindowLength time.Duration
+		// Auth covers the sign in, registration and account recovery routes
+		Auth RateLimit
+		// Public covers the routes of users and the public listings
+		Public RateLimit
+		// Admin covers the administration routes
+		Admin RateLimit
+	}
+
+	// RateLimit is how many requests a client may make to a route group in each window
+	RateLimit struct {
+		// Anonymous is the limit of each IP address, for requests without a valid access token
+		Anonymous int
+		// User is the limit of each signed in user
+		User int
+		// Roles overrides the User limit for the users of some roles, by role ID
+		Roles map[int16]int
+	}
+
+	JWT struct {
+		// KeysDir holds the PEM keys tokens are signed and verified with, named <kid>.pem
+		KeysDir string
+		// SigningKeyID selects the key new tokens are signed with, needed when KeysDir has several private keys
+		SigningKeyID string
+	}
+
+	Encryption struct {
+		// Keys encrypt the personal identifiers stored by the application, written as <id>:<base64 32-byte key>
+		Keys []string
+		// KeyID selects the key new values are encrypted with, needed when there are several keys
+		KeyID string
+		// BlindIndexKey is the base64 key of the hashes personal identifiers are looked up by, it cannot be rotated
+		BlindIndexKey string
 	}
 
 	Database struct {
@@ -75,6 +124,32 @@ type (
 		Password string
 	}
 
+	OIDCProvider struct {
+		// Name identifies the provider in the API routes and in the identities linked to users
+		Name         string
+		Issuer       string
+		ClientID     string
+		ClientSecret string
+		Scopes       []string
+	}
+
+	WebAuthn struct {
+		// RPID is the domain passkeys are bound to, the web client must be served from it or a subdomain
+		RPID   string
+		RPName string
+		// Origins are the web client origins allowed to register and use passkeys
+		Origins []string
+	}
+
+	Geocoding struct {
+		// Provider names the geocoding service, "nominatim" or empty to disable geocoding
+		Provider string
+		// URL is the address of the provider API, e.g. a self-hosted Nominatim
+		URL string
+		// UserAgent identifies the application to the provider, as the public Nominatim requires
+		UserAgent string
+	}
+
 	SMTP struct {
 		Host     string
 		Port     int
@@ -87,9 +162,13 @@ type (
 func Load() Config {
 	return Config{
 		Application: Application{
-			Secret: env.GetString("APP_SECRET", "my-supa-dupa-app-secret-yes-it-is-okay"),
-			Name:   env.GetString("APP_NAME", "entrepreneur-pastoral"),
-			Env:    env.GetString("APP_ENV", "development"),
+			Secret:                     env.GetString("APP_SECRET", "my-supa-dupa-app-secret-yes-it-is-okay"),
+			Name:                       env.GetString("APP_NAME", "entrepreneur-pastoral"),
+			Env:                        env.GetString("APP_ENV", "development"),
+			FrontendURL:                env.GetString("APP_FRONTEND_URL", "http://localhost:3000"),
+			MagicLinkLogin:             env.GetBool("APP_MAGIC_LINK_LOGIN", false),
+			AccountDeletionGracePeriod: env.GetDuration("APP_ACCOUNT_DELETION_GRACE_PERIOD", 30*24*time.Hour),
+			AccountPurgeInterval:       env.GetDuration("APP_ACCOUNT_PURGE_INTERVAL", 1*time.Hour),
 		},
 		API: API{
 			Host:         env.GetString("API_HOST", "localhost"),
@@ -101,15 +180,31 @@ func Load() Config {
 				AllowedOrigins:   env.GetStringSlice("API_CORS_ALLOWED_ORIGINS", []string{"*"}),
 				AllowedMethods:   env.GetStringSlice("API_CORS_ALLOWED_METHODS", []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}),
 				AllowedHeaders:   env.GetStringSlice("API_CORS_ALLOWED_HEADERS", []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"}),
-				ExposedHeaders:   env.GetStringSlice("API_CORS_EXPOSED_HEADERS", []string{"Link"}),
+				ExposedHeaders:   env.GetStringSlice("API_CORS_EXPOSED_HEADERS", []string{"Link", "RateLimit-Limit", "RateLimit-Remaining", "RateLimit-Policy", "Retry-After"}),
 				AllowCredentials: env.GetBool("API_CORS_ALLOW_CREDENTIALS", false),
 				MaxAge:           env.GetInt("API_CORS_MAX_AGE", 300),
 			},
 			RateLimiter: RateLimiter{
-				RequestLimit: env.GetInt("API_RATE_LIMITER_REQUEST_LIMIT", 10),
 				WindowLength: env.GetDuration("API_RATE_LIMITER_WINDOW_LENGTH", 1*time.Minute),
+				Auth:         loadRateLimit("AUTH", RateLimit{Anonymous: 10, User: 30}),
+				Public: loadRateLimit("PUBLIC", RateLimit{Anonymous: 60, User: 120, Roles: map[int16]int{
+					constants.ROLE_ADMIN:        600,
+					constants.ROLE_ENTREPRENEUR: 240,
+				}}),
+				Admin: loadRateLimit("ADMIN", RateLimit{Anonymous: 10, User: 300, Roles: map[int16]int{
+					constants.ROLE_ADMIN: 1200,
+				}}),
 			},
 		},
+		JWT: JWT{
+			KeysDir:      env.GetString("JWT_KEYS_DIR", "keys"),
+			SigningKeyID: env.GetString("JWT_SIGNING_KEY_ID", ""),
+		},
+		Encryption: Encryption{
+			Keys:          env.GetStringSlice("ENCRYPTION_KEYS", nil),
+			KeyID:         env.GetString("ENCRYPTION_KEY_ID", ""),
+			BlindIndexKey: env.GetString("ENCRYPTION_BLIND_INDEX_KEY", ""),
+		},
 		Database: Database{
 			Host:            env.GetString("DB_HOST", "localhost"),
 			Port:            env.GetInt("DB_PORT", 5432),
@@ -141,7 +236,77 @@ func Load() Config {
 			Password: env.GetString("SMTP_PASSWORD", "password"),
 			From:     env.GetString("SMTP_FROM", "user@example.com"),
 		},
+		OIDC: loadOIDCProviders(),
+		WebAuthn: WebAuthn{
+			RPID:    env.GetString("WEBAUTHN_RP_ID", "localhost"),
+			RPName:  env.GetString("WEBAUTHN_RP_NAME", "Entrepreneur Pastoral"),
+			Origins: env.GetStringSlice("WEBAUTHN_ORIGINS", []string{"http://localhost:3000"}),
+		},
+		Geocoding: Geocoding{
+			Provider:  env.GetString("GEOCODING_PROVIDER", ""),
+			URL:       env.GetString("GEOCODING_URL", "https://nominatim.openstreetmap.org"),
+			UserAgent: env.GetString("GEOCODING_USER_AGENT", "entrepreneur-pastoral"),
+		},
+	}
+}
+
+// loadOIDCProviders reads the providers named in OIDC_PROVIDERS, each configured with its
+// own OIDC_<NAME>_* variables, e.g. OIDC_GOOGLE_ISSUER for the "google" provider
+func loadOIDCProviders() []OIDCProvider {
+	var providers []OIDCProvider
+	for _, name := range env.GetStringSlice("OIDC_PROVIDERS", nil) {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name == "" {
+			continue
+		}
+
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		providers = append(providers, OIDCProvider{
+			Name:         name,
+			Issuer:       env.GetString(prefix+"ISSUER", ""),
+			ClientID:     env.GetString(prefix+"CLIENT_ID", ""),
+			ClientSecret: env.GetString(prefix+"CLIENT_SECRET", ""),
+			Scopes:       env.GetStringSlice(prefix+"SCOPES", []string{"openid", "email", "profile"}),
+		})
 	}
+
+	return providers
+}
+
+// loadRateLimit reads the limits of a route group from its API_RATE_LIMITER_<GROUP>_* variables.
+// Role limits are written as space separated <role id>:<limit> pairs, e.g. "1:600 4:240".
+func loadRateLimit(group string, def RateLimit) RateLimit {
+	prefix := "API_RATE_LIMITER_" + group + "_"
+	limit := RateLimit{
+		Anonymous: env.GetInt(prefix+"ANONYMOUS", def.Anonymous),
+		User:      env.GetInt(prefix+"USER", def.User),
+		Roles:     def.Roles,
+	}
+
+	roles := env.GetStringSlice(prefix+"ROLES", nil)
+	if roles == nil {
+		return limit
+	}
+
+	limit.Roles = make(map[int16]int, len(roles))
+	for _, role := range roles {
+		id, value, ok := strings.Cut(strings.TrimSpace(role), ":")
+		if !ok {
+			continue
+		}
+
+		roleID, err := strconv.ParseInt(id, 10, 16)
+		if err != nil {
+			continue
+		}
+		requests, err := strconv.Atoi(value)
+		if err != nil {
+			continue
+		}
+		limit.Roles[int16(roleID)] = requests
+	}
+
+	return limit
 }
 
 func (a Application) IsDevelopment() bool {
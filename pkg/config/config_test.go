@@ -0,0 +1,58 @@
+package config
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLoadRateLimit(t *testing.T) {
+	def := RateLimit{Anonymous: 60, User: 120, Roles: map[int16]int{1: 600}}
+
+	tests := []struct {
+		name     string
+		env      map[string]string
+		expected RateLimit
+	}{
+		{
+			name:     "no variables keeps the defaults",
+			expected: def,
+		},
+		{
+			name: "anonymous and user limits",
+			env: map[string]string{
+				"API_RATE_LIMITER_TEST_ANONYMOUS": "10",
+				"API_RATE_LIMITER_TEST_USER":      "30",
+			},
+			expected: RateLimit{Anonymous: 10, User: 30, Roles: map[int16]int{1: 600}},
+		},
+		{
+			name:     "role limits replace the defaults",
+			env:      map[string]string{"API_RATE_LIMITER_TEST_ROLES": "2:300 4:240"},
+			expected: RateLimit{Anonymous: 60, User: 120, Roles: map[int16]int{2: 300, 4: 240}},
+		},
+		{
+			name:     "malformed pairs are skipped",
+			env:      map[string]string{"API_RATE_LIMITER_TEST_ROLES": "1:600 admin:300 2:many 3 70000:5 4:240"},
+			expected: RateLimit{Anonymous: 60, User: 120, Roles: map[int16]int{1: 600, 4: 240}},
+		},
+		{
+			name:     "empty roles clear the defaults",
+			env:      map[string]string{"API_RATE_LIMITER_TEST_ROLES": ""},
+			expected: RateLimit{Anonymous: 60, User: 120, Roles: map[int16]int{}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, value := range tt.env {
+				t.Setenv(key, value)
+			}
+
+			result := loadRateLimit("TEST", def)
+
+			if !reflect.DeepEqual(result, tt.expected) {
+				t.Errorf("Expected %+v, got %+v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,9 @@
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_user_recovery_codes_user_id;
+DROP TABLE IF EXISTS user_recovery_codes;
+
+-- Triggers must be dropped before the table.
+DROP TRIGGER IF EXISTS set_timestamp_user_two_factor ON user_two_factor;
+DROP TABLE IF EXISTS user_two_factor;
+
+ALTER TABLE users DROP COLUMN IF EXISTS is_two_factor_enabled;
@@ -0,0 +1,50 @@
+-- Flag checked at login to decide whether a second factor is required.
+ALTER TABLE users ADD COLUMN IF NOT EXISTS is_two_factor_enabled BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- Table: user_two_factor
+-- Stores the TOTP shared secret of a user (one-to-one with users).
+-- A row without enabled_at is a pending enrollment.
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id UUID PRIMARY KEY, -- This is both PK and FK
+    secret VARCHAR(64) NOT NULL,
+    last_used_step BIGINT NOT NULL DEFAULT 0, -- Last accepted time step, prevents code replays
+    enabled_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+-- Apply the trigger to 'updated_at' column
+CREATE TRIGGER set_timestamp_user_two_factor
+BEFORE UPDATE ON user_two_factor
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Table: user_recovery_codes
+-- Stores the hashed one-time recovery codes of a user.
+CREATE TABLE IF NOT EXISTS user_recovery_codes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    code_hash BYTEA NOT NULL,
+    used_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_recovery_codes_user_id ON user_recovery_codes(user_id);
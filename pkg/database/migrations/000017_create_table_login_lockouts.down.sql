@@ -0,0 +1,5 @@
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_login_lockouts_created_at;
+DROP INDEX IF EXISTS idx_login_lockouts_email;
+DROP INDEX IF EXISTS idx_login_lockouts_user_id;
+DROP TABLE IF EXISTS login_lockouts;
@@ -0,0 +1,27 @@
+-- Table: login_lockouts
+-- Audit trail of lockouts triggered by repeated failed logins, for administrators.
+-- Account lockouts carry the targeted email (and user when it exists), IP lockouts only the address.
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID,
+    scope VARCHAR(16) NOT NULL CHECK (scope IN ('account', 'ip')),
+    email CITEXT,
+    ip_address VARCHAR(64) NOT NULL,
+    failed_attempts INTEGER NOT NULL,
+    locked_until TIMESTAMPTZ NOT NULL,
+    unlocked_at TIMESTAMPTZ, -- Set when the lockout is lifted before locked_until
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_login_lockouts_user_id ON login_lockouts(user_id);
+CREATE INDEX idx_login_lockouts_email ON login_lockouts(email);
+CREATE INDEX idx_login_lockouts_created_at ON login_lockouts(created_at);
@@ -0,0 +1,19 @@
+-- Table: user_password_history
+-- Hashes of the passwords a user has set, used to reject the reuse of recent passwords.
+CREATE TABLE IF NOT EXISTS user_password_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    password BYTEA NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_user_password_history_user_id ON user_password_history(user_id, created_at DESC);
@@ -0,0 +1,23 @@
+-- Table: user_identities
+-- Links a user to the accounts they sign in with at external OpenID Connect providers.
+CREATE TABLE IF NOT EXISTS user_identities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    provider VARCHAR(50) NOT NULL, -- Provider name from the configuration, e.g. 'google'
+    subject VARCHAR(255) NOT NULL, -- The 'sub' claim, stable for the account at the provider
+    email CITEXT, -- Email reported by the provider when the identity was linked
+    last_login_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT uq_user_identities_provider_subject UNIQUE (provider, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
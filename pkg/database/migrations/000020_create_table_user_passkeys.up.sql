@@ -0,0 +1,24 @@
+-- Table: user_passkeys
+-- WebAuthn credentials users sign in with, as a first factor or as their second factor.
+CREATE TABLE IF NOT EXISTS user_passkeys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    credential_id BYTEA NOT NULL, -- Chosen by the authenticator, sent back on every login
+    public_key BYTEA NOT NULL, -- COSE encoded credential public key
+    sign_count BIGINT NOT NULL DEFAULT 0, -- Authenticator counter, must increase on every login
+    name VARCHAR(100) NOT NULL, -- Friendly name chosen by the user, e.g. 'Work laptop'
+    last_used_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT uq_user_passkeys_credential_id UNIQUE (credential_id)
+);
+
+CREATE INDEX idx_user_passkeys_user_id ON user_passkeys(user_id);
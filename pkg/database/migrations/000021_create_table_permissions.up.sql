@@ -0,0 +1,52 @@
+-- Table: permissions
+-- Stores the actions guarded by the API, e.g. 'business.approve'.
+CREATE TABLE IF NOT EXISTS permissions (
+    id SMALLSERIAL PRIMARY KEY,
+    key VARCHAR(100) NOT NULL UNIQUE,
+    description TEXT
+);
+
+-- Table: role_permissions
+-- Grants permissions to roles. The Admin role holds every permission without being listed here.
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_id SMALLINT NOT NULL,
+    permission_id SMALLINT NOT NULL,
+
+    -- Constraints
+    PRIMARY KEY (role_id, permission_id),
+    CONSTRAINT fk_role
+        FOREIGN KEY(role_id)
+        REFERENCES roles(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_permission
+        FOREIGN KEY(permission_id)
+        REFERENCES permissions(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_role_permissions_permission_id ON role_permissions(permission_id);
+
+-- Add default permissions
+INSERT INTO permissions (key, description)
+VALUES
+    ('user.read', 'View users and login lockouts'),
+    ('user.manage', 'Activate users and change their catholic and entrepreneur flags'),
+    ('user.assign_role', 'Change the role of a user'),
+    ('business.read', 'View businesses, including inactive ones'),
+    ('business.approve', 'Activate and deactivate businesses'),
+    ('church.manage', 'Create, update and delete churches'),
+    ('industry.manage', 'Create, update and delete industries'),
+    ('field_of_work.manage', 'Create, update and delete fields of work'),
+    ('role.manage', 'Manage roles, permissions and their grants')
+ON CONFLICT (key) DO NOTHING;
+
+-- Grant the defaults: Manager gets the Admin module except role management, Assistant can only look
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON
+    (r.name = 'Manager' AND p.key NOT IN ('user.assign_role', 'role.manage'))
+    OR (r.name = 'Assistant' AND p.key IN ('user.read', 'business.read'))
+ON CONFLICT DO NOTHING;
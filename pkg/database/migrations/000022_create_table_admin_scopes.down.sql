@@ -0,0 +1,5 @@
+DELETE FROM permissions WHERE key = 'user.assign_scope';
+
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_church_diocese;
+DROP TABLE IF EXISTS admin_scopes;
@@ -0,0 +1,31 @@
+-- Table: admin_scopes
+-- Limits the administration rights of a user to the people of one church or of a whole diocese.
+-- Users without a row administer every church.
+CREATE TABLE IF NOT EXISTS admin_scopes (
+    user_id UUID PRIMARY KEY,
+    church_id UUID, -- Set when the scope is a single church
+    diocese VARCHAR(255), -- Set when the scope is every church of a diocese, matches church.diocese
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_church
+        FOREIGN KEY(church_id)
+        REFERENCES church(id)
+        ON DELETE RESTRICT -- Dropping the scope with the church would widen it to every church
+        ON UPDATE CASCADE,
+    CONSTRAINT chk_admin_scopes_church_or_diocese CHECK ((church_id IS NULL) <> (diocese IS NULL))
+);
+
+CREATE INDEX idx_church_diocese ON church(diocese);
+
+-- Add the permission to assign scopes, held by Admin only until granted
+INSERT INTO permissions (key, description)
+VALUES ('user.assign_scope', 'Limit users to the church or diocese they administer')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,7 @@
+DELETE FROM permissions WHERE key = 'user.review_attestation';
+
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS uq_catholic_attestations_pending;
+DROP INDEX IF EXISTS idx_catholic_attestations_church_status;
+DROP INDEX IF EXISTS idx_catholic_attestations_user_id;
+DROP TABLE IF EXISTS catholic_attestations;
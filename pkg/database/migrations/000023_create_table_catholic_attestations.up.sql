@@ -0,0 +1,52 @@
+-- Table: catholic_attestations
+-- Requests of users to be recognised as Catholic, reviewed by a manager of the parish they name.
+-- Rows are kept once reviewed, so that every request of a user stays on record.
+CREATE TABLE IF NOT EXISTS catholic_attestations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    church_id UUID NOT NULL, -- Parish of the user, whose managers review the request
+    sacramental_record VARCHAR(255), -- Reference of the baptism or confirmation entry in the parish register
+    letter_url VARCHAR(255), -- Uploaded letter from the parish priest
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewer_id UUID,
+    review_comment TEXT,
+    reviewed_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_church
+        FOREIGN KEY(church_id)
+        REFERENCES church(id)
+        ON DELETE RESTRICT
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_reviewer
+        FOREIGN KEY(reviewer_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL -- The review stands when the reviewer's account is removed
+        ON UPDATE CASCADE,
+    CONSTRAINT chk_catholic_attestations_status CHECK (status IN ('pending', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_catholic_attestations_user_id ON catholic_attestations(user_id);
+CREATE INDEX idx_catholic_attestations_church_status ON catholic_attestations(church_id, status);
+-- A user waits on a single request at a time
+CREATE UNIQUE INDEX uq_catholic_attestations_pending ON catholic_attestations(user_id) WHERE status = 'pending';
+
+-- Add the permission to review attestations and grant it to managers
+INSERT INTO permissions (key, description)
+VALUES ('user.review_attestation', 'Approve or reject the Catholic attestations of parishioners')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.key = 'user.review_attestation'
+WHERE r.name = 'Manager'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,10 @@
+DELETE FROM permissions WHERE key = 'user.review_entrepreneur';
+
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS uq_entrepreneur_applications_open;
+DROP INDEX IF EXISTS idx_entrepreneur_applications_status;
+DROP INDEX IF EXISTS idx_entrepreneur_applications_user_id;
+
+-- Triggers must be dropped before the table.
+DROP TRIGGER IF EXISTS set_timestamp_entrepreneur_applications ON entrepreneur_applications;
+DROP TABLE IF EXISTS entrepreneur_applications;
@@ -0,0 +1,60 @@
+-- Table: entrepreneur_applications
+-- Requests of users to offer businesses, products, services and jobs, reviewed by admins.
+-- Rows are kept once decided, so that every application of a user stays on record.
+CREATE TABLE IF NOT EXISTS entrepreneur_applications (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL,
+    business_name VARCHAR(255) NOT NULL, -- Business the user intends to run
+    business_description TEXT NOT NULL,
+    industry_id SMALLINT NOT NULL,
+    business_references TEXT, -- People or organisations who can vouch for the user
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    reviewer_id UUID,
+    review_comment TEXT, -- Reason of the decision, or the information asked for
+    reviewed_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_industry
+        FOREIGN KEY(industry_id)
+        REFERENCES industries(id)
+        ON DELETE RESTRICT
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_reviewer
+        FOREIGN KEY(reviewer_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT chk_entrepreneur_applications_status CHECK (status IN ('pending', 'info_requested', 'approved', 'rejected'))
+);
+
+CREATE INDEX idx_entrepreneur_applications_user_id ON entrepreneur_applications(user_id);
+CREATE INDEX idx_entrepreneur_applications_status ON entrepreneur_applications(status);
+-- A user has a single application open at a time
+CREATE UNIQUE INDEX uq_entrepreneur_applications_open ON entrepreneur_applications(user_id) WHERE status IN ('pending', 'info_requested');
+
+-- Apply the trigger to 'updated_at' column
+CREATE TRIGGER set_timestamp_entrepreneur_applications
+BEFORE UPDATE ON entrepreneur_applications
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Add the permission to review applications and grant it to managers
+INSERT INTO permissions (key, description)
+VALUES ('user.review_entrepreneur', 'Approve, reject or ask for more information on entrepreneur applications')
+ON CONFLICT (key) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id
+FROM roles r
+JOIN permissions p ON p.key = 'user.review_entrepreneur'
+WHERE r.name = 'Manager'
+ON CONFLICT DO NOTHING;
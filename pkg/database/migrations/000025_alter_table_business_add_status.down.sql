@@ -0,0 +1,14 @@
+ALTER TABLE business ADD COLUMN is_active BOOLEAN NOT NULL DEFAULT TRUE;
+
+UPDATE business SET is_active = (status = 'approved');
+
+DROP INDEX IF EXISTS idx_business_status;
+
+ALTER TABLE business
+    DROP CONSTRAINT IF EXISTS chk_business_status,
+    DROP CONSTRAINT IF EXISTS fk_reviewer,
+    DROP COLUMN IF EXISTS reviewed_at,
+    DROP COLUMN IF EXISTS submitted_at,
+    DROP COLUMN IF EXISTS reviewer_id,
+    DROP COLUMN IF EXISTS status_reason,
+    DROP COLUMN IF EXISTS status;
@@ -0,0 +1,23 @@
+-- Table: business
+-- Businesses go from draft through review before they are listed publicly. Admins approve or
+-- reject them and may suspend approved ones, always recording a reason for the owner.
+-- The status replaces the is_active flag the owner could set on their own.
+ALTER TABLE business
+    ADD COLUMN status VARCHAR(20) NOT NULL DEFAULT 'draft',
+    ADD COLUMN status_reason TEXT, -- Why the business was rejected or suspended
+    ADD COLUMN reviewer_id UUID,
+    ADD COLUMN submitted_at TIMESTAMPTZ, -- Last time the owner sent it to review
+    ADD COLUMN reviewed_at TIMESTAMPTZ, -- Last moderation decision
+    ADD CONSTRAINT fk_reviewer
+        FOREIGN KEY(reviewer_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    ADD CONSTRAINT chk_business_status CHECK (status IN ('draft', 'pending_review', 'approved', 'rejected', 'suspended'));
+
+-- Businesses live until now stay listed, deactivated ones are kept out as suspended
+UPDATE business SET status = CASE WHEN is_active THEN 'approved' ELSE 'suspended' END;
+
+ALTER TABLE business DROP COLUMN is_active;
+
+CREATE INDEX idx_business_status ON business(status);
@@ -0,0 +1,7 @@
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS uq_business_members_owner;
+DROP INDEX IF EXISTS idx_business_members_user_id;
+
+-- Triggers must be dropped before the table.
+DROP TRIGGER IF EXISTS set_timestamp_business_members ON business_members;
+DROP TABLE IF EXISTS business_members;
@@ -0,0 +1,50 @@
+-- Table: business_members
+-- People who run a business along with their role in it. Invited members have no accepted_at
+-- until they accept the invitation. business.user_id keeps pointing to the single owner.
+CREATE TABLE IF NOT EXISTS business_members (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    user_id UUID NOT NULL,
+    role VARCHAR(20) NOT NULL,
+    invited_by UUID,
+    accepted_at TIMESTAMPTZ,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_business
+        FOREIGN KEY(business_id)
+        REFERENCES business(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_invited_by
+        FOREIGN KEY(invited_by)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT uq_business_members_user UNIQUE (business_id, user_id),
+    CONSTRAINT chk_business_members_role CHECK (role IN ('owner', 'manager', 'editor'))
+);
+
+CREATE INDEX idx_business_members_user_id ON business_members(user_id);
+-- A business has a single owner
+CREATE UNIQUE INDEX uq_business_members_owner ON business_members(business_id) WHERE role = 'owner';
+
+-- Apply the trigger to 'updated_at' column
+CREATE TRIGGER set_timestamp_business_members
+BEFORE UPDATE ON business_members
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
+
+-- Every existing business is run by its owner
+INSERT INTO business_members (business_id, user_id, role, accepted_at)
+SELECT id, user_id, 'owner', created_at
+FROM business
+ON CONFLICT DO NOTHING;
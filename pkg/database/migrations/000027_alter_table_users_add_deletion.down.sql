@@ -0,0 +1,25 @@
+-- Anonymised rows cannot point back to a user
+DELETE FROM entrepreneur_applications WHERE user_id IS NULL;
+DELETE FROM catholic_attestations WHERE user_id IS NULL;
+
+ALTER TABLE entrepreneur_applications
+    DROP CONSTRAINT fk_user,
+    ALTER COLUMN user_id SET NOT NULL,
+    ADD CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE;
+
+ALTER TABLE catholic_attestations
+    DROP CONSTRAINT fk_user,
+    ALTER COLUMN user_id SET NOT NULL,
+    ADD CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE;
+
+DROP INDEX IF EXISTS idx_users_deletion_scheduled_at;
+
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_scheduled_at;
@@ -0,0 +1,24 @@
+-- Accounts whose owner asked for their deletion are purged once the grace period ends
+ALTER TABLE users ADD COLUMN deletion_scheduled_at TIMESTAMPTZ;
+
+CREATE INDEX idx_users_deletion_scheduled_at ON users(deletion_scheduled_at) WHERE deletion_scheduled_at IS NOT NULL;
+
+-- Reviewed attestations and applications record decisions of the parish and the admins, they are
+-- kept without their author when the account is purged
+ALTER TABLE catholic_attestations
+    ALTER COLUMN user_id DROP NOT NULL,
+    DROP CONSTRAINT fk_user,
+    ADD CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE;
+
+ALTER TABLE entrepreneur_applications
+    ALTER COLUMN user_id DROP NOT NULL,
+    DROP CONSTRAINT fk_user,
+    ADD CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE;
@@ -0,0 +1,8 @@
+DELETE FROM permissions WHERE key = 'user.impersonate';
+
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_impersonation_logs_created_at;
+DROP INDEX IF EXISTS idx_impersonation_logs_user_id;
+DROP INDEX IF EXISTS idx_impersonation_logs_admin_id;
+
+DROP TABLE IF EXISTS impersonation_logs;
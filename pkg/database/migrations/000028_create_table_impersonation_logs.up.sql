@@ -0,0 +1,38 @@
+-- Table: impersonation_logs
+-- Audit trail of the requests admins made while impersonating a user, refused ones included.
+-- Rows are kept when either account is deleted, without the account.
+CREATE TABLE IF NOT EXISTS impersonation_logs (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    admin_id UUID,
+    user_id UUID,
+    session_id VARCHAR(64) NOT NULL, -- Session of the admin the impersonation token was issued from
+    method VARCHAR(10) NOT NULL,
+    path TEXT NOT NULL,
+    status_code SMALLINT NOT NULL,
+    blocked BOOLEAN NOT NULL DEFAULT FALSE, -- Write requests are refused while impersonating
+    ip_address VARCHAR(64) NOT NULL,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_admin
+        FOREIGN KEY(admin_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE,
+    CONSTRAINT fk_user
+        FOREIGN KEY(user_id)
+        REFERENCES users(id)
+        ON DELETE SET NULL
+        ON UPDATE CASCADE
+);
+
+CREATE INDEX idx_impersonation_logs_admin_id ON impersonation_logs(admin_id);
+CREATE INDEX idx_impersonation_logs_user_id ON impersonation_logs(user_id);
+CREATE INDEX idx_impersonation_logs_created_at ON impersonation_logs(created_at);
+
+-- Add the permission to impersonate users, held by admins only
+INSERT INTO permissions (key, description)
+VALUES ('user.impersonate', 'Act as a user to see what they see, and review the impersonation audit log')
+ON CONFLICT (key) DO NOTHING;
@@ -0,0 +1,8 @@
+-- Run `make encryption-rotate ARGS=-decrypt` first, encrypted values do not fit the original columns.
+DROP INDEX IF EXISTS uq_users_document_id_index;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS document_id_index,
+    ALTER COLUMN phone_number TYPE VARCHAR(20),
+    ALTER COLUMN document_id TYPE VARCHAR(100),
+    ADD CONSTRAINT users_document_id_key UNIQUE (document_id);
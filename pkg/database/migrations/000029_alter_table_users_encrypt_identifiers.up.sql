@@ -0,0 +1,11 @@
+-- Document IDs and phone numbers are encrypted by the application, each value under its own key,
+-- so they no longer fit their original sizes and equal values no longer look alike.
+-- Uniqueness of document IDs moves to document_id_index, a keyed hash of the plain value.
+-- Existing rows keep their plain values until `make encryption-rotate` encrypts them and fills the index.
+ALTER TABLE users
+    DROP CONSTRAINT IF EXISTS users_document_id_key,
+    ALTER COLUMN document_id TYPE TEXT,
+    ALTER COLUMN phone_number TYPE TEXT,
+    ADD COLUMN document_id_index VARCHAR(64);
+
+CREATE UNIQUE INDEX uq_users_document_id_index ON users(document_id_index);
@@ -0,0 +1,14 @@
+DROP INDEX IF EXISTS idx_jobs_search_vector;
+DROP INDEX IF EXISTS idx_services_search_vector;
+DROP INDEX IF EXISTS idx_products_search_vector;
+DROP INDEX IF EXISTS idx_business_search_vector;
+
+ALTER TABLE jobs DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE services DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE products DROP COLUMN IF EXISTS search_vector;
+ALTER TABLE business DROP COLUMN IF EXISTS search_vector;
+
+DROP TEXT SEARCH CONFIGURATION IF EXISTS english_unaccent;
+DROP TEXT SEARCH CONFIGURATION IF EXISTS portuguese_unaccent;
+
+DROP EXTENSION IF EXISTS unaccent;
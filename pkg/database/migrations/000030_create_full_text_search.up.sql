@@ -0,0 +1,46 @@
+-- Full-text search over businesses, products, services and jobs.
+-- Words are unaccented before they are stemmed, so "cafe" finds "café" and the other way around,
+-- and every document is indexed with both the Portuguese and the English dictionaries.
+CREATE EXTENSION IF NOT EXISTS unaccent;
+
+CREATE TEXT SEARCH CONFIGURATION portuguese_unaccent (COPY = portuguese);
+ALTER TEXT SEARCH CONFIGURATION portuguese_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, portuguese_stem;
+
+CREATE TEXT SEARCH CONFIGURATION english_unaccent (COPY = english);
+ALTER TEXT SEARCH CONFIGURATION english_unaccent
+    ALTER MAPPING FOR hword, hword_part, word WITH unaccent, english_stem;
+
+-- Names and titles weigh more than descriptions in the ranking
+ALTER TABLE business ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese_unaccent', name), 'A') ||
+    setweight(to_tsvector('english_unaccent', name), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent', description), 'B') ||
+    setweight(to_tsvector('english_unaccent', description), 'B')
+) STORED;
+
+ALTER TABLE products ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese_unaccent', name), 'A') ||
+    setweight(to_tsvector('english_unaccent', name), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent', description), 'B') ||
+    setweight(to_tsvector('english_unaccent', description), 'B')
+) STORED;
+
+ALTER TABLE services ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese_unaccent', name), 'A') ||
+    setweight(to_tsvector('english_unaccent', name), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent', description), 'B') ||
+    setweight(to_tsvector('english_unaccent', description), 'B')
+) STORED;
+
+ALTER TABLE jobs ADD COLUMN search_vector TSVECTOR GENERATED ALWAYS AS (
+    setweight(to_tsvector('portuguese_unaccent', title), 'A') ||
+    setweight(to_tsvector('english_unaccent', title), 'A') ||
+    setweight(to_tsvector('portuguese_unaccent', description), 'B') ||
+    setweight(to_tsvector('english_unaccent', description), 'B')
+) STORED;
+
+CREATE INDEX idx_business_search_vector ON business USING GIN (search_vector);
+CREATE INDEX idx_products_search_vector ON products USING GIN (search_vector);
+CREATE INDEX idx_services_search_vector ON services USING GIN (search_vector);
+CREATE INDEX idx_jobs_search_vector ON jobs USING GIN (search_vector);
@@ -0,0 +1,8 @@
+DROP INDEX IF EXISTS idx_users_created_at_id;
+DROP INDEX IF EXISTS idx_church_created_at_id;
+DROP INDEX IF EXISTS idx_jobs_created_at_id;
+DROP INDEX IF EXISTS idx_services_created_at_id;
+DROP INDEX IF EXISTS idx_products_created_at_id;
+DROP INDEX IF EXISTS idx_business_created_at_id;
+
+ALTER TABLE church DROP COLUMN IF EXISTS created_at;
@@ -0,0 +1,10 @@
+-- Lists are paged by (created_at, id), the id telling apart rows created at the same time.
+-- Churches predate the column, the existing ones share the time of the migration.
+ALTER TABLE church ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX idx_business_created_at_id ON business(created_at, id);
+CREATE INDEX idx_products_created_at_id ON products(created_at, id);
+CREATE INDEX idx_services_created_at_id ON services(created_at, id);
+CREATE INDEX idx_jobs_created_at_id ON jobs(created_at, id);
+CREATE INDEX idx_church_created_at_id ON church(created_at, id);
+CREATE INDEX idx_users_created_at_id ON users(created_at, id);
@@ -0,0 +1,10 @@
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_business_locations_earth;
+DROP INDEX IF EXISTS idx_business_locations_business_id;
+
+-- Triggers must be dropped before the table.
+DROP TRIGGER IF EXISTS set_timestamp_business_locations ON business_locations;
+DROP TABLE IF EXISTS business_locations;
+
+DROP EXTENSION IF EXISTS earthdistance;
+DROP EXTENSION IF EXISTS cube;
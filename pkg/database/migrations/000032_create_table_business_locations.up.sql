@@ -0,0 +1,45 @@
+-- Locations are searched by distance with earthdistance, which measures on a sphere and needs cube.
+CREATE EXTENSION IF NOT EXISTS cube;
+CREATE EXTENSION IF NOT EXISTS earthdistance;
+
+-- Table: business_locations
+-- The places a business works from. A business serves the people within service_radius_km of
+-- a location, 0 meaning it only serves them at the location itself.
+CREATE TABLE IF NOT EXISTS business_locations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    label VARCHAR(100),
+    street_line_1 VARCHAR(255) NOT NULL,
+    street_line_2 VARCHAR(255),
+    city VARCHAR(100) NOT NULL,
+    state_province VARCHAR(100) NOT NULL,
+    postal_code VARCHAR(20) NOT NULL,
+    country VARCHAR(100) NOT NULL,
+    latitude DOUBLE PRECISION NOT NULL,
+    longitude DOUBLE PRECISION NOT NULL,
+    service_radius_km NUMERIC(6, 2) NOT NULL DEFAULT 0,
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_business
+        FOREIGN KEY(business_id)
+        REFERENCES business(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT chk_business_locations_latitude CHECK (latitude BETWEEN -90 AND 90),
+    CONSTRAINT chk_business_locations_longitude CHECK (longitude BETWEEN -180 AND 180),
+    -- Bounded so the distance searches can use the index, see domain.MaxServiceRadiusKm
+    CONSTRAINT chk_business_locations_service_radius CHECK (service_radius_km BETWEEN 0 AND 100)
+);
+
+CREATE INDEX idx_business_locations_business_id ON business_locations(business_id);
+CREATE INDEX idx_business_locations_earth ON business_locations USING GIST (ll_to_earth(latitude, longitude));
+
+-- Apply the trigger to 'updated_at' column
+CREATE TRIGGER set_timestamp_business_locations
+BEFORE UPDATE ON business_locations
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
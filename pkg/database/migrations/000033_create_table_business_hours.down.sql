@@ -0,0 +1,9 @@
+-- Triggers must be dropped before the table.
+DROP TRIGGER IF EXISTS set_timestamp_business_hour_exceptions ON business_hour_exceptions;
+DROP TABLE IF EXISTS business_hour_exceptions;
+
+-- Indexes must be dropped before the table.
+DROP INDEX IF EXISTS idx_business_hours_business_id;
+DROP TABLE IF EXISTS business_hours;
+
+ALTER TABLE business_locations DROP COLUMN IF EXISTS timezone;
@@ -0,0 +1,59 @@
+-- Hours are kept in the local time of each location, which carries its timezone.
+ALTER TABLE business_locations ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'America/Sao_Paulo';
+
+-- Table: business_hours
+-- The weekly schedule of a business, as periods it is open on a day of the week, 0 being Sunday.
+-- A period ends on the day it starts, closing at 24:00 at the latest.
+CREATE TABLE IF NOT EXISTS business_hours (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    weekday SMALLINT NOT NULL,
+    opens_at TIME NOT NULL,
+    closes_at TIME NOT NULL,
+
+    -- Constraints
+    CONSTRAINT fk_business
+        FOREIGN KEY(business_id)
+        REFERENCES business(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT chk_business_hours_weekday CHECK (weekday BETWEEN 0 AND 6),
+    CONSTRAINT chk_business_hours_period CHECK (opens_at < closes_at)
+);
+
+CREATE INDEX idx_business_hours_business_id ON business_hours(business_id, weekday);
+
+-- Table: business_hour_exceptions
+-- Dates the weekly schedule does not apply, such as holy days of obligation. The business is
+-- either closed the whole day or open during the special hours.
+CREATE TABLE IF NOT EXISTS business_hour_exceptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    business_id UUID NOT NULL,
+    date DATE NOT NULL,
+    closed BOOLEAN NOT NULL DEFAULT TRUE,
+    opens_at TIME,
+    closes_at TIME,
+    note VARCHAR(255),
+
+    -- Timestamps
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Constraints
+    CONSTRAINT fk_business
+        FOREIGN KEY(business_id)
+        REFERENCES business(id)
+        ON DELETE CASCADE
+        ON UPDATE CASCADE,
+    CONSTRAINT uq_business_hour_exceptions_date UNIQUE (business_id, date),
+    CONSTRAINT chk_business_hour_exceptions_hours CHECK (
+        (closed AND opens_at IS NULL AND closes_at IS NULL) OR
+        (NOT closed AND opens_at IS NOT NULL AND closes_at IS NOT NULL AND opens_at < closes_at)
+    )
+);
+
+-- Apply the trigger to 'updated_at' column
+CREATE TRIGGER set_timestamp_business_hour_exceptions
+BEFORE UPDATE ON business_hour_exceptions
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_timestamp();
@@ -0,0 +1,61 @@
+// Package geocoding finds the coordinates of postal addresses. Providers plug in through the
+// Geocoder interface, Nominatim being the one built in.
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+)
+
+var (
+	ErrNotFound    = errors.New("address not found")
+	ErrUnavailable = errors.New("geocoding provider unavailable")
+)
+
+// Address is the postal address to geocode
+type Address struct {
+	StreetLine1   string
+	StreetLine2   string
+	City          string
+	StateProvince string
+	PostalCode    string
+	Country       string
+}
+
+// String writes the address on a single line, from the street to the country
+func (a Address) String() string {
+	var parts []string
+	for _, part := range []string{a.StreetLine1, a.StreetLine2, a.City, a.StateProvince, a.PostalCode, a.Country} {
+		if part = strings.TrimSpace(part); part != "" {
+			parts = append(parts, part)
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+type Coordinates struct {
+	Latitude  float64
+	Longitude float64
+}
+
+// Geocoder finds the coordinates of an address. It returns ErrNotFound when the provider does not
+// know the address, and ErrUnavailable when the provider could not be reached or failed.
+type Geocoder interface {
+	Geocode(ctx context.Context, address Address) (Coordinates, error)
+}
+
+// New returns the geocoder of the configured provider, or nil when geocoding is disabled
+func New(cfg config.Geocoding) (Geocoder, error) {
+	switch strings.ToLower(cfg.Provider) {
+	case "":
+		return nil, nil
+	case "nominatim":
+		return NewNominatim(cfg, nil), nil
+	default:
+		return nil, fmt.Errorf("unknown geocoding provider %q", cfg.Provider)
+	}
+}
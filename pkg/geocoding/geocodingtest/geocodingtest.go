@@ -0,0 +1,53 @@
+// Package geocodingtest geocodes addresses offline for tests. The stub only knows the addresses
+// it is given and never reaches a provider.
+package geocodingtest
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/geocoding"
+)
+
+type Stub struct {
+	mu        sync.Mutex
+	addresses map[string]geocoding.Coordinates
+	// Err, when set, is returned by every lookup, e.g. geocoding.ErrUnavailable
+	Err error
+	// Calls counts the lookups, known addresses or not
+	Calls int
+}
+
+func NewStub() *Stub {
+	return &Stub{addresses: map[string]geocoding.Coordinates{}}
+}
+
+// Add makes the stub return the coordinates for the address, matched regardless of case
+func (s *Stub) Add(address geocoding.Address, coordinates geocoding.Coordinates) *Stub {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.addresses[key(address)] = coordinates
+	return s
+}
+
+func (s *Stub) Geocode(ctx context.Context, address geocoding.Address) (geocoding.Coordinates, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.Calls++
+	if s.Err != nil {
+		return geocoding.Coordinates{}, s.Err
+	}
+
+	coordinates, ok := s.addresses[key(address)]
+	if !ok {
+		return geocoding.Coordinates{}, geocoding.ErrNotFound
+	}
+	return coordinates, nil
+}
+
+func key(address geocoding.Address) string {
+	return strings.ToLower(address.String())
+}
@@ -0,0 +1,91 @@
+package geocoding
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+)
+
+// maxResponseSize bounds the responses read from the provider
+const maxResponseSize = 1 << 20
+
+// Nominatim geocodes with the OpenStreetMap search API, either the public one or a self-hosted
+// instance. The public one allows a request per second and asks every client to identify itself.
+type Nominatim struct {
+	config config.Geocoding
+	client *http.Client
+}
+
+func NewNominatim(cfg config.Geocoding, client *http.Client) *Nominatim {
+	if client == nil {
+		client = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	return &Nominatim{
+		config: cfg,
+		client: client,
+	}
+}
+
+// Geocode looks the address up by its structured fields and returns the best match
+func (n *Nominatim) Geocode(ctx context.Context, address Address) (Coordinates, error) {
+	street := strings.TrimSpace(strings.Join([]string{address.StreetLine1, address.StreetLine2}, " "))
+	params := url.Values{
+		"format":     {"jsonv2"},
+		"limit":      {"1"},
+		"street":     {street},
+		"city":       {address.City},
+		"state":      {address.StateProvince},
+		"postalcode": {address.PostalCode},
+		"country":    {address.Country},
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, strings.TrimRight(n.config.URL, "/")+"/search?"+params.Encode(), nil)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", n.config.UserAgent)
+
+	res, err := n.client.Do(req)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		return Coordinates{}, fmt.Errorf("%w: unexpected status %d", ErrUnavailable, res.StatusCode)
+	}
+
+	// Nominatim writes the coordinates as strings
+	var places []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(io.LimitReader(res.Body, maxResponseSize)).Decode(&places); err != nil {
+		return Coordinates{}, fmt.Errorf("%w: %v", ErrUnavailable, err)
+	}
+
+	if len(places) == 0 {
+		return Coordinates{}, ErrNotFound
+	}
+
+	latitude, err := strconv.ParseFloat(places[0].Lat, 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: invalid latitude %q", ErrUnavailable, places[0].Lat)
+	}
+	longitude, err := strconv.ParseFloat(places[0].Lon, 64)
+	if err != nil {
+		return Coordinates{}, fmt.Errorf("%w: invalid longitude %q", ErrUnavailable, places[0].Lon)
+	}
+
+	return Coordinates{Latitude: latitude, Longitude: longitude}, nil
+}
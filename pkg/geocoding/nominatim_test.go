@@ -0,0 +1,82 @@
+package geocoding
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+)
+
+var praca = Address{
+	StreetLine1:   "Praça da Sé",
+	City:          "São Paulo",
+	StateProvince: "SP",
+	PostalCode:    "01001-000",
+	Country:       "Brazil",
+}
+
+func TestNominatim_Geocode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if r.URL.Path != "/search" || r.Header.Get("User-Agent") != "test-agent" {
+			http.Error(w, "bad request", http.StatusBadRequest)
+			return
+		}
+
+		switch query.Get("city") {
+		case "São Paulo":
+			w.Write([]byte(`[{"lat": "-23.5503", "lon": "-46.6339"}]`))
+		case "Nowhere":
+			w.Write([]byte(`[]`))
+		default:
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	geocoder := NewNominatim(config.Geocoding{URL: server.URL + "/", UserAgent: "test-agent"}, nil)
+	ctx := context.Background()
+
+	coordinates, err := geocoder.Geocode(ctx, praca)
+	if err != nil {
+		t.Fatalf("Failed to geocode: %v", err)
+	}
+	if coordinates.Latitude != -23.5503 || coordinates.Longitude != -46.6339 {
+		t.Errorf("Unexpected coordinates: %+v", coordinates)
+	}
+
+	t.Run("unknown address", func(t *testing.T) {
+		if _, err := geocoder.Geocode(ctx, Address{City: "Nowhere"}); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, got %v", err)
+		}
+	})
+
+	t.Run("provider failure", func(t *testing.T) {
+		if _, err := geocoder.Geocode(ctx, Address{City: "Elsewhere"}); !errors.Is(err, ErrUnavailable) {
+			t.Errorf("Expected ErrUnavailable, got %v", err)
+		}
+	})
+}
+
+func TestNew(t *testing.T) {
+	if geocoder, err := New(config.Geocoding{}); geocoder != nil || err != nil {
+		t.Errorf("Expected geocoding to be disabled, got %v, %v", geocoder, err)
+	}
+	if geocoder, err := New(config.Geocoding{Provider: "Nominatim"}); err != nil {
+		t.Errorf("Failed to create Nominatim geocoder: %v", err)
+	} else if _, ok := geocoder.(*Nominatim); !ok {
+		t.Errorf("Expected a Nominatim geocoder, got %T", geocoder)
+	}
+	if _, err := New(config.Geocoding{Provider: "unknown"}); err == nil {
+		t.Error("Expected an error for an unknown provider")
+	}
+}
+
+func TestAddress_String(t *testing.T) {
+	if got := praca.String(); got != "Praça da Sé, São Paulo, SP, 01001-000, Brazil" {
+		t.Errorf("Unexpected address: %q", got)
+	}
+}
@@ -14,6 +14,18 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	// AccessTokenExpiry is kept short since access tokens are renewed through the refresh endpoint.
+	AccessTokenExpiry = 15 * time.Minute
+	// RefreshTokenExpiry bounds how long a refresh token family can stay idle before a new login is required.
+	RefreshTokenExpiry = 7 * 24 * time.Hour
+	// ImpersonationTokenExpiry bounds how long an admin may act as a user, impersonation tokens are never refreshed.
+	ImpersonationTokenExpiry = 30 * time.Minute
+
+	refreshTokenCookieName = "rt"
+	refreshTokenCookiePath = "/api/v1/auth"
+)
+
 var (
 	ErrTokenExpired = jwt.ErrTokenExpired
 	ErrInvalidToken = errors.New("invalid or expired token")
@@ -23,30 +35,58 @@ var (
 // Claims defines the structure of the JWT claims.
 type Claims struct {
 	UserID string `json:"user_id"`
+	// RoleID is the role of the user when the token was issued, it only sizes their rate limits:
+	// authorization reads the current role of the user
+	RoleID int16 `json:"role_id,omitempty"`
+	// ImpersonatorID is the admin acting as the user, set on impersonation tokens only
+	ImpersonatorID string `json:"impersonator_id,omitempty"`
 	jwt.RegisteredClaims
 }
 
-// TokenManager
+// TokenManager signs access tokens with the signing key of its KeySet and accepts
+// tokens from any key of the set, see KeySet for how keys are rotated.
 type TokenManager struct {
-	secret string
+	keys *KeySet
 }
 
-func NewTokenManager(secret string) *TokenManager {
-	return &TokenManager{secret: secret}
+func NewTokenManager(keys *KeySet) *TokenManager {
+	return &TokenManager{keys: keys}
 }
 
 // GenerateToken creates a new JWT token for the given user ID.
-func (t *TokenManager) GenerateToken(userID string) (string, error) {
-	claims := &Claims{
+// The session ID is carried in the "jti" claim so the token can be revoked server-side.
+func (t *TokenManager) GenerateToken(userID string, roleID int16, sessionID string) (string, error) {
+	return t.sign(&Claims{
 		UserID: userID,
+		RoleID: roleID,
 		RegisteredClaims: jwt.RegisteredClaims{
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(AccessTokenExpiry)),
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 		},
-	}
+	})
+}
+
+// GenerateImpersonationToken creates a JWT token letting the admin act as the user.
+// It carries the session of the admin, so that ending it also ends the impersonation.
+func (t *TokenManager) GenerateImpersonationToken(userID string, roleID int16, adminID, sessionID string) (string, error) {
+	return t.sign(&Claims{
+		UserID:         userID,
+		RoleID:         roleID,
+		ImpersonatorID: adminID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        sessionID,
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(ImpersonationTokenExpiry)),
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+		},
+	})
+}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	tokenString, err := token.SignedString([]byte(t.secret))
+func (t *TokenManager) sign(claims *Claims) (string, error) {
+	key := t.keys.signing
+	token := jwt.NewWithClaims(key.Method, claims)
+	token.Header["kid"] = key.ID
+	tokenString, err := token.SignedString(key.private)
 	if err != nil {
 		return "", fmt.Errorf("failed to sign token: %w", err)
 	}
@@ -57,13 +97,9 @@ func (t *TokenManager) GenerateToken(userID string) (string, error) {
 // ParseToken parses and validates a JWT token string, returning the claims if valid.
 func (t *TokenManager) ParseToken(tokenString string) (*Claims, error) {
 	claims := &Claims{}
-	token, err := jwt.ParseWithClaims(tokenString, claims, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
-		}
-
-		return []byte(t.secret), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, claims, t.keys.verificationKey,
+		jwt.WithValidMethods([]string{jwt.SigningMethodRS256.Alg(), jwt.SigningMethodEdDSA.Alg()}),
+	)
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
@@ -76,6 +112,11 @@ func (t *TokenManager) ParseToken(tokenString string) (*Claims, error) {
 	return claims, nil
 }
 
+// JWKS returns the public keys that verify the issued tokens
+func (t *TokenManager) JWKS() *JWKS {
+	return t.keys.JWKS()
+}
+
 func HashPassword(password string) ([]byte, error) {
 	return bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 }
@@ -128,18 +169,31 @@ func GenerateRandomToken(length int) (string, error) {
 
 func SetRefreshTokenCookie(w http.ResponseWriter, value string) {
 	http.SetCookie(w, &http.Cookie{
-		Name:     "rt",
+		Name:     refreshTokenCookieName,
 		Value:    value,
 		HttpOnly: true,
 		Secure:   true,
 		SameSite: http.SameSiteStrictMode,
-		Path:     "/api/v1/auth",
-		MaxAge:   604800,
+		Path:     refreshTokenCookiePath,
+		MaxAge:   int(RefreshTokenExpiry.Seconds()),
+	})
+}
+
+// ClearRefreshTokenCookie instructs the client to drop the refresh token cookie.
+func ClearRefreshTokenCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     refreshTokenCookieName,
+		Value:    "",
+		HttpOnly: true,
+		Secure:   true,
+		SameSite: http.SameSiteStrictMode,
+		Path:     refreshTokenCookiePath,
+		MaxAge:   -1,
 	})
 }
 
 func GetRefreshTokenCookie(r *http.Request) (string, error) {
-	cookie, err := r.Cookie("rt")
+	cookie, err := r.Cookie(refreshTokenCookieName)
 	if err != nil {
 		return "", err
 	}
@@ -11,24 +11,41 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newTestTokenManager creates a TokenManager signing with a throwaway key
+func newTestTokenManager(t *testing.T) *TokenManager {
+	t.Helper()
+
+	keys, err := NewEphemeralKeySet()
+	if err != nil {
+		t.Fatalf("Failed to generate key set: %v", err)
+	}
+
+	return NewTokenManager(keys)
+}
+
 func TestNewTokenManager(t *testing.T) {
-	secret := "test-secret"
-	tm := NewTokenManager(secret)
+	keys, err := NewEphemeralKeySet()
+	if err != nil {
+		t.Fatalf("Failed to generate key set: %v", err)
+	}
+	tm := NewTokenManager(keys)
 
 	if tm == nil {
 		t.Fatal("NewTokenManager returned nil")
 	}
 
-	if tm.secret != secret {
-		t.Errorf("Expected secret %s, got %s", secret, tm.secret)
+	if tm.keys != keys {
+		t.Error("Expected the given key set to be used")
 	}
 }
 
 func TestTokenManager_GenerateToken(t *testing.T) {
-	tm := NewTokenManager("test-secret")
+	tm := newTestTokenManager(t)
 	userID := "user-123"
 
-	token, err := tm.GenerateToken(userID)
+	sessionID := "session-123"
+
+	token, err := tm.GenerateToken(userID, 5, sessionID)
 	if err != nil {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
@@ -46,10 +63,52 @@ func TestTokenManager_GenerateToken(t *testing.T) {
 	if claims.UserID != userID {
 		t.Errorf("Expected UserID %s, got %s", userID, claims.UserID)
 	}
+
+	if claims.ID != sessionID {
+		t.Errorf("Expected session ID %s, got %s", sessionID, claims.ID)
+	}
+
+	if claims.RoleID != 5 {
+		t.Errorf("Expected RoleID 5, got %d", claims.RoleID)
+	}
+
+	if claims.ImpersonatorID != "" {
+		t.Errorf("Expected no impersonator, got %s", claims.ImpersonatorID)
+	}
+}
+
+func TestTokenManager_GenerateImpersonationToken(t *testing.T) {
+	tm := newTestTokenManager(t)
+
+	token, err := tm.GenerateImpersonationToken("user-123", 5, "admin-456", "session-789")
+	if err != nil {
+		t.Fatalf("Failed to generate impersonation token: %v", err)
+	}
+
+	claims, err := tm.ParseToken(token)
+	if err != nil {
+		t.Fatalf("Failed to parse impersonation token: %v", err)
+	}
+
+	if claims.UserID != "user-123" {
+		t.Errorf("Expected UserID user-123, got %s", claims.UserID)
+	}
+
+	if claims.ImpersonatorID != "admin-456" {
+		t.Errorf("Expected ImpersonatorID admin-456, got %s", claims.ImpersonatorID)
+	}
+
+	if claims.ID != "session-789" {
+		t.Errorf("Expected session ID session-789, got %s", claims.ID)
+	}
+
+	if lifetime := claims.ExpiresAt.Sub(claims.IssuedAt.Time); lifetime != ImpersonationTokenExpiry {
+		t.Errorf("Expected the token to last %s, got %s", ImpersonationTokenExpiry, lifetime)
+	}
 }
 
 func TestTokenManager_ParseToken(t *testing.T) {
-	tm := NewTokenManager("test-secret")
+	tm := newTestTokenManager(t)
 	userID := "user-456"
 
 	tests := []struct {
@@ -61,7 +120,7 @@ func TestTokenManager_ParseToken(t *testing.T) {
 		{
 			name: "valid token",
 			setupToken: func() string {
-				token, _ := tm.GenerateToken(userID)
+				token, _ := tm.GenerateToken(userID, 5, "session-123")
 				return token
 			},
 			expectError: false,
@@ -83,8 +142,9 @@ func TestTokenManager_ParseToken(t *testing.T) {
 						IssuedAt:  jwt.NewNumericDate(time.Now().Add(-2 * time.Hour)),
 					},
 				}
-				token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-				tokenString, _ := token.SignedString([]byte("test-secret"))
+				token := jwt.NewWithClaims(tm.keys.signing.Method, claims)
+				token.Header["kid"] = tm.keys.SigningKeyID()
+				tokenString, _ := token.SignedString(tm.keys.signing.private)
 				return tokenString
 			},
 			expectError: true,
@@ -93,14 +153,33 @@ func TestTokenManager_ParseToken(t *testing.T) {
 			},
 		},
 		{
-			name: "token with wrong secret",
+			name: "token from unknown key",
 			setupToken: func() string {
-				wrongTM := NewTokenManager("wrong-secret")
-				token, _ := wrongTM.GenerateToken(userID)
+				token, _ := newTestTokenManager(t).GenerateToken(userID, 5, "session-123")
 				return token
 			},
 			expectError: true,
 		},
+		{
+			name: "token signed by another key under a known kid",
+			setupToken: func() string {
+				other := newTestTokenManager(t)
+				other.keys.signing.ID = tm.keys.SigningKeyID()
+				token, _ := other.GenerateToken(userID, 5, "session-123")
+				return token
+			},
+			expectError: true,
+		},
+		{
+			name: "token with symmetric algorithm",
+			setupToken: func() string {
+				token := jwt.NewWithClaims(jwt.SigningMethodHS256, &Claims{UserID: userID})
+				token.Header["kid"] = tm.keys.SigningKeyID()
+				tokenString, _ := token.SignedString([]byte("test-secret"))
+				return tokenString
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -525,11 +604,11 @@ func TestGetRefreshTokenCookie(t *testing.T) {
 
 func TestTokenManager_Integration(t *testing.T) {
 	// Integration test: Generate, parse, and verify token lifecycle
-	tm := NewTokenManager("integration-test-secret")
+	tm := newTestTokenManager(t)
 	userID := "integration-user-789"
 
 	// Generate token
-	token, err := tm.GenerateToken(userID)
+	token, err := tm.GenerateToken(userID, 5, "session-123")
 	if err != nil {
 		t.Fatalf("Failed to generate token: %v", err)
 	}
@@ -553,8 +632,8 @@ func TestTokenManager_Integration(t *testing.T) {
 		t.Error("Token IssuedAt should be in the past")
 	}
 
-	// Verify token expires in approximately 24 hours
-	expectedExpiry := time.Now().Add(24 * time.Hour)
+	// Verify token expires with the access token lifetime
+	expectedExpiry := time.Now().Add(AccessTokenExpiry)
 	timeDiff := claims.ExpiresAt.Time.Sub(expectedExpiry)
 	if timeDiff > time.Minute || timeDiff < -time.Minute {
 		t.Errorf("Token expiry time is off by %v", timeDiff)
@@ -0,0 +1,70 @@
+package auth
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+)
+
+func TestDecodeCBOR(t *testing.T) {
+	// Vectors from RFC 8949 Appendix A
+	tests := []struct {
+		encoded  string
+		expected any
+	}{
+		{encoded: "00", expected: int64(0)},
+		{encoded: "17", expected: int64(23)},
+		{encoded: "1818", expected: int64(24)},
+		{encoded: "1903e8", expected: int64(1000)},
+		{encoded: "1a000f4240", expected: int64(1000000)},
+		{encoded: "20", expected: int64(-1)},
+		{encoded: "3903e7", expected: int64(-1000)},
+		{encoded: "f4", expected: false},
+		{encoded: "f5", expected: true},
+		{encoded: "f6", expected: nil},
+		{encoded: "4401020304", expected: []byte{1, 2, 3, 4}},
+		{encoded: "6449455446", expected: "IETF"},
+		{encoded: "83010203", expected: []any{int64(1), int64(2), int64(3)}},
+		{encoded: "a201020304", expected: map[any]any{int64(1): int64(2), int64(3): int64(4)}},
+		{encoded: "a26161016162820203", expected: map[any]any{"a": int64(1), "b": []any{int64(2), int64(3)}}},
+	}
+
+	for _, tt := range tests {
+		data, _ := hex.DecodeString(tt.encoded)
+
+		value, rest, err := decodeCBOR(data)
+		if err != nil {
+			t.Fatalf("Failed to decode %s: %v", tt.encoded, err)
+		}
+
+		if len(rest) != 0 {
+			t.Errorf("Decoding %s left %d bytes", tt.encoded, len(rest))
+		}
+
+		if !reflect.DeepEqual(value, tt.expected) {
+			t.Errorf("Decoding %s expected %#v, got %#v", tt.encoded, tt.expected, value)
+		}
+	}
+}
+
+func TestDecodeCBOR_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":              "",
+		"truncated argument": "19",
+		"truncated string":   "4401",
+		"truncated map":      "a201",
+		"indefinite length":  "5f",
+		"float":              "fa47c35000",
+		"array map key":      "a18001",
+		"oversized array":    "9bffffffffffffffff",
+		"excessive nesting":  "818181818181818181818100",
+	}
+
+	for name, encoded := range tests {
+		data, _ := hex.DecodeString(encoded)
+
+		if _, _, err := decodeCBOR(data); err == nil {
+			t.Errorf("Expected %s to fail", name)
+		}
+	}
+}
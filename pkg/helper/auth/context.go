@@ -3,3 +3,6 @@ package auth
 type ContextKey string
 
 const UserContextKey ContextKey = "user"
+
+// AdminScopeContextKey holds the scope of the authenticated admin on admin routes, absent when unrestricted
+const AdminScopeContextKey ContextKey = "admin_scope"
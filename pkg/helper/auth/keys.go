@@ -0,0 +1,234 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// minRSAKeyBits is the smallest RSA modulus accepted for signing or verifying tokens
+const minRSAKeyBits = 2048
+
+var (
+	ErrNoSigningKey   = errors.New("no signing key available")
+	ErrUnknownKeyID   = errors.New("unknown key id")
+	ErrUnsupportedKey = errors.New("unsupported key type")
+)
+
+// SigningKey is a key pair identified by its "kid". Keys loaded from a public key
+// only verify tokens, which is how a retired key is kept until its tokens expire.
+type SigningKey struct {
+	ID      string
+	Method  jwt.SigningMethod
+	private crypto.Signer
+	public  crypto.PublicKey
+}
+
+// KeySet holds the key used to sign new tokens and every key tokens are accepted from.
+//
+// Keys are rotated by adding the new key to the directory, pointing the signing key id
+// to it and removing the old private key, leaving its public key around for at least
+// AccessTokenExpiry so the tokens it signed stay valid.
+type KeySet struct {
+	signing *SigningKey
+	keys    map[string]*SigningKey
+}
+
+// LoadKeySet reads every PEM file of the directory, using the file name without its
+// extension as the key id. The signing key is the one named by signingKeyID, or the
+// only private key of the directory when no id is given.
+func LoadKeySet(dir, signingKeyID string) (*KeySet, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list keys: %w", err)
+	}
+	sort.Strings(paths)
+
+	set := &KeySet{keys: make(map[string]*SigningKey)}
+	var privateKeys []*SigningKey
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key %s: %w", path, err)
+		}
+
+		id := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		key, err := parseKey(id, data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", path, err)
+		}
+
+		set.keys[id] = key
+		if key.private != nil {
+			privateKeys = append(privateKeys, key)
+		}
+	}
+
+	switch {
+	case signingKeyID != "":
+		key, ok := set.keys[signingKeyID]
+		if !ok || key.private == nil {
+			return nil, fmt.Errorf("%w: %q has no private key in %s", ErrNoSigningKey, signingKeyID, dir)
+		}
+		set.signing = key
+	case len(privateKeys) == 1:
+		set.signing = privateKeys[0]
+	case len(privateKeys) == 0:
+		return nil, fmt.Errorf("%w: no private key in %s", ErrNoSigningKey, dir)
+	default:
+		return nil, fmt.Errorf("%w: %d private keys in %s, set the signing key id", ErrNoSigningKey, len(privateKeys), dir)
+	}
+
+	return set, nil
+}
+
+// NewEphemeralKeySet generates an Ed25519 key that only lives in memory. Tokens it signs
+// stop being valid on restart, so it is meant for tests and local development.
+func NewEphemeralKeySet() (*KeySet, error) {
+	public, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("failed to generate key id: %w", err)
+	}
+
+	key := &SigningKey{
+		ID:      "ephemeral-" + hex.EncodeToString(id),
+		Method:  jwt.SigningMethodEdDSA,
+		private: private,
+		public:  public,
+	}
+
+	return &KeySet{signing: key, keys: map[string]*SigningKey{key.ID: key}}, nil
+}
+
+// SigningKeyID returns the id of the key new tokens are signed with
+func (s *KeySet) SigningKeyID() string {
+	return s.signing.ID
+}
+
+// verificationKey returns the public key a token must have been signed with
+func (s *KeySet) verificationKey(token *jwt.Token) (any, error) {
+	id, _ := token.Header["kid"].(string)
+	key, ok := s.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+
+	// The algorithm is bound to the key, never taken from the token alone
+	if token.Method.Alg() != key.Method.Alg() {
+		return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+	}
+
+	return key.public, nil
+}
+
+func parseKey(id string, data []byte) (*SigningKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	var parsed any
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("%w: PEM block %q", ErrUnsupportedKey, block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	key := &SigningKey{ID: id}
+	if signer, ok := parsed.(crypto.Signer); ok {
+		key.private = signer
+		parsed = signer.Public()
+	}
+
+	switch public := parsed.(type) {
+	case *rsa.PublicKey:
+		if public.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("%w: RSA key shorter than %d bits", ErrUnsupportedKey, minRSAKeyBits)
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, fmt.Errorf("%w: %T", ErrUnsupportedKey, public)
+	}
+	key.public = parsed
+
+	return key, nil
+}
+
+// JWK is the public part of a key in the JSON Web Key format (RFC 7517)
+type JWK struct {
+	Kty string `json:"kty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+	// RSA keys
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Ed25519 keys
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS is the document other services fetch to verify tokens on their own
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS lists the public keys tokens are accepted from
+func (s *KeySet) JWKS() *JWKS {
+	ids := make([]string, 0, len(s.keys))
+	for id := range s.keys {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	jwks := &JWKS{Keys: make([]JWK, 0, len(ids))}
+	for _, id := range ids {
+		key := s.keys[id]
+		jwk := JWK{Use: "sig", Alg: key.Method.Alg(), Kid: key.ID}
+
+		switch public := key.public.(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(public.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(public)
+		}
+
+		jwks.Keys = append(jwks.Keys, jwk)
+	}
+
+	return jwks
+}
@@ -0,0 +1,176 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// writeKey stores the private key, or only its public part, as a PEM file named after the key id
+func writeKey(t *testing.T, dir, id string, private any, publicOnly bool) {
+	t.Helper()
+
+	block := &pem.Block{Type: "PRIVATE KEY"}
+	var err error
+	if publicOnly {
+		block.Type = "PUBLIC KEY"
+		switch key := private.(type) {
+		case *rsa.PrivateKey:
+			block.Bytes, err = x509.MarshalPKIXPublicKey(&key.PublicKey)
+		case ed25519.PrivateKey:
+			block.Bytes, err = x509.MarshalPKIXPublicKey(key.Public())
+		}
+	} else {
+		block.Bytes, err = x509.MarshalPKCS8PrivateKey(private)
+	}
+	if err != nil {
+		t.Fatalf("Failed to marshal key: %v", err)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, id+".pem"), pem.EncodeToMemory(block), 0o600); err != nil {
+		t.Fatalf("Failed to write key: %v", err)
+	}
+}
+
+func TestLoadKeySet(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+
+	t.Run("single private key signs", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "2025-01", rsaKey, true)
+		writeKey(t, dir, "2025-02", edKey, false)
+
+		keys, err := LoadKeySet(dir, "")
+		if err != nil {
+			t.Fatalf("Failed to load keys: %v", err)
+		}
+
+		if keys.SigningKeyID() != "2025-02" {
+			t.Errorf("Expected signing key 2025-02, got %s", keys.SigningKeyID())
+		}
+	})
+
+	t.Run("signing key id picks among private keys", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "2025-01", rsaKey, false)
+		writeKey(t, dir, "2025-02", edKey, false)
+
+		if _, err := LoadKeySet(dir, ""); !errors.Is(err, ErrNoSigningKey) {
+			t.Errorf("Expected ErrNoSigningKey with two private keys, got %v", err)
+		}
+
+		keys, err := LoadKeySet(dir, "2025-01")
+		if err != nil {
+			t.Fatalf("Failed to load keys: %v", err)
+		}
+
+		if keys.SigningKeyID() != "2025-01" {
+			t.Errorf("Expected signing key 2025-01, got %s", keys.SigningKeyID())
+		}
+	})
+
+	t.Run("public key cannot sign", func(t *testing.T) {
+		dir := t.TempDir()
+		writeKey(t, dir, "2025-01", rsaKey, true)
+
+		if _, err := LoadKeySet(dir, "2025-01"); !errors.Is(err, ErrNoSigningKey) {
+			t.Errorf("Expected ErrNoSigningKey, got %v", err)
+		}
+	})
+
+	t.Run("weak RSA key", func(t *testing.T) {
+		weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+		if err != nil {
+			t.Fatalf("Failed to generate RSA key: %v", err)
+		}
+
+		dir := t.TempDir()
+		writeKey(t, dir, "weak", weakKey, false)
+
+		if _, err := LoadKeySet(dir, ""); !errors.Is(err, ErrUnsupportedKey) {
+			t.Errorf("Expected ErrUnsupportedKey, got %v", err)
+		}
+	})
+}
+
+func TestTokenManager_KeyRotation(t *testing.T) {
+	oldKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("Failed to generate RSA key: %v", err)
+	}
+	_, newKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("Failed to generate Ed25519 key: %v", err)
+	}
+
+	before := t.TempDir()
+	writeKey(t, before, "old", oldKey, false)
+	beforeKeys, err := LoadKeySet(before, "")
+	if err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+
+	token, err := NewTokenManager(beforeKeys).GenerateToken("user-123", 5, "session-123")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+
+	// The old key is retired to its public part while the new one takes over signing
+	after := t.TempDir()
+	writeKey(t, after, "old", oldKey, true)
+	writeKey(t, after, "new", newKey, false)
+	afterKeys, err := LoadKeySet(after, "new")
+	if err != nil {
+		t.Fatalf("Failed to load keys: %v", err)
+	}
+	tm := NewTokenManager(afterKeys)
+
+	claims, err := tm.ParseToken(token)
+	if err != nil {
+		t.Fatalf("Token signed before the rotation should still be valid: %v", err)
+	}
+	if claims.UserID != "user-123" {
+		t.Errorf("Expected UserID user-123, got %s", claims.UserID)
+	}
+
+	rotated, err := tm.GenerateToken("user-123", 5, "session-123")
+	if err != nil {
+		t.Fatalf("Failed to generate token: %v", err)
+	}
+	if _, err := tm.ParseToken(rotated); err != nil {
+		t.Errorf("Failed to parse token signed with the new key: %v", err)
+	}
+
+	jwks := tm.JWKS()
+	if len(jwks.Keys) != 2 {
+		t.Fatalf("Expected 2 keys in the JWKS, got %d", len(jwks.Keys))
+	}
+
+	for _, jwk := range jwks.Keys {
+		switch jwk.Kid {
+		case "new":
+			if jwk.Kty != "OKP" || jwk.Crv != "Ed25519" || jwk.Alg != "EdDSA" || jwk.X == "" {
+				t.Errorf("Unexpected Ed25519 JWK: %+v", jwk)
+			}
+		case "old":
+			if jwk.Kty != "RSA" || jwk.Alg != "RS256" || jwk.N == "" || jwk.E != "AQAB" {
+				t.Errorf("Unexpected RSA JWK: %+v", jwk)
+			}
+		default:
+			t.Errorf("Unexpected key id %s", jwk.Kid)
+		}
+	}
+}
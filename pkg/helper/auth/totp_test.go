@@ -0,0 +1,109 @@
+package auth
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the SHA1 seed used by the RFC 6238 test vectors
+var rfc6238Secret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestGenerateTOTPCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8 digit codes, we keep the last 6
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{unix: 59, expected: "287082"},
+		{unix: 1111111109, expected: "081804"},
+		{unix: 1111111111, expected: "050471"},
+		{unix: 1234567890, expected: "005924"},
+		{unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := GenerateTOTPCode(rfc6238Secret, time.Unix(tt.unix, 0))
+		if err != nil {
+			t.Fatalf("Failed to generate code: %v", err)
+		}
+
+		if code != tt.expected {
+			t.Errorf("At %d expected %s, got %s", tt.unix, tt.expected, code)
+		}
+	}
+}
+
+func TestValidateTOTPCode(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("Failed to generate secret: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	code, _ := GenerateTOTPCode(secret, now)
+
+	if step, ok := ValidateTOTPCode(secret, code, now); !ok || step != now.Unix()/30 {
+		t.Errorf("Expected current code to be valid at step %d, got %d (%v)", now.Unix()/30, step, ok)
+	}
+
+	// One period of drift is tolerated
+	if _, ok := ValidateTOTPCode(secret, code, now.Add(30*time.Second)); !ok {
+		t.Error("Code from the previous period should be accepted")
+	}
+
+	if _, ok := ValidateTOTPCode(secret, code, now.Add(2*time.Minute)); ok {
+		t.Error("Code from two minutes ago should be rejected")
+	}
+
+	if _, ok := ValidateTOTPCode(secret, "12345", now); ok {
+		t.Error("Code with wrong length should be rejected")
+	}
+
+	if _, ok := ValidateTOTPCode("not base32!", code, now); ok {
+		t.Error("Invalid secret should be rejected")
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Entrepreneur Pastoral", "john@example.com", "ABCDEF")
+
+	if !strings.HasPrefix(uri, "otpauth://totp/Entrepreneur%20Pastoral:john@example.com?") {
+		t.Errorf("Unexpected URI label: %s", uri)
+	}
+
+	for _, param := range []string{"secret=ABCDEF", "issuer=Entrepreneur+Pastoral", "digits=6", "period=30"} {
+		if !strings.Contains(uri, param) {
+			t.Errorf("URI %s is missing %s", uri, param)
+		}
+	}
+}
+
+func TestGenerateRecoveryCodes(t *testing.T) {
+	codes, err := GenerateRecoveryCodes(10)
+	if err != nil {
+		t.Fatalf("Failed to generate recovery codes: %v", err)
+	}
+
+	seen := make(map[string]bool)
+	for _, code := range codes {
+		if len(code) != 17 || code[8] != '-' {
+			t.Errorf("Unexpected recovery code format: %s", code)
+		}
+		if seen[code] {
+			t.Errorf("Duplicate recovery code: %s", code)
+		}
+		seen[code] = true
+	}
+}
+
+func TestHashRecoveryCode_Normalizes(t *testing.T) {
+	expected := HashRecoveryCode("abcdefgh-ijklmnop")
+
+	for _, input := range []string{"ABCDEFGH-IJKLMNOP", " abcdefghijklmnop ", "abcdefgh-ijklmnop"} {
+		if string(HashRecoveryCode(input)) != string(expected) {
+			t.Errorf("Hash of %q does not match", input)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package auth
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+// WebAuthnTimeout is how long the browser waits for the authenticator, challenges are kept as long
+const WebAuthnTimeout = 5 * time.Minute
+
+const webauthnChallengeSize = 32
+
+// COSE algorithm identifiers (RFC 9053) of the credential keys we accept, in order of preference
+const (
+	coseAlgES256 = -7
+	coseAlgEdDSA = -8
+	coseAlgRS256 = -257
+)
+
+// Authenticator data flags (WebAuthn §6.1)
+const (
+	authDataUserPresent  = 0x01
+	authDataUserVerified = 0x04
+	authDataAttested     = 0x40
+)
+
+var (
+	ErrInvalidWebAuthnResponse = errors.New("invalid webauthn response")
+	// ErrWebAuthnSignCount means the authenticator counter went backwards, the mark of a cloned credential
+	ErrWebAuthnSignCount = errors.New("webauthn sign counter did not increase")
+)
+
+// WebAuthnBytes is binary data carried as unpadded base64url in JSON, as the browser API serializes it
+type WebAuthnBytes []byte
+
+func (b WebAuthnBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(base64.RawURLEncoding.EncodeToString(b))
+}
+
+func (b *WebAuthnBytes) UnmarshalJSON(data []byte) error {
+	var encoded string
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return err
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(encoded, "="))
+	if err != nil {
+		return err
+	}
+	*b = decoded
+
+	return nil
+}
+
+// WebAuthnCredentialDescriptor points the browser to a credential it already holds
+type WebAuthnCredentialDescriptor struct {
+	Type string        `json:"type"`
+	ID   WebAuthnBytes `json:"id"`
+}
+
+type WebAuthnRelyingPartyEntity struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// WebAuthnUser is the account a credential is created for. ID is the user handle the
+// authenticator returns on every discoverable login.
+type WebAuthnUser struct {
+	ID          WebAuthnBytes `json:"id"`
+	Name        string        `json:"name"`
+	DisplayName string        `json:"displayName"`
+}
+
+type WebAuthnCredentialParameter struct {
+	Type string `json:"type"`
+	Alg  int    `json:"alg"`
+}
+
+type WebAuthnAuthenticatorSelection struct {
+	ResidentKey        string `json:"residentKey"`
+	RequireResidentKey bool   `json:"requireResidentKey"`
+	UserVerification   string `json:"userVerification"`
+}
+
+// WebAuthnCreationOptions is passed as publicKey to navigator.credentials.create()
+type WebAuthnCreationOptions struct {
+	Challenge              WebAuthnBytes                  `json:"challenge"`
+	RP                     WebAuthnRelyingPartyEntity     `json:"rp"`
+	User                   WebAuthnUser                   `json:"user"`
+	PubKeyCredParams       []WebAuthnCredentialParameter  `json:"pubKeyCredParams"`
+	Timeout                int64                          `json:"timeout"`
+	ExcludeCredentials     []WebAuthnCredentialDescriptor `json:"excludeCredentials"`
+	AuthenticatorSelection WebAuthnAuthenticatorSelection `json:"authenticatorSelection"`
+	Attestation            string                         `json:"attestation"`
+}
+
+// WebAuthnRequestOptions is passed as publicKey to navigator.credentials.get()
+type WebAuthnRequestOptions struct {
+	Challenge        WebAuthnBytes                  `json:"challenge"`
+	Timeout          int64                          `json:"timeout"`
+	RPID             string                         `json:"rpId"`
+	AllowCredentials []WebAuthnCredentialDescriptor `json:"allowCredentials"`
+	UserVerification string                         `json:"userVerification"`
+}
+
+// WebAuthnAttestation is the credential returned by navigator.credentials.create()
+type WebAuthnAttestation struct {
+	ID       string                      `json:"id"`
+	RawID    WebAuthnBytes               `json:"rawId"`
+	Type     string                      `json:"type"`
+	Response WebAuthnAttestationResponse `json:"response"`
+}
+
+type WebAuthnAttestationResponse struct {
+	ClientDataJSON    WebAuthnBytes `json:"clientDataJSON"`
+	AttestationObject WebAuthnBytes `json:"attestationObject"`
+}
+
+// WebAuthnAssertion is the credential returned by navigator.credentials.get()
+type WebAuthnAssertion struct {
+	ID       string                    `json:"id"`
+	RawID    WebAuthnBytes             `json:"rawId"`
+	Type     string                    `json:"type"`
+	Response WebAuthnAssertionResponse `json:"response"`
+}
+
+type WebAuthnAssertionResponse struct {
+	ClientDataJSON    WebAuthnBytes `json:"clientDataJSON"`
+	AuthenticatorData WebAuthnBytes `json:"authenticatorData"`
+	Signature         WebAuthnBytes `json:"signature"`
+	UserHandle        WebAuthnBytes `json:"userHandle,omitempty"`
+}
+
+// WebAuthnCredential is what a verified registration leaves to store. PublicKey stays in its
+// COSE encoding and is handed back as is to VerifyAssertion.
+type WebAuthnCredential struct {
+	ID           []byte
+	PublicKey    []byte
+	SignCount    uint32
+	UserVerified bool
+}
+
+// WebAuthnLogin is the outcome of a verified assertion
+type WebAuthnLogin struct {
+	SignCount    uint32
+	UserVerified bool
+}
+
+// RelyingParty verifies the ceremonies run by browsers on behalf of this service. ID is the
+// domain credentials are scoped to and Origins the web origins allowed to use them.
+type RelyingParty struct {
+	ID      string
+	Name    string
+	Origins []string
+}
+
+// NewWebAuthnChallenge generates the random challenge of a ceremony
+func NewWebAuthnChallenge() ([]byte, error) {
+	challenge := make([]byte, webauthnChallengeSize)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, err
+	}
+
+	return challenge, nil
+}
+
+// CreationOptions asks for a discoverable credential, so it can later sign in without an email,
+// and skips attestation, as we trust any authenticator the user picks
+func (rp *RelyingParty) CreationOptions(challenge []byte, user WebAuthnUser, exclude [][]byte) *WebAuthnCreationOptions {
+	return &WebAuthnCreationOptions{
+		Challenge: challenge,
+		RP:        WebAuthnRelyingPartyEntity{ID: rp.ID, Name: rp.Name},
+		User:      user,
+		PubKeyCredParams: []WebAuthnCredentialParameter{
+			{Type: "public-key", Alg: coseAlgES256},
+			{Type: "public-key", Alg: coseAlgEdDSA},
+			{Type: "public-key", Alg: coseAlgRS256},
+		},
+		Timeout:            WebAuthnTimeout.Milliseconds(),
+		ExcludeCredentials: credentialDescriptors(exclude),
+		AuthenticatorSelection: WebAuthnAuthenticatorSelection{
+			ResidentKey:        "required",
+			RequireResidentKey: true,
+			UserVerification:   "preferred",
+		},
+		Attestation: "none",
+	}
+}
+
+// RequestOptions builds a login request, restricted to the allowed credentials when any are given
+func (rp *RelyingParty) RequestOptions(challenge []byte, allow [][]byte) *WebAuthnRequestOptions {
+	return &WebAuthnRequestOptions{
+		Challenge:        challenge,
+		Timeout:          WebAuthnTimeout.Milliseconds(),
+		RPID:             rp.ID,
+		AllowCredentials: credentialDescriptors(allow),
+		UserVerification: "preferred",
+	}
+}
+
+func credentialDescriptors(ids [][]byte) []WebAuthnCredentialDescriptor {
+	descriptors := make([]WebAuthnCredentialDescriptor, 0, len(ids))
+	for _, id := range ids {
+		descriptors = append(descriptors, WebAuthnCredentialDescriptor{Type: "public-key", ID: id})
+	}
+
+	return descriptors
+}
+
+// VerifyRegistration checks a new credential against the challenge it was created for
+// (WebAuthn §7.1). The attestation statement is not verified since none is requested.
+func (rp *RelyingParty) VerifyRegistration(challenge []byte, attestation *WebAuthnAttestation) (*WebAuthnCredential, error) {
+	if attestation == nil || attestation.Type != "public-key" {
+		return nil, fmt.Errorf("%w: unexpected credential type", ErrInvalidWebAuthnResponse)
+	}
+
+	if err := rp.verifyClientData(attestation.Response.ClientDataJSON, "webauthn.create", challenge); err != nil {
+		return nil, err
+	}
+
+	decoded, _, err := decodeCBOR(attestation.Response.AttestationObject)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	object, _ := decoded.(map[any]any)
+	authData, ok := object["authData"].([]byte)
+	if !ok {
+		return nil, fmt.Errorf("%w: missing authenticator data", ErrInvalidWebAuthnResponse)
+	}
+
+	data, err := rp.parseAuthenticatorData(authData)
+	if err != nil {
+		return nil, err
+	}
+
+	if data.flags&authDataAttested == 0 || len(data.rest) < 18 {
+		return nil, fmt.Errorf("%w: missing attested credential", ErrInvalidWebAuthnResponse)
+	}
+
+	// Attested credential data: AAGUID, credential id length and id, then the COSE key
+	attested := data.rest[16:]
+	idLength := int(binary.BigEndian.Uint16(attested))
+	attested = attested[2:]
+	if len(attested) < idLength {
+		return nil, fmt.Errorf("%w: truncated credential id", ErrInvalidWebAuthnResponse)
+	}
+
+	credentialID := attested[:idLength]
+	if !bytes.Equal(credentialID, attestation.RawID) {
+		return nil, fmt.Errorf("%w: credential id mismatch", ErrInvalidWebAuthnResponse)
+	}
+
+	key, rest, err := decodeCBOR(attested[idLength:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	publicKey := attested[idLength : len(attested)-len(rest)]
+	if _, _, err := parseCOSEKey(key); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	return &WebAuthnCredential{
+		ID:           bytes.Clone(credentialID),
+		PublicKey:    bytes.Clone(publicKey),
+		SignCount:    data.signCount,
+		UserVerified: data.flags&authDataUserVerified != 0,
+	}, nil
+}
+
+// VerifyAssertion checks a login against the challenge and the stored credential (WebAuthn §7.2).
+// Callers persist the returned counter, a counter not moving past storedSignCount is rejected
+// unless the authenticator does not keep one.
+func (rp *RelyingParty) VerifyAssertion(challenge []byte, assertion *WebAuthnAssertion, publicKey []byte, storedSignCount uint32) (*WebAuthnLogin, error) {
+	if assertion == nil || assertion.Type != "public-key" {
+		return nil, fmt.Errorf("%w: unexpected credential type", ErrInvalidWebAuthnResponse)
+	}
+
+	if err := rp.verifyClientData(assertion.Response.ClientDataJSON, "webauthn.get", challenge); err != nil {
+		return nil, err
+	}
+
+	data, err := rp.parseAuthenticatorData(assertion.Response.AuthenticatorData)
+	if err != nil {
+		return nil, err
+	}
+
+	key, _, err := decodeCBOR(publicKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: stored key: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	public, alg, err := parseCOSEKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: stored key: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	clientDataHash := sha256.Sum256(assertion.Response.ClientDataJSON)
+	signed := append(bytes.Clone(assertion.Response.AuthenticatorData), clientDataHash[:]...)
+	if !verifyCOSESignature(public, alg, signed, assertion.Response.Signature) {
+		return nil, fmt.Errorf("%w: bad signature", ErrInvalidWebAuthnResponse)
+	}
+
+	if (data.signCount != 0 || storedSignCount != 0) && data.signCount <= storedSignCount {
+		return nil, ErrWebAuthnSignCount
+	}
+
+	return &WebAuthnLogin{
+		SignCount:    data.signCount,
+		UserVerified: data.flags&authDataUserVerified != 0,
+	}, nil
+}
+
+type clientData struct {
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Origin    string `json:"origin"`
+}
+
+func (rp *RelyingParty) verifyClientData(raw []byte, ceremony string, challenge []byte) error {
+	var data clientData
+	if err := json.Unmarshal(raw, &data); err != nil {
+		return fmt.Errorf("%w: client data: %v", ErrInvalidWebAuthnResponse, err)
+	}
+
+	if data.Type != ceremony {
+		return fmt.Errorf("%w: unexpected ceremony %q", ErrInvalidWebAuthnResponse, data.Type)
+	}
+
+	received, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(data.Challenge, "="))
+	if err != nil || len(challenge) == 0 || subtle.ConstantTimeCompare(received, challenge) != 1 {
+		return fmt.Errorf("%w: challenge mismatch", ErrInvalidWebAuthnResponse)
+	}
+
+	if !slices.Contains(rp.Origins, data.Origin) {
+		return fmt.Errorf("%w: unexpected origin %q", ErrInvalidWebAuthnResponse, data.Origin)
+	}
+
+	return nil
+}
+
+type authenticatorData struct {
+	flags     byte
+	signCount uint32
+	// rest holds the attested credential data and extensions, when present
+	rest []byte
+}
+
+func (rp *RelyingParty) parseAuthenticatorData(raw []byte) (*authenticatorData, error) {
+	if len(raw) < 37 {
+		return nil, fmt.Errorf("%w: truncated authenticator data", ErrInvalidWebAuthnResponse)
+	}
+
+	rpIDHash := sha256.Sum256([]byte(rp.ID))
+	if subtle.ConstantTimeCompare(raw[:32], rpIDHash[:]) != 1 {
+		return nil, fmt.Errorf("%w: relying party mismatch", ErrInvalidWebAuthnResponse)
+	}
+
+	data := &authenticatorData{
+		flags:     raw[32],
+		signCount: binary.BigEndian.Uint32(raw[33:37]),
+		rest:      raw[37:],
+	}
+
+	if data.flags&authDataUserPresent == 0 {
+		return nil, fmt.Errorf("%w: user not present", ErrInvalidWebAuthnResponse)
+	}
+
+	return data, nil
+}
+
+// parseCOSEKey reads a credential public key in its COSE_Key form (RFC 9052 §7)
+func parseCOSEKey(decoded any) (crypto.PublicKey, int64, error) {
+	key, ok := decoded.(map[any]any)
+	if !ok {
+		return nil, 0, errors.New("cose key is not a map")
+	}
+
+	kty, _ := key[int64(1)].(int64)
+	alg, _ := key[int64(3)].(int64)
+
+	switch {
+	case kty == 2 && alg == coseAlgES256:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		y, _ := key[int64(-3)].([]byte)
+		if crv != 1 || len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("invalid P-256 key")
+		}
+
+		// Rejects points that are not on the curve
+		if _, err := ecdh.P256().NewPublicKey(append(append([]byte{0x04}, x...), y...)); err != nil {
+			return nil, 0, err
+		}
+
+		return &ecdsa.PublicKey{Curve: elliptic.P256(), X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, alg, nil
+	case kty == 1 && alg == coseAlgEdDSA:
+		crv, _ := key[int64(-1)].(int64)
+		x, _ := key[int64(-2)].([]byte)
+		if crv != 6 || len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("invalid Ed25519 key")
+		}
+
+		return ed25519.PublicKey(x), alg, nil
+	case kty == 3 && alg == coseAlgRS256:
+		n, _ := key[int64(-1)].([]byte)
+		e, _ := key[int64(-2)].([]byte)
+		if len(n)*8 < minRSAKeyBits || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("invalid RSA key")
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	default:
+		return nil, 0, fmt.Errorf("%w: kty %d alg %d", ErrUnsupportedKey, kty, alg)
+	}
+}
+
+func verifyCOSESignature(public crypto.PublicKey, alg int64, signed, signature []byte) bool {
+	switch alg {
+	case coseAlgES256:
+		digest := sha256.Sum256(signed)
+		return ecdsa.VerifyASN1(public.(*ecdsa.PublicKey), digest[:], signature)
+	case coseAlgEdDSA:
+		return ed25519.Verify(public.(ed25519.PublicKey), signed, signature)
+	case coseAlgRS256:
+		digest := sha256.Sum256(signed)
+		return rsa.VerifyPKCS1v15(public.(*rsa.PublicKey), crypto.SHA256, digest[:], signature) == nil
+	default:
+		return false
+	}
+}
@@ -0,0 +1,134 @@
+package auth_test
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth/webauthntest"
+)
+
+var relyingParty = &auth.RelyingParty{ID: "example.com", Name: "Example", Origins: []string{"https://example.com"}}
+
+func newChallenge(t *testing.T) []byte {
+	challenge, err := auth.NewWebAuthnChallenge()
+	if err != nil {
+		t.Fatalf("Failed to generate challenge: %v", err)
+	}
+
+	return challenge
+}
+
+func register(t *testing.T, authenticator *webauthntest.Authenticator) *auth.WebAuthnCredential {
+	challenge := newChallenge(t)
+	options := relyingParty.CreationOptions(challenge, auth.WebAuthnUser{ID: []byte("user-1"), Name: "john@example.com"}, nil)
+
+	credential, err := relyingParty.VerifyRegistration(challenge, authenticator.Create(t, options))
+	if err != nil {
+		t.Fatalf("Failed to verify registration: %v", err)
+	}
+
+	return credential
+}
+
+func TestRelyingParty_Registration(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://example.com")
+	credential := register(t, authenticator)
+
+	if len(credential.ID) == 0 || len(credential.PublicKey) == 0 || !credential.UserVerified {
+		t.Errorf("Unexpected credential: %+v", credential)
+	}
+
+	t.Run("challenge mismatch", func(t *testing.T) {
+		options := relyingParty.CreationOptions(newChallenge(t), auth.WebAuthnUser{ID: []byte("user-1")}, nil)
+
+		if _, err := relyingParty.VerifyRegistration(newChallenge(t), authenticator.Create(t, options)); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+
+	t.Run("foreign origin", func(t *testing.T) {
+		phishing := webauthntest.NewAuthenticator("https://examp1e.com")
+		challenge := newChallenge(t)
+		options := relyingParty.CreationOptions(challenge, auth.WebAuthnUser{ID: []byte("user-1")}, nil)
+
+		if _, err := relyingParty.VerifyRegistration(challenge, phishing.Create(t, options)); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+
+	t.Run("survives JSON round trip", func(t *testing.T) {
+		challenge := newChallenge(t)
+		options := relyingParty.CreationOptions(challenge, auth.WebAuthnUser{ID: []byte("user-1")}, nil)
+
+		data, err := json.Marshal(authenticator.Create(t, options))
+		if err != nil {
+			t.Fatalf("Failed to encode attestation: %v", err)
+		}
+
+		var attestation auth.WebAuthnAttestation
+		if err := json.Unmarshal(data, &attestation); err != nil {
+			t.Fatalf("Failed to decode attestation: %v", err)
+		}
+
+		if _, err := relyingParty.VerifyRegistration(challenge, &attestation); err != nil {
+			t.Errorf("Failed to verify registration: %v", err)
+		}
+	})
+}
+
+func TestRelyingParty_Assertion(t *testing.T) {
+	authenticator := webauthntest.NewAuthenticator("https://example.com")
+	credential := register(t, authenticator)
+
+	challenge := newChallenge(t)
+	assertion := authenticator.Get(t, relyingParty.RequestOptions(challenge, nil))
+
+	login, err := relyingParty.VerifyAssertion(challenge, assertion, credential.PublicKey, credential.SignCount)
+	if err != nil {
+		t.Fatalf("Failed to verify assertion: %v", err)
+	}
+
+	if login.SignCount != 1 || !login.UserVerified {
+		t.Errorf("Unexpected login: %+v", login)
+	}
+
+	t.Run("replayed counter", func(t *testing.T) {
+		if _, err := relyingParty.VerifyAssertion(challenge, assertion, credential.PublicKey, login.SignCount); !errors.Is(err, auth.ErrWebAuthnSignCount) {
+			t.Errorf("Expected ErrWebAuthnSignCount, got %v", err)
+		}
+	})
+
+	t.Run("wrong challenge", func(t *testing.T) {
+		if _, err := relyingParty.VerifyAssertion(newChallenge(t), assertion, credential.PublicKey, 0); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+
+	t.Run("tampered authenticator data", func(t *testing.T) {
+		tampered := *assertion
+		tampered.Response.AuthenticatorData = append(auth.WebAuthnBytes(nil), assertion.Response.AuthenticatorData...)
+		tampered.Response.AuthenticatorData[36]++
+
+		if _, err := relyingParty.VerifyAssertion(challenge, &tampered, credential.PublicKey, 0); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+
+	t.Run("another credential key", func(t *testing.T) {
+		other := register(t, webauthntest.NewAuthenticator("https://example.com"))
+
+		if _, err := relyingParty.VerifyAssertion(challenge, assertion, other.PublicKey, 0); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+
+	t.Run("another relying party", func(t *testing.T) {
+		other := &auth.RelyingParty{ID: "evil.example", Origins: relyingParty.Origins}
+
+		if _, err := other.VerifyAssertion(challenge, assertion, credential.PublicKey, 0); !errors.Is(err, auth.ErrInvalidWebAuthnResponse) {
+			t.Errorf("Expected ErrInvalidWebAuthnResponse, got %v", err)
+		}
+	})
+}
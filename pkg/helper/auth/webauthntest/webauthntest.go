@@ -0,0 +1,259 @@
+// Package webauthntest provides a software authenticator for tests. It answers the options
+// built by auth.RelyingParty as a browser and platform authenticator would, holding ES256
+// discoverable credentials in memory.
+package webauthntest
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/auth"
+)
+
+const (
+	flagUserPresent  = 0x01
+	flagUserVerified = 0x04
+	flagAttested     = 0x40
+)
+
+type credential struct {
+	id         []byte
+	rpID       string
+	userHandle []byte
+	key        *ecdsa.PrivateKey
+	signCount  uint32
+}
+
+// Authenticator signs on behalf of Origin. UserVerified controls whether it reports a
+// verified user (PIN or biometrics) or only a present one.
+type Authenticator struct {
+	Origin       string
+	UserVerified bool
+
+	credentials []*credential
+}
+
+func NewAuthenticator(origin string) *Authenticator {
+	return &Authenticator{Origin: origin, UserVerified: true}
+}
+
+// Create makes a new credential, as navigator.credentials.create() would
+func (a *Authenticator) Create(tb testing.TB, options *auth.WebAuthnCreationOptions) *auth.WebAuthnAttestation {
+	tb.Helper()
+
+	for _, excluded := range options.ExcludeCredentials {
+		if a.find(options.RP.ID, excluded.ID) != nil {
+			tb.Fatalf("Authenticator already holds an excluded credential")
+		}
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		tb.Fatalf("Failed to generate credential key: %v", err)
+	}
+
+	cred := &credential{
+		id:         random(tb, 16),
+		rpID:       options.RP.ID,
+		userHandle: bytes.Clone(options.User.ID),
+		key:        key,
+	}
+	a.credentials = append(a.credentials, cred)
+
+	// Attested credential data: zero AAGUID, credential id and its COSE key
+	attested := make([]byte, 16, 18+len(cred.id))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(cred.id)))
+	attested = append(attested, cred.id...)
+	attested = append(attested, coseKey(tb, &key.PublicKey)...)
+
+	authData := a.authenticatorData(cred, flagAttested)
+	authData = append(authData, attested...)
+
+	return &auth.WebAuthnAttestation{
+		ID:    base64.RawURLEncoding.EncodeToString(cred.id),
+		RawID: cred.id,
+		Type:  "public-key",
+		Response: auth.WebAuthnAttestationResponse{
+			ClientDataJSON: a.clientData(tb, "webauthn.create", options.Challenge),
+			AttestationObject: encodeMap([]entry{
+				{key: encodeText("fmt"), value: encodeText("none")},
+				{key: encodeText("attStmt"), value: encodeMap(nil)},
+				{key: encodeText("authData"), value: encodeBytes(authData)},
+			}),
+		},
+	}
+}
+
+// Get signs the challenge with a credential for the relying party, as navigator.credentials.get()
+// would. Without allowed credentials it uses the last discoverable one it made.
+func (a *Authenticator) Get(tb testing.TB, options *auth.WebAuthnRequestOptions) *auth.WebAuthnAssertion {
+	tb.Helper()
+
+	var cred *credential
+	if len(options.AllowCredentials) == 0 {
+		for _, c := range a.credentials {
+			if c.rpID == options.RPID {
+				cred = c
+			}
+		}
+	}
+	for _, allowed := range options.AllowCredentials {
+		if c := a.find(options.RPID, allowed.ID); c != nil {
+			cred = c
+		}
+	}
+	if cred == nil {
+		tb.Fatalf("Authenticator holds no credential for %s", options.RPID)
+	}
+
+	cred.signCount++
+	authData := a.authenticatorData(cred, 0)
+	clientData := a.clientData(tb, "webauthn.get", options.Challenge)
+
+	clientDataHash := sha256.Sum256(clientData)
+	digest := sha256.Sum256(append(bytes.Clone(authData), clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, cred.key, digest[:])
+	if err != nil {
+		tb.Fatalf("Failed to sign assertion: %v", err)
+	}
+
+	return &auth.WebAuthnAssertion{
+		ID:    base64.RawURLEncoding.EncodeToString(cred.id),
+		RawID: cred.id,
+		Type:  "public-key",
+		Response: auth.WebAuthnAssertionResponse{
+			ClientDataJSON:    clientData,
+			AuthenticatorData: authData,
+			Signature:         signature,
+			UserHandle:        cred.userHandle,
+		},
+	}
+}
+
+// coseKey encodes an ES256 public key as the COSE_Key found in attested credential data
+func coseKey(tb testing.TB, key *ecdsa.PublicKey) []byte {
+	tb.Helper()
+
+	point, err := key.ECDH()
+	if err != nil {
+		tb.Fatalf("Failed to encode credential key: %v", err)
+	}
+	// Uncompressed point: 0x04 || x || y
+	raw := point.Bytes()
+
+	return encodeMap([]entry{
+		{key: encodeInt(1), value: encodeInt(2)},
+		{key: encodeInt(3), value: encodeInt(-7)},
+		{key: encodeInt(-1), value: encodeInt(1)},
+		{key: encodeInt(-2), value: encodeBytes(raw[1:33])},
+		{key: encodeInt(-3), value: encodeBytes(raw[33:])},
+	})
+}
+
+func (a *Authenticator) find(rpID string, id []byte) *credential {
+	for _, c := range a.credentials {
+		if c.rpID == rpID && bytes.Equal(c.id, id) {
+			return c
+		}
+	}
+
+	return nil
+}
+
+func (a *Authenticator) authenticatorData(cred *credential, flags byte) []byte {
+	flags |= flagUserPresent
+	if a.UserVerified {
+		flags |= flagUserVerified
+	}
+
+	rpIDHash := sha256.Sum256([]byte(cred.rpID))
+	data := append(rpIDHash[:], flags)
+
+	return binary.BigEndian.AppendUint32(data, cred.signCount)
+}
+
+func (a *Authenticator) clientData(tb testing.TB, ceremony string, challenge []byte) []byte {
+	data, err := json.Marshal(map[string]any{
+		"type":        ceremony,
+		"challenge":   base64.RawURLEncoding.EncodeToString(challenge),
+		"origin":      a.Origin,
+		"crossOrigin": false,
+	})
+	if err != nil {
+		tb.Fatalf("Failed to encode client data: %v", err)
+	}
+
+	return data
+}
+
+func random(tb testing.TB, size int) []byte {
+	data := make([]byte, size)
+	if _, err := rand.Read(data); err != nil {
+		tb.Fatalf("Failed to generate random bytes: %v", err)
+	}
+
+	return data
+}
+
+// The CBOR encoding below covers what authenticators emit, with map keys in the canonical
+// order (shorter encodings first, then bytewise) CTAP2 requires
+
+type entry struct {
+	key, value []byte
+}
+
+func encodeHead(major byte, argument uint64) []byte {
+	switch {
+	case argument < 24:
+		return []byte{major<<5 | byte(argument)}
+	case argument <= 0xff:
+		return []byte{major<<5 | 24, byte(argument)}
+	case argument <= 0xffff:
+		return binary.BigEndian.AppendUint16([]byte{major<<5 | 25}, uint16(argument))
+	case argument <= 0xffffffff:
+		return binary.BigEndian.AppendUint32([]byte{major<<5 | 26}, uint32(argument))
+	default:
+		return binary.BigEndian.AppendUint64([]byte{major<<5 | 27}, argument)
+	}
+}
+
+func encodeInt(value int64) []byte {
+	if value < 0 {
+		return encodeHead(1, uint64(-1-value))
+	}
+
+	return encodeHead(0, uint64(value))
+}
+
+func encodeBytes(value []byte) []byte {
+	return append(encodeHead(2, uint64(len(value))), value...)
+}
+
+func encodeText(value string) []byte {
+	return append(encodeHead(3, uint64(len(value))), value...)
+}
+
+func encodeMap(entries []entry) []byte {
+	sort.Slice(entries, func(i, j int) bool {
+		if len(entries[i].key) != len(entries[j].key) {
+			return len(entries[i].key) < len(entries[j].key)
+		}
+		return bytes.Compare(entries[i].key, entries[j].key) < 0
+	})
+
+	data := encodeHead(5, uint64(len(entries)))
+	for _, e := range entries {
+		data = append(data, e.key...)
+		data = append(data, e.value...)
+	}
+
+	return data
+}
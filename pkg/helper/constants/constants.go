@@ -13,12 +13,35 @@ const (
 	ROLE_USER
 )
 
+// Keys of the permissions seeded in the "permissions" table and required by the admin routes
+const (
+	PERMISSION_USER_READ                = "user.read"
+	PERMISSION_USER_MANAGE              = "user.manage"
+	PERMISSION_USER_ASSIGN_ROLE         = "user.assign_role"
+	PERMISSION_USER_ASSIGN_SCOPE        = "user.assign_scope"
+	PERMISSION_USER_REVIEW_ATTESTATION  = "user.review_attestation"
+	PERMISSION_USER_REVIEW_ENTREPRENEUR = "user.review_entrepreneur"
+	PERMISSION_USER_IMPERSONATE         = "user.impersonate"
+	PERMISSION_BUSINESS_READ            = "business.read"
+	PERMISSION_BUSINESS_APPROVE         = "business.approve"
+	PERMISSION_CHURCH_MANAGE            = "church.manage"
+	PERMISSION_INDUSTRY_MANAGE          = "industry.manage"
+	PERMISSION_FIELD_OF_WORK_MANAGE     = "field_of_work.manage"
+	PERMISSION_ROLE_MANAGE              = "role.manage"
+)
+
 const (
 	QUEUE_NOTIFICATIONS = "notifications"
 )
 
 const (
-	EMAIL_TEMPLATE_WELCOME        = "welcome.html"
-	EMAIL_TEMPLATE_VERIFY_ACCOUNT = "verify_account.html"
-	EMAIL_TEMPLATE_PASSWORD_RESET = "password_reset.html"
+	EMAIL_TEMPLATE_WELCOME                  = "welcome.html"
+	EMAIL_TEMPLATE_VERIFY_ACCOUNT           = "verify_account.html"
+	EMAIL_TEMPLATE_PASSWORD_RESET           = "password_reset.html"
+	EMAIL_TEMPLATE_ACCOUNT_LOCKED           = "account_locked.html"
+	EMAIL_TEMPLATE_MAGIC_LINK               = "magic_link.html"
+	EMAIL_TEMPLATE_ATTESTATION              = "catholic_attestation.html"
+	EMAIL_TEMPLATE_ENTREPRENEUR_APPLICATION = "entrepreneur_application.html"
+	EMAIL_TEMPLATE_BUSINESS_INVITATION      = "business_invitation.html"
+	EMAIL_TEMPLATE_ACCOUNT_DELETION         = "account_deletion.html"
 )
@@ -0,0 +1,286 @@
+package encryption
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const (
+	// keySize is the size of the AES-256 keys, for both the key encryption keys and the data keys
+	keySize = 32
+	// envelopePrefix marks the values encrypted by a Keyring, followed by the key id, the wrapped
+	// data key and the encrypted value, separated by dots
+	envelopePrefix = "v1"
+)
+
+var (
+	ErrNoKeys           = errors.New("no encryption keys configured")
+	ErrNoActiveKey      = errors.New("no active encryption key")
+	ErrUnknownKeyID     = errors.New("unknown encryption key id")
+	ErrInvalidKey       = errors.New("invalid encryption key")
+	ErrInvalidEnvelope  = errors.New("invalid encrypted value")
+	ErrNoBlindIndexKey  = errors.New("no blind index key")
+	ErrInvalidKeyFormat = errors.New("encryption keys must be given as <id>:<base64 key>")
+)
+
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Keyring encrypts values with envelope encryption: every value gets its own random data key,
+// which is stored next to it, wrapped by the active key encryption key of the ring.
+//
+// Keys are rotated by adding a new key to the ring and making it the active one. Values wrapped
+// by older keys stay readable as long as their key is in the ring, and Rewrap moves them to the
+// active key without touching the data itself, after which the old key can be removed.
+//
+// The ring also computes blind indexes, keyed hashes of a value that let it be looked up or
+// checked for uniqueness without being stored in the clear.
+type Keyring struct {
+	activeID   string
+	keys       map[string]cipher.AEAD
+	blindIndex []byte
+}
+
+// NewKeyring builds a ring from 32-byte keys by id, activeID naming the key new values are wrapped with
+func NewKeyring(keys map[string][]byte, activeID string, blindIndexKey []byte) (*Keyring, error) {
+	if _, ok := keys[activeID]; !ok {
+		return nil, ErrNoActiveKey
+	}
+	if len(blindIndexKey) < keySize {
+		return nil, ErrNoBlindIndexKey
+	}
+
+	ring := &Keyring{
+		activeID:   activeID,
+		keys:       make(map[string]cipher.AEAD, len(keys)),
+		blindIndex: blindIndexKey,
+	}
+	for id, key := range keys {
+		if !keyIDPattern.MatchString(id) {
+			return nil, fmt.Errorf("%w: id %q", ErrInvalidKey, id)
+		}
+
+		aead, err := newAEAD(key)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", err, id)
+		}
+		ring.keys[id] = aead
+	}
+
+	return ring, nil
+}
+
+// ParseKeyring builds a ring from keys written as "<id>:<base64 key>" and a base64 blind index key.
+// The active key is the one named by activeID, or the only key when no id is given.
+func ParseKeyring(encodedKeys []string, activeID, encodedBlindIndexKey string) (*Keyring, error) {
+	keys := make(map[string][]byte, len(encodedKeys))
+	for _, encoded := range encodedKeys {
+		if encoded = strings.TrimSpace(encoded); encoded == "" {
+			continue
+		}
+
+		id, value, ok := strings.Cut(encoded, ":")
+		if !ok {
+			return nil, ErrInvalidKeyFormat
+		}
+
+		key, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidKeyFormat, id)
+		}
+		keys[id] = key
+	}
+
+	if len(keys) == 0 {
+		return nil, ErrNoKeys
+	}
+	if activeID == "" && len(keys) == 1 {
+		for id := range keys {
+			activeID = id
+		}
+	}
+
+	blindIndexKey, err := base64.StdEncoding.DecodeString(encodedBlindIndexKey)
+	if err != nil {
+		return nil, ErrNoBlindIndexKey
+	}
+
+	return NewKeyring(keys, activeID, blindIndexKey)
+}
+
+// DeriveKeyring builds a ring from a secret, for development only: anyone who knows the
+// secret can read the values, and they are lost if it changes.
+func DeriveKeyring(secret string) (*Keyring, error) {
+	key := sha256.Sum256([]byte("encryption:" + secret))
+	blindIndexKey := sha256.Sum256([]byte("blind-index:" + secret))
+
+	return NewKeyring(map[string][]byte{"derived": key[:]}, "derived", blindIndexKey[:])
+}
+
+// Encrypt seals the value under a new data key wrapped by the active key
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	dataKey := make([]byte, keySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	wrappedKey, err := seal(k.keys[k.activeID], dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	ciphertext, err := seal(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+
+	return strings.Join([]string{envelopePrefix, k.activeID, encode(wrappedKey), encode(ciphertext)}, "."), nil
+}
+
+// Decrypt opens a value sealed by Encrypt with any key of the ring
+func (k *Keyring) Decrypt(value string) (string, error) {
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return "", err
+	}
+
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return "", err
+	}
+
+	dataAEAD, err := newAEAD(dataKey)
+	if err != nil {
+		return "", err
+	}
+
+	plaintext, err := open(dataAEAD, env.ciphertext)
+	if err != nil {
+		return "", err
+	}
+
+	return string(plaintext), nil
+}
+
+// Rewrap wraps the data key of the value with the active key, reporting whether it changed
+func (k *Keyring) Rewrap(value string) (string, bool, error) {
+	env, err := parseEnvelope(value)
+	if err != nil {
+		return "", false, err
+	}
+
+	if env.keyID == k.activeID {
+		return value, false, nil
+	}
+
+	dataKey, err := k.unwrap(env)
+	if err != nil {
+		return "", false, err
+	}
+
+	wrappedKey, err := seal(k.keys[k.activeID], dataKey)
+	if err != nil {
+		return "", false, err
+	}
+
+	return strings.Join([]string{envelopePrefix, k.activeID, encode(wrappedKey), encode(env.ciphertext)}, "."), true, nil
+}
+
+// BlindIndex returns the keyed hash of the value, hex encoded
+func (k *Keyring) BlindIndex(value string) string {
+	mac := hmac.New(sha256.New, k.blindIndex)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// IsEncrypted reports whether the value was sealed by a Keyring, as opposed to stored in the clear
+func IsEncrypted(value string) bool {
+	return strings.HasPrefix(value, envelopePrefix+".")
+}
+
+type envelope struct {
+	keyID      string
+	wrappedKey []byte
+	ciphertext []byte
+}
+
+func parseEnvelope(value string) (*envelope, error) {
+	parts := strings.Split(value, ".")
+	if len(parts) != 4 || parts[0] != envelopePrefix {
+		return nil, ErrInvalidEnvelope
+	}
+
+	wrappedKey, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	ciphertext, err := base64.RawURLEncoding.DecodeString(parts[3])
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	return &envelope{keyID: parts[1], wrappedKey: wrappedKey, ciphertext: ciphertext}, nil
+}
+
+func (k *Keyring) unwrap(env *envelope) ([]byte, error) {
+	aead, ok := k.keys[env.keyID]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownKeyID, env.keyID)
+	}
+
+	return open(aead, env.wrappedKey)
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != keySize {
+		return nil, ErrInvalidKey
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts the data, prefixed with its random nonce
+func seal(aead cipher.AEAD, data []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	return aead.Seal(nonce, nonce, data, nil), nil
+}
+
+func open(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, ErrInvalidEnvelope
+	}
+
+	data, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, ErrInvalidEnvelope
+	}
+
+	return data, nil
+}
+
+func encode(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
@@ -0,0 +1,144 @@
+package encryption
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, keySize)
+}
+
+func newTestKeyring(t *testing.T, keys map[string][]byte, activeID string) *Keyring {
+	t.Helper()
+
+	ring, err := NewKeyring(keys, activeID, testKey(0xff))
+	if err != nil {
+		t.Fatalf("Failed to create keyring: %v", err)
+	}
+	return ring
+}
+
+func TestKeyring_EncryptDecrypt(t *testing.T) {
+	ring := newTestKeyring(t, map[string][]byte{"k1": testKey(1)}, "k1")
+
+	sealed, err := ring.Encrypt("123.456.789-00")
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+	if !IsEncrypted(sealed) || strings.Contains(sealed, "123.456") {
+		t.Fatalf("Expected an encrypted value, got %q", sealed)
+	}
+
+	again, _ := ring.Encrypt("123.456.789-00")
+	if again == sealed {
+		t.Error("Expected every encryption to use a fresh data key")
+	}
+
+	plaintext, err := ring.Decrypt(sealed)
+	if err != nil {
+		t.Fatalf("Failed to decrypt: %v", err)
+	}
+	if plaintext != "123.456.789-00" {
+		t.Errorf("Expected the original value, got %q", plaintext)
+	}
+}
+
+func TestKeyring_DecryptTampered(t *testing.T) {
+	ring := newTestKeyring(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	sealed, _ := ring.Encrypt("5511999999999")
+
+	parts := strings.Split(sealed, ".")
+	ciphertext, _ := base64.RawURLEncoding.DecodeString(parts[3])
+	ciphertext[len(ciphertext)-1] ^= 1
+	parts[3] = base64.RawURLEncoding.EncodeToString(ciphertext)
+
+	if _, err := ring.Decrypt(strings.Join(parts, ".")); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Expected ErrInvalidEnvelope, got %v", err)
+	}
+	if _, err := ring.Decrypt("5511999999999"); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("Expected ErrInvalidEnvelope for a plaintext value, got %v", err)
+	}
+}
+
+func TestKeyring_Rotation(t *testing.T) {
+	old := newTestKeyring(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	sealed, _ := old.Encrypt("123.456.789-00")
+
+	rotated := newTestKeyring(t, map[string][]byte{"k1": testKey(1), "k2": testKey(2)}, "k2")
+
+	// Values wrapped by the previous key stay readable until they are rewrapped
+	if plaintext, err := rotated.Decrypt(sealed); err != nil || plaintext != "123.456.789-00" {
+		t.Fatalf("Expected the old value to decrypt, got %q, %v", plaintext, err)
+	}
+
+	rewrapped, changed, err := rotated.Rewrap(sealed)
+	if err != nil || !changed {
+		t.Fatalf("Expected the value to be rewrapped, got %v, %v", changed, err)
+	}
+	if !strings.HasPrefix(rewrapped, "v1.k2.") {
+		t.Errorf("Expected the value to be wrapped by k2, got %q", rewrapped)
+	}
+
+	if _, changed, _ := rotated.Rewrap(rewrapped); changed {
+		t.Error("Expected a value wrapped by the active key to be left alone")
+	}
+
+	retired := newTestKeyring(t, map[string][]byte{"k2": testKey(2)}, "k2")
+	if plaintext, err := retired.Decrypt(rewrapped); err != nil || plaintext != "123.456.789-00" {
+		t.Errorf("Expected the rewrapped value to decrypt without k1, got %q, %v", plaintext, err)
+	}
+	if _, err := retired.Decrypt(sealed); !errors.Is(err, ErrUnknownKeyID) {
+		t.Errorf("Expected ErrUnknownKeyID, got %v", err)
+	}
+}
+
+func TestKeyring_BlindIndex(t *testing.T) {
+	ring := newTestKeyring(t, map[string][]byte{"k1": testKey(1)}, "k1")
+	rotated := newTestKeyring(t, map[string][]byte{"k2": testKey(2)}, "k2")
+
+	index := ring.BlindIndex("123.456.789-00")
+	if len(index) != 64 {
+		t.Errorf("Expected a hex SHA-256, got %q", index)
+	}
+	if index != rotated.BlindIndex("123.456.789-00") {
+		t.Error("Expected the blind index not to depend on the encryption keys")
+	}
+	if index == ring.BlindIndex("123.456.789-01") {
+		t.Error("Expected different values to have different indexes")
+	}
+}
+
+func TestParseKeyring(t *testing.T) {
+	k1 := base64.StdEncoding.EncodeToString(testKey(1))
+	k2 := base64.StdEncoding.EncodeToString(testKey(2))
+	index := base64.StdEncoding.EncodeToString(testKey(3))
+
+	tests := []struct {
+		name     string
+		keys     []string
+		activeID string
+		index    string
+		err      error
+	}{
+		{name: "SingleKey", keys: []string{"k1:" + k1}, index: index},
+		{name: "ActiveKey", keys: []string{"k1:" + k1, "k2:" + k2}, activeID: "k2", index: index},
+		{name: "NoActiveKey", keys: []string{"k1:" + k1, "k2:" + k2}, index: index, err: ErrNoActiveKey},
+		{name: "NoKeys", keys: []string{""}, index: index, err: ErrNoKeys},
+		{name: "MissingID", keys: []string{k1}, index: index, err: ErrInvalidKeyFormat},
+		{name: "ShortKey", keys: []string{"k1:" + base64.StdEncoding.EncodeToString([]byte("short"))}, index: index, err: ErrInvalidKey},
+		{name: "NoBlindIndexKey", keys: []string{"k1:" + k1}, err: ErrNoBlindIndexKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseKeyring(tt.keys, tt.activeID, tt.index)
+			if !errors.Is(err, tt.err) {
+				t.Errorf("Expected %v, got %v", tt.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package pagination
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/helper/response"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+// DefaultLimit is the size of the cursor pages requested without a limit
+const DefaultLimit = 20
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// Cursor points between two rows of a list sorted by creation, the id telling apart the rows
+// created at the same time. Unlike an offset, it keeps its place when rows are added or removed
+// before it, and the database seeks to it through the (created_at, id) index instead of reading
+// and skipping every row before it.
+//
+// Cursors travel as opaque tokens. An empty token is the start of the list, which is how a
+// client switches a list from offsets to cursors.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+	// Backward reads the page before the cursor instead of the one after it
+	Backward bool
+}
+
+type cursorToken struct {
+	CreatedAt time.Time `json:"t"`
+	ID        uuid.UUID `json:"i"`
+	Backward  bool      `json:"b,omitempty"`
+}
+
+// IsStart reports whether the cursor is the start of the list.
+func (c *Cursor) IsStart() bool {
+	return c.ID == uuid.Nil
+}
+
+func (c Cursor) MarshalText() ([]byte, error) {
+	if c.IsStart() {
+		return []byte{}, nil
+	}
+
+	token, err := json.Marshal(cursorToken(c))
+	if err != nil {
+		return nil, err
+	}
+
+	return []byte(base64.RawURLEncoding.EncodeToString(token)), nil
+}
+
+func (c *Cursor) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = Cursor{}
+		return nil
+	}
+
+	token, err := base64.RawURLEncoding.DecodeString(string(text))
+	if err != nil {
+		return ErrInvalidCursor
+	}
+
+	var decoded cursorToken
+	if err := json.Unmarshal(token, &decoded); err != nil || decoded.ID == uuid.Nil {
+		return ErrInvalidCursor
+	}
+
+	*c = Cursor(decoded)
+	return nil
+}
+
+func (c Cursor) String() string {
+	text, _ := c.MarshalText()
+	return string(text)
+}
+
+// Limit returns the size of a cursor page, the requested one or DefaultLimit.
+func Limit(limit *int) int {
+	if limit == nil || *limit <= 0 {
+		return DefaultLimit
+	}
+	return *limit
+}
+
+// Keyset restricts the query to the page after the cursor, or before it when reading backward,
+// sorted by creation then id. It fetches one row more than the limit, which tells Trim whether
+// another page follows.
+func Keyset(baseQuery sq.SelectBuilder, cursor *Cursor, descending bool, limit int) sq.SelectBuilder {
+	// A page before the cursor is read in the opposite order, from the cursor back, and put
+	// back in list order by Trim
+	if cursor.Backward {
+		descending = !descending
+	}
+
+	direction, comparison := "ASC", ">"
+	if descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if !cursor.IsStart() {
+		baseQuery = baseQuery.Where(sq.Expr("(created_at, id) "+comparison+" (?, ?)", cursor.CreatedAt, cursor.ID))
+	}
+
+	return baseQuery.
+		OrderBy("created_at "+direction, "id "+direction).
+		Limit(uint64(limit + 1))
+}
+
+// Page holds the cursors to the pages around a page of a list, nil where there is none.
+type Page struct {
+	NextCursor *Cursor `json:"next_cursor,omitempty"`
+	PrevCursor *Cursor `json:"prev_cursor,omitempty"`
+}
+
+// Meta returns the cursors as the metadata of a response, nil when the list is not paged by cursor.
+func (p Page) Meta() *response.Meta {
+	if p.NextCursor == nil && p.PrevCursor == nil {
+		return nil
+	}
+
+	meta := &response.Meta{}
+	if p.NextCursor != nil {
+		meta.NextCursor = p.NextCursor.String()
+	}
+	if p.PrevCursor != nil {
+		meta.PrevCursor = p.PrevCursor.String()
+	}
+	return meta
+}
+
+// Trim turns the rows fetched by Keyset into a page: it drops the extra row, puts the rows of a
+// backward page back in list order, and points the cursors at the first and last rows.
+func Trim[T any](rows []T, cursor *Cursor, limit int, key func(T) (time.Time, uuid.UUID)) ([]T, Page) {
+	more := len(rows) > limit
+	if more {
+		rows = rows[:limit]
+	}
+	if cursor.Backward {
+		slices.Reverse(rows)
+	}
+
+	var page Page
+	if len(rows) == 0 {
+		return rows, page
+	}
+
+	// Reading forward, there is a previous page unless this is the first one. Reading
+	// backward, the page the cursor came from follows, and more rows mean another page before.
+	if (more && cursor.Backward) || (!cursor.Backward && !cursor.IsStart()) {
+		createdAt, id := key(rows[0])
+		page.PrevCursor = &Cursor{CreatedAt: createdAt, ID: id, Backward: true}
+	}
+	if more || cursor.Backward {
+		createdAt, id := key(rows[len(rows)-1])
+		page.NextCursor = &Cursor{CreatedAt: createdAt, ID: id}
+	}
+
+	return rows, page
+}
@@ -0,0 +1,165 @@
+package pagination
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/google/uuid"
+)
+
+type row struct {
+	createdAt time.Time
+	id        uuid.UUID
+}
+
+func rowKey(r row) (time.Time, uuid.UUID) {
+	return r.createdAt, r.id
+}
+
+func testRows(n int) []row {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]row, n)
+	for i := range rows {
+		rows[i] = row{createdAt: start.Add(-time.Duration(i) * time.Hour), id: uuid.New()}
+	}
+	return rows
+}
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{CreatedAt: time.Date(2026, 3, 4, 5, 6, 7, 8, time.UTC), ID: uuid.New(), Backward: true}
+
+	token := cursor.String()
+	if token == "" || strings.ContainsAny(token, "+/=") {
+		t.Fatalf("Expected a URL safe token, got %q", token)
+	}
+
+	var decoded Cursor
+	if err := decoded.UnmarshalText([]byte(token)); err != nil {
+		t.Fatalf("Failed to decode cursor: %v", err)
+	}
+	if !decoded.CreatedAt.Equal(cursor.CreatedAt) || decoded.ID != cursor.ID || !decoded.Backward {
+		t.Errorf("Expected %+v, got %+v", cursor, decoded)
+	}
+}
+
+func TestCursor_EmptyTokenIsStart(t *testing.T) {
+	var filter struct {
+		Cursor *Cursor `json:"cursor"`
+	}
+	if err := json.Unmarshal([]byte(`{"cursor": ""}`), &filter); err != nil {
+		t.Fatalf("Failed to decode filter: %v", err)
+	}
+	if filter.Cursor == nil || !filter.Cursor.IsStart() {
+		t.Fatalf("Expected the start cursor, got %+v", filter.Cursor)
+	}
+	if filter.Cursor.String() != "" {
+		t.Errorf("Expected the start cursor to encode as an empty token, got %q", filter.Cursor.String())
+	}
+}
+
+func TestCursor_InvalidToken(t *testing.T) {
+	for _, token := range []string{"not a token", "bm90IGpzb24", "e30"} {
+		var cursor Cursor
+		if err := cursor.UnmarshalText([]byte(token)); err != ErrInvalidCursor {
+			t.Errorf("Expected ErrInvalidCursor for %q, got %v", token, err)
+		}
+	}
+}
+
+func TestLimit(t *testing.T) {
+	zero, ten := 0, 10
+	if got := Limit(nil); got != DefaultLimit {
+		t.Errorf("Expected %d without a limit, got %d", DefaultLimit, got)
+	}
+	if got := Limit(&zero); got != DefaultLimit {
+		t.Errorf("Expected %d for a zero limit, got %d", DefaultLimit, got)
+	}
+	if got := Limit(&ten); got != 10 {
+		t.Errorf("Expected 10, got %d", got)
+	}
+}
+
+func TestKeyset(t *testing.T) {
+	base := sq.Select("id").From("products")
+
+	query, args, err := Keyset(base, &Cursor{}, true, 10).ToSql()
+	if err != nil {
+		t.Fatalf("Failed to build query: %v", err)
+	}
+	if query != "SELECT id FROM products ORDER BY created_at DESC, id DESC LIMIT 11" || len(args) != 0 {
+		t.Errorf("Unexpected first page query %q %v", query, args)
+	}
+
+	cursor := &Cursor{CreatedAt: time.Now(), ID: uuid.New(), Backward: true}
+	query, args, err = Keyset(base, cursor, true, 10).ToSql()
+	if err != nil {
+		t.Fatalf("Failed to build query: %v", err)
+	}
+	if query != "SELECT id FROM products WHERE (created_at, id) > (?, ?) ORDER BY created_at ASC, id ASC LIMIT 11" || len(args) != 2 {
+		t.Errorf("Unexpected backward page query %q %v", query, args)
+	}
+}
+
+func TestTrim_FirstPage(t *testing.T) {
+	rows := testRows(4)
+
+	page, cursors := Trim(rows, &Cursor{}, 3, rowKey)
+	if len(page) != 3 {
+		t.Fatalf("Expected 3 rows, got %d", len(page))
+	}
+	if cursors.PrevCursor != nil {
+		t.Error("Expected no previous page on the first page")
+	}
+	if cursors.NextCursor == nil || cursors.NextCursor.ID != rows[2].id || cursors.NextCursor.Backward {
+		t.Errorf("Expected the next cursor at the last row, got %+v", cursors.NextCursor)
+	}
+}
+
+func TestTrim_LastPage(t *testing.T) {
+	rows := testRows(2)
+	cursor := &Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+
+	page, cursors := Trim(rows, cursor, 3, rowKey)
+	if len(page) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(page))
+	}
+	if cursors.NextCursor != nil {
+		t.Error("Expected no next page on the last page")
+	}
+	if cursors.PrevCursor == nil || cursors.PrevCursor.ID != rows[0].id || !cursors.PrevCursor.Backward {
+		t.Errorf("Expected a backward cursor at the first row, got %+v", cursors.PrevCursor)
+	}
+}
+
+func TestTrim_Backward(t *testing.T) {
+	// Rows read backward come nearest the cursor first
+	rows := testRows(4)
+	fetched := []row{rows[3], rows[2], rows[1], rows[0]}
+	cursor := &Cursor{CreatedAt: time.Now(), ID: uuid.New(), Backward: true}
+
+	page, cursors := Trim(fetched, cursor, 3, rowKey)
+	if len(page) != 3 || page[0].id != rows[1].id || page[2].id != rows[3].id {
+		t.Fatalf("Expected rows 1 to 3 in list order, got %+v", page)
+	}
+	if cursors.PrevCursor == nil || cursors.PrevCursor.ID != rows[1].id {
+		t.Errorf("Expected a previous page before row 1, got %+v", cursors.PrevCursor)
+	}
+	if cursors.NextCursor == nil || cursors.NextCursor.ID != rows[3].id {
+		t.Errorf("Expected the next cursor at row 3, got %+v", cursors.NextCursor)
+	}
+}
+
+func TestPage_Meta(t *testing.T) {
+	if meta := (Page{}).Meta(); meta != nil {
+		t.Errorf("Expected no meta without cursors, got %+v", meta)
+	}
+
+	next := &Cursor{CreatedAt: time.Now(), ID: uuid.New()}
+	meta := Page{NextCursor: next}.Meta()
+	if meta == nil || meta.NextCursor != next.String() || meta.PrevCursor != "" {
+		t.Errorf("Expected only the next cursor, got %+v", meta)
+	}
+}
@@ -44,6 +44,9 @@ type Meta struct {
 	PageSize   int   `json:"page_size,omitempty"`
 	TotalPages int   `json:"total_pages,omitempty"`
 	TotalCount int64 `json:"total_count,omitempty"`
+	// NextCursor and PrevCursor page through lists paged by cursor instead of by offset
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // JSON writes a JSON response with the given status code
@@ -122,6 +125,11 @@ func UnprocessableEntity(w http.ResponseWriter, message string, details map[stri
 	Error(w, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", message, details)
 }
 
+// TooManyRequests writes a 429 Too Many Requests response
+func TooManyRequests(w http.ResponseWriter, message string) {
+	Error(w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", message, nil)
+}
+
 // InternalServerError writes a 500 Internal Server Error response
 func InternalServerError(w http.ResponseWriter, message string) {
 	Error(w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", message, nil)
@@ -223,6 +231,11 @@ func UnprocessableEntityT(ctx context.Context, w http.ResponseWriter, messageKey
 	ErrorT(ctx, w, http.StatusUnprocessableEntity, "UNPROCESSABLE_ENTITY", messageKey, details)
 }
 
+// TooManyRequestsT writes a 429 Too Many Requests response with translated message
+func TooManyRequestsT(ctx context.Context, w http.ResponseWriter, messageKey string) {
+	ErrorT(ctx, w, http.StatusTooManyRequests, "TOO_MANY_REQUESTS", messageKey, nil)
+}
+
 // InternalServerErrorT writes a 500 Internal Server Error response with translated message
 func InternalServerErrorT(ctx context.Context, w http.ResponseWriter, messageKey string) {
 	ErrorT(ctx, w, http.StatusInternalServerError, "INTERNAL_SERVER_ERROR", messageKey, nil)
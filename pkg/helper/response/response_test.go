@@ -167,6 +167,25 @@ func TestConflict(t *testing.T) {
 	}
 }
 
+func TestTooManyRequests(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	TooManyRequests(w, "Too many attempts")
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Errorf("Expected status %d, got %d", http.StatusTooManyRequests, w.Code)
+	}
+
+	var resp Response
+	if err := json.NewDecoder(w.Body).Decode(&resp); err != nil {
+		t.Fatalf("Failed to decode response: %v", err)
+	}
+
+	if resp.Error.Code != "TOO_MANY_REQUESTS" {
+		t.Errorf("Expected error code 'TOO_MANY_REQUESTS', got '%s'", resp.Error.Code)
+	}
+}
+
 func TestInternalServerError(t *testing.T) {
 	w := httptest.NewRecorder()
 
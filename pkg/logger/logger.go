@@ -23,5 +23,5 @@ func New(app config.Application) *zap.SugaredLogger {
 		logLevel,
 	)
 
-	return zap.New(core, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).Sugar()
+	return zap.New(redactCore{core}, zap.AddCaller(), zap.AddStacktrace(zapcore.ErrorLevel)).Sugar()
 }
@@ -0,0 +1,95 @@
+package logger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redacted replaces the value of the fields that hold personal identifiers
+const redacted = "[REDACTED]"
+
+// redactedKeys are the names of the fields holding personal identifiers, lowercased and without separators
+var redactedKeys = map[string]bool{
+	"documentid":  true,
+	"document":    true,
+	"cpf":         true,
+	"phone":       true,
+	"phonenumber": true,
+}
+
+func isRedacted(key string) bool {
+	key = strings.NewReplacer("_", "", "-", "", ".", "").Replace(strings.ToLower(key))
+	return redactedKeys[key]
+}
+
+// redactCore keeps personal identifiers out of the logs, whether they are logged as fields of
+// their own or as part of a struct or map, such as a user or a request
+type redactCore struct {
+	zapcore.Core
+}
+
+func (c redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return redactCore{c.Core.With(redactFields(fields))}
+}
+
+func (c redactCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c redactCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(entry, redactFields(fields))
+}
+
+func redactFields(fields []zapcore.Field) []zapcore.Field {
+	redactedFields := make([]zapcore.Field, len(fields))
+	for i, field := range fields {
+		switch {
+		case isRedacted(field.Key):
+			redactedFields[i] = zap.String(field.Key, redacted)
+		case field.Type == zapcore.ReflectType:
+			redactedFields[i] = redactReflected(field)
+		default:
+			redactedFields[i] = field
+		}
+	}
+	return redactedFields
+}
+
+// redactReflected redacts the keys of a value logged through reflection, which is encoded as JSON
+func redactReflected(field zapcore.Field) zapcore.Field {
+	data, err := json.Marshal(field.Interface)
+	if err != nil {
+		return field
+	}
+
+	var value any
+	if err := json.Unmarshal(data, &value); err != nil {
+		return field
+	}
+
+	return zap.Any(field.Key, redactValue(value))
+}
+
+func redactValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, nested := range v {
+			if isRedacted(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(nested)
+			}
+		}
+	case []any:
+		for i, nested := range v {
+			v[i] = redactValue(nested)
+		}
+	}
+	return value
+}
@@ -0,0 +1,57 @@
+package logger
+
+import (
+	"testing"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRedactCore(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := zap.New(redactCore{core}).Sugar()
+
+	type request struct {
+		Email      string `json:"email"`
+		DocumentID string `json:"document_id"`
+		Address    struct {
+			Phone string `json:"phone"`
+		} `json:"address"`
+	}
+	req := request{Email: "maria@example.com", DocumentID: "123.456.789-00"}
+	req.Address.Phone = "5511999999999"
+
+	log.With("cpf", "123.456.789-00").Infow("user registered",
+		"documentID", "123.456.789-00",
+		"phone_number", "5511999999999",
+		"email", "maria@example.com",
+		"request", req,
+	)
+	log.Debugw("skipped", "documentID", "123.456.789-00")
+
+	if logs.Len() != 1 {
+		t.Fatalf("Expected 1 entry, got %d", logs.Len())
+	}
+	fields := logs.All()[0].ContextMap()
+
+	for _, key := range []string{"cpf", "documentID", "phone_number"} {
+		if fields[key] != redacted {
+			t.Errorf("Expected %s to be redacted, got %v", key, fields[key])
+		}
+	}
+	if fields["email"] != "maria@example.com" {
+		t.Errorf("Expected email to be kept, got %v", fields["email"])
+	}
+
+	logged, ok := fields["request"].(map[string]any)
+	if !ok {
+		t.Fatalf("Expected request to be logged as a map, got %T", fields["request"])
+	}
+	if logged["document_id"] != redacted || logged["address"].(map[string]any)["phone"] != redacted {
+		t.Errorf("Expected nested identifiers to be redacted, got %v", logged)
+	}
+	if logged["email"] != "maria@example.com" {
+		t.Errorf("Expected nested email to be kept, got %v", logged["email"])
+	}
+}
@@ -0,0 +1,124 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/oidc/oidctest"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const redirectURL = "https://app.example.com/auth/oidc/stub/callback"
+
+func TestProvider_Exchange(t *testing.T) {
+	stub := oidctest.NewProvider(t)
+	provider := NewProvider(stub.Config("stub"), nil)
+	ctx := context.Background()
+
+	authorizationURL, err := provider.AuthCodeURL(ctx, redirectURL, "state-123", "nonce-123", "verifier-123")
+	if err != nil {
+		t.Fatalf("Failed to build authorization URL: %v", err)
+	}
+
+	code, state := stub.Authorize(t, authorizationURL, oidctest.Identity{
+		Subject:       "subject-123",
+		Email:         "john@example.com",
+		EmailVerified: true,
+		GivenName:     "John",
+		FamilyName:    "Doe",
+	})
+	if state != "state-123" {
+		t.Errorf("Expected state state-123, got %s", state)
+	}
+
+	t.Run("wrong code verifier", func(t *testing.T) {
+		if _, err := provider.Exchange(ctx, redirectURL, code, "another-verifier", "nonce-123"); !errors.Is(err, ErrExchangeFailed) {
+			t.Errorf("Expected ErrExchangeFailed, got %v", err)
+		}
+	})
+
+	code, _ = stub.Authorize(t, authorizationURL, oidctest.Identity{Subject: "subject-123", Email: "john@example.com", EmailVerified: true})
+
+	claims, err := provider.Exchange(ctx, redirectURL, code, "verifier-123", "nonce-123")
+	if err != nil {
+		t.Fatalf("Failed to exchange code: %v", err)
+	}
+	if claims.Subject != "subject-123" || claims.Email != "john@example.com" || !bool(claims.EmailVerified) {
+		t.Errorf("Unexpected claims: %+v", claims)
+	}
+
+	t.Run("code is single use", func(t *testing.T) {
+		if _, err := provider.Exchange(ctx, redirectURL, code, "verifier-123", "nonce-123"); !errors.Is(err, ErrExchangeFailed) {
+			t.Errorf("Expected ErrExchangeFailed, got %v", err)
+		}
+	})
+}
+
+func TestProvider_Verify(t *testing.T) {
+	stub := oidctest.NewProvider(t)
+	provider := NewProvider(stub.Config("stub"), nil)
+	ctx := context.Background()
+
+	validClaims := func() jwt.MapClaims {
+		return jwt.MapClaims{
+			"iss":   stub.URL,
+			"aud":   oidctest.ClientID,
+			"sub":   "subject-123",
+			"exp":   time.Now().Add(time.Minute).Unix(),
+			"nonce": "nonce-123",
+		}
+	}
+
+	if _, err := provider.Verify(ctx, stub.SignIDToken(t, validClaims()), "nonce-123"); err != nil {
+		t.Fatalf("Failed to verify ID token: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(jwt.MapClaims)
+	}{
+		{"wrong audience", func(c jwt.MapClaims) { c["aud"] = "another-client" }},
+		{"wrong issuer", func(c jwt.MapClaims) { c["iss"] = "https://attacker.example.com" }},
+		{"expired", func(c jwt.MapClaims) { c["exp"] = time.Now().Add(-time.Minute).Unix() }},
+		{"missing expiry", func(c jwt.MapClaims) { delete(c, "exp") }},
+		{"nonce mismatch", func(c jwt.MapClaims) { c["nonce"] = "another-nonce" }},
+		{"missing subject", func(c jwt.MapClaims) { delete(c, "sub") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := validClaims()
+			tt.modify(claims)
+
+			if _, err := provider.Verify(ctx, stub.SignIDToken(t, claims), "nonce-123"); !errors.Is(err, ErrInvalidIDToken) {
+				t.Errorf("Expected ErrInvalidIDToken, got %v", err)
+			}
+		})
+	}
+
+	t.Run("unsigned", func(t *testing.T) {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodNone, validClaims()).SignedString(jwt.UnsafeAllowNoneSignatureType)
+		if err != nil {
+			t.Fatalf("Failed to build token: %v", err)
+		}
+
+		if _, err := provider.Verify(ctx, token, "nonce-123"); !errors.Is(err, ErrInvalidIDToken) {
+			t.Errorf("Expected ErrInvalidIDToken, got %v", err)
+		}
+	})
+}
+
+func TestBool_UnmarshalJSON(t *testing.T) {
+	for input, expected := range map[string]bool{`true`: true, `false`: false, `"true"`: true, `"false"`: false} {
+		var value Bool
+		if err := json.Unmarshal([]byte(input), &value); err != nil {
+			t.Fatalf("Failed to unmarshal %s: %v", input, err)
+		}
+		if bool(value) != expected {
+			t.Errorf("Expected %s to be %v", input, expected)
+		}
+	}
+}
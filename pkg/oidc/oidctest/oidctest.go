@@ -0,0 +1,224 @@
+// Package oidctest runs a local OpenID Connect provider for tests. It serves the discovery
+// document, its keys and a token endpoint, while Authorize stands in for the user signing in
+// at the provider.
+package oidctest
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/In-the-name-and-glory-of-God/entrepreneur-pastoral/pkg/config"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	ClientID     = "test-client"
+	ClientSecret = "test-secret"
+	keyID        = "test-key"
+)
+
+// Identity is the account the user signs in with at the provider
+type Identity struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	GivenName     string
+	FamilyName    string
+}
+
+// grant is an authorization code waiting to be exchanged
+type grant struct {
+	identity      Identity
+	nonce         string
+	redirectURI   string
+	codeChallenge string
+}
+
+type Provider struct {
+	*httptest.Server
+	key *rsa.PrivateKey
+
+	mu     sync.Mutex
+	grants map[string]grant
+}
+
+// NewProvider starts a provider that is shut down when the test ends
+func NewProvider(tb testing.TB) *Provider {
+	tb.Helper()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		tb.Fatalf("Failed to generate provider key: %v", err)
+	}
+
+	p := &Provider{key: key, grants: make(map[string]grant)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", p.discovery)
+	mux.HandleFunc("GET /jwks", p.jwks)
+	mux.HandleFunc("POST /token", p.token)
+	p.Server = httptest.NewServer(mux)
+	tb.Cleanup(p.Close)
+
+	return p
+}
+
+// Config returns the provider configuration for the client of this provider
+func (p *Provider) Config(name string) config.OIDCProvider {
+	return config.OIDCProvider{
+		Name:         name,
+		Issuer:       p.URL,
+		ClientID:     ClientID,
+		ClientSecret: ClientSecret,
+		Scopes:       []string{"openid", "email", "profile"},
+	}
+}
+
+// Authorize signs the identity in at the authorization URL and returns the code and state the
+// provider redirects back with
+func (p *Provider) Authorize(tb testing.TB, authorizationURL string, identity Identity) (code, state string) {
+	tb.Helper()
+
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		tb.Fatalf("Failed to parse authorization URL: %v", err)
+	}
+
+	query := parsed.Query()
+	if query.Get("client_id") != ClientID || query.Get("code_challenge_method") != "S256" {
+		tb.Fatalf("Unexpected authorization request: %s", authorizationURL)
+	}
+
+	code = randomHex()
+	p.mu.Lock()
+	p.grants[code] = grant{
+		identity:      identity,
+		nonce:         query.Get("nonce"),
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+	}
+	p.mu.Unlock()
+
+	return code, query.Get("state")
+}
+
+// SignIDToken signs the claims with the provider key, as any ID token it issues
+func (p *Provider) SignIDToken(tb testing.TB, claims jwt.MapClaims) string {
+	tb.Helper()
+
+	signed, err := p.sign(claims)
+	if err != nil {
+		tb.Fatalf("Failed to sign ID token: %v", err)
+	}
+
+	return signed
+}
+
+func (p *Provider) sign(claims jwt.MapClaims) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = keyID
+
+	return token.SignedString(p.key)
+}
+
+func (p *Provider) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 p.URL,
+		"authorization_endpoint": p.URL + "/authorize",
+		"token_endpoint":         p.URL + "/token",
+		"jwks_uri":               p.URL + "/jwks",
+	})
+}
+
+func (p *Provider) jwks(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"keys": []map[string]string{{
+			"kty": "RSA",
+			"use": "sig",
+			"alg": "RS256",
+			"kid": keyID,
+			"n":   base64.RawURLEncoding.EncodeToString(p.key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(p.key.E)).Bytes()),
+		}},
+	})
+}
+
+func (p *Provider) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	if r.PostForm.Get("client_id") != ClientID || r.PostForm.Get("client_secret") != ClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+
+	code := r.PostForm.Get("code")
+	p.mu.Lock()
+	g, ok := p.grants[code]
+	delete(p.grants, code)
+	p.mu.Unlock()
+
+	verifier := r.PostForm.Get("code_verifier")
+	if !ok || r.PostForm.Get("redirect_uri") != g.redirectURI || verifier == "" || challenge(verifier) != g.codeChallenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss":            p.URL,
+		"aud":            ClientID,
+		"sub":            g.identity.Subject,
+		"iat":            now.Unix(),
+		"exp":            now.Add(5 * time.Minute).Unix(),
+		"nonce":          g.nonce,
+		"email":          g.identity.Email,
+		"email_verified": g.identity.EmailVerified,
+		"given_name":     g.identity.GivenName,
+		"family_name":    g.identity.FamilyName,
+	}
+
+	signed, err := p.sign(claims)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"access_token": randomHex(),
+		"token_type":   "Bearer",
+		"expires_in":   300,
+		"id_token":     signed,
+	})
+}
+
+func challenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func randomHex() string {
+	data := make([]byte, 16)
+	_, _ = rand.Read(data)
+
+	return hex.EncodeToString(data)
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -24,6 +24,9 @@ type CacheStorage interface {
 	Del(ctx context.Context, key string) error
 	Scan(ctx context.Context, match string) ([]string, error)
 	Exists(ctx context.Context, key string) (bool, error)
+	Incr(ctx context.Context, key string, expiration time.Duration) (int64, error)
+	HIncr(ctx context.Context, key string, field string) (int64, error)
+	TTL(ctx context.Context, key string) (time.Duration, error)
 }
 
 type CachePrefix uint8
@@ -37,6 +40,15 @@ const (
 	CACHE_PREFIX_JOB_PROFILE_LIST
 	CACHE_PREFIX_BUSINESS
 	CACHE_PREFIX_BUSINESS_LIST
+	CACHE_PREFIX_TWO_FACTOR_CHALLENGE
+	CACHE_PREFIX_LOGIN_ATTEMPT
+	CACHE_PREFIX_ACCOUNT_UNLOCK
+	CACHE_PREFIX_VERIFICATION_RESEND
+	CACHE_PREFIX_MAGIC_LINK
+	CACHE_PREFIX_OIDC_STATE
+	CACHE_PREFIX_OIDC_REGISTRATION
+	CACHE_PREFIX_WEBAUTHN
+	CACHE_PREFIX_ROLE_PERMISSIONS
 )
 
 func (p CachePrefix) String() string {
@@ -57,6 +69,24 @@ func (p CachePrefix) String() string {
 		return "business"
 	case CACHE_PREFIX_BUSINESS_LIST:
 		return "business_list"
+	case CACHE_PREFIX_TWO_FACTOR_CHALLENGE:
+		return "two_factor_challenge"
+	case CACHE_PREFIX_LOGIN_ATTEMPT:
+		return "login_attempt"
+	case CACHE_PREFIX_ACCOUNT_UNLOCK:
+		return "account_unlock"
+	case CACHE_PREFIX_VERIFICATION_RESEND:
+		return "verification_resend"
+	case CACHE_PREFIX_MAGIC_LINK:
+		return "magic_link"
+	case CACHE_PREFIX_OIDC_STATE:
+		return "oidc_state"
+	case CACHE_PREFIX_OIDC_REGISTRATION:
+		return "oidc_registration"
+	case CACHE_PREFIX_WEBAUTHN:
+		return "webauthn"
+	case CACHE_PREFIX_ROLE_PERMISSIONS:
+		return "role_permissions"
 	default:
 		return ""
 	}
@@ -100,16 +130,21 @@ func (c Cache) GetAndDel(ctx context.Context, key string, dest any) error {
 		return err
 	}
 
-	// Convert the result to a slice and scan into dest
+	// HGETALL replies with a flat list of field and value pairs, scanned into dest as a map
 	if resultSlice, ok := result.([]interface{}); ok {
 		// Check if the slice is empty (key didn't exist)
 		if len(resultSlice) == 0 {
 			return nil
 		}
 
-		// Use redis.NewSliceResult to create a compatible result for Scan
-		cmd := redis.NewSliceResult(resultSlice, nil)
-		if err := cmd.Scan(dest); err != nil {
+		fields := make(map[string]string, len(resultSlice)/2)
+		for i := 0; i+1 < len(resultSlice); i += 2 {
+			field, _ := resultSlice[i].(string)
+			value, _ := resultSlice[i+1].(string)
+			fields[field] = value
+		}
+
+		if err := redis.NewMapStringStringResult(fields, nil).Scan(dest); err != nil {
 			return err
 		}
 	}
@@ -204,3 +239,57 @@ func (c Cache) Exists(ctx context.Context, key string) (bool, error) {
 
 	return res == 1, nil
 }
+
+// Incr increments a counter and returns its new value. The expiration is only
+// applied when the counter is created, so it bounds a fixed window.
+func (c Cache) Incr(ctx context.Context, key string, expiration time.Duration) (int64, error) {
+	script := redis.NewScript(`
+		local count = redis.call('INCR', KEYS[1])
+		if count == 1 and tonumber(ARGV[1]) > 0 then
+			redis.call('PEXPIRE', KEYS[1], ARGV[1])
+		end
+		return count
+	`)
+
+	return script.Run(ctx, c.client, []string{key}, expiration.Milliseconds()).Int64()
+}
+
+// HIncr increments a field of an existing hash and returns its new value, or ErrCacheMiss when
+// the hash does not exist, so that an expired hash is not brought back without an expiration.
+func (c Cache) HIncr(ctx context.Context, key string, field string) (int64, error) {
+	script := redis.NewScript(`
+		if redis.call('EXISTS', KEYS[1]) == 0 then
+			return -1
+		end
+		return redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+	`)
+
+	count, err := script.Run(ctx, c.client, []string{key}, field).Int64()
+	if err != nil {
+		return 0, err
+	}
+	if count == -1 {
+		return 0, ErrCacheMiss
+	}
+
+	return count, nil
+}
+
+// TTL returns the time left before the key expires, or ErrCacheMiss when it does not exist
+func (c Cache) TTL(ctx context.Context, key string) (time.Duration, error) {
+	ttl, err := c.client.PTTL(ctx, key).Result()
+	if err != nil {
+		return 0, err
+	}
+
+	// Redis answers -2 for a missing key and -1 for a key without expiration,
+	// which the client passes through unscaled
+	if ttl == -2 {
+		return 0, ErrCacheMiss
+	}
+	if ttl < 0 {
+		return 0, nil
+	}
+
+	return ttl, nil
+}
@@ -12,6 +12,7 @@ Test Coverage:
 - Hash operations (Get, Set with structs)
 - Atomic operations (GetAndDel, GetStringAndDel)
 - Key scanning and existence checks
+- Counters and remaining time to live
 - Error handling and edge cases
 - Cache expiration
 - Prefix enumeration
@@ -335,6 +336,26 @@ func TestCache_GetAndDel_Hash(t *testing.T) {
 		assert.False(t, exists)
 	})
 
+	t.Run("GetAndDel scans the hash into a struct", func(t *testing.T) {
+		type challenge struct {
+			UserID   string `redis:"user_id"`
+			Attempts int    `redis:"attempts"`
+		}
+		key := "test:hash:getdel:struct"
+
+		err := cache.Set(ctx, key, &challenge{UserID: "user-123", Attempts: 2}, 0)
+		require.NoError(t, err)
+
+		var result challenge
+		err = cache.GetAndDel(ctx, key, &result)
+		require.NoError(t, err)
+		assert.Equal(t, challenge{UserID: "user-123", Attempts: 2}, result)
+
+		exists, err := cache.Exists(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, exists)
+	})
+
 	t.Run("GetAndDel on non-existent key", func(t *testing.T) {
 		_, err := cache.GetStringAndDel(ctx, "nonexistent:key")
 		// GetStringAndDel may return an error for non-existent keys
@@ -475,6 +496,101 @@ func TestCache_Scan(t *testing.T) {
 	})
 }
 
+// Test Incr
+func TestCache_Incr(t *testing.T) {
+	cache, mr, cleanup := setupRedisTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("Counts within a fixed window", func(t *testing.T) {
+		key := "test:counter"
+
+		for expected := int64(1); expected <= 3; expected++ {
+			count, err := cache.Incr(ctx, key, time.Minute)
+			assert.NoError(t, err)
+			assert.Equal(t, expected, count)
+		}
+
+		// Later increments must not extend the window
+		mr.FastForward(30 * time.Second)
+		_, err := cache.Incr(ctx, key, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, 30*time.Second, mr.TTL(key))
+
+		mr.FastForward(31 * time.Second)
+		count, err := cache.Incr(ctx, key, time.Minute)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1), count)
+	})
+
+	t.Run("Without expiration", func(t *testing.T) {
+		key := "test:counter:persistent"
+
+		_, err := cache.Incr(ctx, key, 0)
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), mr.TTL(key))
+	})
+}
+
+// Test HIncr
+func TestCache_HIncr(t *testing.T) {
+	cache, mr, cleanup := setupRedisTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("Counts a field of the hash", func(t *testing.T) {
+		key := "test:hash:counter"
+		err := cache.Set(ctx, key, map[string]any{"name": "value"}, time.Minute)
+		assert.NoError(t, err)
+
+		for expected := int64(1); expected <= 3; expected++ {
+			count, err := cache.HIncr(ctx, key, "attempts")
+			assert.NoError(t, err)
+			assert.Equal(t, expected, count)
+		}
+
+		// The hash keeps its expiration and its other fields
+		assert.Equal(t, time.Minute, mr.TTL(key))
+		assert.Equal(t, "value", mr.HGet(key, "name"))
+	})
+
+	t.Run("Missing hash", func(t *testing.T) {
+		_, err := cache.HIncr(ctx, "nonexistent:hash", "attempts")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+		assert.False(t, mr.Exists("nonexistent:hash"))
+	})
+}
+
+// Test TTL
+func TestCache_TTL(t *testing.T) {
+	cache, _, cleanup := setupRedisTest(t)
+	defer cleanup()
+	ctx := context.Background()
+
+	t.Run("Key with expiration", func(t *testing.T) {
+		err := cache.SetString(ctx, "test:ttl", "value", time.Minute)
+		assert.NoError(t, err)
+
+		ttl, err := cache.TTL(ctx, "test:ttl")
+		assert.NoError(t, err)
+		assert.InDelta(t, time.Minute, ttl, float64(time.Second))
+	})
+
+	t.Run("Key without expiration", func(t *testing.T) {
+		err := cache.SetString(ctx, "test:ttl:persistent", "value", 0)
+		assert.NoError(t, err)
+
+		ttl, err := cache.TTL(ctx, "test:ttl:persistent")
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), ttl)
+	})
+
+	t.Run("Missing key", func(t *testing.T) {
+		_, err := cache.TTL(ctx, "nonexistent:key")
+		assert.ErrorIs(t, err, ErrCacheMiss)
+	})
+}
+
 // Test edge cases and error scenarios
 func TestCache_EdgeCases(t *testing.T) {
 	cache, _, cleanup := setupRedisTest(t)